@@ -40,6 +40,8 @@ const (
 	MimetypeTypedData         = "data/typed"
 	MimetypeClique            = "application/x-clique-header"
 	MimetypeTextPlain         = "text/plain"
+
+	MimetypeSetCodeAuthorization = "application/x-set-code-authorization"
 )
 
 // Wallet represents a software or hardware wallet that might contain one or more
@@ -0,0 +1,92 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// errInvalidChildKey is returned if a derived key is out of the curve range,
+// which BIP-32 mandates to be treated as an invalid child (probability < 2^-127).
+var errInvalidChildKey = errors.New("invalid child key")
+
+// DeriveKey derives the private key at the given BIP-32 path from a hierarchical
+// deterministic seed, such as the one generated from a BIP-39 mnemonic.
+func DeriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length: %d bytes", len(seed))
+	}
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key, chain := new(big.Int).SetBytes(sum[:32]), sum[32:]
+	if key.Sign() == 0 || key.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, errInvalidChildKey
+	}
+	for _, index := range path {
+		var err error
+		if key, chain, err = deriveChild(key, chain, index); err != nil {
+			return nil, fmt.Errorf("failed to derive %v: %w", path, err)
+		}
+	}
+	return crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+}
+
+// deriveChild derives the private child key at the given index of an extended
+// private key.
+func deriveChild(key *big.Int, chain []byte, index uint32) (*big.Int, []byte, error) {
+	var data []byte
+	if index >= 0x80000000 {
+		// Hardened child, derived from the private key
+		data = append([]byte{0x00}, math.PaddedBigBytes(key, 32)...)
+	} else {
+		// Normal child, derived from the public key
+		priv, err := crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&priv.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, chain)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(n) >= 0 {
+		return nil, nil, errInvalidChildKey
+	}
+	child := tweak.Add(tweak, key)
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, nil, errInvalidChildKey
+	}
+	return child, sum[32:], nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// The English wordlist of BIP-39, https://github.com/bitcoin/bips/blob/master/bip-0039/english.txt
+//
+//go:embed bip39_english.txt
+var bip39English string
+
+var (
+	// mnemonicWords is the list of words mnemonics are composed of.
+	mnemonicWords = strings.Fields(bip39English)
+
+	// mnemonicIndices is the reverse mapping of words to their position in the list.
+	mnemonicIndices = make(map[string]int, len(mnemonicWords))
+)
+
+var (
+	// ErrInvalidMnemonic is returned if a mnemonic has an invalid length or
+	// contains words not in the wordlist.
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+	// ErrMnemonicChecksum is returned if the checksum embedded in a mnemonic
+	// does not match its entropy.
+	ErrMnemonicChecksum = errors.New("invalid mnemonic checksum")
+)
+
+func init() {
+	if len(mnemonicWords) != 2048 {
+		panic(fmt.Sprintf("invalid BIP-39 wordlist length: %d", len(mnemonicWords)))
+	}
+	for i, word := range mnemonicWords {
+		mnemonicIndices[word] = i
+	}
+}
+
+// NewMnemonic generates a BIP-39 mnemonic of the given entropy strength in bits,
+// which must be a multiple of 32 between 128 and 256.
+func NewMnemonic(bits int) (string, error) {
+	if bits%32 != 0 || bits < 128 || bits > 256 {
+		return "", fmt.Errorf("invalid entropy size: %d bits", bits)
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := io.ReadFull(rand.Reader, entropy); err != nil {
+		return "", err
+	}
+	return EntropyToMnemonic(entropy)
+}
+
+// EntropyToMnemonic encodes the given entropy into a BIP-39 mnemonic.
+func EntropyToMnemonic(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if bits%32 != 0 || bits < 128 || bits > 256 {
+		return "", fmt.Errorf("invalid entropy size: %d bits", bits)
+	}
+	// Append the checksum (first bits/32 bits of the hash) to the entropy and
+	// split the result into 11 bit word indices.
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), checksum[0])
+
+	words := make([]string, (bits+bits/32)/11)
+	for i := range words {
+		var index int
+		for j := 0; j < 11; j++ {
+			bit := i*11 + j
+			index = index<<1 | int(data[bit/8]>>(7-bit%8)&1)
+		}
+		words[i] = mnemonicWords[index]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy decodes a BIP-39 mnemonic into the entropy it encodes,
+// verifying its checksum.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return nil, fmt.Errorf("%w: %d words", ErrInvalidMnemonic, len(words))
+	}
+	var (
+		bits = len(words) * 11
+		data = make([]byte, (bits+7)/8)
+	)
+	for i, word := range words {
+		index, ok := mnemonicIndices[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, word)
+		}
+		for j := 0; j < 11; j++ {
+			if index>>(10-j)&1 == 1 {
+				bit := i*11 + j
+				data[bit/8] |= 1 << (7 - bit%8)
+			}
+		}
+	}
+	var (
+		checksumBits = bits / 33
+		entropy      = data[:(bits-checksumBits)/8]
+		checksum     = sha256.Sum256(entropy)
+		mask         = byte(0xff) << (8 - checksumBits)
+	)
+	if data[len(entropy)]&mask != checksum[0]&mask {
+		return nil, ErrMnemonicChecksum
+	}
+	return entropy, nil
+}
+
+// MnemonicToSeed validates a BIP-39 mnemonic and converts it into the seed used
+// to derive hierarchical deterministic keys from, protected by the optional
+// password (sometimes called the 25th word).
+func MnemonicToSeed(mnemonic, password string) ([]byte, error) {
+	mnemonic = norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	if _, err := MnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+	salt := "mnemonic" + norm.NFKD.String(password)
+	return pbkdf2.Key([]byte(mnemonic), []byte(salt), 2048, 64, sha512.New), nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tests the mnemonic encoding and seed generation against the official BIP-39
+// test vectors, which all use the password "TREZOR".
+func TestMnemonicVectors(t *testing.T) {
+	t.Parallel()
+
+	blob, err := os.ReadFile("testdata/bip39_vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors map[string][][3]string
+	if err := json.Unmarshal(blob, &vectors); err != nil {
+		t.Fatal(err)
+	}
+	for i, vector := range vectors["english"] {
+		entropy := common.FromHex(vector[0])
+
+		mnemonic, err := EntropyToMnemonic(entropy)
+		if err != nil {
+			t.Fatalf("vector %d: failed to encode entropy: %v", i, err)
+		}
+		if mnemonic != vector[1] {
+			t.Errorf("vector %d: mnemonic mismatch: have %q, want %q", i, mnemonic, vector[1])
+		}
+		decoded, err := MnemonicToEntropy(vector[1])
+		if err != nil {
+			t.Fatalf("vector %d: failed to decode mnemonic: %v", i, err)
+		}
+		if !bytes.Equal(decoded, entropy) {
+			t.Errorf("vector %d: entropy mismatch: have %x, want %x", i, decoded, entropy)
+		}
+		seed, err := MnemonicToSeed(vector[1], "TREZOR")
+		if err != nil {
+			t.Fatalf("vector %d: failed to create seed: %v", i, err)
+		}
+		if have := hex.EncodeToString(seed); have != vector[2] {
+			t.Errorf("vector %d: seed mismatch: have %s, want %s", i, have, vector[2])
+		}
+	}
+}
+
+func TestInvalidMnemonics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mnemonic string
+		err      error
+	}{
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", ErrInvalidMnemonic},
+		{"legal winner thank year wave sausage worth useful legal winner thank yellow yellow", ErrInvalidMnemonic},
+		{"letter advice cage absurd amount doctor acoustic avoid letter advice caged above", ErrInvalidMnemonic},
+		{"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo, wrong", ErrInvalidMnemonic},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", ErrMnemonicChecksum},
+		{"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo", ErrMnemonicChecksum},
+	}
+	for i, tt := range tests {
+		if _, err := MnemonicToSeed(tt.mnemonic, ""); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	t.Parallel()
+
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := NewMnemonic(bits)
+		if err != nil {
+			t.Fatalf("failed to generate %d bit mnemonic: %v", bits, err)
+		}
+		if words := len(strings.Fields(mnemonic)); words != bits*33/32/11 {
+			t.Errorf("%d bit mnemonic length mismatch: have %d words, want %d", bits, words, bits*33/32/11)
+		}
+		if _, err := MnemonicToEntropy(mnemonic); err != nil {
+			t.Errorf("%d bit mnemonic invalid: %v", bits, err)
+		}
+	}
+	if _, err := NewMnemonic(100); err == nil {
+		t.Errorf("invalid entropy size accepted")
+	}
+}
+
+// testMnemonic is the well known mnemonic of the default development accounts
+// of various Ethereum tools.
+const testMnemonic = "test test test test test test test test test test test junk"
+
+var testMnemonicAccounts = []common.Address{
+	common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+	common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+	common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"),
+}
+
+func TestDeriveKey(t *testing.T) {
+	t.Parallel()
+
+	seed, err := MnemonicToSeed(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := accounts.DefaultIterator(accounts.DefaultBaseDerivationPath)
+	for i, want := range testMnemonicAccounts {
+		key, err := DeriveKey(seed, next())
+		if err != nil {
+			t.Fatalf("account %d: failed to derive key: %v", i, err)
+		}
+		if have := crypto.PubkeyToAddress(key.PublicKey); have != want {
+			t.Errorf("account %d: address mismatch: have %v, want %v", i, have, want)
+		}
+	}
+}
+
+func TestImportMnemonic(t *testing.T) {
+	t.Parallel()
+	dir, ks := tmpKeyStore(t)
+
+	url, accs, err := ks.ImportMnemonic(testMnemonic, "", "foo", 2)
+	if err != nil {
+		t.Fatalf("failed to import mnemonic: %v", err)
+	}
+	if !strings.HasPrefix(url.Path, dir) {
+		t.Errorf("seed file outside of keystore: %v", url)
+	}
+	// Derive a further account, check that the accounts are usable and that the
+	// seed file itself is not picked up as an account.
+	more, err := ks.DeriveAccounts(url, "foo", 1)
+	if err != nil {
+		t.Fatalf("failed to derive accounts: %v", err)
+	}
+	accs = append(accs, more...)
+	for i, want := range testMnemonicAccounts {
+		if accs[i].Address != want {
+			t.Errorf("account %d: address mismatch: have %v, want %v", i, accs[i].Address, want)
+		}
+		if err := ks.Unlock(accs[i], "foo"); err != nil {
+			t.Errorf("account %d: failed to unlock: %v", i, err)
+		}
+	}
+	if _, err := ks.DeriveAccounts(url, "bar", 1); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("derivation with wrong passphrase: have %v, want %v", err, ErrDecrypt)
+	}
+	if _, err := ks.DeriveAccounts(accs[0].URL, "foo", 1); err == nil {
+		t.Errorf("derivation from key file succeeded")
+	}
+	ks.cache.maybeReload()
+	if have := len(ks.Accounts()); have != len(testMnemonicAccounts) {
+		t.Errorf("account count mismatch: have %d, want %d", have, len(testMnemonicAccounts))
+	}
+	// Importing the same mnemonic again should reuse the existing seed and accounts
+	reurl, accs, err := ks.ImportMnemonic(testMnemonic, "", "foo", 1)
+	if err != nil || accs[0].Address != testMnemonicAccounts[0] {
+		t.Errorf("failed to reimport mnemonic: %v", err)
+	}
+	if reurl != url {
+		t.Errorf("seed file not reused: have %v, want %v", reurl, url)
+	}
+	if have := len(ks.Accounts()); have != len(testMnemonicAccounts) {
+		t.Errorf("account count mismatch after reimport: have %d, want %d", have, len(testMnemonicAccounts))
+	}
+	seeds, _ := filepath.Glob(filepath.Join(dir, "*--seed-*"))
+	if len(seeds) != 1 {
+		t.Errorf("seed file count mismatch: have %d, want 1", len(seeds))
+	}
+	// The derivation continues after the accounts derived before the reimport
+	more, err = ks.DeriveAccounts(url, "foo", 1)
+	if err != nil {
+		t.Fatalf("failed to derive accounts after reimport: %v", err)
+	}
+	if more[0].Address == testMnemonicAccounts[len(testMnemonicAccounts)-1] {
+		t.Errorf("derived account repeated after reimport")
+	}
+	// Reimporting with a different passphrase is rejected
+	if _, _, err := ks.ImportMnemonic(testMnemonic, "", "bar", 1); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("reimport with wrong passphrase: have %v, want %v", err, ErrDecrypt)
+	}
+	// A different mnemonic password yields a different seed
+	if reurl, _, err := ks.ImportMnemonic(testMnemonic, "TREZOR", "foo", 1); err != nil || reurl == url {
+		t.Errorf("seed of different mnemonic password reused: %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+/*
+
+This file implements the version 4 key file format, which follows the modular
+layout of EIP-2335: the key derivation, checksum and cipher are described by
+separate modules, each consisting of a function, its parameters and a message.
+
+The crypto is documented at https://eips.ethereum.org/EIPS/eip-2335
+
+*/
+
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// versionV4 is the version of the modular key file format.
+	versionV4 = 4
+
+	// KDFScrypt selects scrypt as the key derivation function of version 4 key files.
+	KDFScrypt = "scrypt"
+
+	// KDFPBKDF2 selects PBKDF2 (HMAC-SHA256) as the key derivation function of
+	// version 4 key files.
+	KDFPBKDF2 = "pbkdf2"
+
+	// StandardPBKDF2C is the PBKDF2 iteration count recommended by EIP-2335.
+	StandardPBKDF2C = 1 << 18
+)
+
+// KDFConfig selects the key derivation function protecting a version 4 key file,
+// along with its parameters.
+type KDFConfig struct {
+	Function string // Key derivation function, KDFScrypt or KDFPBKDF2
+	ScryptN  int    // Scrypt CPU/memory cost parameter
+	ScryptP  int    // Scrypt parallelization parameter
+	PBKDF2C  int    // PBKDF2 iteration count
+}
+
+var (
+	// StandardScryptKDF is the recommended scrypt configuration.
+	StandardScryptKDF = KDFConfig{Function: KDFScrypt, ScryptN: StandardScryptN, ScryptP: StandardScryptP}
+
+	// LightScryptKDF is the light scrypt configuration, for memory constrained
+	// environments.
+	LightScryptKDF = KDFConfig{Function: KDFScrypt, ScryptN: LightScryptN, ScryptP: LightScryptP}
+
+	// StandardPBKDF2KDF is the recommended PBKDF2 configuration.
+	StandardPBKDF2KDF = KDFConfig{Function: KDFPBKDF2, PBKDF2C: StandardPBKDF2C}
+)
+
+type encryptedKeyJSONV4 struct {
+	Crypto      CryptoJSONV4 `json:"crypto"`
+	Description string       `json:"description"`
+	Pubkey      string       `json:"pubkey"`
+	Path        string       `json:"path"`
+	Address     string       `json:"address"` // Not part of EIP-2335, needed to index the key files
+	UUID        string       `json:"uuid"`
+	Version     int          `json:"version"`
+}
+
+// CryptoJSONV4 is the modular crypto section of the version 4 key format.
+type CryptoJSONV4 struct {
+	KDF      cryptoModuleJSON `json:"kdf"`
+	Checksum cryptoModuleJSON `json:"checksum"`
+	Cipher   cryptoModuleJSON `json:"cipher"`
+}
+
+type cryptoModuleJSON struct {
+	Function string                 `json:"function"`
+	Params   map[string]interface{} `json:"params"`
+	Message  string                 `json:"message"`
+}
+
+// normalizePasswordV4 processes a password as mandated by EIP-2335: it is
+// normalized to NFKD and stripped of the C0, C1 and Delete control codes.
+func normalizePasswordV4(auth string) []byte {
+	return []byte(strings.Map(func(r rune) rune {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			return -1
+		}
+		return r
+	}, norm.NFKD.String(auth)))
+}
+
+// EncryptDataV4 encrypts the data given as 'data' with the password 'auth' using
+// the configured key derivation function.
+func EncryptDataV4(data []byte, auth string, kdf KDFConfig) (CryptoJSONV4, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		panic("reading from crypto/rand failed: " + err.Error())
+	}
+	var (
+		password   = normalizePasswordV4(auth)
+		derivedKey []byte
+		kdfParams  = map[string]interface{}{"dklen": scryptDKLen, "salt": hex.EncodeToString(salt)}
+	)
+	switch kdf.Function {
+	case KDFScrypt:
+		var err error
+		if derivedKey, err = scrypt.Key(password, salt, kdf.ScryptN, scryptR, kdf.ScryptP, scryptDKLen); err != nil {
+			return CryptoJSONV4{}, err
+		}
+		kdfParams["n"] = kdf.ScryptN
+		kdfParams["r"] = scryptR
+		kdfParams["p"] = kdf.ScryptP
+	case KDFPBKDF2:
+		if kdf.PBKDF2C <= 0 {
+			return CryptoJSONV4{}, fmt.Errorf("invalid PBKDF2 iteration count: %d", kdf.PBKDF2C)
+		}
+		derivedKey = pbkdf2.Key(password, salt, kdf.PBKDF2C, scryptDKLen, sha256.New)
+		kdfParams["c"] = kdf.PBKDF2C
+		kdfParams["prf"] = "hmac-sha256"
+	default:
+		return CryptoJSONV4{}, fmt.Errorf("unsupported KDF: %s", kdf.Function)
+	}
+	iv := make([]byte, aes.BlockSize) // 16
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		panic("reading from crypto/rand failed: " + err.Error())
+	}
+	cipherText, err := aesCTRXOR(derivedKey[:16], data, iv)
+	if err != nil {
+		return CryptoJSONV4{}, err
+	}
+	checksum := sha256.Sum256(append(derivedKey[16:32:32], cipherText...))
+
+	return CryptoJSONV4{
+		KDF: cryptoModuleJSON{
+			Function: kdf.Function,
+			Params:   kdfParams,
+		},
+		Checksum: cryptoModuleJSON{
+			Function: "sha256",
+			Params:   map[string]interface{}{},
+			Message:  hex.EncodeToString(checksum[:]),
+		},
+		Cipher: cryptoModuleJSON{
+			Function: "aes-128-ctr",
+			Params:   map[string]interface{}{"iv": hex.EncodeToString(iv)},
+			Message:  hex.EncodeToString(cipherText),
+		},
+	}, nil
+}
+
+// DecryptDataV4 decrypts the data protected by a version 4 crypto section.
+func DecryptDataV4(cryptoJSON CryptoJSONV4, auth string) ([]byte, error) {
+	if cryptoJSON.Cipher.Function != "aes-128-ctr" {
+		return nil, fmt.Errorf("cipher not supported: %v", cryptoJSON.Cipher.Function)
+	}
+	if cryptoJSON.Checksum.Function != "sha256" {
+		return nil, fmt.Errorf("checksum not supported: %v", cryptoJSON.Checksum.Function)
+	}
+	checksum, err := hex.DecodeString(cryptoJSON.Checksum.Message)
+	if err != nil {
+		return nil, err
+	}
+	ivHex, _ := cryptoJSON.Cipher.Params["iv"].(string)
+	iv, err := hex.DecodeString(ivHex)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := hex.DecodeString(cryptoJSON.Cipher.Message)
+	if err != nil {
+		return nil, err
+	}
+	derivedKey, err := getKDFKeyV4(cryptoJSON.KDF, normalizePasswordV4(auth))
+	if err != nil {
+		return nil, err
+	}
+	calculated := sha256.Sum256(append(derivedKey[16:32:32], cipherText...))
+	if !bytes.Equal(calculated[:], checksum) {
+		return nil, ErrDecrypt
+	}
+	return aesCTRXOR(derivedKey[:16], cipherText, iv)
+}
+
+// getKDFKeyV4 derives the decryption key of a version 4 crypto section.
+func getKDFKeyV4(kdf cryptoModuleJSON, password []byte) ([]byte, error) {
+	// Validate the parameters upfront, the version 3 derivation assumes them sane
+	var required []string
+	switch kdf.Function {
+	case KDFScrypt:
+		required = []string{"dklen", "n", "r", "p"}
+	case KDFPBKDF2:
+		required = []string{"dklen", "c"}
+		if _, ok := kdf.Params["prf"].(string); !ok {
+			return nil, errors.New("missing pbkdf2 prf")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported KDF: %s", kdf.Function)
+	}
+	if _, ok := kdf.Params["salt"].(string); !ok {
+		return nil, fmt.Errorf("missing %s salt", kdf.Function)
+	}
+	for _, name := range required {
+		if _, ok := kdf.Params[name].(float64); !ok {
+			if _, ok := kdf.Params[name].(int); !ok {
+				return nil, fmt.Errorf("missing %s parameter %q", kdf.Function, name)
+			}
+		}
+	}
+	if dkLen := ensureInt(kdf.Params["dklen"]); dkLen < 32 {
+		return nil, fmt.Errorf("derived key too short: %d bytes", dkLen)
+	}
+	// The parameters are the same as in the version 3 format, reuse its derivation
+	return getKDFKey(CryptoJSON{KDF: kdf.Function, KDFParams: kdf.Params}, string(password))
+}
+
+// EncryptKeyV4 encrypts a key into a version 4 json blob using the configured
+// key derivation function.
+func EncryptKeyV4(key *Key, auth string, kdf KDFConfig) ([]byte, error) {
+	keyBytes := math.PaddedBigBytes(key.PrivateKey.D, 32)
+	cryptoStruct, err := EncryptDataV4(keyBytes, auth, kdf)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&encryptedKeyJSONV4{
+		Crypto:  cryptoStruct,
+		Pubkey:  hex.EncodeToString(crypto.CompressPubkey(&key.PrivateKey.PublicKey)),
+		Address: hex.EncodeToString(key.Address[:]),
+		UUID:    key.Id.String(),
+		Version: versionV4,
+	})
+}
+
+func decryptKeyV4(keyProtected *encryptedKeyJSONV4, auth string) (keyBytes []byte, keyId []byte, err error) {
+	if keyProtected.Version != versionV4 {
+		return nil, nil, fmt.Errorf("version not supported: %v", keyProtected.Version)
+	}
+	keyUUID, err := uuid.Parse(keyProtected.UUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyId = keyUUID[:]
+	plainText, err := DecryptDataV4(keyProtected.Crypto, auth)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plainText, keyId, err
+}
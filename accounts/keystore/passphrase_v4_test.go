@@ -0,0 +1,128 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type KeyStoreTestV4 struct {
+	Json     encryptedKeyJSONV4
+	Password string
+	Priv     string
+}
+
+// Tests the version 4 decryption against the EIP-2335 test vectors.
+func TestV4_Vectors(t *testing.T) {
+	t.Parallel()
+	tests := make(map[string]KeyStoreTestV4)
+	if err := common.LoadJSON("testdata/v4_test_vector.json", &tests); err != nil {
+		t.Fatal(err)
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			keyBytes, _, err := decryptKeyV4(&test.Json, test.Password)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := hex.EncodeToString(keyBytes); have != test.Priv {
+				t.Fatalf("decrypted bytes mismatch: have %s, want %s", have, test.Priv)
+			}
+			if _, _, err := decryptKeyV4(&test.Json, "testpassword"); !errors.Is(err, ErrDecrypt) {
+				t.Fatalf("wrong password error mismatch: have %v, want %v", err, ErrDecrypt)
+			}
+		})
+	}
+}
+
+// Tests that version 4 keys can be encrypted and decrypted with all supported
+// key derivation functions.
+func TestV4_EncryptDecrypt(t *testing.T) {
+	t.Parallel()
+	key, err := newKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kdfs := []KDFConfig{
+		{Function: KDFScrypt, ScryptN: veryLightScryptN, ScryptP: veryLightScryptP},
+		{Function: KDFPBKDF2, PBKDF2C: 2},
+	}
+	for _, kdf := range kdfs {
+		keyjson, err := EncryptKeyV4(key, "foo\u0000bar", kdf)
+		if err != nil {
+			t.Fatalf("%s: failed to encrypt key: %v", kdf.Function, err)
+		}
+		if _, err := DecryptKey(keyjson, "bad"); !errors.Is(err, ErrDecrypt) {
+			t.Errorf("%s: wrong password error mismatch: have %v, want %v", kdf.Function, err, ErrDecrypt)
+		}
+		// Control codes are stripped from version 4 passwords
+		decrypted, err := DecryptKey(keyjson, "foobar")
+		if err != nil {
+			t.Fatalf("%s: failed to decrypt key: %v", kdf.Function, err)
+		}
+		if decrypted.Address != key.Address || decrypted.Id != key.Id {
+			t.Errorf("%s: key mismatch: have %x/%v, want %x/%v", kdf.Function, decrypted.Address, decrypted.Id, key.Address, key.Id)
+		}
+	}
+	if _, err := EncryptKeyV4(key, "foo", KDFConfig{Function: "argon2"}); err == nil {
+		t.Errorf("unsupported KDF accepted")
+	}
+	if _, err := EncryptKeyV4(key, "foo", KDFConfig{Function: KDFPBKDF2}); err == nil {
+		t.Errorf("zero PBKDF2 iteration count accepted")
+	}
+}
+
+// Tests that version 4 key files are picked up and usable by the key store.
+func TestV4_KeyStore(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	key, err := newKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyjson, err := EncryptKeyV4(key, "foo", KDFConfig{Function: KDFPBKDF2, PBKDF2C: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, keyFileName(key.Address)), keyjson, 0600); err != nil {
+		t.Fatal(err)
+	}
+	ks := NewKeyStore(dir, veryLightScryptN, veryLightScryptP)
+	if !ks.HasAddress(key.Address) {
+		t.Fatalf("version 4 key not found in key store")
+	}
+	acc, err := ks.Find(accounts.Account{Address: key.Address})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Unlock(acc, "bar"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("wrong password error mismatch: have %v, want %v", err, ErrDecrypt)
+	}
+	if err := ks.Unlock(acc, "foo"); err != nil {
+		t.Errorf("failed to unlock version 4 key: %v", err)
+	}
+}
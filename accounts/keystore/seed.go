@@ -0,0 +1,240 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+const (
+	// seedType is the type tag of the key files holding hierarchical deterministic
+	// seeds, distinguishing them from the key files holding single keys.
+	seedType = "hd-seed"
+
+	// seedVersion is the version of the seed key file format.
+	seedVersion = 1
+)
+
+// encryptedSeedJSON is the on-disk format of a hierarchical deterministic seed.
+// Seed files carry no address, so they are not picked up as accounts; instead,
+// the accounts derived from them are stored as regular key files.
+type encryptedSeedJSON struct {
+	Type     string     `json:"type"`
+	Crypto   CryptoJSON `json:"crypto"`
+	Id       string     `json:"id"`
+	Version  int        `json:"version"`
+	BasePath string     `json:"basepath"` // Base derivation path of the accounts
+	Derived  int        `json:"derived"`  // Number of accounts derived so far
+	First    string     `json:"first"`    // Address of the first account, identifying the seed on re-import
+}
+
+// seedFileName implements the naming convention for seed files:
+// UTC--<created_at UTC ISO8601>--seed-<uuid>
+func seedFileName(id uuid.UUID) string {
+	return fmt.Sprintf("UTC--%s--seed-%s", toISO8601(time.Now().UTC()), id)
+}
+
+// scryptParams returns the key derivation parameters configured for the keystore.
+func (ks *KeyStore) scryptParams() (int, int) {
+	if store, ok := ks.storage.(*keyStorePassphrase); ok {
+		return store.scryptN, store.scryptP
+	}
+	return StandardScryptN, StandardScryptP
+}
+
+// ImportMnemonic stores the seed of a BIP-39 mnemonic, protected by the optional
+// mnemonic password, into the key directory as a seed file encrypted with the
+// passphrase. The first n accounts along the default derivation path are derived
+// from it and stored as regular key files, encrypted with the same passphrase.
+//
+// If the mnemonic was imported before, its seed file is reused instead of being
+// duplicated, which requires the passphrase to match the previous import.
+//
+// The URL of the seed file is returned, to be used to derive further accounts.
+func (ks *KeyStore) ImportMnemonic(mnemonic, password, passphrase string, n int) (accounts.URL, []accounts.Account, error) {
+	seed, err := MnemonicToSeed(mnemonic, password)
+	if err != nil {
+		return accounts.URL{}, nil, err
+	}
+	defer clear(seed)
+
+	// Identify the seed by its first account, to detect previous imports
+	base := accounts.DefaultBaseDerivationPath
+	priv, err := DeriveKey(seed, accounts.DefaultIterator(base)())
+	if err != nil {
+		return accounts.URL{}, nil, err
+	}
+	first := crypto.PubkeyToAddress(priv.PublicKey).Hex()
+	zeroKey(priv)
+
+	ks.importMu.Lock()
+	defer ks.importMu.Unlock()
+
+	url, file := ks.findSeed(base.String(), first)
+	if file != nil {
+		existing, err := DecryptDataV3(file.Crypto, passphrase)
+		if err != nil {
+			return accounts.URL{}, nil, fmt.Errorf("mnemonic already imported with a different passphrase: %w", err)
+		}
+		clear(existing)
+	} else {
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return accounts.URL{}, nil, err
+		}
+		scryptN, scryptP := ks.scryptParams()
+		cryptoStruct, err := EncryptDataV3(seed, []byte(passphrase), scryptN, scryptP)
+		if err != nil {
+			return accounts.URL{}, nil, err
+		}
+		file = &encryptedSeedJSON{
+			Type:     seedType,
+			Crypto:   cryptoStruct,
+			Id:       id.String(),
+			Version:  seedVersion,
+			BasePath: base.String(),
+			First:    first,
+		}
+		url = accounts.URL{Scheme: KeyStoreScheme, Path: ks.storage.JoinPath(seedFileName(id))}
+	}
+	accs, err := ks.deriveAccounts(url, file, seed, passphrase, 0, n)
+	if err != nil {
+		return accounts.URL{}, nil, err
+	}
+	return url, accs, nil
+}
+
+// findSeed looks up the seed file in the key directory with the given base path
+// and first account. Unreadable files are skipped. The caller must hold the
+// import lock.
+func (ks *KeyStore) findSeed(basePath string, first string) (accounts.URL, *encryptedSeedJSON) {
+	entries, err := os.ReadDir(ks.cache.keydir)
+	if err != nil {
+		return accounts.URL{}, nil
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.Contains(entry.Name(), "--seed-") {
+			continue
+		}
+		path := filepath.Join(ks.cache.keydir, entry.Name())
+		blob, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		file := new(encryptedSeedJSON)
+		if err := json.Unmarshal(blob, file); err != nil {
+			continue
+		}
+		if file.Type == seedType && file.Version == seedVersion && file.BasePath == basePath && file.First == first {
+			return accounts.URL{Scheme: KeyStoreScheme, Path: path}, file
+		}
+	}
+	return accounts.URL{}, nil
+}
+
+// DeriveAccounts derives the next n accounts from the seed file at the given URL,
+// storing them as regular key files encrypted with the passphrase of the seed.
+func (ks *KeyStore) DeriveAccounts(url accounts.URL, passphrase string, n int) ([]accounts.Account, error) {
+	if url.Scheme != KeyStoreScheme {
+		return nil, fmt.Errorf("unsupported seed scheme: %s", url.Scheme)
+	}
+	blob, err := os.ReadFile(url.Path)
+	if err != nil {
+		return nil, err
+	}
+	ks.importMu.Lock()
+	defer ks.importMu.Unlock()
+
+	file := new(encryptedSeedJSON)
+	if err := json.Unmarshal(blob, file); err != nil {
+		return nil, err
+	}
+	if file.Type != seedType {
+		return nil, errors.New("not a seed file")
+	}
+	if file.Version != seedVersion {
+		return nil, fmt.Errorf("seed version not supported: %v", file.Version)
+	}
+	seed, err := DecryptDataV3(file.Crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+
+	return ks.deriveAccounts(url, file, seed, passphrase, file.Derived, n)
+}
+
+// deriveAccounts derives n accounts of a seed starting at the given index, storing
+// them into the key directory along with the updated seed file. The caller must
+// hold the import lock.
+func (ks *KeyStore) deriveAccounts(url accounts.URL, file *encryptedSeedJSON, seed []byte, passphrase string, start int, n int) ([]accounts.Account, error) {
+	base, err := accounts.ParseDerivationPath(file.BasePath)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		derive = accounts.DefaultIterator(base)
+		accs   = make([]accounts.Account, 0, n)
+	)
+	for i := 0; i < start; i++ {
+		derive()
+	}
+	for i := 0; i < n; i++ {
+		path := derive()
+		priv, err := DeriveKey(seed, path)
+		if err != nil {
+			return nil, err
+		}
+		key := newKeyFromECDSA(priv)
+		if ks.cache.hasAddress(key.Address) {
+			// Account already imported, e.g. from a previous import of the same mnemonic
+			zeroKey(priv)
+			acc, err := ks.Find(accounts.Account{Address: key.Address})
+			if err != nil {
+				return nil, err
+			}
+			accs = append(accs, acc)
+			continue
+		}
+		acc, err := ks.importKey(key, passphrase)
+		zeroKey(priv)
+		if err != nil {
+			return nil, err
+		}
+		accs = append(accs, acc)
+	}
+	// Persist the number of derived accounts, to continue from there later
+	file.Derived = max(file.Derived, start+n)
+	content, err := json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeKeyFile(url.Path, content); err != nil {
+		return nil, err
+	}
+	return accs, nil
+}
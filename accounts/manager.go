@@ -17,6 +17,8 @@
 package accounts
 
 import (
+	"errors"
+	"io"
 	"reflect"
 	"sort"
 	"sync"
@@ -91,14 +93,33 @@ func NewManager(config *Config, backends ...Backend) *Manager {
 	return am
 }
 
-// Close terminates the account manager's internal notification processes.
+// Close terminates the account manager's internal notification processes and
+// releases the resources held by the backends, if they support it.
 func (am *Manager) Close() error {
 	for _, w := range am.wallets {
 		w.Close()
 	}
 	errc := make(chan error)
 	am.quit <- errc
-	return <-errc
+	errs := []error{<-errc}
+
+	// Close the backends without holding the lock, as they might block on event
+	// delivery until the subscriptions are torn down by the update loop
+	var closers []io.Closer
+	am.lock.RLock()
+	for _, backends := range am.backends {
+		for _, backend := range backends {
+			if closer, ok := backend.(io.Closer); ok {
+				closers = append(closers, closer)
+			}
+		}
+	}
+	am.lock.RUnlock()
+
+	for _, closer := range closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
 }
 
 // AddBackend starts the tracking of an additional backend for wallet updates.
@@ -0,0 +1,239 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build cgo
+
+package pkcs11wallet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/miekg/pkcs11"
+)
+
+// refreshCycle is the maximum time between wallet refreshes, as PKCS#11 has no
+// portable way of notifying about token insertions and removals.
+const refreshCycle = time.Second
+
+// refreshThrottling is the minimum time between wallet refreshes to avoid thrashing.
+const refreshThrottling = 500 * time.Millisecond
+
+// Hub is an accounts.Backend that can find and handle the tokens of a hardware
+// security module, accessed through its PKCS#11 module.
+type Hub struct {
+	config Config      // Module, token selection and credentials of the hub
+	ctx    *pkcs11.Ctx // Loaded and initialized PKCS#11 module
+
+	refreshed   time.Time               // Time instance when the list of wallets was last refreshed
+	wallets     map[uint]*Wallet        // Mapping from slot ids to wallet instances
+	updateFeed  event.Feed              // Event feed to notify wallet additions/removals
+	updateScope event.SubscriptionScope // Subscription scope tracking current live listeners
+	updating    bool                    // Whether the event notification loop is running
+	closed      bool                    // Whether the hub was closed, releasing the module
+
+	quit      chan struct{}  // Channel closed on hub termination, stopping the updater
+	wg        sync.WaitGroup // Tracks the running updater, to await it on termination
+	stateLock sync.RWMutex   // Protects the internals of the hub from racey access
+}
+
+// NewHub loads the configured PKCS#11 module and creates a new wallet manager for
+// the tokens it exposes.
+func NewHub(config Config) (*Hub, error) {
+	ctx := pkcs11.New(config.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %s", config.Module)
+	}
+	if err := ctx.Initialize(); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+		ctx.Destroy()
+		return nil, err
+	}
+	hub := &Hub{
+		config:  config,
+		ctx:     ctx,
+		wallets: make(map[uint]*Wallet),
+		quit:    make(chan struct{}),
+	}
+	hub.refreshWallets()
+	return hub, nil
+}
+
+// Wallets implements accounts.Backend, returning all the currently tracked tokens
+// of the hardware security module.
+func (hub *Hub) Wallets() []accounts.Wallet {
+	// Make sure the list of wallets is up to date
+	hub.refreshWallets()
+
+	hub.stateLock.RLock()
+	defer hub.stateLock.RUnlock()
+
+	cpy := make([]accounts.Wallet, 0, len(hub.wallets))
+	for _, wallet := range hub.wallets {
+		cpy = append(cpy, wallet)
+	}
+	sort.Sort(accounts.WalletsByURL(cpy))
+	return cpy
+}
+
+// refreshWallets scans the slots of the module for tokens matching the config
+// and updates the list of wallets based on the found tokens.
+func (hub *Hub) refreshWallets() {
+	// The module is scanned while holding the lock, so it cannot be released by
+	// a concurrent close
+	hub.stateLock.Lock()
+
+	// Don't scan the module like crazy it the user fetches wallets in a loop
+	if hub.closed || time.Since(hub.refreshed) < refreshThrottling {
+		hub.stateLock.Unlock()
+		return
+	}
+	// Retrieve all the slots with a token present
+	slots, err := hub.ctx.GetSlotList(true)
+	if err != nil {
+		hub.stateLock.Unlock()
+		log.Error("Failed to enumerate PKCS#11 slots", "err", err)
+		return
+	}
+	// Transform the current list of wallets into the new one
+
+	events := []accounts.WalletEvent{}
+	seen := make(map[uint]struct{})
+
+	for _, slot := range slots {
+		if hub.config.Slot != nil && *hub.config.Slot != slot {
+			continue
+		}
+		info, err := hub.ctx.GetTokenInfo(slot)
+		if err != nil {
+			log.Debug("Failed to retrieve PKCS#11 token info", "slot", slot, "err", err)
+			continue
+		}
+		if hub.config.Token != "" && hub.config.Token != info.Label {
+			continue
+		}
+		// Mark the slot as present
+		seen[slot] = struct{}{}
+
+		// If we already know about this token, skip to the next slot, otherwise clean up
+		if wallet, ok := hub.wallets[slot]; ok {
+			if wallet.serial == info.SerialNumber {
+				continue
+			}
+			wallet.Close()
+			events = append(events, accounts.WalletEvent{Wallet: wallet, Kind: accounts.WalletDropped})
+			delete(hub.wallets, slot)
+		}
+		// New token detected, start tracking among the wallets
+		wallet := newWallet(hub, slot, info)
+		hub.wallets[slot] = wallet
+		events = append(events, accounts.WalletEvent{Wallet: wallet, Kind: accounts.WalletArrived})
+	}
+	// Remove any wallets no longer present
+	for slot, wallet := range hub.wallets {
+		if _, ok := seen[slot]; !ok {
+			wallet.Close()
+			events = append(events, accounts.WalletEvent{Wallet: wallet, Kind: accounts.WalletDropped})
+			delete(hub.wallets, slot)
+		}
+	}
+	hub.refreshed = time.Now()
+	hub.stateLock.Unlock()
+
+	for _, event := range events {
+		hub.updateFeed.Send(event)
+	}
+}
+
+// Subscribe implements accounts.Backend, creating an async subscription to
+// receive notifications on the addition or removal of tokens.
+func (hub *Hub) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	// We need the mutex to reliably start/stop the update loop
+	hub.stateLock.Lock()
+	defer hub.stateLock.Unlock()
+
+	// Subscribe the caller and track the subscriber count
+	sub := hub.updateScope.Track(hub.updateFeed.Subscribe(sink))
+
+	// Subscribers require an active notification loop, start it
+	if !hub.updating && !hub.closed {
+		hub.updating = true
+		hub.wg.Add(1)
+		go hub.updater()
+	}
+	return sub
+}
+
+// updater is responsible for maintaining an up-to-date list of wallets managed
+// by the PKCS#11 hub, and for firing wallet addition/removal events.
+func (hub *Hub) updater() {
+	defer hub.wg.Done()
+
+	timer := time.NewTimer(refreshCycle)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+		case <-hub.quit:
+			return
+		}
+		timer.Reset(refreshCycle)
+
+		// Run the wallet refresher
+		hub.refreshWallets()
+
+		// If all our subscribers left, stop the updater
+		hub.stateLock.Lock()
+		if hub.updateScope.Count() == 0 {
+			hub.updating = false
+			hub.stateLock.Unlock()
+			return
+		}
+		hub.stateLock.Unlock()
+	}
+}
+
+// Close stops the event notification loop, closes the sessions of all tracked
+// wallets and releases the PKCS#11 module. The hub is unusable afterwards.
+func (hub *Hub) Close() error {
+	hub.stateLock.Lock()
+	if hub.closed {
+		hub.stateLock.Unlock()
+		return nil
+	}
+	hub.closed = true
+	close(hub.quit)
+
+	for slot, wallet := range hub.wallets {
+		wallet.Close()
+		delete(hub.wallets, slot)
+	}
+	hub.stateLock.Unlock()
+
+	// Wait for the updater to terminate before releasing the module
+	hub.wg.Wait()
+	hub.updateScope.Close()
+
+	err := hub.ctx.Finalize()
+	hub.ctx.Destroy()
+	return err
+}
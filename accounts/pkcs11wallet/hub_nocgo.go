@@ -0,0 +1,54 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !cgo
+
+package pkcs11wallet
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Hub is a stub PKCS#11 hub for builds without cgo, which is required to load
+// PKCS#11 modules.
+type Hub struct{}
+
+// NewHub always fails, as PKCS#11 modules cannot be loaded without cgo.
+func NewHub(config Config) (*Hub, error) {
+	return nil, errors.New("PKCS#11 support requires cgo")
+}
+
+// Wallets implements accounts.Backend, returning no wallets.
+func (hub *Hub) Wallets() []accounts.Wallet {
+	return nil
+}
+
+// Close is a no-op, as there is no module to release.
+func (hub *Hub) Close() error {
+	return nil
+}
+
+// Subscribe implements accounts.Backend, creating a subscription that never
+// delivers any events.
+func (hub *Hub) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
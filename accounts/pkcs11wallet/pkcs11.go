@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package pkcs11wallet implements support for hardware security modules that are
+// accessible through a PKCS#11 module.
+//
+// Every token matching the configuration is exposed as a wallet, whose accounts
+// are the secp256k1 key pairs stored on the token. The private keys never leave
+// the token: transactions and data are signed by the token itself, the wallet only
+// turns the resulting signatures into Ethereum's recoverable format.
+package pkcs11wallet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Scheme is the URI prefix for PKCS#11 wallets.
+const Scheme = "pkcs11"
+
+// Config contains the settings of a PKCS#11 hub.
+type Config struct {
+	Module string // Path to the PKCS#11 module (shared library) of the HSM
+	Slot   *uint  // Slot of the token holding the keys, nil to use all slots
+	Token  string // Label of the token holding the keys, empty to use all tokens
+	PIN    string // User PIN to log into the tokens with, empty to require it on open
+}
+
+var (
+	// ErrPINNeeded is returned if opening a token requires logging in, but no
+	// PIN was provided, neither on open nor in the configuration.
+	ErrPINNeeded = errors.New("pkcs11: token PIN needed")
+
+	// ErrPubkeyMismatch is returned if the public key recovered from a signature
+	// created by the token does not match the one of the signing key.
+	ErrPubkeyMismatch = errors.New("pkcs11: public key mismatch")
+)
+
+// secp256k1OID is the DER encoded object identifier of the secp256k1 curve, as
+// stored in the CKA_EC_PARAMS attribute of the keys.
+var secp256k1OID = []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1halfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// parsePublicKey parses the CKA_EC_POINT attribute of an elliptic curve public
+// key. The standard mandates a DER encoded octet string, but some modules return
+// the raw point, so both are accepted.
+func parsePublicKey(point []byte) (*ecdsa.PublicKey, error) {
+	var raw []byte
+	if rest, err := asn1.Unmarshal(point, &raw); err != nil || len(rest) > 0 {
+		raw = point
+	}
+	switch len(raw) {
+	case 65:
+		return crypto.UnmarshalPubkey(raw)
+	case 33:
+		return crypto.DecompressPubkey(raw)
+	default:
+		return nil, fmt.Errorf("invalid public key length: %d bytes", len(raw))
+	}
+}
+
+// makeRecoverableSignature converts a raw r || s signature created by the token
+// into Ethereum's [R || S || V] format. The s value is normalized into the lower
+// half of the curve order, as the token might produce either, after which the
+// recovery id is found by matching the recovered key against the expected one.
+func makeRecoverableSignature(hash, sig []byte, pubkey *ecdsa.PublicKey) ([]byte, error) {
+	if len(sig) != 64 {
+		return nil, fmt.Errorf("invalid signature length: %d bytes", len(sig))
+	}
+	s := new(big.Int).SetBytes(sig[32:])
+	if s.Cmp(secp256k1halfN) > 0 {
+		s.Sub(secp256k1N, s)
+	}
+	rsv := make([]byte, 65)
+	copy(rsv, sig[:32])
+	s.FillBytes(rsv[32:64])
+
+	expected := crypto.FromECDSAPub(pubkey)
+	for v := byte(0); v < 2; v++ {
+		rsv[64] = v
+		if recovered, err := crypto.Ecrecover(hash, rsv); err == nil && bytes.Equal(recovered, expected) {
+			return rsv, nil
+		}
+	}
+	return nil, ErrPubkeyMismatch
+}
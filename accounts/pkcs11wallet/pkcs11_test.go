@@ -0,0 +1,78 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pkcs11wallet
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	raw := crypto.FromECDSAPub(&key.PublicKey)
+	der, err := asn1.Marshal(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, point := range [][]byte{der, raw, crypto.CompressPubkey(&key.PublicKey)} {
+		pubkey, err := parsePublicKey(point)
+		if err != nil {
+			t.Fatalf("test %d: failed to parse public key: %v", i, err)
+		}
+		if !pubkey.Equal(&key.PublicKey) {
+			t.Errorf("test %d: public key mismatch", i)
+		}
+	}
+	if _, err := parsePublicKey(raw[:64]); err == nil {
+		t.Errorf("truncated public key accepted")
+	}
+}
+
+func TestMakeRecoverableSignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+
+	for i := 0; i < 16; i++ {
+		hash := crypto.Keccak256([]byte{byte(i)})
+		want, err := crypto.Sign(hash, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Tokens might produce either s value, make sure both are normalized
+		high := new(big.Int).Sub(secp256k1N, new(big.Int).SetBytes(want[32:64]))
+		for _, s := range [][]byte{want[32:64], high.FillBytes(make([]byte, 32))} {
+			sig, err := makeRecoverableSignature(hash, append(append([]byte{}, want[:32]...), s...), &key.PublicKey)
+			if err != nil {
+				t.Fatalf("test %d: failed to make signature recoverable: %v", i, err)
+			}
+			if !bytes.Equal(sig, want) {
+				t.Errorf("test %d: signature mismatch: have %x, want %x", i, sig, want)
+			}
+		}
+		if _, err := makeRecoverableSignature(hash, want[:64], &other.PublicKey); !errors.Is(err, ErrPubkeyMismatch) {
+			t.Errorf("test %d: foreign key error mismatch: have %v, want %v", i, err, ErrPubkeyMismatch)
+		}
+	}
+	if _, err := makeRecoverableSignature(make([]byte, 32), make([]byte, 65), &key.PublicKey); err == nil {
+		t.Errorf("invalid signature length accepted")
+	}
+}
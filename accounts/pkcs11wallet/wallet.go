@@ -0,0 +1,335 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build cgo
+
+package pkcs11wallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/miekg/pkcs11"
+)
+
+// maxFindObjects is the number of object handles retrieved at once during key
+// discovery.
+const maxFindObjects = 64
+
+// signingKey is a secp256k1 key pair stored on the token.
+type signingKey struct {
+	account accounts.Account    // Account derived from the public key
+	pubkey  *ecdsa.PublicKey    // Public key to match the signatures against
+	handle  pkcs11.ObjectHandle // Handle of the private key within the session
+}
+
+// Wallet represents a token of a hardware security module, exposing the secp256k1
+// keys stored on it as accounts.
+type Wallet struct {
+	hub    *Hub         // PKCS#11 hub the token belongs to
+	slot   uint         // Slot the token is inserted into
+	label  string       // Label of the token
+	serial string       // Serial number of the token, used to identify it
+	url    accounts.URL // Textual URL uniquely identifying this wallet
+
+	flags   uint                           // Token flags, defining its login requirements
+	session pkcs11.SessionHandle           // Session with the token, only valid while open
+	opened  bool                           // Whether the wallet is open and keys discovered
+	keys    map[common.Address]*signingKey // Signing keys discovered on the token
+
+	lock sync.Mutex // Lock serializing access to the session
+}
+
+// newWallet creates a wallet for the token in the given slot.
+func newWallet(hub *Hub, slot uint, info pkcs11.TokenInfo) *Wallet {
+	return &Wallet{
+		hub:    hub,
+		slot:   slot,
+		label:  info.Label,
+		serial: info.SerialNumber,
+		url:    accounts.URL{Scheme: Scheme, Path: info.SerialNumber},
+		flags:  info.Flags,
+	}
+}
+
+// URL implements accounts.Wallet, returning the URL of the token.
+func (w *Wallet) URL() accounts.URL {
+	return w.url
+}
+
+// Status implements accounts.Wallet, returning a textual status of the token.
+func (w *Wallet) Status() (string, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if !w.opened {
+		return fmt.Sprintf("Closed, token %q", w.label), nil
+	}
+	return fmt.Sprintf("Online, token %q", w.label), nil
+}
+
+// Open implements accounts.Wallet, opening a session with the token, logging in
+// with the passphrase as the user PIN and discovering the secp256k1 keys on it.
+//
+// If the passphrase is empty, the PIN of the hub configuration is used instead.
+// Tokens with a protected authentication path, such as a PIN pad, are logged
+// into without a PIN.
+func (w *Wallet) Open(passphrase string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.opened {
+		return accounts.ErrWalletAlreadyOpen
+	}
+	pin := passphrase
+	if pin == "" {
+		pin = w.hub.config.PIN
+	}
+	login := w.flags&pkcs11.CKF_LOGIN_REQUIRED != 0
+	if login && pin == "" && w.flags&pkcs11.CKF_PROTECTED_AUTHENTICATION_PATH == 0 {
+		return ErrPINNeeded
+	}
+	session, err := w.hub.ctx.OpenSession(w.slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return err
+	}
+	if login {
+		// The login state is shared by all sessions of the application with the
+		// token, so other wallets might have logged in already.
+		if err := w.hub.ctx.Login(session, pkcs11.CKU_USER, pin); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+			w.hub.ctx.CloseSession(session)
+			return err
+		}
+	}
+	keys, err := w.discover(session)
+	if err != nil {
+		w.hub.ctx.CloseSession(session)
+		return err
+	}
+	w.session, w.keys, w.opened = session, keys, true
+
+	// Notify anyone listening for wallet events that a new token is accessible
+	go w.hub.updateFeed.Send(accounts.WalletEvent{Wallet: w, Kind: accounts.WalletOpened})
+
+	return nil
+}
+
+// discover finds the secp256k1 key pairs on the token that can be signed with.
+// The key pairs are matched by their CKA_ID attributes.
+func (w *Wallet) discover(session pkcs11.SessionHandle) (map[common.Address]*signingKey, error) {
+	pubkeys, err := w.findObjects(session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, secp256k1OID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[common.Address]*signingKey)
+	for _, handle := range pubkeys {
+		attrs, err := w.hub.ctx.GetAttributeValue(session, handle, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_ID, nil),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+		})
+		if err != nil {
+			log.Debug("Failed to retrieve PKCS#11 public key", "token", w.label, "err", err)
+			continue
+		}
+		id, point := attrs[0].Value, attrs[1].Value
+
+		pubkey, err := parsePublicKey(point)
+		if err != nil {
+			log.Debug("Invalid PKCS#11 public key", "token", w.label, "id", fmt.Sprintf("%x", id), "err", err)
+			continue
+		}
+		privkeys, err := w.findObjects(session, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+			pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+			pkcs11.NewAttribute(pkcs11.CKA_ID, id),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(privkeys) == 0 {
+			log.Debug("No PKCS#11 signing key for public key", "token", w.label, "id", fmt.Sprintf("%x", id))
+			continue
+		}
+		address := crypto.PubkeyToAddress(*pubkey)
+		keys[address] = &signingKey{
+			account: accounts.Account{
+				Address: address,
+				URL:     accounts.URL{Scheme: Scheme, Path: fmt.Sprintf("%s/%x", w.serial, id)},
+			},
+			pubkey: pubkey,
+			handle: privkeys[0],
+		}
+	}
+	return keys, nil
+}
+
+// findObjects retrieves the handles of all the objects matching the template.
+func (w *Wallet) findObjects(session pkcs11.SessionHandle, template []*pkcs11.Attribute) ([]pkcs11.ObjectHandle, error) {
+	if err := w.hub.ctx.FindObjectsInit(session, template); err != nil {
+		return nil, err
+	}
+	var handles []pkcs11.ObjectHandle
+	for {
+		batch, _, err := w.hub.ctx.FindObjects(session, maxFindObjects)
+		if err != nil {
+			w.hub.ctx.FindObjectsFinal(session)
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		handles = append(handles, batch...)
+	}
+	return handles, w.hub.ctx.FindObjectsFinal(session)
+}
+
+// Close implements accounts.Wallet, closing the session with the token.
+func (w *Wallet) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if !w.opened {
+		return nil
+	}
+	w.opened, w.keys = false, nil
+	return w.hub.ctx.CloseSession(w.session)
+}
+
+// Accounts implements accounts.Wallet, returning the list of accounts backed by
+// the keys discovered on the token.
+func (w *Wallet) Accounts() []accounts.Account {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	cpy := make([]accounts.Account, 0, len(w.keys))
+	for _, key := range w.keys {
+		cpy = append(cpy, key.account)
+	}
+	sort.Sort(accounts.AccountsByURL(cpy))
+	return cpy
+}
+
+// Contains implements accounts.Wallet, returning whether a particular account is
+// or is not backed by a key of this token.
+func (w *Wallet) Contains(account accounts.Account) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	_, exists := w.keys[account.Address]
+	return exists
+}
+
+// Derive implements accounts.Wallet, but is a noop for PKCS#11 wallets since the
+// keys on the token are not hierarchical deterministic.
+func (w *Wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop for PKCS#11 wallets since
+// the keys on the token are discovered when opening it.
+func (w *Wallet) SelfDerive(bases []accounts.DerivationPath, chain ethereum.ChainStateReader) {
+}
+
+// signHash signs the given hash with the token key backing the account.
+func (w *Wallet) signHash(account accounts.Account, hash []byte) ([]byte, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if !w.opened {
+		return nil, accounts.ErrWalletClosed
+	}
+	key, ok := w.keys[account.Address]
+	if !ok {
+		return nil, accounts.ErrUnknownAccount
+	}
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}
+	if err := w.hub.ctx.SignInit(w.session, mechanism, key.handle); err != nil {
+		return nil, err
+	}
+	sig, err := w.hub.ctx.Sign(w.session, hash)
+	if err != nil {
+		return nil, err
+	}
+	return makeRecoverableSignature(hash, sig, key.pubkey)
+}
+
+// signHashWithPassphrase opens the wallet with the passphrase as the user PIN if
+// needed, and signs the given hash.
+func (w *Wallet) signHashWithPassphrase(account accounts.Account, passphrase string, hash []byte) ([]byte, error) {
+	if err := w.Open(passphrase); err != nil && !errors.Is(err, accounts.ErrWalletAlreadyOpen) {
+		return nil, err
+	}
+	return w.signHash(account, hash)
+}
+
+// SignData implements accounts.Wallet, signing the hash of the given data.
+func (w *Wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return w.signHash(account, crypto.Keccak256(data))
+}
+
+// SignDataWithPassphrase implements accounts.Wallet, signing the hash of the given
+// data with the passphrase used as the user PIN if the wallet is not open yet.
+func (w *Wallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return w.signHashWithPassphrase(account, passphrase, crypto.Keccak256(data))
+}
+
+// SignText implements accounts.Wallet, signing the hash of the given text,
+// prefixed by the Ethereum prefix scheme.
+func (w *Wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return w.signHash(account, accounts.TextHash(text))
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, signing the hash of the given
+// text with the passphrase used as the user PIN if the wallet is not open yet.
+func (w *Wallet) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
+	return w.signHashWithPassphrase(account, passphrase, accounts.TextHash(text))
+}
+
+// SignTx implements accounts.Wallet, signing the given transaction with the token
+// key backing the account.
+func (w *Wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	hash := signer.Hash(tx)
+	sig, err := w.signHash(account, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, signing the given transaction
+// with the passphrase used as the user PIN if the wallet is not open yet.
+func (w *Wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if err := w.Open(passphrase); err != nil && !errors.Is(err, accounts.ErrWalletAlreadyOpen) {
+		return nil, err
+	}
+	return w.SignTx(account, tx, chainID)
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build cgo
+
+package pkcs11wallet
+
+import (
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/miekg/pkcs11"
+)
+
+// Tests signing with a key generated on a real token. The test needs a token
+// initialized with a user PIN, e.g. with SoftHSM:
+//
+//	softhsm2-util --init-token --free --label geth-test --pin 1234 --so-pin 123456
+//	PKCS11_MODULE=/usr/lib/softhsm/libsofthsm2.so PKCS11_TOKEN=geth-test PKCS11_PIN=1234 go test
+func TestWalletSigning(t *testing.T) {
+	config := Config{
+		Module: os.Getenv("PKCS11_MODULE"),
+		Token:  os.Getenv("PKCS11_TOKEN"),
+		PIN:    os.Getenv("PKCS11_PIN"),
+	}
+	if config.Module == "" || config.Token == "" {
+		t.Skip("PKCS11_MODULE and PKCS11_TOKEN not set")
+	}
+	hub, err := NewHub(config)
+	if err != nil {
+		t.Fatalf("failed to create hub: %v", err)
+	}
+	t.Cleanup(func() { hub.Close() })
+
+	wallets := hub.Wallets()
+	if len(wallets) != 1 {
+		t.Fatalf("wallet count mismatch: have %d, want 1", len(wallets))
+	}
+	wallet := wallets[0].(*Wallet)
+
+	// Generate a fresh key pair on the token to sign with
+	address := generateTestKey(t, hub, wallet.slot, config.PIN)
+
+	if err := wallet.Open(""); err != nil {
+		t.Fatalf("failed to open wallet: %v", err)
+	}
+	defer wallet.Close()
+
+	account := accounts.Account{Address: address}
+	if !wallet.Contains(account) {
+		t.Fatalf("generated key not discovered, have %v", wallet.Accounts())
+	}
+	chainID := big.NewInt(1337)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21000,
+		To:        &common.Address{0xaa},
+		Value:     big.NewInt(1),
+	})
+	for i := 0; i < 8; i++ {
+		signed, err := wallet.SignTx(account, tx, chainID)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		if err != nil {
+			t.Fatalf("failed to recover sender: %v", err)
+		}
+		if sender != address {
+			t.Fatalf("sender mismatch: have %v, want %v", sender, address)
+		}
+	}
+	sig, err := wallet.SignText(account, []byte("hello"))
+	if err != nil {
+		t.Fatalf("failed to sign text: %v", err)
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), sig)
+	if err != nil || crypto.PubkeyToAddress(*pubkey) != address {
+		t.Fatalf("text signature not recoverable: %v", err)
+	}
+}
+
+// generateTestKey generates a secp256k1 key pair on the token in the given slot,
+// deleting it when the test finishes.
+func generateTestKey(t *testing.T, hub *Hub, slot uint, pin string) common.Address {
+	session, err := hub.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		t.Fatalf("failed to open session: %v", err)
+	}
+	t.Cleanup(func() { hub.ctx.CloseSession(session) })
+
+	if err := hub.ctx.Login(session, pkcs11.CKU_USER, pin); err != nil {
+		t.Fatalf("failed to log in: %v", err)
+	}
+	id := crypto.Keccak256([]byte(t.Name()))[:8]
+	pub, priv, err := hub.ctx.GenerateKeyPair(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, secp256k1OID),
+			pkcs11.NewAttribute(pkcs11.CKA_ID, id),
+		},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_ID, id),
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to generate key pair: %v", err)
+	}
+	t.Cleanup(func() {
+		hub.ctx.DestroyObject(session, priv)
+		hub.ctx.DestroyObject(session, pub)
+	})
+	attrs, err := hub.ctx.GetAttributeValue(session, pub, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil {
+		t.Fatalf("failed to retrieve public key: %v", err)
+	}
+	pubkey, err := parsePublicKey(attrs[0].Value)
+	if err != nil {
+		t.Fatalf("failed to parse public key: %v", err)
+	}
+	return crypto.PubkeyToAddress(*pubkey)
+}
+
+// Tests that closing the hub stops the notification loop and drops the wallets.
+// Requires a PKCS#11 token, see TestWalletSigning.
+func TestHubClose(t *testing.T) {
+	config := Config{
+		Module: os.Getenv("PKCS11_MODULE"),
+		Token:  os.Getenv("PKCS11_TOKEN"),
+	}
+	if config.Module == "" || config.Token == "" {
+		t.Skip("PKCS11_MODULE and PKCS11_TOKEN not set")
+	}
+	hub, err := NewHub(config)
+	if err != nil {
+		t.Fatalf("failed to create hub: %v", err)
+	}
+	sub := hub.Subscribe(make(chan accounts.WalletEvent, 8))
+
+	if err := hub.Close(); err != nil {
+		t.Fatalf("failed to close hub: %v", err)
+	}
+	select {
+	case <-sub.Err():
+	case <-time.After(time.Second):
+		t.Fatal("subscription not terminated on close")
+	}
+	if wallets := hub.Wallets(); len(wallets) != 0 {
+		t.Fatalf("wallets retained after close: %v", wallets)
+	}
+	if err := hub.Close(); err != nil {
+		t.Fatalf("failed to close hub twice: %v", err)
+	}
+}
+
+func TestNewHubInvalidModule(t *testing.T) {
+	if _, err := NewHub(Config{Module: "/nonexistent/libpkcs11.so"}); err == nil {
+		t.Fatalf("hub created with nonexistent module")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -64,6 +65,33 @@ func TestImportRaw(t *testing.T) {
 	})
 }
 
+// TestImportMnemonic tests clef --importmnemonic
+func TestImportMnemonic(t *testing.T) {
+	t.Parallel()
+	mnemonicPath := filepath.Join(t.TempDir(), fmt.Sprintf("%v-mnemonic.test", t.Name()))
+	os.WriteFile(mnemonicPath, []byte("test test test test test test test test test test test junk\n"), 0600)
+
+	clef := runClef(t, "--suppress-bootwarn", "--lightkdf", "importmnemonic", "--accounts", "2", mnemonicPath)
+	clef.input("").input("myverylongpassword").input("myverylongpassword")
+	out := string(clef.Output())
+	for _, addr := range []string{"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"} {
+		if !strings.Contains(out, "Address "+addr) {
+			t.Logf("Output\n%v", out)
+			t.Errorf("account %v not imported", addr)
+		}
+	}
+	// Derive a further account from the imported seed
+	seed := regexp.MustCompile(`Seed file: (\S+)`).FindStringSubmatch(out)
+	if seed == nil {
+		t.Fatalf("seed file not shown:\n%v", out)
+	}
+	clef = runWithKeystore(t, clef.Datadir, "--suppress-bootwarn", "--lightkdf", "derive", seed[1])
+	clef.input("myverylongpassword")
+	if out := string(clef.Output()); !strings.Contains(out, "Address 0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC") {
+		t.Errorf("account not derived:\n%v", out)
+	}
+}
+
 // TestListAccounts tests clef --list-accounts
 func TestListAccounts(t *testing.T) {
 	t.Parallel()
@@ -37,6 +37,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/pkcs11wallet"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -126,6 +127,19 @@ var (
 		Name:  "rules",
 		Usage: "Path to the rule file to auto-authorize requests with",
 	}
+	policyFlag = &cli.StringFlag{
+		Name:  "policy",
+		Usage: "Path to the declarative policy file to auto-authorize requests with, evaluated before the rules",
+	}
+	mnemonicAccountsFlag = &cli.IntFlag{
+		Name:  "accounts",
+		Usage: "Number of accounts to derive from the mnemonic or seed",
+		Value: 1,
+	}
+	attestPolicyFlag = &cli.BoolFlag{
+		Name:  "policy",
+		Usage: "Attest a policy file instead of a rule file",
+	}
 	stdiouiFlag = &cli.BoolFlag{
 		Name: "stdio-ui",
 		Usage: "Use STDIN/STDOUT as a channel for an external UI. " +
@@ -152,18 +166,19 @@ the rule-engine to work.`,
 	attestCommand = &cli.Command{
 		Action:    attestFile,
 		Name:      "attest",
-		Usage:     "Attest that a js-file is to be used",
+		Usage:     "Attest that a js-file or policy file is to be used",
 		ArgsUsage: "<sha256sum>",
 		Flags: []cli.Flag{
 			logLevelFlag,
 			configdirFlag,
 			signerSecretFlag,
+			attestPolicyFlag,
 		},
 		Description: `
 The attest command stores the sha256 of the rule.js-file that you want to use for automatic processing of
-incoming requests.
+incoming requests. With --policy, the sha256 of the declarative policy file is stored instead.
 
-Whenever you make an edit to the rule file, you need to use attestation to tell
+Whenever you make an edit to the rule or policy file, you need to use attestation to tell
 Clef that the file is 'safe' to execute.`,
 	}
 	setCredentialCommand = &cli.Command{
@@ -256,6 +271,43 @@ Imports an unencrypted private key from <keyfile> and creates a new account.
 Prints the address.
 The keyfile is assumed to contain an unencrypted private key in hexadecimal format.
 The account is saved in encrypted format, you are prompted for a password.
+`}
+	importMnemonicCommand = &cli.Command{
+		Action:    mnemonicImport,
+		Name:      "importmnemonic",
+		Usage:     "Import a BIP-39 mnemonic and derive accounts from it.",
+		ArgsUsage: "<mnemonicfile>",
+		Flags: []cli.Flag{
+			logLevelFlag,
+			keystoreFlag,
+			utils.LightKDFFlag,
+			acceptFlag,
+			mnemonicAccountsFlag,
+		},
+		Description: `
+Imports a BIP-39 mnemonic from <mnemonicfile> and derives the requested number of
+accounts along the default derivation path (m/44'/60'/0'/0/n). Prints the addresses.
+The seed of the mnemonic and the accounts are saved in encrypted format, you are
+prompted for a password, as well as for the optional mnemonic password.
+Importing the same mnemonic again reuses the seed saved before.
+`}
+	deriveCommand = &cli.Command{
+		Action:    deriveAccounts,
+		Name:      "derive",
+		Usage:     "Derive further accounts from an imported seed.",
+		ArgsUsage: "<seedfile>",
+		Flags: []cli.Flag{
+			logLevelFlag,
+			keystoreFlag,
+			utils.LightKDFFlag,
+			acceptFlag,
+			mnemonicAccountsFlag,
+		},
+		Description: `
+Derives the requested number of accounts from the seed file <seedfile> saved by
+importmnemonic, continuing after the accounts derived before. Prints the addresses.
+The accounts are saved in encrypted format, you are prompted for the password of
+the seed.
 `}
 )
 
@@ -271,6 +323,10 @@ func init() {
 		utils.LightKDFFlag,
 		utils.NoUSBFlag,
 		utils.SmartCardDaemonPathFlag,
+		utils.PKCS11ModuleFlag,
+		utils.PKCS11SlotFlag,
+		utils.PKCS11TokenFlag,
+		utils.PKCS11PINFileFlag,
 		utils.HTTPListenAddrFlag,
 		utils.HTTPVirtualHostsFlag,
 		utils.IPCDisabledFlag,
@@ -281,6 +337,7 @@ func init() {
 		customDBFlag,
 		auditLogFlag,
 		ruleFlag,
+		policyFlag,
 		stdiouiFlag,
 		testFlag,
 		advancedMode,
@@ -293,6 +350,8 @@ func init() {
 		delCredentialCommand,
 		newAccountCommand,
 		importRawCommand,
+		importMnemonicCommand,
+		deriveCommand,
 		gendocCommand,
 		listAccountsCommand,
 		listWalletsCommand,
@@ -393,6 +452,11 @@ func attestFile(ctx *cli.Context) error {
 	// Initialize the encrypted storages
 	configStorage := storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "config.json"), confKey)
 	val := ctx.Args().First()
+	if ctx.Bool(attestPolicyFlag.Name) {
+		configStorage.Put("policy_sha256", val)
+		log.Info("Policy attestation updated", "sha256", val)
+		return nil
+	}
 	configStorage.Put("ruleset_sha256", val)
 	log.Info("Ruleset attestation updated", "sha256", val)
 	return nil
@@ -408,7 +472,7 @@ func initInternalApi(c *cli.Context) (*core.UIServerAPI, core.UIClientAPI, error
 		ksLoc                     = c.String(keystoreFlag.Name)
 		lightKdf                  = c.Bool(utils.LightKDFFlag.Name)
 	)
-	am := core.StartClefAccountManager(ksLoc, true, lightKdf, "")
+	am := core.StartClefAccountManager(ksLoc, true, lightKdf, "", nil)
 	api := core.NewSignerAPI(am, 0, true, ui, nil, false, pwStorage)
 	internalApi := core.NewUIServerAPI(api)
 	return internalApi, ui, nil
@@ -601,6 +665,101 @@ Make sure to backup keystore and passwords in a safe location.`,
 	return nil
 }
 
+// mnemonicImport imports a BIP-39 mnemonic via CLI.
+func mnemonicImport(c *cli.Context) error {
+	if c.Args().Len() != 1 {
+		return errors.New("<mnemonicfile> must be given as first argument")
+	}
+	internalApi, ui, err := initInternalApi(c)
+	if err != nil {
+		return err
+	}
+	mnemonic, err := os.ReadFile(c.Args().First())
+	if err != nil {
+		return err
+	}
+	readPw := func(prompt string) (string, error) {
+		resp, err := ui.OnInputRequired(core.UserInputRequest{
+			Title:      "Password",
+			Prompt:     prompt,
+			IsPassword: true,
+		})
+		if err != nil {
+			return "", err
+		}
+		return resp.Text, nil
+	}
+	mnemonicPw, err := readPw("Please enter the mnemonic password, if any")
+	if err != nil {
+		return err
+	}
+	first, err := readPw("Please enter a password for the imported accounts")
+	if err != nil {
+		return err
+	}
+	second, err := readPw("Please repeat the password you just entered")
+	if err != nil {
+		return err
+	}
+	if first != second {
+		//lint:ignore ST1005 This is a message for the user
+		return errors.New("Passwords do not match")
+	}
+	imported, err := internalApi.ImportMnemonic(string(mnemonic), mnemonicPw, first, c.Int(mnemonicAccountsFlag.Name))
+	if err != nil {
+		return err
+	}
+	var addrs strings.Builder
+	for _, acc := range imported.Accounts {
+		fmt.Fprintf(&addrs, "  Address %v\n  Keystore file: %v\n", acc.Address, acc.URL.Path)
+	}
+	ui.ShowInfo(fmt.Sprintf(`Mnemonic imported:
+%s  Seed file: %v
+
+Further accounts can be derived from the seed file via the derive command.
+
+The keys are now encrypted; losing the password will result in permanently losing
+access to the keys and all associated funds, unless the mnemonic is kept safe!
+
+Make sure to backup the mnemonic in a safe location.`,
+		addrs.String(), imported.Seed.Path))
+	return nil
+}
+
+// deriveAccounts derives further accounts from an imported seed via CLI.
+func deriveAccounts(c *cli.Context) error {
+	if c.Args().Len() != 1 {
+		return errors.New("<seedfile> must be given as first argument")
+	}
+	path, err := filepath.Abs(c.Args().First())
+	if err != nil {
+		return err
+	}
+	internalApi, ui, err := initInternalApi(c)
+	if err != nil {
+		return err
+	}
+	resp, err := ui.OnInputRequired(core.UserInputRequest{
+		Title:      "Password",
+		Prompt:     "Please enter the password of the seed",
+		IsPassword: true,
+	})
+	if err != nil {
+		return err
+	}
+	seed := accounts.URL{Scheme: keystore.KeyStoreScheme, Path: path}
+	accs, err := internalApi.DeriveAccounts(seed, resp.Text, c.Int(mnemonicAccountsFlag.Name))
+	if err != nil {
+		return err
+	}
+	var addrs strings.Builder
+	for _, acc := range accs {
+		fmt.Fprintf(&addrs, "  Address %v\n  Keystore file: %v\n", acc.Address, acc.URL.Path)
+	}
+	ui.ShowInfo(fmt.Sprintf("Accounts derived:\n%s", addrs.String()))
+	return nil
+}
+
 // ipcEndpoint resolves an IPC endpoint based on a configured value, taking into
 // account the set data folders as well as the designated platform we're currently
 // running on.
@@ -622,6 +781,31 @@ func ipcEndpoint(ipcPath, datadir string) string {
 	return ipcPath
 }
 
+// makePKCS11Config assembles the configuration of the PKCS#11 hub from the
+// command line flags, or returns nil if no PKCS#11 module is configured.
+func makePKCS11Config(c *cli.Context) *pkcs11wallet.Config {
+	module := c.String(utils.PKCS11ModuleFlag.Name)
+	if module == "" {
+		return nil
+	}
+	config := &pkcs11wallet.Config{
+		Module: module,
+		Token:  c.String(utils.PKCS11TokenFlag.Name),
+	}
+	if c.IsSet(utils.PKCS11SlotFlag.Name) {
+		slot := c.Uint(utils.PKCS11SlotFlag.Name)
+		config.Slot = &slot
+	}
+	if file := c.String(utils.PKCS11PINFileFlag.Name); file != "" {
+		pin, err := os.ReadFile(file)
+		if err != nil {
+			utils.Fatalf("Failed to read PKCS#11 PIN file: %v", err)
+		}
+		config.PIN = strings.TrimRight(string(pin), "\r\n")
+	}
+	return config
+}
+
 func signer(c *cli.Context) error {
 	// If we have some unrecognized command, bail out
 	if c.NArg() > 0 {
@@ -650,8 +834,9 @@ func signer(c *cli.Context) error {
 	log.Info("Loaded 4byte database", "embeds", embeds, "locals", locals, "local", fourByteLocal)
 
 	var (
-		api       core.ExternalAPI
-		pwStorage storage.Storage = &storage.NoStorage{}
+		api          core.ExternalAPI
+		pwStorage    storage.Storage = &storage.NoStorage{}
+		policyEngine interface{ SetAuditor(core.DecisionAuditor) }
 	)
 	configDir := c.String(configdirFlag.Name)
 	if stretchedKey, err := readMasterKey(c, ui); err != nil {
@@ -663,6 +848,7 @@ func signer(c *cli.Context) error {
 		pwkey := crypto.Keccak256([]byte("credentials"), stretchedKey)
 		jskey := crypto.Keccak256([]byte("jsstorage"), stretchedKey)
 		confkey := crypto.Keccak256([]byte("config"), stretchedKey)
+		policykey := crypto.Keccak256([]byte("policystorage"), stretchedKey)
 
 		// Initialize the encrypted storages
 		pwStorage = storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "credentials.json"), pwkey)
@@ -692,6 +878,29 @@ func signer(c *cli.Context) error {
 				}
 			}
 		}
+		// Do we have a policy-file? It wraps the rules, so it's evaluated first
+		if policyFile := c.String(policyFlag.Name); policyFile != "" {
+			blob, err := os.ReadFile(policyFile)
+			if err != nil {
+				log.Warn("Could not load policy, disabling", "file", policyFile, "err", err)
+			} else {
+				shasum := sha256.Sum256(blob)
+				foundShaSum := hex.EncodeToString(shasum[:])
+				storedShasum, _ := configStorage.Get("policy_sha256")
+				if storedShasum != foundShaSum {
+					log.Warn("Policy hash not attested, disabling", "hash", foundShaSum, "attested", storedShasum)
+				} else {
+					policy, err := rules.ParsePolicy(blob)
+					if err != nil {
+						utils.Fatalf("Invalid policy file: %v", err)
+					}
+					policyStorage := storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "policystorage.json"), policykey)
+					engine := rules.NewPolicyEvaluator(ui, policy, policyStorage, db)
+					ui, policyEngine = engine, engine
+					log.Info("Policy engine configured", "file", policyFile, "fallback", policy.Fallback)
+				}
+			}
+		}
 	}
 	var (
 		chainId  = c.Int64(chainIdFlag.Name)
@@ -703,7 +912,7 @@ func signer(c *cli.Context) error {
 	)
 	log.Info("Starting signer", "chainid", chainId, "keystore", ksLoc,
 		"light-kdf", lightKdf, "advanced", advanced)
-	am := core.StartClefAccountManager(ksLoc, nousb, lightKdf, scpath)
+	am := core.StartClefAccountManager(ksLoc, nousb, lightKdf, scpath, makePKCS11Config(c))
 	defer am.Close()
 	apiImpl := core.NewSignerAPI(am, chainId, nousb, ui, db, advanced, pwStorage)
 
@@ -714,10 +923,14 @@ func signer(c *cli.Context) error {
 
 	// Audit logging
 	if logfile := c.String(auditLogFlag.Name); logfile != "" {
-		api, err = core.NewAuditLogger(logfile, api)
+		auditLogger, err := core.NewAuditLogger(logfile, api)
 		if err != nil {
 			utils.Fatalf(err.Error())
 		}
+		if policyEngine != nil {
+			policyEngine.SetAuditor(auditLogger)
+		}
+		api = auditLogger
 		log.Info("Audit logs configured", "file", logfile)
 	}
 	// register signer API with server
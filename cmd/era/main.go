@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -32,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/internal/era"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/urfave/cli/v2"
@@ -59,6 +62,11 @@ var (
 		Name:  "txs",
 		Usage: "print full transaction values",
 	}
+	addrFlag = &cli.StringFlag{
+		Name:  "addr",
+		Usage: "listening address of the era1 server",
+		Value: "localhost:8080",
+	}
 )
 
 var (
@@ -83,6 +91,14 @@ var (
 		Usage:     "verifies each era1 against expected accumulator root",
 		Action:    verify,
 	}
+	serveCommand = &cli.Command{
+		Name:   "serve",
+		Usage:  "serves the era1 files and their blocks over HTTP under /era/",
+		Action: serve,
+		Flags: []cli.Flag{
+			addrFlag,
+		},
+	}
 )
 
 func init() {
@@ -90,6 +106,7 @@ func init() {
 		blockCommand,
 		infoCommand,
 		verifyCommand,
+		serveCommand,
 	}
 	app.Flags = []cli.Flag{
 		dirFlag,
@@ -305,6 +322,24 @@ func checkAccumulator(e *era.Era) error {
 	return nil
 }
 
+// serve serves the era1 files of the directory over HTTP until interrupted.
+func serve(ctx *cli.Context) error {
+	handler, err := node.NewEraHandler(ctx.String(dirFlag.Name), ctx.String(networkFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer handler.Close()
+	mux := http.NewServeMux()
+	mux.Handle("/era/", http.StripPrefix("/era", handler))
+
+	listener, err := net.Listen("tcp", ctx.String(addrFlag.Name))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Serving era1 files on http://%s/era/\n", listener.Addr())
+	return http.Serve(listener, mux)
+}
+
 // readHashes reads a file of newline-delimited hashes.
 func readHashes(f string) ([]common.Hash, error) {
 	b, err := os.ReadFile(f)
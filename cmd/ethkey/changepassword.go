@@ -36,10 +36,17 @@ var commandChangePassphrase = &cli.Command{
 	Usage:     "change the password on a keyfile",
 	ArgsUsage: "<keyfile>",
 	Description: `
-Change the password of a keyfile.`,
+Change the password of a keyfile.
+
+The keyfile is rewritten in the format selected by --format (v3 or v4) and, for
+v4 keyfiles, protected by the --kdf key derivation function. This can be used to
+convert keyfiles between the formats.`,
 	Flags: []cli.Flag{
 		passphraseFlag,
 		newPassphraseFlag,
+		lightKDFFlag,
+		keyFormatFlag,
+		kdfFlag,
 	},
 	Action: func(ctx *cli.Context) error {
 		keyfilepath := ctx.Args().First()
@@ -71,7 +78,7 @@ Change the password of a keyfile.`,
 		}
 
 		// Encrypt the key with the new passphrase.
-		newJson, err := keystore.EncryptKey(key, newPhrase, keystore.StandardScryptN, keystore.StandardScryptP)
+		newJson, err := encryptKey(ctx, key, newPhrase)
 		if err != nil {
 			utils.Fatalf("Error encrypting with new password: %v", err)
 		}
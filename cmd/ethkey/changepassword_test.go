@@ -0,0 +1,64 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChangePasswordFormat(t *testing.T) {
+	t.Parallel()
+	tmpdir := t.TempDir()
+
+	keyfile := filepath.Join(tmpdir, "the-keyfile")
+	oldpass := filepath.Join(tmpdir, "oldpass")
+	newpass := filepath.Join(tmpdir, "newpass")
+	os.WriteFile(oldpass, []byte("foobar"), 0600)
+	os.WriteFile(newpass, []byte("barfoo"), 0600)
+
+	// Create a v3 key and convert it to the v4 format.
+	generate := runEthkey(t, "generate", "--lightkdf", "--passwordfile", oldpass, keyfile)
+	_, matches := generate.ExpectRegexp(`Address: (0x[0-9a-fA-F]{40})\n`)
+	address := matches[1]
+	generate.ExpectExit()
+
+	change := runEthkey(t, "changepassword", "--passwordfile", oldpass, "--newpasswordfile", newpass, "--format", "v4", "--kdf", "pbkdf2", keyfile)
+	change.Expect("Please provide a new password\n")
+	change.ExpectExit()
+
+	content, err := os.ReadFile(keyfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keyjson struct {
+		Version int
+		Crypto  struct{ KDF struct{ Function string } }
+	}
+	if err := json.Unmarshal(content, &keyjson); err != nil {
+		t.Fatal(err)
+	}
+	if keyjson.Version != 4 || keyjson.Crypto.KDF.Function != "pbkdf2" {
+		t.Fatalf("keyfile not converted: version %d, kdf %q", keyjson.Version, keyjson.Crypto.KDF.Function)
+	}
+	// Check that the converted key is usable with the new password.
+	inspect := runEthkey(t, "inspect", "--passwordfile", newpass, keyfile)
+	inspect.ExpectRegexp(`Address:\s+` + address + `\nPublic key:\s+[0-9a-f]+\n`)
+	inspect.ExpectExit()
+}
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -32,6 +33,7 @@ import (
 type outputGenerate struct {
 	Address      string
 	AddressEIP55 string
+	Mnemonic     string `json:",omitempty"`
 }
 
 var (
@@ -43,6 +45,15 @@ var (
 		Name:  "lightkdf",
 		Usage: "use less secure scrypt parameters",
 	}
+	mnemonicFlag = &cli.BoolFlag{
+		Name:  "mnemonic",
+		Usage: "derive the key from a new BIP-39 mnemonic, which is printed",
+	}
+	hdPathFlag = &cli.StringFlag{
+		Name:  "hdpath",
+		Usage: "BIP-32 derivation path of the key derived from the mnemonic",
+		Value: accounts.DefaultBaseDerivationPath.String(),
+	}
 )
 
 var commandGenerate = &cli.Command{
@@ -54,12 +65,25 @@ Generate a new keyfile.
 
 If you want to encrypt an existing private key, it can be specified by setting
 --privatekey with the location of the file containing the private key.
+
+If you want to be able to recover the key from a BIP-39 mnemonic, set --mnemonic
+to derive the key from a newly generated one at the --hdpath derivation path.
+Write down the printed mnemonic and keep it safe: anyone knowing it controls the
+key.
+
+The keyfile is written in the v3 format by default. Set --format v4 to write it
+in the EIP-2335 style v4 format instead, protected by the --kdf key derivation
+function (scrypt or pbkdf2).
 `,
 	Flags: []cli.Flag{
 		passphraseFlag,
 		jsonFlag,
 		privateKeyFlag,
 		lightKDFFlag,
+		keyFormatFlag,
+		kdfFlag,
+		mnemonicFlag,
+		hdPathFlag,
 	},
 	Action: func(ctx *cli.Context) error {
 		// Check if keyfile path given and make sure it doesn't already exist.
@@ -74,13 +98,35 @@ If you want to encrypt an existing private key, it can be specified by setting
 		}
 
 		var privateKey *ecdsa.PrivateKey
+		var mnemonic string
 		var err error
+		if ctx.IsSet(privateKeyFlag.Name) && ctx.Bool(mnemonicFlag.Name) {
+			utils.Fatalf("Flags --%s and --%s are mutually exclusive", privateKeyFlag.Name, mnemonicFlag.Name)
+		}
 		if file := ctx.String(privateKeyFlag.Name); file != "" {
 			// Load private key from file.
 			privateKey, err = crypto.LoadECDSA(file)
 			if err != nil {
 				utils.Fatalf("Can't load private key: %v", err)
 			}
+		} else if ctx.Bool(mnemonicFlag.Name) {
+			// Derive the key from a new mnemonic.
+			path, err := accounts.ParseDerivationPath(ctx.String(hdPathFlag.Name))
+			if err != nil {
+				utils.Fatalf("Invalid derivation path: %v", err)
+			}
+			mnemonic, err = keystore.NewMnemonic(256)
+			if err != nil {
+				utils.Fatalf("Failed to generate mnemonic: %v", err)
+			}
+			seed, err := keystore.MnemonicToSeed(mnemonic, "")
+			if err != nil {
+				utils.Fatalf("Failed to generate seed: %v", err)
+			}
+			privateKey, err = keystore.DeriveKey(seed, path)
+			if err != nil {
+				utils.Fatalf("Failed to derive private key: %v", err)
+			}
 		} else {
 			// If not loaded, generate random.
 			privateKey, err = crypto.GenerateKey()
@@ -102,11 +148,7 @@ If you want to encrypt an existing private key, it can be specified by setting
 
 		// Encrypt key with passphrase.
 		passphrase := getPassphrase(ctx, true)
-		scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
-		if ctx.Bool(lightKDFFlag.Name) {
-			scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
-		}
-		keyjson, err := keystore.EncryptKey(key, passphrase, scryptN, scryptP)
+		keyjson, err := encryptKey(ctx, key, passphrase)
 		if err != nil {
 			utils.Fatalf("Error encrypting key: %v", err)
 		}
@@ -121,12 +163,16 @@ If you want to encrypt an existing private key, it can be specified by setting
 
 		// Output some information.
 		out := outputGenerate{
-			Address: key.Address.Hex(),
+			Address:  key.Address.Hex(),
+			Mnemonic: mnemonic,
 		}
 		if ctx.Bool(jsonFlag.Name) {
 			mustPrintJSON(out)
 		} else {
 			fmt.Println("Address:", out.Address)
+			if out.Mnemonic != "" {
+				fmt.Println("Mnemonic:", out.Mnemonic)
+			}
 		}
 		return nil
 	},
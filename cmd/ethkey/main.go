@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/urfave/cli/v2"
 )
@@ -51,6 +52,16 @@ var (
 		Name:  "json",
 		Usage: "output JSON instead of human-readable format",
 	}
+	keyFormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "keyfile format to write: v3 or v4 (EIP-2335 style)",
+		Value: "v3",
+	}
+	kdfFlag = &cli.StringFlag{
+		Name:  "kdf",
+		Usage: "key derivation function of v4 keyfiles: scrypt or pbkdf2",
+		Value: keystore.KDFScrypt,
+	}
 )
 
 func main() {
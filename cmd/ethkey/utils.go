@@ -22,6 +22,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/urfave/cli/v2"
 )
@@ -45,6 +46,44 @@ func getPassphrase(ctx *cli.Context, confirmation bool) string {
 	return utils.GetPassPhrase("", confirmation)
 }
 
+// encryptKey encrypts a key with the given passphrase into a keyfile, using the
+// format and key derivation function selected by the command line flags.
+func encryptKey(ctx *cli.Context, key *keystore.Key, passphrase string) ([]byte, error) {
+	light := ctx.Bool(lightKDFFlag.Name)
+	switch format := ctx.String(keyFormatFlag.Name); format {
+	case "v3":
+		if kdf := ctx.String(kdfFlag.Name); kdf != keystore.KDFScrypt {
+			return nil, fmt.Errorf("v3 keyfiles do not support the %s key derivation function", kdf)
+		}
+		scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+		if light {
+			scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+		}
+		return keystore.EncryptKey(key, passphrase, scryptN, scryptP)
+
+	case "v4":
+		var kdf keystore.KDFConfig
+		switch function := ctx.String(kdfFlag.Name); function {
+		case keystore.KDFScrypt:
+			kdf = keystore.StandardScryptKDF
+			if light {
+				kdf = keystore.LightScryptKDF
+			}
+		case keystore.KDFPBKDF2:
+			if light {
+				return nil, fmt.Errorf("--%s is only supported by scrypt", lightKDFFlag.Name)
+			}
+			kdf = keystore.StandardPBKDF2KDF
+		default:
+			return nil, fmt.Errorf("unknown key derivation function %q", function)
+		}
+		return keystore.EncryptKeyV4(key, passphrase, kdf)
+
+	default:
+		return nil, fmt.Errorf("unknown keyfile format %q", format)
+	}
+}
+
 // mustPrintJSON prints the JSON encoding of the given object and
 // exits the program with an error message when the marshaling fails.
 func mustPrintJSON(jsonObject interface{}) {
@@ -20,16 +20,30 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/console/prompt"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
 )
 
+var (
+	mnemonicAccountsFlag = &cli.IntFlag{
+		Name:  "accounts",
+		Usage: "Number of accounts to derive from the mnemonic or seed",
+		Value: 1,
+	}
+	mnemonicPasswordFlag = &cli.BoolFlag{
+		Name:  "mnemonic-password",
+		Usage: "Prompt for the BIP-39 password (a.k.a. 25th word) protecting the mnemonic",
+	}
+)
+
 var (
 	walletCommand = &cli.Command{
 		Name:      "wallet",
@@ -184,6 +198,63 @@ Note:
 As you can directly copy your encrypted accounts to another ethereum instance,
 this import mechanism is not needed when you transfer an account between
 nodes.
+`,
+			},
+			{
+				Name:   "import-mnemonic",
+				Usage:  "Import a BIP-39 mnemonic and derive accounts from it",
+				Action: accountImportMnemonic,
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.KeyStoreDirFlag,
+					utils.PasswordFileFlag,
+					utils.LightKDFFlag,
+					mnemonicAccountsFlag,
+					mnemonicPasswordFlag,
+				},
+				ArgsUsage: "[<mnemonicFile>]",
+				Description: `
+    geth account import-mnemonic [options] [<mnemonicfile>]
+
+Imports a BIP-39 mnemonic from <mnemonicfile>, or prompts for it if no file is
+given, and derives the requested number of accounts along the default derivation
+path (m/44'/60'/0'/0/n). Prints the addresses.
+
+The seed of the mnemonic is saved into the keystore in encrypted format, so
+further accounts can be derived from it later. The derived accounts are saved
+as regular encrypted key files. You are prompted for a password protecting both.
+
+Importing the same mnemonic again reuses the seed saved before, which requires
+the same password.
+
+For non-interactive use the password can be specified with the -password flag:
+
+    geth account import-mnemonic [options] <mnemonicfile>
+`,
+			},
+			{
+				Name:   "derive",
+				Usage:  "Derive further accounts from an imported seed",
+				Action: accountDerive,
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.KeyStoreDirFlag,
+					utils.PasswordFileFlag,
+					utils.LightKDFFlag,
+					mnemonicAccountsFlag,
+				},
+				ArgsUsage: "<seedFile>",
+				Description: `
+    geth account derive [options] <seedfile>
+
+Derives the requested number of accounts from the seed file saved by
+import-mnemonic, continuing after the accounts derived before. Prints the
+addresses. You are prompted for the password of the seed, which also protects
+the derived accounts.
+
+For non-interactive use the password can be specified with the -password flag:
+
+    geth account derive [options] <seedfile>
 `,
 			},
 		},
@@ -365,3 +436,89 @@ func accountImport(ctx *cli.Context) error {
 	fmt.Printf("Address: {%x}\n", acct.Address)
 	return nil
 }
+
+// accountImportMnemonic imports a BIP-39 mnemonic into the keystore, deriving
+// the requested number of accounts from it.
+func accountImportMnemonic(ctx *cli.Context) error {
+	if ctx.Args().Len() > 1 {
+		utils.Fatalf("mnemonic file must be given as the only argument")
+	}
+	var mnemonic string
+	if ctx.Args().Len() == 1 {
+		blob, err := os.ReadFile(ctx.Args().First())
+		if err != nil {
+			utils.Fatalf("Failed to read the mnemonic: %v", err)
+		}
+		mnemonic = string(blob)
+	} else {
+		input, err := prompt.Stdin.PromptPassword("Mnemonic: ")
+		if err != nil {
+			utils.Fatalf("Failed to read the mnemonic: %v", err)
+		}
+		mnemonic = input
+	}
+	var mnemonicPassword string
+	if ctx.Bool(mnemonicPasswordFlag.Name) {
+		input, err := prompt.Stdin.PromptPassword("Mnemonic password: ")
+		if err != nil {
+			utils.Fatalf("Failed to read the mnemonic password: %v", err)
+		}
+		mnemonicPassword = input
+	}
+	n := ctx.Int(mnemonicAccountsFlag.Name)
+	if n < 1 {
+		utils.Fatalf("At least one account must be derived")
+	}
+	am := makeAccountManager(ctx)
+	backends := am.Backends(keystore.KeyStoreType)
+	if len(backends) == 0 {
+		utils.Fatalf("Keystore is not available")
+	}
+	ks := backends[0].(*keystore.KeyStore)
+	password, ok := readPasswordFromFile(ctx.Path(utils.PasswordFileFlag.Name))
+	if !ok {
+		password = utils.GetPassPhrase("Your new accounts are locked with a password. Please give a password. Do not forget this password.", true)
+	}
+	seed, accs, err := ks.ImportMnemonic(mnemonic, mnemonicPassword, password, n)
+	if err != nil {
+		utils.Fatalf("Could not import the mnemonic: %v", err)
+	}
+	for _, acct := range accs {
+		fmt.Printf("Address: {%x}\n", acct.Address)
+	}
+	fmt.Printf("Seed: %s\n", seed.Path)
+	return nil
+}
+
+// accountDerive derives further accounts from a seed imported into the keystore.
+func accountDerive(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("seed file must be given as the only argument")
+	}
+	path, err := filepath.Abs(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Invalid seed file: %v", err)
+	}
+	n := ctx.Int(mnemonicAccountsFlag.Name)
+	if n < 1 {
+		utils.Fatalf("At least one account must be derived")
+	}
+	am := makeAccountManager(ctx)
+	backends := am.Backends(keystore.KeyStoreType)
+	if len(backends) == 0 {
+		utils.Fatalf("Keystore is not available")
+	}
+	ks := backends[0].(*keystore.KeyStore)
+	password, ok := readPasswordFromFile(ctx.Path(utils.PasswordFileFlag.Name))
+	if !ok {
+		password = utils.GetPassPhrase("Please give the password of the seed.", false)
+	}
+	accs, err := ks.DeriveAccounts(accounts.URL{Scheme: keystore.KeyStoreScheme, Path: path}, password, n)
+	if err != nil {
+		utils.Fatalf("Could not derive the accounts: %v", err)
+	}
+	for _, acct := range accs {
+		fmt.Printf("Address: {%x}\n", acct.Address)
+	}
+	return nil
+}
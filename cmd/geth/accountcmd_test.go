@@ -119,6 +119,45 @@ func TestAccountImport(t *testing.T) {
 	}
 }
 
+func TestAccountImportMnemonic(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	mnemonicFile := filepath.Join(dir, "mnemonic.txt")
+	if err := os.WriteFile(mnemonicFile, []byte("test test test test test test test test test test test junk\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	passwordFile := filepath.Join(dir, "password.txt")
+	if err := os.WriteFile(passwordFile, []byte("foobar"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	geth := runGeth(t, "--lightkdf", "--datadir", dir, "account", "import-mnemonic", "--accounts", "2", "--password", passwordFile, mnemonicFile)
+	geth.Expect(`
+Address: {f39fd6e51aad88f6f4ce6ab8827279cfffb92266}
+Address: {70997970c51812dc3a010c7d01b50e0d17dc79c8}
+`)
+	geth.ExpectRegexp(`Seed: .*UTC--.+--seed-[0-9a-f-]{36}\n`)
+	geth.ExpectExit()
+
+	// Importing the mnemonic again must reuse the seed file
+	geth = runGeth(t, "--lightkdf", "--datadir", dir, "account", "import-mnemonic", "--password", passwordFile, mnemonicFile)
+	geth.Expect(`
+Address: {f39fd6e51aad88f6f4ce6ab8827279cfffb92266}
+`)
+	geth.ExpectRegexp(`Seed: .*UTC--.+--seed-[0-9a-f-]{36}\n`)
+	geth.ExpectExit()
+
+	seeds, err := filepath.Glob(filepath.Join(dir, "keystore", "*--seed-*"))
+	if err != nil || len(seeds) != 1 {
+		t.Fatalf("seed file count mismatch: have %d, want 1 (err %v)", len(seeds), err)
+	}
+	// Further accounts continue after the ones derived before
+	geth = runGeth(t, "--lightkdf", "--datadir", dir, "account", "derive", "--password", passwordFile, seeds[0])
+	geth.Expect(`
+Address: {3c44cdddb6a900fa2b585dd299e03d12fa4293bc}
+`)
+	geth.ExpectExit()
+}
+
 func TestAccountHelp(t *testing.T) {
 	t.Parallel()
 	geth := runGeth(t, "account", "-h")
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/external"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/pkcs11wallet"
 	"github.com/ethereum/go-ethereum/accounts/scwallet"
 	"github.com/ethereum/go-ethereum/accounts/usbwallet"
 	"github.com/ethereum/go-ethereum/beacon/blsync"
@@ -218,6 +219,12 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 	if ctx.IsSet(utils.GraphQLEnabledFlag.Name) {
 		utils.RegisterGraphQLService(stack, backend, filterSystem, &cfg.Node)
 	}
+	// Serve the era1 history files if requested.
+	if ctx.IsSet(utils.EraServeFlag.Name) {
+		if err := stack.RegisterEraHandler(ctx.String(utils.EraServeFlag.Name), ""); err != nil {
+			utils.Fatalf("Failed to serve era1 files: %v", err)
+		}
+	}
 	// Add the Ethereum Stats daemon if requested.
 	if cfg.Ethstats.URL != "" {
 		utils.RegisterEthStatsService(stack, backend, cfg.Ethstats.URL)
@@ -231,7 +238,9 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 		utils.RegisterFullSyncTester(stack, eth, common.BytesToHash(hex))
 	}
 
-	if ctx.IsSet(utils.DeveloperFlag.Name) {
+	if cfg.Eth.Follow != "" {
+		// Followers don't sync, the chain is driven by the followed node.
+	} else if ctx.IsSet(utils.DeveloperFlag.Name) {
 		// Start dev mode.
 		simBeacon, err := catalyst.NewSimulatedBeacon(ctx.Uint64(utils.DeveloperPeriodFlag.Name), cfg.Eth.Miner.PendingFeeRecipient, eth)
 		if err != nil {
@@ -403,6 +412,26 @@ func setAccountManagerBackends(conf *node.Config, am *accounts.Manager, keydir s
 			am.AddBackend(schub)
 		}
 	}
+	if len(conf.PKCS11Module) > 0 {
+		config := pkcs11wallet.Config{
+			Module: conf.PKCS11Module,
+			Slot:   conf.PKCS11Slot,
+			Token:  conf.PKCS11Token,
+		}
+		if len(conf.PKCS11PINFile) > 0 {
+			pin, err := os.ReadFile(conf.PKCS11PINFile)
+			if err != nil {
+				return fmt.Errorf("failed to read PKCS#11 PIN file: %v", err)
+			}
+			config.PIN = strings.TrimRight(string(pin), "\r\n")
+		}
+		// Start a hub for the tokens of the hardware security module
+		if p11hub, err := pkcs11wallet.NewHub(config); err != nil {
+			log.Warn(fmt.Sprintf("Failed to start PKCS#11 hub, disabling: %v", err))
+		} else {
+			am.AddBackend(p11hub)
+		}
+	}
 
 	return nil
 }
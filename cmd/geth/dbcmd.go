@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -32,12 +33,14 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/console/prompt"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/ethereum/go-ethereum/triedb"
@@ -83,6 +86,8 @@ Remove blockchain and state databases`,
 			dbMetadataCmd,
 			dbCheckStateContentCmd,
 			dbInspectHistoryCmd,
+			dbIndexAddressesCmd,
+			dbVerifyAddressesCmd,
 		},
 	}
 	dbInspectCmd = &cli.Command{
@@ -207,6 +212,25 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		}, utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command queries the history of the account or storage slot within the specified block range",
 	}
+	dbIndexAddressesCmd = &cli.Command{
+		Action: indexAddresses,
+		Name:   "index-addresses",
+		Usage:  "Build the address appearance index up to the chain head",
+		Flags: slices.Concat([]cli.Flag{
+			utils.AddressIndexLimitFlag,
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command builds or updates the address appearance index, which maps addresses
+to the transactions they appear in, covering the canonical chain up to the current head block.
+Only the senders and recipients of transactions are indexed, along with the participants of
+internal calls previously collected by a node running with --history.addresses.internal.`,
+	}
+	dbVerifyAddressesCmd = &cli.Command{
+		Action:      verifyAddresses,
+		Name:        "verify-addresses",
+		Usage:       "Verify the address appearance index against the canonical chain",
+		Flags:       slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+		Description: "This command checks that every block in the range of the address appearance index is indexed completely.",
+	}
 )
 
 func removeDB(ctx *cli.Context) error {
@@ -907,3 +931,75 @@ func inspectHistory(ctx *cli.Context) error {
 	}
 	return inspectStorage(triedb, start, end, address, slot, ctx.Bool("raw"))
 }
+
+// indexAddresses builds the address appearance index up to the chain head.
+func indexAddresses(ctx *cli.Context) error {
+	var (
+		stack, _  = makeConfigNode(ctx)
+		interrupt = make(chan os.Signal, 1)
+		stop      = make(chan struct{})
+	)
+	defer stack.Close()
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+	defer close(interrupt)
+	go func() {
+		if _, ok := <-interrupt; ok {
+			log.Info("Interrupted during address indexing, stopping at next block")
+		}
+		close(stop)
+	}()
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	config, head, err := addressIndexChain(db)
+	if err != nil {
+		return err
+	}
+	// Blocks below the freezer tail have been pruned, and can't be indexed.
+	cutoff, err := db.Tail()
+	if err != nil {
+		return err
+	}
+	var (
+		limit = ctx.Uint64(utils.AddressIndexLimitFlag.Name)
+		start = time.Now()
+	)
+	if err := core.IndexAddresses(db, config, cutoff, head.NumberU64(), limit, stop); err != nil {
+		return err
+	}
+	tail, indexed := rawdb.ReadAddressIndexTail(db), rawdb.ReadAddressIndexHead(db)
+	if tail == nil || indexed == nil {
+		return errors.New("address index is empty")
+	}
+	log.Info("Indexed address appearances", "tail", *tail, "head", *indexed, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// verifyAddresses checks the address appearance index against the canonical chain.
+func verifyAddresses(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	config, _, err := addressIndexChain(db)
+	if err != nil {
+		return err
+	}
+	return core.VerifyAddressIndex(db, config)
+}
+
+// addressIndexChain retrieves the chain config and the head block of the database.
+func addressIndexChain(db ethdb.Database) (*params.ChainConfig, *types.Block, error) {
+	config := rawdb.ReadChainConfig(db, rawdb.ReadCanonicalHash(db, 0))
+	if config == nil {
+		return nil, nil, errors.New("chain config not found")
+	}
+	head := rawdb.ReadHeadBlock(db)
+	if head == nil {
+		return nil, nil, errors.New("head block not found")
+	}
+	return config, head, nil
+}
@@ -57,11 +57,17 @@ var (
 		utils.PasswordFileFlag,
 		utils.BootnodesFlag,
 		utils.MinFreeDiskSpaceFlag,
+		utils.RemoteAncientFlag,
+		utils.RemoteAncientCacheFlag,
 		utils.KeyStoreDirFlag,
 		utils.ExternalSignerFlag,
 		utils.NoUSBFlag, // deprecated
 		utils.USBFlag,
 		utils.SmartCardDaemonPathFlag,
+		utils.PKCS11ModuleFlag,
+		utils.PKCS11SlotFlag,
+		utils.PKCS11TokenFlag,
+		utils.PKCS11PINFileFlag,
 		utils.OverridePrague,
 		utils.OverrideVerkle,
 		utils.EnablePersonal, // deprecated
@@ -76,6 +82,14 @@ var (
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.TxPoolPolicyFileFlag,
+		utils.TxPoolMaxCallDataFlag,
+		utils.TxPoolNoCreateFlag,
+		utils.TxPoolSenderRateFlag,
+		utils.TxPoolSenderBurstFlag,
+		utils.TxPoolSnapshotFlag,
+		utils.TxPoolSnapshotIntervalFlag,
+		utils.TxPoolSnapshotSizeFlag,
 		utils.BlobPoolDataDirFlag,
 		utils.BlobPoolDataCapFlag,
 		utils.BlobPoolPriceBumpFlag,
@@ -86,11 +100,17 @@ var (
 		utils.SnapshotFlag,
 		utils.TxLookupLimitFlag, // deprecated
 		utils.TransactionHistoryFlag,
+		utils.AddressIndexFlag,
+		utils.AddressIndexLimitFlag,
+		utils.AddressIndexInternalFlag,
 		utils.ChainHistoryFlag,
 		utils.LogHistoryFlag,
 		utils.LogNoHistoryFlag,
+		utils.FollowFlag,
+		utils.FollowIntervalFlag,
 		utils.LogExportCheckpointsFlag,
 		utils.StateHistoryFlag,
+		utils.HistoricStateWindowFlag,
 		utils.LightServeFlag,    // deprecated
 		utils.LightIngressFlag,  // deprecated
 		utils.LightEgressFlag,   // deprecated
@@ -122,6 +142,7 @@ var (
 		utils.MinerEtherbaseFlag, // deprecated
 		utils.MinerExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
+		utils.MinerOrderingFlag,
 		utils.MinerPendingFeeRecipientFlag,
 		utils.MinerNewPayloadTimeoutFlag, // deprecated
 		utils.NATFlag,
@@ -170,6 +191,7 @@ var (
 		utils.JWTSecretFlag,
 		utils.HTTPVirtualHostsFlag,
 		utils.GraphQLEnabledFlag,
+		utils.EraServeFlag,
 		utils.GraphQLCORSDomainFlag,
 		utils.GraphQLVirtualHostsFlag,
 		utils.HTTPApiFlag,
@@ -189,6 +211,10 @@ var (
 		utils.AllowUnprotectedTxs,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
+		utils.RPCRateLimitFlag,
+		utils.RPCRateLimitBurstFlag,
+		utils.RPCRateLimitConcurrencyFlag,
+		utils.RPCRateLimitMethodsFlag,
 	}
 
 	metricsFlags = []cli.Flag{
@@ -206,6 +232,9 @@ var (
 		utils.MetricsInfluxDBTokenFlag,
 		utils.MetricsInfluxDBBucketFlag,
 		utils.MetricsInfluxDBOrganizationFlag,
+		utils.TracingEndpointFlag,
+		utils.TracingHeadersFlag,
+		utils.TracingSampleRatioFlag,
 	}
 )
 
@@ -247,6 +276,8 @@ func init() {
 		snapshotCommand,
 		// See verkle.go
 		verkleCommand,
+		// See witnesscmd.go
+		statelessCommand,
 	}
 	if logTestCommand != nil {
 		app.Commands = append(app.Commands, logTestCommand)
@@ -343,6 +374,9 @@ func geth(ctx *cli.Context) error {
 	}
 
 	prepare(ctx)
+	if tracer := utils.SetupTracing(ctx); tracer != nil {
+		defer tracer.Close()
+	}
 	stack := makeFullNode(ctx)
 	defer stack.Close()
 
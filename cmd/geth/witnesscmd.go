@@ -0,0 +1,188 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	witnessGenesisFlag = &cli.StringFlag{
+		Name:  "genesis",
+		Usage: "Genesis JSON file to take the chain config from, if no network is selected",
+	}
+	statelessCommand = &cli.Command{
+		Action:    executeWitness,
+		Name:      "stateless",
+		Usage:     "Re-execute a block statelessly from its execution witness",
+		ArgsUsage: "<block file> <witness file>",
+		Flags:     slices.Concat([]cli.Flag{witnessGenesisFlag}, utils.NetworkFlags),
+		Description: `
+The stateless command executes a block using nothing but the pre-state, codes and
+ancestor headers contained in its execution witness, as served by the
+debug_executionWitness RPC method. No database is needed.
+
+The block file holds the RLP encoding of the block (as served by debug_getRawBlock),
+either as binary or hex. The witness file holds either the JSON encoding of the
+witness (optionally wrapped in a JSON-RPC response) or its RLP encoding.
+
+The computed state and receipt roots are printed, and checked against the ones in
+the block header if set.`,
+	}
+)
+
+// executeWitness re-executes a block from a witness file.
+func executeWitness(ctx *cli.Context) error {
+	if ctx.Args().Len() != 2 {
+		utils.Fatalf("This command requires two arguments.")
+	}
+	config, err := witnessChainConfig(ctx)
+	if err != nil {
+		return err
+	}
+	block, err := readWitnessBlock(ctx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("failed to read block: %v", err)
+	}
+	witness, err := readWitness(ctx.Args().Get(1))
+	if err != nil {
+		return fmt.Errorf("failed to read witness: %v", err)
+	}
+	if len(witness.Headers) == 0 || witness.Headers[0].Hash() != block.ParentHash() {
+		return errors.New("witness does not belong to the block, parent header mismatch")
+	}
+	// Remove the roots to be computed from the block, so the stateless runner
+	// has to derive them from the witness
+	context := block.Header()
+	context.Root = common.Hash{}
+	context.ReceiptHash = common.Hash{}
+	task := types.NewBlockWithHeader(context).WithBody(*block.Body())
+
+	log.Info("Executing block statelessly", "number", block.Number(), "hash", block.Hash(), "txs", len(block.Transactions()), "nodes", len(witness.State), "codes", len(witness.Codes))
+	stateRoot, receiptRoot, err := core.ExecuteStateless(config, vm.Config{}, task, witness)
+	if err != nil {
+		return fmt.Errorf("stateless execution failed: %v", err)
+	}
+	fmt.Printf("State root:    %#x\n", stateRoot)
+	fmt.Printf("Receipts root: %#x\n", receiptRoot)
+
+	if root := block.Root(); root != (common.Hash{}) && root != stateRoot {
+		return fmt.Errorf("state root mismatch: block %x, computed %x", root, stateRoot)
+	}
+	if root := block.ReceiptHash(); root != (common.Hash{}) && root != receiptRoot {
+		return fmt.Errorf("receipts root mismatch: block %x, computed %x", root, receiptRoot)
+	}
+	return nil
+}
+
+// witnessChainConfig returns the chain config of the selected network preset,
+// or the one in the specified genesis file.
+func witnessChainConfig(ctx *cli.Context) (*params.ChainConfig, error) {
+	if utils.IsNetworkPreset(ctx) {
+		return utils.MakeGenesis(ctx).Config, nil
+	}
+	path := ctx.String(witnessGenesisFlag.Name)
+	if path == "" {
+		return nil, errors.New("chain config required, select a network or specify a genesis file")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genesis file: %v", err)
+	}
+	defer file.Close()
+
+	var genesis struct {
+		Config *params.ChainConfig `json:"config"`
+	}
+	if err := json.NewDecoder(file).Decode(&genesis); err != nil {
+		return nil, fmt.Errorf("invalid genesis file: %v", err)
+	}
+	if genesis.Config == nil {
+		return nil, errors.New("genesis file has no chain config")
+	}
+	return genesis.Config, nil
+}
+
+// readWitnessBlob reads a binary file, decoding it from hex if it contains a
+// hex string (optionally JSON quoted).
+func readWitnessBlob(path string) ([]byte, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	text := bytes.Trim(bytes.TrimSpace(blob), `"`)
+	if bytes.HasPrefix(text, []byte("0x")) {
+		return hexutil.Decode(string(text))
+	}
+	return blob, nil
+}
+
+// readWitnessBlock reads the RLP encoded block from the given file.
+func readWitnessBlock(path string) (*types.Block, error) {
+	blob, err := readWitnessBlob(path)
+	if err != nil {
+		return nil, err
+	}
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(blob, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// readWitness reads the JSON or RLP encoded witness from the given file.
+func readWitness(path string) (*stateless.Witness, error) {
+	blob, err := readWitnessBlob(path)
+	if err != nil {
+		return nil, err
+	}
+	witness := new(stateless.Witness)
+	if !bytes.HasPrefix(bytes.TrimSpace(blob), []byte("{")) {
+		if err := rlp.DecodeBytes(blob, witness); err != nil {
+			return nil, err
+		}
+		return witness, nil
+	}
+	// Unwrap the witness if the file contains the raw JSON-RPC response
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(blob, &response); err == nil && len(response.Result) > 0 {
+		blob = response.Result
+	}
+	if err := json.Unmarshal(blob, witness); err != nil {
+		return nil, err
+	}
+	return witness, nil
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -53,6 +54,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/otterscan"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/remotedb"
@@ -60,6 +62,7 @@ import (
 	"github.com/ethereum/go-ethereum/graphql"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/metrics/exp"
@@ -111,6 +114,17 @@ var (
 		Usage:    "Root directory for ancient data (default = inside chaindata)",
 		Category: flags.EthCategory,
 	}
+	RemoteAncientFlag = &cli.StringFlag{
+		Name:     "datadir.ancient.remote",
+		Usage:    "URL of the chain ancient data in an S3-compatible bucket, served read-only instead of the local chain freezer; newer blocks are not frozen and stay in the key-value store (credentials from the default AWS credential chain)",
+		Category: flags.EthCategory,
+	}
+	RemoteAncientCacheFlag = &cli.IntFlag{
+		Name:     "datadir.ancient.remote.cache",
+		Usage:    "Megabytes of memory allocated to caching the remote ancient data",
+		Value:    ethconfig.Defaults.DatabaseRemoteFreezerCache,
+		Category: flags.EthCategory,
+	}
 	MinFreeDiskSpaceFlag = &flags.DirectoryFlag{
 		Name:     "datadir.minfreedisk",
 		Usage:    "Minimum free disk space in MB, once reached triggers auto shut down (default = --cache.gc converted to MB, 0 = disabled)",
@@ -132,6 +146,26 @@ var (
 		Value:    pcsclite.PCSCDSockName,
 		Category: flags.AccountCategory,
 	}
+	PKCS11ModuleFlag = &cli.StringFlag{
+		Name:     "pkcs11.module",
+		Usage:    "Path to the PKCS#11 module of a hardware security module to sign with",
+		Category: flags.AccountCategory,
+	}
+	PKCS11SlotFlag = &cli.UintFlag{
+		Name:     "pkcs11.slot",
+		Usage:    "PKCS#11 slot of the token holding the keys (default = all slots)",
+		Category: flags.AccountCategory,
+	}
+	PKCS11TokenFlag = &cli.StringFlag{
+		Name:     "pkcs11.token",
+		Usage:    "Label of the PKCS#11 token holding the keys (default = all tokens)",
+		Category: flags.AccountCategory,
+	}
+	PKCS11PINFileFlag = &cli.StringFlag{
+		Name:     "pkcs11.pinfile",
+		Usage:    "File containing the user PIN of the PKCS#11 tokens",
+		Category: flags.AccountCategory,
+	}
 	NetworkIdFlag = &cli.Uint64Flag{
 		Name:     "networkid",
 		Usage:    "Explicitly set network id (integer)(For testnets: use --sepolia, --holesky, --hoodi instead)",
@@ -265,12 +299,29 @@ var (
 		Usage:    "Scheme to use for storing ethereum state ('hash' or 'path')",
 		Category: flags.StateCategory,
 	}
+	FollowFlag = &flags.DirectoryFlag{
+		Name:     "follow",
+		Usage:    "Data directory of a running node to follow read-only, serving its chain over RPC without networking (must be on the same filesystem as the datadir)",
+		Category: flags.StateCategory,
+	}
+	FollowIntervalFlag = &cli.DurationFlag{
+		Name:     "follow.interval",
+		Usage:    "Interval at which the followed node's database is reloaded",
+		Value:    ethconfig.Defaults.FollowInterval,
+		Category: flags.StateCategory,
+	}
 	StateHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.state",
 		Usage:    "Number of recent blocks to retain state history for, only relevant in state.scheme=path (default = 90,000 blocks, 0 = entire chain)",
 		Value:    ethconfig.Defaults.StateHistory,
 		Category: flags.StateCategory,
 	}
+	HistoricStateWindowFlag = &cli.Uint64Flag{
+		Name:     "history.state.window",
+		Usage:    "Number of recent blocks whose state is served from the state histories, only relevant in state.scheme=path (0 = disabled)",
+		Value:    ethconfig.Defaults.HistoricStateWindow,
+		Category: flags.StateCategory,
+	}
 	TransactionHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.transactions",
 		Usage:    "Number of recent blocks to maintain transactions index for (default = about one year, 0 = entire chain)",
@@ -283,6 +334,21 @@ var (
 		Value:    ethconfig.Defaults.HistoryMode.String(),
 		Category: flags.StateCategory,
 	}
+	AddressIndexFlag = &cli.BoolFlag{
+		Name:     "history.addresses",
+		Usage:    "Maintain an index of the transactions each address appears in (required by the ots API)",
+		Category: flags.StateCategory,
+	}
+	AddressIndexLimitFlag = &cli.Uint64Flag{
+		Name:     "history.addresses.limit",
+		Usage:    "Number of recent blocks to maintain the address index for (default = 0, entire chain)",
+		Category: flags.StateCategory,
+	}
+	AddressIndexInternalFlag = &cli.BoolFlag{
+		Name:     "history.addresses.internal",
+		Usage:    "Index the participants of internal calls in the address index (only for blocks executed locally)",
+		Category: flags.StateCategory,
+	}
 	LogHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.logs",
 		Usage:    "Number of recent blocks to maintain log search index for (default = about one year, 0 = entire chain)",
@@ -422,6 +488,49 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolPolicyFileFlag = &cli.StringFlag{
+		Name:     "txpool.policy",
+		Usage:    "JSON file of sender and recipient allow- and deny-lists, reloaded on change",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolMaxCallDataFlag = &cli.Uint64Flag{
+		Name:     "txpool.maxcalldata",
+		Usage:    "Maximum input data size of accepted transactions (0 = unlimited)",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolNoCreateFlag = &cli.BoolFlag{
+		Name:     "txpool.nocreate",
+		Usage:    "Rejects contract creation transactions",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSenderRateFlag = &cli.Float64Flag{
+		Name:     "txpool.senderrate",
+		Usage:    "Maximum number of transactions accepted per second from a single sender (0 = unlimited)",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSenderBurstFlag = &cli.IntFlag{
+		Name:     "txpool.senderburst",
+		Usage:    "Maximum number of transactions accepted at once from a single sender",
+		Value:    1,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSnapshotFlag = &cli.StringFlag{
+		Name:     "txpool.snapshot",
+		Usage:    "File to persist the full transaction pool in across restarts (relative to datadir, empty = disabled)",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSnapshotIntervalFlag = &cli.DurationFlag{
+		Name:     "txpool.snapshotinterval",
+		Usage:    "Time interval to regenerate the transaction pool snapshot (0 = on shutdown only)",
+		Value:    ethconfig.Defaults.TxPoolSnapshot.Interval,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSnapshotSizeFlag = &cli.Uint64Flag{
+		Name:     "txpool.snapshotsize",
+		Usage:    "Maximum size of the transaction pool snapshot in bytes (0 = unlimited)",
+		Value:    ethconfig.Defaults.TxPoolSnapshot.MaxSize,
+		Category: flags.TxPoolCategory,
+	}
 	// Blob transaction pool settings
 	BlobPoolDataDirFlag = &cli.StringFlag{
 		Name:     "blobpool.datadir",
@@ -524,6 +633,12 @@ var (
 		Value:    ethconfig.Defaults.Miner.Recommit,
 		Category: flags.MinerCategory,
 	}
+	MinerOrderingFlag = &cli.StringFlag{
+		Name:     "miner.ordering",
+		Usage:    "Ordering strategy of the transactions in the built blocks (price, fifo)",
+		Value:    miner.OrderingPrice,
+		Category: flags.MinerCategory,
+	}
 	MinerPendingFeeRecipientFlag = &cli.StringFlag{
 		Name:     "miner.pending.feeRecipient",
 		Usage:    "0x prefixed public address for the pending block producer (not used for actual block production)",
@@ -676,6 +791,11 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	EraServeFlag = &flags.DirectoryFlag{
+		Name:     "http.era",
+		Usage:    "Directory of era1 files to serve on the HTTP-RPC server under /era/ (requires --http)",
+		Category: flags.APICategory,
+	}
 	GraphQLEnabledFlag = &cli.BoolFlag{
 		Name:     "graphql",
 		Usage:    "Enable GraphQL on the HTTP-RPC server. Note that GraphQL can only be started if an HTTP server is started as well.",
@@ -755,6 +875,26 @@ var (
 		Value:    node.DefaultConfig.BatchResponseMaxSize,
 		Category: flags.APICategory,
 	}
+	RPCRateLimitFlag = &cli.Float64Flag{
+		Name:     "rpc.ratelimit",
+		Usage:    "Maximum sustained number of RPC requests per second per client (0 = unlimited)",
+		Category: flags.APICategory,
+	}
+	RPCRateLimitBurstFlag = &cli.IntFlag{
+		Name:     "rpc.ratelimit.burst",
+		Usage:    "Maximum burst of RPC requests per client",
+		Category: flags.APICategory,
+	}
+	RPCRateLimitConcurrencyFlag = &cli.IntFlag{
+		Name:     "rpc.ratelimit.concurrency",
+		Usage:    "Maximum number of in-flight RPC requests per client (0 = unlimited)",
+		Category: flags.APICategory,
+	}
+	RPCRateLimitMethodsFlag = &cli.StringFlag{
+		Name:     "rpc.ratelimit.methods",
+		Usage:    "Comma separated per-client method limits as method=rate[:burst[:concurrency]], e.g. eth_getLogs=5:10:2,debug_*=1",
+		Category: flags.APICategory,
+	}
 
 	// Network Settings
 	MaxPeersFlag = &cli.IntFlag{
@@ -961,6 +1101,24 @@ Please note that --` + MetricsHTTPFlag.Name + ` must be set to start the server.
 		Value:    metrics.DefaultConfig.InfluxDBOrganization,
 		Category: flags.MetricsCategory,
 	}
+
+	// Tracing flags
+	TracingEndpointFlag = &cli.StringFlag{
+		Name:     "tracing.endpoint",
+		Usage:    "OpenTelemetry collector URL to export trace spans to over OTLP/HTTP (enables tracing)",
+		Category: flags.MetricsCategory,
+	}
+	TracingHeadersFlag = &cli.StringFlag{
+		Name:     "tracing.headers",
+		Usage:    "Comma-separated list of key=value HTTP headers sent to the trace collector",
+		Category: flags.MetricsCategory,
+	}
+	TracingSampleRatioFlag = &cli.Float64Flag{
+		Name:     "tracing.sampleratio",
+		Usage:    "Fraction of the traces started by the node to record, in the [0, 1] range",
+		Value:    1,
+		Category: flags.MetricsCategory,
+	}
 )
 
 var (
@@ -1192,6 +1350,58 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(BatchResponseMaxSize.Name) {
 		cfg.BatchResponseMaxSize = ctx.Int(BatchResponseMaxSize.Name)
 	}
+
+	if ctx.IsSet(RPCRateLimitFlag.Name) {
+		cfg.RPCRateLimits.Client.Rate = ctx.Float64(RPCRateLimitFlag.Name)
+	}
+	if ctx.IsSet(RPCRateLimitBurstFlag.Name) {
+		cfg.RPCRateLimits.Client.Burst = ctx.Int(RPCRateLimitBurstFlag.Name)
+	}
+	if ctx.IsSet(RPCRateLimitConcurrencyFlag.Name) {
+		cfg.RPCRateLimits.Client.MaxConcurrent = ctx.Int(RPCRateLimitConcurrencyFlag.Name)
+	}
+	if ctx.IsSet(RPCRateLimitMethodsFlag.Name) {
+		limits, err := parseMethodRateLimits(ctx.String(RPCRateLimitMethodsFlag.Name))
+		if err != nil {
+			Fatalf("Option %q: %v", RPCRateLimitMethodsFlag.Name, err)
+		}
+		cfg.RPCRateLimits.Methods = limits
+	}
+}
+
+// parseMethodRateLimits parses a comma separated list of method=rate[:burst[:concurrency]]
+// entries into per-method RPC limits.
+func parseMethodRateLimits(spec string) (map[string]rpc.RateLimit, error) {
+	limits := make(map[string]rpc.RateLimit)
+	for _, entry := range SplitAndTrim(spec) {
+		method, value, ok := strings.Cut(entry, "=")
+		if !ok || method == "" {
+			return nil, fmt.Errorf("invalid method limit %q", entry)
+		}
+		var (
+			limit rpc.RateLimit
+			parts = strings.Split(value, ":")
+			err   error
+		)
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid method limit %q", entry)
+		}
+		if limit.Rate, err = strconv.ParseFloat(parts[0], 64); err != nil || limit.Rate < 0 {
+			return nil, fmt.Errorf("invalid rate in method limit %q", entry)
+		}
+		if len(parts) > 1 {
+			if limit.Burst, err = strconv.Atoi(parts[1]); err != nil || limit.Burst < 0 {
+				return nil, fmt.Errorf("invalid burst in method limit %q", entry)
+			}
+		}
+		if len(parts) > 2 {
+			if limit.MaxConcurrent, err = strconv.Atoi(parts[2]); err != nil || limit.MaxConcurrent < 0 {
+				return nil, fmt.Errorf("invalid concurrency in method limit %q", entry)
+			}
+		}
+		limits[method] = limit
+	}
+	return limits, nil
 }
 
 // setGraphQL creates the GraphQL listener interface string from the set
@@ -1347,8 +1557,8 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 		cfg.NetRestrict = list
 	}
 
-	if ctx.Bool(DeveloperFlag.Name) {
-		// --dev mode can't use p2p networking.
+	if ctx.Bool(DeveloperFlag.Name) || ctx.IsSet(FollowFlag.Name) {
+		// --dev and --follow modes can't use p2p networking.
 		cfg.MaxPeers = 0
 		cfg.ListenAddr = ""
 		cfg.NoDial = true
@@ -1367,6 +1577,7 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	setNodeUserIdent(ctx, cfg)
 	SetDataDir(ctx, cfg)
 	setSmartCard(ctx, cfg)
+	setPKCS11(ctx, cfg)
 
 	if ctx.IsSet(JWTSecretFlag.Name) {
 		cfg.JWTSecret = ctx.String(JWTSecretFlag.Name)
@@ -1434,6 +1645,22 @@ func setSmartCard(ctx *cli.Context, cfg *node.Config) {
 	cfg.SmartCardDaemonPath = path
 }
 
+func setPKCS11(ctx *cli.Context, cfg *node.Config) {
+	if ctx.IsSet(PKCS11ModuleFlag.Name) {
+		cfg.PKCS11Module = ctx.String(PKCS11ModuleFlag.Name)
+	}
+	if ctx.IsSet(PKCS11SlotFlag.Name) {
+		slot := ctx.Uint(PKCS11SlotFlag.Name)
+		cfg.PKCS11Slot = &slot
+	}
+	if ctx.IsSet(PKCS11TokenFlag.Name) {
+		cfg.PKCS11Token = ctx.String(PKCS11TokenFlag.Name)
+	}
+	if ctx.IsSet(PKCS11PINFileFlag.Name) {
+		cfg.PKCS11PINFile = ctx.String(PKCS11PINFileFlag.Name)
+	}
+}
+
 func SetDataDir(ctx *cli.Context, cfg *node.Config) {
 	switch {
 	case ctx.IsSet(DataDirFlag.Name):
@@ -1507,6 +1734,36 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	}
 }
 
+func setTxPoolPolicy(ctx *cli.Context, cfg *txpool.PolicyConfig) {
+	if ctx.IsSet(TxPoolPolicyFileFlag.Name) {
+		cfg.ListFile = ctx.String(TxPoolPolicyFileFlag.Name)
+	}
+	if ctx.IsSet(TxPoolMaxCallDataFlag.Name) {
+		cfg.MaxCallDataSize = ctx.Uint64(TxPoolMaxCallDataFlag.Name)
+	}
+	if ctx.IsSet(TxPoolNoCreateFlag.Name) {
+		cfg.NoContractCreation = ctx.Bool(TxPoolNoCreateFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSenderRateFlag.Name) {
+		cfg.SenderRate = ctx.Float64(TxPoolSenderRateFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSenderBurstFlag.Name) {
+		cfg.SenderBurst = ctx.Int(TxPoolSenderBurstFlag.Name)
+	}
+}
+
+func setTxPoolSnapshot(ctx *cli.Context, cfg *txpool.SnapshotConfig) {
+	if ctx.IsSet(TxPoolSnapshotFlag.Name) {
+		cfg.Path = ctx.String(TxPoolSnapshotFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSnapshotIntervalFlag.Name) {
+		cfg.Interval = ctx.Duration(TxPoolSnapshotIntervalFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSnapshotSizeFlag.Name) {
+		cfg.MaxSize = ctx.Uint64(TxPoolSnapshotSizeFlag.Name)
+	}
+}
+
 func setBlobPool(ctx *cli.Context, cfg *blobpool.Config) {
 	if ctx.IsSet(BlobPoolDataDirFlag.Name) {
 		cfg.Datadir = ctx.String(BlobPoolDataDirFlag.Name)
@@ -1539,6 +1796,13 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 		log.Warn("The flag --miner.newpayload-timeout is deprecated and will be removed, please use --miner.recommit")
 		cfg.Recommit = ctx.Duration(MinerNewPayloadTimeoutFlag.Name)
 	}
+	if ctx.IsSet(MinerOrderingFlag.Name) {
+		ordering := ctx.String(MinerOrderingFlag.Name)
+		if _, err := miner.LookupOrdering(ordering); err != nil {
+			Fatalf("Invalid miner ordering: %v", err)
+		}
+		cfg.Ordering = ordering
+	}
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1574,12 +1838,16 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	// Avoid conflicting network flags, don't allow network id override on preset networks
 	flags.CheckExclusive(ctx, MainnetFlag, DeveloperFlag, SepoliaFlag, HoleskyFlag, HoodiFlag, NetworkIdFlag)
 	flags.CheckExclusive(ctx, DeveloperFlag, ExternalSignerFlag) // Can't use both ephemeral unlocked and external signer
+	flags.CheckExclusive(ctx, DeveloperFlag, FollowFlag)         // Dev mode creates its own chain, it can't follow another
+	flags.CheckExclusive(ctx, FollowFlag, RemoteAncientFlag)     // Followers use the chain freezer of the followed node
 
 	// Set configurations from CLI flags
 	setEtherbase(ctx, cfg)
 	setGPO(ctx, &cfg.GPO)
 	setTxPool(ctx, &cfg.TxPool)
 	setBlobPool(ctx, &cfg.BlobPool)
+	setTxPoolPolicy(ctx, &cfg.TxPoolPolicy)
+	setTxPoolSnapshot(ctx, &cfg.TxPoolSnapshot)
 	setMiner(ctx, &cfg.Miner)
 	setRequiredBlocks(ctx, cfg)
 	setLes(ctx, cfg)
@@ -1630,6 +1898,12 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(AncientFlag.Name) {
 		cfg.DatabaseFreezer = ctx.String(AncientFlag.Name)
 	}
+	if ctx.IsSet(RemoteAncientFlag.Name) {
+		cfg.DatabaseRemoteFreezer = ctx.String(RemoteAncientFlag.Name)
+	}
+	if ctx.IsSet(RemoteAncientCacheFlag.Name) {
+		cfg.DatabaseRemoteFreezerCache = ctx.Int(RemoteAncientCacheFlag.Name)
+	}
 
 	if gcmode := ctx.String(GCModeFlag.Name); gcmode != "full" && gcmode != "archive" {
 		Fatalf("--%s must be either 'full' or 'archive'", GCModeFlag.Name)
@@ -1649,6 +1923,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(StateHistoryFlag.Name) {
 		cfg.StateHistory = ctx.Uint64(StateHistoryFlag.Name)
 	}
+	if ctx.IsSet(HistoricStateWindowFlag.Name) {
+		cfg.HistoricStateWindow = ctx.Uint64(HistoricStateWindowFlag.Name)
+	}
 	if ctx.IsSet(StateSchemeFlag.Name) {
 		cfg.StateScheme = ctx.String(StateSchemeFlag.Name)
 	}
@@ -1675,6 +1952,15 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 			log.Warn("Forcing hash state-scheme for archive mode")
 		}
 	}
+	if ctx.IsSet(AddressIndexFlag.Name) {
+		cfg.AddressIndex = ctx.Bool(AddressIndexFlag.Name)
+	}
+	if ctx.IsSet(AddressIndexLimitFlag.Name) {
+		cfg.AddressIndexLimit = ctx.Uint64(AddressIndexLimitFlag.Name)
+	}
+	if ctx.IsSet(AddressIndexInternalFlag.Name) {
+		cfg.AddressIndexInternal = ctx.Bool(AddressIndexInternalFlag.Name)
+	}
 	if ctx.IsSet(LogHistoryFlag.Name) {
 		cfg.LogHistory = ctx.Uint64(LogHistoryFlag.Name)
 	}
@@ -1684,6 +1970,12 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(LogExportCheckpointsFlag.Name) {
 		cfg.LogExportCheckpoints = ctx.String(LogExportCheckpointsFlag.Name)
 	}
+	if ctx.IsSet(FollowFlag.Name) {
+		cfg.Follow = ctx.String(FollowFlag.Name)
+	}
+	if ctx.IsSet(FollowIntervalFlag.Name) {
+		cfg.FollowInterval = ctx.Duration(FollowIntervalFlag.Name)
+	}
 	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheTrieFlag.Name) {
 		cfg.TrieCleanCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheTrieFlag.Name) / 100
 	}
@@ -1976,6 +2268,7 @@ func RegisterEthService(stack *node.Node, cfg *ethconfig.Config) (*eth.EthAPIBac
 		Fatalf("Failed to register the Ethereum service: %v", err)
 	}
 	stack.RegisterAPIs(tracers.APIs(backend.APIBackend))
+	stack.RegisterAPIs(otterscan.APIs(backend.APIBackend))
 	return backend.APIBackend, backend
 }
 
@@ -2061,6 +2354,31 @@ func SetupMetrics(cfg *metrics.Config) {
 	go metrics.CollectProcessMetrics(3 * time.Second)
 }
 
+// SetupTracing enables the export of trace spans if a collector is configured.
+// The returned tracer must be closed on shutdown to flush the pending spans.
+func SetupTracing(ctx *cli.Context) *telemetry.Tracer {
+	if !ctx.IsSet(TracingEndpointFlag.Name) {
+		return nil
+	}
+	headers := make(map[string]string)
+	for _, header := range strings.Split(ctx.String(TracingHeadersFlag.Name), ",") {
+		if key, value, ok := strings.Cut(header, "="); ok {
+			headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	endpoint := ctx.String(TracingEndpointFlag.Name)
+	tracer, err := telemetry.Enable(telemetry.Config{
+		Endpoint:    endpoint,
+		Headers:     headers,
+		SampleRatio: ctx.Float64(TracingSampleRatioFlag.Name),
+	})
+	if err != nil {
+		Fatalf("Failed to enable tracing: %v", err)
+	}
+	log.Info("Enabling trace export", "endpoint", endpoint, "ratio", ctx.Float64(TracingSampleRatioFlag.Name))
+	return tracer
+}
+
 // SplitTagsFlag parses a comma-separated list of k=v metrics tags.
 func SplitTagsFlag(tagsFlag string) map[string]string {
 	tags := strings.Split(tagsFlag, ",")
@@ -20,6 +20,8 @@ package utils
 import (
 	"reflect"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 func Test_SplitTagsFlag(t *testing.T) {
@@ -64,3 +66,47 @@ func Test_SplitTagsFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMethodRateLimits(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		spec    string
+		want    map[string]rpc.RateLimit
+		wantErr bool
+	}{
+		{
+			spec: "eth_getLogs=5:10:2, debug_*=0.5",
+			want: map[string]rpc.RateLimit{
+				"eth_getLogs": {Rate: 5, Burst: 10, MaxConcurrent: 2},
+				"debug_*":     {Rate: 0.5},
+			},
+		},
+		{
+			spec: "debug_traceCall=0:0:1",
+			want: map[string]rpc.RateLimit{
+				"debug_traceCall": {MaxConcurrent: 1},
+			},
+		},
+		{spec: "eth_getLogs", wantErr: true},
+		{spec: "=5", wantErr: true},
+		{spec: "eth_getLogs=x", wantErr: true},
+		{spec: "eth_getLogs=1:2:3:4", wantErr: true},
+		{spec: "eth_getLogs=1:-2", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseMethodRateLimits(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected error", tt.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: have %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,666 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// addrIndexer is the module responsible for maintaining the address appearance
+// index, which maps addresses to the transactions they appear in.
+//
+// The index covers the canonical blocks between its tail and its head. It is
+// built forward from the history cutoff, or from the most recent blocks if the
+// number of indexed blocks is limited, towards the chain head. For every indexed
+// block a record of its appearances is retained, allowing the entries of blocks
+// which are reorged out of the canonical chain, or fall out of the indexing range,
+// to be removed.
+type addrIndexer struct {
+	cutoff uint64
+	limit  uint64
+	chain  *BlockChain
+	db     ethdb.Database
+	term   chan chan struct{}
+	closed chan struct{}
+}
+
+// newAddrIndexer initializes the address appearance indexer.
+func newAddrIndexer(limit uint64, chain *BlockChain) *addrIndexer {
+	cutoff, _ := chain.HistoryPruningCutoff()
+	indexer := &addrIndexer{
+		cutoff: cutoff,
+		limit:  limit,
+		chain:  chain,
+		db:     chain.db,
+		term:   make(chan chan struct{}),
+		closed: make(chan struct{}),
+	}
+	go indexer.loop()
+
+	if limit == 0 {
+		log.Info("Initialized address appearance indexer", "from", cutoff)
+	} else {
+		log.Info("Initialized address appearance indexer", "from", cutoff, "limit", limit)
+	}
+	return indexer
+}
+
+// run indexes the address appearances of all canonical blocks up to the given
+// head. If the stop channel is closed, the task should terminate as soon as
+// possible. The done channel will be closed once the task is complete.
+func (indexer *addrIndexer) run(head uint64, stop chan struct{}, done chan struct{}) {
+	defer close(done)
+
+	if err := IndexAddresses(indexer.db, indexer.chain.Config(), indexer.cutoff, head, indexer.limit, stop); err != nil {
+		log.Warn("Failed to index address appearances", "err", err)
+	}
+}
+
+// IndexAddresses updates the address appearance index of the database to cover
+// the canonical chain up to the given head. If limit is non-zero, only the most
+// recent blocks are indexed and older entries are pruned. Blocks below the given
+// cutoff are never indexed, as their bodies are assumed to be unavailable.
+//
+// Indexing can be aborted by closing the interrupt channel, in which case the
+// progress made so far is retained.
+func IndexAddresses(db ethdb.Database, config *params.ChainConfig, cutoff, head, limit uint64, interrupt chan struct{}) error {
+	from := cutoff
+	if limit > 0 && head+1 > limit {
+		from = max(from, head+1-limit)
+	}
+	if from > head {
+		return nil
+	}
+	// Drop the entries of non-canonical blocks and, if the indexed range has no
+	// overlap with the desired one, the entire index.
+	tail, next := rewindAddressIndex(db, head)
+	if tail != nil && next < from {
+		pruneAddressIndex(db, *tail, next, nil)
+		tail = nil
+	}
+	if tail == nil {
+		next = from
+	}
+	// Index new blocks first, as the recent ones are the most relevant.
+	if next <= head {
+		if err := indexAddressesForward(db, config, tail == nil, next, head, interrupt); err != nil {
+			return err
+		}
+		select {
+		case <-interrupt:
+			return nil
+		default:
+		}
+		if tail == nil {
+			tail = &from
+		}
+	}
+	// Remove the entries which fell out of the indexing range, and extend the
+	// index towards the old blocks if the range was widened.
+	if *tail < from {
+		pruneAddressIndex(db, *tail, from, interrupt)
+	} else if *tail > from {
+		return indexAddressesBackward(db, config, from, *tail, interrupt)
+	}
+	return nil
+}
+
+// rewindAddressIndex removes the entries of all indexed blocks which are above
+// the given head or no longer canonical. It returns the tail of the index, and
+// the number of the first block to be indexed. A nil tail is returned if the
+// index is empty.
+func rewindAddressIndex(db ethdb.Database, head uint64) (*uint64, uint64) {
+	var (
+		tail    = rawdb.ReadAddressIndexTail(db)
+		indexed = rawdb.ReadAddressIndexHead(db)
+	)
+	if tail == nil || indexed == nil {
+		// If the index range is only partially known, rebuild it from scratch.
+		if tail != nil || indexed != nil {
+			var end uint64
+			if indexed != nil {
+				end = *indexed + 1
+			}
+			pruneAddressIndex(db, 0, end, nil)
+		}
+		return nil, 0
+	}
+	var (
+		batch  = db.NewBatch()
+		number = *indexed
+		next   = *tail
+	)
+	for {
+		hash, appearances, ok := rawdb.ReadAddressIndexBlock(db, number)
+		if ok && number <= head && hash == rawdb.ReadCanonicalHash(db, number) {
+			next = number + 1
+			break
+		}
+		if ok {
+			rawdb.DeleteAddressIndexBlock(batch, number, appearances)
+		}
+		if number <= *tail {
+			break
+		}
+		number--
+	}
+	if next > *tail {
+		rawdb.WriteAddressIndexHead(batch, next-1)
+	} else {
+		rawdb.DeleteAddressIndexHead(batch)
+		rawdb.DeleteAddressIndexTail(batch)
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to rewind address index", "err", err)
+	}
+	if next <= *indexed {
+		log.Debug("Rewound address index", "from", *indexed, "to", next)
+	}
+	if next == *tail {
+		return nil, 0
+	}
+	return tail, next
+}
+
+// indexAddressesForward indexes the canonical blocks in the range [from, to],
+// extending the index head. If fresh is set, the index is empty and its tail
+// is initialized to the first block.
+func indexAddressesForward(db ethdb.Database, config *params.ChainConfig, fresh bool, from, to uint64, interrupt chan struct{}) error {
+	var (
+		batch  = db.NewBatch()
+		start  = time.Now()
+		logged = start
+		blocks int
+	)
+	flush := func(number uint64) {
+		if fresh {
+			rawdb.WriteAddressIndexTail(batch, from)
+			fresh = false
+		}
+		rawdb.WriteAddressIndexHead(batch, number)
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed writing address index batch", "err", err)
+		}
+		batch.Reset()
+	}
+	defer func() {
+		if batch.ValueSize() > 0 {
+			flush(from + uint64(blocks) - 1)
+		}
+	}()
+	for number := from; number <= to; number++ {
+		select {
+		case <-interrupt:
+			log.Debug("Address indexing interrupted", "blocks", blocks, "head", number-1, "elapsed", common.PrettyDuration(time.Since(start)))
+			return nil
+		default:
+		}
+		if err := indexAddressBlock(db, batch, config, number); err != nil {
+			return err
+		}
+		blocks++
+
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			flush(number)
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Indexing address appearances", "blocks", blocks, "head", number, "target", to, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if time.Since(start) > 8*time.Second {
+		log.Info("Indexed address appearances", "blocks", blocks, "head", to, "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	return nil
+}
+
+// indexAddressesBackward indexes the canonical blocks in the range [from, to),
+// in descending order, extending the index tail.
+func indexAddressesBackward(db ethdb.Database, config *params.ChainConfig, from, to uint64, interrupt chan struct{}) error {
+	var (
+		batch  = db.NewBatch()
+		start  = time.Now()
+		logged = start
+		tail   = to
+	)
+	flush := func() {
+		rawdb.WriteAddressIndexTail(batch, tail)
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed writing address index batch", "err", err)
+		}
+		batch.Reset()
+	}
+	defer func() {
+		if batch.ValueSize() > 0 {
+			flush()
+		}
+	}()
+	for tail > from {
+		select {
+		case <-interrupt:
+			log.Debug("Address indexing interrupted", "blocks", to-tail, "tail", tail, "elapsed", common.PrettyDuration(time.Since(start)))
+			return nil
+		default:
+		}
+		if err := indexAddressBlock(db, batch, config, tail-1); err != nil {
+			return err
+		}
+		tail--
+
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			flush()
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Indexing old address appearances", "blocks", to-tail, "tail", tail, "target", from, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if time.Since(start) > 8*time.Second {
+		log.Info("Indexed old address appearances", "blocks", to-from, "tail", from, "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	return nil
+}
+
+// indexAddressBlock adds the address appearances of the canonical block with
+// the given number to the batch.
+func indexAddressBlock(db ethdb.Database, batch ethdb.KeyValueWriter, config *params.ChainConfig, number uint64) error {
+	hash := rawdb.ReadCanonicalHash(db, number)
+	block := rawdb.ReadBlock(db, hash, number)
+	if block == nil {
+		return fmt.Errorf("missing block #%d [%x]", number, hash)
+	}
+	appearances := mergeAddressAppearances(blockAddressAppearances(config, block), rawdb.ReadAddressInternalAppearances(db, number, hash))
+	rawdb.WriteAddressIndexBlock(batch, number, hash, appearances)
+	return nil
+}
+
+// pruneAddressIndex removes the entries of the blocks in the range [from, to)
+// from the index, moving the tail of the index to the end of the range. If the
+// range covers the whole index, it is deleted entirely. The internal call records
+// of the blocks are removed along.
+func pruneAddressIndex(db ethdb.Database, from, to uint64, interrupt chan struct{}) {
+	var (
+		batch  = db.NewBatch()
+		start  = time.Now()
+		number = from
+	)
+	flush := func() {
+		if indexed := rawdb.ReadAddressIndexHead(db); indexed != nil && *indexed >= number {
+			rawdb.WriteAddressIndexTail(batch, number)
+		} else {
+			rawdb.DeleteAddressIndexHead(batch)
+			rawdb.DeleteAddressIndexTail(batch)
+		}
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed writing address index batch", "err", err)
+		}
+		batch.Reset()
+	}
+	defer flush()
+
+	for ; number < to; number++ {
+		select {
+		case <-interrupt:
+			log.Debug("Address index pruning interrupted", "tail", number, "elapsed", common.PrettyDuration(time.Since(start)))
+			return
+		default:
+		}
+		if _, appearances, ok := rawdb.ReadAddressIndexBlock(db, number); ok {
+			rawdb.DeleteAddressIndexBlock(batch, number, appearances)
+		}
+		rawdb.DeleteAddressInternalAppearances(db, batch, number)
+
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			flush()
+		}
+	}
+	if time.Since(start) > 8*time.Second {
+		log.Info("Pruned address appearances", "blocks", to-from, "tail", to, "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+}
+
+// VerifyAddressIndex checks the consistency of the address appearance index with
+// the canonical chain: every block in the indexed range must have a record, all
+// the senders and recipients of its transactions must be indexed, and so must be
+// every recorded appearance.
+func VerifyAddressIndex(db ethdb.Database, config *params.ChainConfig) error {
+	var (
+		tail = rawdb.ReadAddressIndexTail(db)
+		head = rawdb.ReadAddressIndexHead(db)
+	)
+	if tail == nil && head == nil {
+		return errors.New("address index not found")
+	}
+	if tail == nil || head == nil {
+		return errors.New("address index has incomplete range")
+	}
+	if *tail > *head {
+		return fmt.Errorf("address index has invalid range [%d, %d]", *tail, *head)
+	}
+	var (
+		start  = time.Now()
+		logged = start
+	)
+	for number := *tail; number <= *head; number++ {
+		hash := rawdb.ReadCanonicalHash(db, number)
+		indexed, appearances, ok := rawdb.ReadAddressIndexBlock(db, number)
+		if !ok {
+			return fmt.Errorf("missing address index record of block #%d", number)
+		}
+		if indexed != hash {
+			return fmt.Errorf("address index record of block #%d has non-canonical hash %x, want %x", number, indexed, hash)
+		}
+		block := rawdb.ReadBlock(db, hash, number)
+		if block == nil {
+			return fmt.Errorf("missing block #%d [%x]", number, hash)
+		}
+		recorded := make(map[rawdb.BlockAddressAppearance]struct{}, len(appearances))
+		for _, a := range appearances {
+			if !rawdb.HasAddressAppearance(db, a.Address, number, a.TxIndex) {
+				return fmt.Errorf("missing address appearance of %x in block #%d, tx %d", a.Address, number, a.TxIndex)
+			}
+			recorded[a] = struct{}{}
+		}
+		for _, a := range blockAddressAppearances(config, block) {
+			if _, ok := recorded[a]; !ok {
+				return fmt.Errorf("unindexed address appearance of %x in block #%d, tx %d", a.Address, number, a.TxIndex)
+			}
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Verifying address index", "number", number, "tail", *tail, "head", *head, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	log.Info("Verified address index", "tail", *tail, "head", *head, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// loop is the scheduler of the indexer, launching indexing tasks whenever the
+// chain head changes.
+func (indexer *addrIndexer) loop() {
+	defer close(indexer.closed)
+
+	var (
+		stop   chan struct{} // Non-nil if background routine is active
+		done   chan struct{} // Non-nil if background routine is active
+		rerun  bool          // Whether the head changed while a task was running
+		headCh = make(chan ChainHeadEvent)
+		sub    = indexer.chain.SubscribeChainHeadEvent(headCh)
+	)
+	defer sub.Unsubscribe()
+
+	launch := func() {
+		stop = make(chan struct{})
+		done = make(chan struct{})
+		go indexer.run(indexer.chain.CurrentBlock().Number.Uint64(), stop, done)
+	}
+	launch()
+
+	for {
+		select {
+		case <-headCh:
+			if done == nil {
+				launch()
+			} else {
+				rerun = true
+			}
+
+		case <-done:
+			stop, done = nil, nil
+			if rerun {
+				rerun = false
+				launch()
+			}
+
+		case ch := <-indexer.term:
+			if stop != nil {
+				close(stop)
+			}
+			if done != nil {
+				log.Info("Waiting background address indexer to exit")
+				<-done
+			}
+			close(ch)
+			return
+		}
+	}
+}
+
+// close shuts down the indexer. Safe to be called for multiple times.
+func (indexer *addrIndexer) close() {
+	ch := make(chan struct{})
+	select {
+	case indexer.term <- ch:
+		<-ch
+	case <-indexer.closed:
+	}
+}
+
+// blockAddressAppearances collects the addresses appearing in the transactions of
+// the given block: senders, recipients, created contracts and the authorities of
+// set-code authorizations.
+func blockAddressAppearances(config *params.ChainConfig, block *types.Block) []rawdb.BlockAddressAppearance {
+	var (
+		signer      = types.MakeSigner(config, block.Number(), block.Time())
+		appearances []rawdb.BlockAddressAppearance
+	)
+	for i, tx := range block.Transactions() {
+		seen := make(map[common.Address]struct{})
+		add := func(addr common.Address) {
+			if _, ok := seen[addr]; ok {
+				return
+			}
+			seen[addr] = struct{}{}
+			appearances = append(appearances, rawdb.BlockAddressAppearance{Address: addr, TxIndex: uint32(i)})
+		}
+		from, err := types.Sender(signer, tx)
+		if err == nil {
+			add(from)
+		}
+		if to := tx.To(); to != nil {
+			add(*to)
+		} else if err == nil {
+			add(crypto.CreateAddress(from, tx.Nonce()))
+		}
+		for _, auth := range tx.SetCodeAuthorizations() {
+			if authority, err := auth.Authority(); err == nil {
+				add(authority)
+			}
+		}
+	}
+	return appearances
+}
+
+// mergeAddressAppearances adds the internal call appearances of a block to its
+// transaction level appearances, skipping duplicates. The result is ordered by
+// transaction index.
+func mergeAddressAppearances(appearances, internal []rawdb.BlockAddressAppearance) []rawdb.BlockAddressAppearance {
+	if len(internal) == 0 {
+		return appearances
+	}
+	seen := make(map[rawdb.BlockAddressAppearance]struct{}, len(appearances))
+	for _, a := range appearances {
+		seen[a] = struct{}{}
+	}
+	for _, a := range internal {
+		if _, ok := seen[a]; ok {
+			continue
+		}
+		seen[a] = struct{}{}
+		appearances = append(appearances, a)
+	}
+	slices.SortStableFunc(appearances, func(a, b rawdb.BlockAddressAppearance) int {
+		return cmp.Compare(a.TxIndex, b.TxIndex)
+	})
+	return appearances
+}
+
+// addrTracer is a live tracer collecting the participants of the internal calls
+// of transactions, which are not derivable from the block alone. The collected
+// appearances are held until the block passed validation, and then stored along
+// with it, for the indexer to merge them into the address appearance index.
+//
+// Only blocks executed by the node are covered; blocks imported without being
+// executed, e.g. during snap sync, lack internal call appearances.
+type addrTracer struct {
+	config      *params.ChainConfig
+	block       *types.Block
+	precompiles map[common.Address]struct{}
+	txIndex     int
+	inTx        bool
+	seen        map[common.Address]struct{}
+	appearances []rawdb.BlockAddressAppearance
+
+	pendingHash common.Hash                    // Hash of the last fully executed block
+	pending     []rawdb.BlockAddressAppearance // Internal call appearances of the last fully executed block
+}
+
+// newAddrTracer creates a tracer collecting the internal call appearances of
+// the executed blocks.
+func newAddrTracer(config *params.ChainConfig) *addrTracer {
+	return &addrTracer{config: config}
+}
+
+// store writes the internal call appearances collected while executing the
+// given block into the batch. It must only be called once the block has been
+// validated.
+func (t *addrTracer) store(batch ethdb.KeyValueWriter, block *types.Block) {
+	if t.pendingHash != block.Hash() {
+		return
+	}
+	if len(t.pending) > 0 {
+		rawdb.WriteAddressInternalAppearances(batch, block.NumberU64(), block.Hash(), t.pending)
+	}
+	t.pendingHash, t.pending = common.Hash{}, nil
+}
+
+// hooks returns the tracing hooks of the tracer, chained after the given ones.
+func (t *addrTracer) hooks(base *tracing.Hooks) *tracing.Hooks {
+	hooks := new(tracing.Hooks)
+	if base != nil {
+		*hooks = *base
+	}
+	if prev := hooks.OnBlockStart; prev != nil {
+		hooks.OnBlockStart = func(ev tracing.BlockEvent) { prev(ev); t.OnBlockStart(ev) }
+	} else {
+		hooks.OnBlockStart = t.OnBlockStart
+	}
+	if prev := hooks.OnBlockEnd; prev != nil {
+		hooks.OnBlockEnd = func(err error) { prev(err); t.OnBlockEnd(err) }
+	} else {
+		hooks.OnBlockEnd = t.OnBlockEnd
+	}
+	if prev := hooks.OnTxStart; prev != nil {
+		hooks.OnTxStart = func(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+			prev(env, tx, from)
+			t.OnTxStart(env, tx, from)
+		}
+	} else {
+		hooks.OnTxStart = t.OnTxStart
+	}
+	if prev := hooks.OnTxEnd; prev != nil {
+		hooks.OnTxEnd = func(receipt *types.Receipt, err error) { prev(receipt, err); t.OnTxEnd(receipt, err) }
+	} else {
+		hooks.OnTxEnd = t.OnTxEnd
+	}
+	if prev := hooks.OnEnter; prev != nil {
+		hooks.OnEnter = func(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+			prev(depth, typ, from, to, input, gas, value)
+			t.OnEnter(depth, typ, from, to, input, gas, value)
+		}
+	} else {
+		hooks.OnEnter = t.OnEnter
+	}
+	return hooks
+}
+
+func (t *addrTracer) OnBlockStart(ev tracing.BlockEvent) {
+	t.block = ev.Block
+	t.precompiles = nil
+	t.txIndex = -1
+	t.inTx = false
+	t.appearances = nil
+	t.pendingHash, t.pending = common.Hash{}, nil
+}
+
+func (t *addrTracer) OnBlockEnd(err error) {
+	t.block = nil
+	t.inTx = false
+	t.appearances = nil
+}
+
+func (t *addrTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	if t.block == nil {
+		return
+	}
+	if t.precompiles == nil {
+		rules := t.config.Rules(env.BlockNumber, env.Random != nil, env.Time)
+		t.precompiles = make(map[common.Address]struct{})
+		for _, addr := range vm.ActivePrecompiles(rules) {
+			t.precompiles[addr] = struct{}{}
+		}
+	}
+	t.txIndex++
+	t.inTx = true
+	t.seen = make(map[common.Address]struct{})
+}
+
+func (t *addrTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	// The participants of the top level call are indexed from the transaction.
+	if !t.inTx || depth == 0 {
+		return
+	}
+	for _, addr := range []common.Address{from, to} {
+		if _, ok := t.precompiles[addr]; ok {
+			continue
+		}
+		if _, ok := t.seen[addr]; ok {
+			continue
+		}
+		t.seen[addr] = struct{}{}
+		t.appearances = append(t.appearances, rawdb.BlockAddressAppearance{Address: addr, TxIndex: uint32(t.txIndex)})
+	}
+}
+
+func (t *addrTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	if !t.inTx {
+		return
+	}
+	t.inTx = false
+
+	// Retain the appearances once the last transaction is executed, as the block
+	// end is only signalled after the block has been written. They are stored
+	// along with the block if it passes validation.
+	if t.txIndex == len(t.block.Transactions())-1 {
+		t.pendingHash, t.pending = t.block.Hash(), t.appearances
+	}
+}
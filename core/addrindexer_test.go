@@ -0,0 +1,290 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// waitAddressIndex waits until the address index has caught up with the given head.
+func waitAddressIndex(t *testing.T, db ethdb.Database, head uint64, hash common.Hash) {
+	t.Helper()
+	for i := 0; i < 500; i++ {
+		if indexed := rawdb.ReadAddressIndexHead(db); indexed != nil && *indexed == head {
+			if h, _, ok := rawdb.ReadAddressIndexBlock(db, head); ok && h == hash {
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("address index did not reach head %d", head)
+}
+
+// TestAddrIndexer tests that the address indexer follows the canonical chain,
+// including across reorgs.
+func TestAddrIndexer(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		recvA  = common.HexToAddress("0xaaaa")
+		recvB  = common.HexToAddress("0xbbbb")
+		signer = types.HomesteadSigner{}
+		gspec  = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = ethash.NewFaker()
+	)
+	transfer := func(to common.Address) func(int, *BlockGen) {
+		return func(i int, gen *BlockGen) {
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), to, big.NewInt(1000), params.TxGas, big.NewInt(10*params.InitialBaseFee), nil), signer, key)
+			gen.AddTx(tx)
+		}
+	}
+	// Contract creations are indexed under the created address.
+	var created common.Address
+	genDb, blocks, _ := GenerateChainWithGenesis(gspec, engine, 8, func(i int, gen *BlockGen) {
+		if i == 0 {
+			created = crypto.CreateAddress(sender, gen.TxNonce(sender))
+			tx, _ := types.SignTx(types.NewContractCreation(gen.TxNonce(sender), common.Big0, 100000, big.NewInt(10*params.InitialBaseFee), []byte{0x00}), signer, key)
+			gen.AddTx(tx)
+			return
+		}
+		transfer(recvA)(i, gen)
+	})
+	cacheConfig := *defaultCacheConfig
+	cacheConfig.AddressIndex = true
+
+	db := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(db, &cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	waitAddressIndex(t, db, 8, blocks[7].Hash())
+
+	if have := rawdb.ReadAddressAppearances(db, sender, 0, 100, 0); len(have) != 8 {
+		t.Fatalf("wrong number of sender appearances: have %d, want 8", len(have))
+	}
+	if have := rawdb.ReadAddressAppearances(db, created, 0, 100, 0); len(have) != 1 || have[0].BlockNumber != 1 {
+		t.Fatalf("wrong contract creation appearances: %v", have)
+	}
+	if have := rawdb.ReadAddressAppearances(db, recvA, 0, 100, 0); len(have) != 7 {
+		t.Fatalf("wrong number of recipient appearances: have %d, want 7", len(have))
+	}
+	// Reorg the chain from block 5 onwards, sending to a different recipient.
+	fork, _ := GenerateChain(gspec.Config, blocks[3], engine, genDb, 6, transfer(recvB))
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	waitAddressIndex(t, db, 10, fork[5].Hash())
+
+	if have := rawdb.ReadAddressAppearances(db, recvA, 0, 100, 0); len(have) != 3 {
+		t.Fatalf("wrong number of recipient appearances after reorg: have %d, want 3", len(have))
+	}
+	if have := rawdb.ReadAddressAppearances(db, recvB, 0, 100, 0); len(have) != 6 || have[0].BlockNumber != 5 {
+		t.Fatalf("wrong appearances of new recipient after reorg: %v", have)
+	}
+	if have := rawdb.ReadAddressAppearances(db, sender, 0, 100, 0); len(have) != 10 {
+		t.Fatalf("wrong number of sender appearances after reorg: have %d, want 10", len(have))
+	}
+	// Backwards search returns the latest appearances first.
+	before := rawdb.ReadAddressAppearancesBefore(db, sender, 10, 3)
+	if len(before) != 3 || before[0].BlockNumber != 9 || before[2].BlockNumber != 7 {
+		t.Fatalf("wrong backwards search result: %v", before)
+	}
+	// A zero limit means no limit, like for the forward search.
+	if before := rawdb.ReadAddressAppearancesBefore(db, sender, 10, 0); len(before) != 9 || before[0].BlockNumber != 9 {
+		t.Fatalf("wrong unlimited backwards search result: %v", before)
+	}
+}
+
+// TestAddrIndexerLimit tests that the address index is pruned to the configured
+// number of recent blocks, and extended again if the limit is raised.
+func TestAddrIndexerLimit(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		recv   = common.HexToAddress("0xaaaa")
+		signer = types.HomesteadSigner{}
+		gspec  = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = ethash.NewFaker()
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 8, func(i int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), recv, big.NewInt(1000), params.TxGas, big.NewInt(10*params.InitialBaseFee), nil), signer, key)
+		gen.AddTx(tx)
+	})
+	cacheConfig := *defaultCacheConfig
+	cacheConfig.AddressIndex = true
+	cacheConfig.AddressIndexLimit = 3
+
+	db := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(db, &cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	waitAddressIndex(t, db, 8, blocks[7].Hash())
+	chain.Stop()
+
+	check := func(tail uint64) {
+		t.Helper()
+		if have := rawdb.ReadAddressIndexTail(db); have == nil || *have != tail {
+			t.Fatalf("wrong index tail: have %v, want %d", have, tail)
+		}
+		have := rawdb.ReadAddressAppearances(db, recv, 0, 100, 0)
+		if want := 8 - max(tail, 1) + 1; uint64(len(have)) != want || have[0].BlockNumber != max(tail, 1) {
+			t.Fatalf("wrong appearances with tail %d: %v", tail, have)
+		}
+		for number := uint64(0); number < tail; number++ {
+			if _, _, ok := rawdb.ReadAddressIndexBlock(db, number); ok {
+				t.Fatalf("block %d not pruned", number)
+			}
+		}
+		if err := VerifyAddressIndex(db, gspec.Config); err != nil {
+			t.Fatalf("failed to verify index: %v", err)
+		}
+	}
+	check(6)
+
+	// Raising the limit extends the index backwards, lowering it prunes again.
+	for _, limit := range []uint64{5, 0, 2} {
+		if err := IndexAddresses(db, gspec.Config, 0, 8, limit, nil); err != nil {
+			t.Fatalf("failed to index with limit %d: %v", limit, err)
+		}
+		want := uint64(0)
+		if limit > 0 {
+			want = 9 - limit
+		}
+		check(want)
+	}
+	// A gap between the indexed range and the desired one drops the old entries.
+	if err := IndexAddresses(db, gspec.Config, 0, 2, 1, nil); err != nil {
+		t.Fatalf("failed to index: %v", err)
+	}
+	if have := rawdb.ReadAddressAppearances(db, recv, 0, 100, 0); len(have) != 1 || have[0].BlockNumber != 2 {
+		t.Fatalf("wrong appearances after reindexing: %v", have)
+	}
+}
+
+// TestAddrIndexerInternal tests that the participants of internal calls are
+// indexed if enabled.
+func TestAddrIndexerInternal(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		caller = common.HexToAddress("0xcccc")
+		callee = common.HexToAddress("0xdddd")
+		signer = types.HomesteadSigner{}
+		// CALL(gas, callee, 0, 0, 0, 0, 0), then SHA256 precompile, which is not indexed
+		code = append(append([]byte{
+			byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+			byte(vm.PUSH20)}, callee.Bytes()...),
+			byte(vm.GAS), byte(vm.CALL),
+			byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 2,
+			byte(vm.GAS), byte(vm.STATICCALL), byte(vm.STOP),
+		)
+		gspec = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender: {Balance: big.NewInt(params.Ether)},
+				caller: {Code: code},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = ethash.NewFaker()
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, func(i int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), common.HexToAddress("0xaaaa"), big.NewInt(1000), params.TxGas, big.NewInt(10*params.InitialBaseFee), nil), signer, key)
+		gen.AddTx(tx)
+		tx, _ = types.SignTx(types.NewTransaction(gen.TxNonce(sender), caller, big.NewInt(0), 100000, big.NewInt(10*params.InitialBaseFee), nil), signer, key)
+		gen.AddTx(tx)
+	})
+	for _, internal := range []bool{false, true} {
+		cacheConfig := *defaultCacheConfig
+		cacheConfig.AddressIndex = true
+		cacheConfig.AddressIndexInternal = internal
+
+		db := rawdb.NewMemoryDatabase()
+		chain, err := NewBlockChain(db, &cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+		if err != nil {
+			t.Fatalf("failed to create chain: %v", err)
+		}
+		// The internal calls of a block failing validation must not be recorded.
+		header := blocks[0].Header()
+		header.Root = common.Hash{0x01}
+		invalid := types.NewBlockWithHeader(header).WithBody(*blocks[0].Body())
+		if _, err := chain.InsertChain(types.Blocks{invalid}); err == nil {
+			t.Fatal("invalid block inserted")
+		}
+		if have := rawdb.ReadAddressInternalAppearances(db, 1, invalid.Hash()); len(have) != 0 {
+			t.Fatalf("internal calls of invalid block recorded: %v", have)
+		}
+		if _, err := chain.InsertChain(blocks); err != nil {
+			t.Fatalf("failed to insert chain: %v", err)
+		}
+		waitAddressIndex(t, db, 2, blocks[1].Hash())
+		chain.Stop()
+
+		have := rawdb.ReadAddressAppearances(db, callee, 0, 100, 0)
+		if !internal {
+			if len(have) != 0 {
+				t.Fatalf("internal call indexed without being enabled: %v", have)
+			}
+			continue
+		}
+		want := []rawdb.AddressAppearance{{BlockNumber: 1, TxIndex: 1}, {BlockNumber: 2, TxIndex: 1}}
+		if !reflect.DeepEqual(have, want) {
+			t.Fatalf("wrong internal call appearances: have %v, want %v", have, want)
+		}
+		if have := rawdb.ReadAddressAppearances(db, common.BytesToAddress([]byte{2}), 0, 100, 0); len(have) != 0 {
+			t.Fatalf("precompile indexed: %v", have)
+		}
+		if err := VerifyAddressIndex(db, gspec.Config); err != nil {
+			t.Fatalf("failed to verify index: %v", err)
+		}
+		// Verification fails if a block record is missing.
+		_, appearances, _ := rawdb.ReadAddressIndexBlock(db, 1)
+		rawdb.DeleteAddressIndexBlock(db, 1, appearances)
+		if err := VerifyAddressIndex(db, gspec.Config); err == nil {
+			t.Fatal("verification passed with missing block record")
+		}
+	}
+}
@@ -18,6 +18,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -48,6 +49,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/syncx"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/internal/version"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
@@ -111,6 +113,10 @@ const (
 	receiptsCacheLimit = 32
 	txLookupCacheLimit = 1024
 
+	// maxFollowerReorgAnnouncement is the maximum number of blocks whose logs are
+	// announced when the head of a followed chain is reloaded.
+	maxFollowerReorgAnnouncement = 1024
+
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	//
 	// Changelog:
@@ -157,6 +163,7 @@ type CacheConfig struct {
 	SnapshotLimit       int           // Memory allowance (MB) to use for caching snapshot entries in memory
 	Preimages           bool          // Whether to store preimage of trie key to the disk
 	StateHistory        uint64        // Number of blocks from head whose state histories are reserved.
+	HistoricStateWindow uint64        // Number of recent blocks whose state can be read from state histories (path scheme only)
 	StateScheme         string        // Scheme used to store ethereum states and merkle tree nodes on top
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
@@ -165,6 +172,14 @@ type CacheConfig struct {
 	// This defines the cutoff block for history expiry.
 	// Blocks before this number may be unavailable in the chain database.
 	ChainHistoryMode history.HistoryMode
+
+	AddressIndex         bool   // Whether to maintain the address appearance index
+	AddressIndexLimit    uint64 // Number of recent blocks to maintain the address index for (0 = entire chain)
+	AddressIndexInternal bool   // Whether to index the participants of internal calls too
+
+	Follower bool // Whether the database is owned and written by another process
+
+	StateFork state.ForkSource // Remote state to fall back to for data missing locally (simulation only)
 }
 
 // triedbConfig derives the configures for trie database.
@@ -183,6 +198,9 @@ func (c *CacheConfig) triedbConfig(isVerkle bool) *triedb.Config {
 			StateHistory:    c.StateHistory,
 			CleanCacheSize:  c.TrieCleanLimit * 1024 * 1024,
 			WriteBufferSize: c.TrieDirtyLimit * 1024 * 1024,
+			ReadOnly:        c.Follower,
+
+			HistoricStateWindow: c.HistoricStateWindow,
 		}
 	}
 	return config
@@ -240,7 +258,10 @@ type BlockChain struct {
 	flushInterval atomic.Int64                     // Time interval (processing time) after which to flush a state
 	triedb        *triedb.Database                 // The database handler for maintaining trie nodes.
 	statedb       *state.CachingDB                 // State database to reuse between imports (contains state cache)
+	historicdb    *state.HistoricDB                // State database for the historic states, nil if not enabled
 	txIndexer     *txIndexer                       // Transaction indexer, might be nil if not enabled
+	addrIndexer   *addrIndexer                     // Address appearance indexer, might be nil if not enabled
+	addrTracer    *addrTracer                      // Collector of internal call appearances, nil if not indexed
 
 	hc               *HeaderChain
 	rmLogsFeed       event.Feed
@@ -331,12 +352,21 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		vmConfig:      vmConfig,
 		logger:        vmConfig.Tracer,
 	}
+	// Collect the participants of internal calls during block processing, if
+	// they are to be indexed.
+	if cacheConfig.AddressIndex && cacheConfig.AddressIndexInternal {
+		bc.addrTracer = newAddrTracer(chainConfig)
+		bc.logger = bc.addrTracer.hooks(vmConfig.Tracer)
+	}
 	bc.hc, err = NewHeaderChain(db, chainConfig, engine, bc.insertStopped)
 	if err != nil {
 		return nil, err
 	}
 	bc.flushInterval.Store(int64(cacheConfig.TrieTimeLimit))
-	bc.statedb = state.NewDatabase(bc.triedb, nil)
+	bc.statedb = state.NewDatabase(bc.triedb, nil).WithFork(cacheConfig.StateFork)
+	if cacheConfig.StateScheme == rawdb.PathScheme && cacheConfig.HistoricStateWindow > 0 {
+		bc.historicdb = state.NewHistoricDatabase(bc.triedb)
+	}
 	bc.validator = NewBlockValidator(chainConfig, bc)
 	bc.prefetcher = newStatePrefetcher(chainConfig, bc.hc)
 	bc.processor = NewStateProcessor(chainConfig, bc.hc)
@@ -358,9 +388,13 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	// If Geth is initialized with an external ancient store, re-initialize the
 	// missing chain indexes and chain flags. This procedure can survive crash
 	// and can be resumed in next restart since chain flags are updated in last step.
-	if bc.empty() {
+	if bc.empty() && !cacheConfig.Follower {
 		rawdb.InitDatabaseFromFreezer(bc.db)
 	}
+	// The followed database can't be reset, ensure it's initialized.
+	if cacheConfig.Follower && rawdb.ReadHeadBlockHash(db) == (common.Hash{}) {
+		return nil, errors.New("followed database is not initialized")
+	}
 	// Load blockchain states from disk
 	if err := bc.loadLastState(); err != nil {
 		return nil, err
@@ -369,7 +403,11 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	// if there is no available state, waiting for state sync.
 	head := bc.CurrentBlock()
 	if !bc.HasState(head.Root) {
-		if head.Number.Uint64() == 0 {
+		if cacheConfig.Follower {
+			// The state of the followed chain is only available once it's persisted
+			// by the owner, nothing to repair.
+			log.Info("Head state is not persisted yet", "number", head.Number, "hash", head.Hash())
+		} else if head.Number.Uint64() == 0 {
 			// The genesis state is missing, which is only possible in the path-based
 			// scheme. This situation occurs when the initial state sync is not finished
 			// yet, or the chain head is rewound below the pivot point. In both scenarios,
@@ -404,7 +442,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		}
 	}
 	// Ensure that a previous crash in SetHead doesn't leave extra ancients
-	if frozen, err := bc.db.Ancients(); err == nil && frozen > 0 {
+	if frozen, err := bc.db.Ancients(); err == nil && frozen > 0 && !cacheConfig.Follower {
 		var (
 			needRewind bool
 			low        uint64
@@ -456,7 +494,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	}
 
 	// Load any existing snapshot, regenerating it if loading failed
-	if bc.cacheConfig.SnapshotLimit > 0 {
+	if bc.cacheConfig.SnapshotLimit > 0 && !bc.cacheConfig.Follower {
 		// If the chain was rewound past the snapshot persistent layer (causing
 		// a recovery block number to be persisted to disk), check if we're still
 		// in recovery mode and in that case, don't invalidate the snapshot on a
@@ -477,11 +515,14 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		bc.snaps, _ = snapshot.New(snapconfig, bc.db, bc.triedb, head.Root)
 
 		// Re-initialize the state database with snapshot
-		bc.statedb = state.NewDatabase(bc.triedb, bc.snaps)
+		bc.statedb = state.NewDatabase(bc.triedb, bc.snaps).WithFork(bc.cacheConfig.StateFork)
 	}
 
 	// Rewind the chain in case of an incompatible config upgrade.
 	if compatErr != nil {
+		if cacheConfig.Follower {
+			return nil, fmt.Errorf("followed chain requires rewinding: %w", compatErr)
+		}
 		log.Warn("Rewinding chain to upgrade configuration", "err", compatErr)
 		if compatErr.RewindToTime > 0 {
 			bc.SetHeadWithTimestamp(compatErr.RewindToTime)
@@ -490,13 +531,131 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		}
 		rawdb.WriteChainConfig(db, genesisHash, chainConfig)
 	}
-	// Start tx indexer if it's enabled.
-	if txLookupLimit != nil {
+	// Start tx indexer if it's enabled. The indexes of a followed chain are
+	// maintained by the owner of the database.
+	if txLookupLimit != nil && !cacheConfig.Follower {
 		bc.txIndexer = newTxIndexer(*txLookupLimit, bc)
 	}
+	// Start address indexer if it's enabled.
+	if bc.cacheConfig.AddressIndex && !cacheConfig.Follower {
+		bc.addrIndexer = newAddrIndexer(bc.cacheConfig.AddressIndexLimit, bc)
+	}
 	return bc, nil
 }
 
+// Reload loads the chain markers written by the owner of the followed database
+// and announces the blocks imported since the previous reload. The database has
+// to be refreshed beforehand to make the writes of the owner visible. It's only
+// supported if the chain is a follower.
+//
+// The owner keeps the states of the recent blocks in memory and only persists
+// them periodically, thus the head of the follower is pinned to the newest block
+// of the owner's chain whose state is available in the database.
+func (bc *BlockChain) Reload() error {
+	if !bc.cacheConfig.Follower {
+		return errors.New("chain is not a follower")
+	}
+	if !bc.chainmu.TryLock() {
+		return errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+
+	// Reconstruct the state layers if the persistent state has been advanced.
+	if err := bc.triedb.Reload(); err != nil {
+		return err
+	}
+	hash := rawdb.ReadHeadBlockHash(bc.db)
+	head := bc.GetHeaderByHash(hash)
+	if head == nil {
+		return fmt.Errorf("head block %x missing", hash)
+	}
+	for !bc.HasState(head.Root) {
+		if head.Number.Uint64() == 0 {
+			return fmt.Errorf("no state available below head block %x", hash)
+		}
+		parent := bc.GetHeader(head.ParentHash, head.Number.Uint64()-1)
+		if parent == nil {
+			return fmt.Errorf("block %x missing", head.ParentHash)
+		}
+		head = parent
+	}
+	oldHead := bc.CurrentBlock()
+	if head.Hash() != oldHead.Hash() {
+		// Lookups cached for the previous head might be invalidated by a reorg.
+		bc.txLookupCache.Purge()
+
+		bc.currentBlock.Store(head)
+		headBlockGauge.Update(int64(head.Number.Uint64()))
+	}
+	if hash := rawdb.ReadHeadHeaderHash(bc.db); hash != (common.Hash{}) {
+		if header := bc.GetHeaderByHash(hash); header != nil {
+			bc.hc.SetCurrentHeader(header)
+		}
+	}
+	if hash := rawdb.ReadHeadFastBlockHash(bc.db); hash != (common.Hash{}) {
+		if header := bc.GetHeaderByHash(hash); header != nil {
+			bc.currentSnapBlock.Store(header)
+			headFastBlockGauge.Update(int64(header.Number.Uint64()))
+		}
+	}
+	// The safe block is not persisted, track the finalized block instead.
+	if hash := rawdb.ReadFinalizedBlockHash(bc.db); hash != (common.Hash{}) {
+		if header := bc.GetHeaderByHash(hash); header != nil {
+			bc.currentFinalBlock.Store(header)
+			headFinalizedBlockGauge.Update(int64(header.Number.Uint64()))
+			bc.currentSafeBlock.Store(header)
+			headSafeBlockGauge.Update(int64(header.Number.Uint64()))
+		}
+	}
+	if head.Hash() != oldHead.Hash() {
+		bc.announceReload(oldHead, head)
+	}
+	return nil
+}
+
+// announceReload posts the events of the chain switching from the old head to
+// the new head, which are both already known to be canonical in the followed
+// database. The logs are only announced if the two heads are close enough.
+func (bc *BlockChain) announceReload(oldHead, newHead *types.Header) {
+	var (
+		oldChain, newChain []*types.Header
+		oldBlock, newBlock = oldHead, newHead
+	)
+	for oldBlock != nil && newBlock != nil && oldBlock.Hash() != newBlock.Hash() {
+		if len(oldChain)+len(newChain) > maxFollowerReorgAnnouncement {
+			oldBlock, newBlock = nil, nil
+			break
+		}
+		if oldBlock.Number.Uint64() >= newBlock.Number.Uint64() {
+			oldChain = append(oldChain, oldBlock)
+			oldBlock = bc.GetHeader(oldBlock.ParentHash, oldBlock.Number.Uint64()-1)
+		} else {
+			newChain = append(newChain, newBlock)
+			newBlock = bc.GetHeader(newBlock.ParentHash, newBlock.Number.Uint64()-1)
+		}
+	}
+	if oldBlock != nil && newBlock != nil {
+		for _, header := range oldChain {
+			if block := bc.GetBlock(header.Hash(), header.Number.Uint64()); block != nil {
+				if logs := bc.collectLogs(block, true); len(logs) > 0 {
+					bc.rmLogsFeed.Send(RemovedLogsEvent{logs})
+				}
+			}
+		}
+		for i := len(newChain) - 1; i >= 0; i-- {
+			if block := bc.GetBlock(newChain[i].Hash(), newChain[i].Number.Uint64()); block != nil {
+				if logs := bc.collectLogs(block, false); len(logs) > 0 {
+					bc.logsFeed.Send(logs)
+				}
+			}
+		}
+	} else {
+		log.Debug("Skipped announcing logs of followed chain", "old", oldHead.Number, "new", newHead.Number)
+	}
+	bc.chainFeed.Send(ChainEvent{Header: newHead})
+	bc.chainHeadFeed.Send(ChainHeadEvent{Header: newHead})
+}
+
 // empty returns an indicator whether the blockchain is empty.
 // Note, it's a special case that we connect a non-empty ancient
 // database with an empty node, so that we can plugin the ancient
@@ -1184,6 +1343,10 @@ func (bc *BlockChain) stopWithoutSaving() {
 	if bc.txIndexer != nil {
 		bc.txIndexer.close()
 	}
+	// Signal shutdown address indexer.
+	if bc.addrIndexer != nil {
+		bc.addrIndexer.close()
+	}
 	// Unsubscribe all subscriptions registered from blockchain.
 	bc.scope.Close()
 
@@ -1205,6 +1368,18 @@ func (bc *BlockChain) stopWithoutSaving() {
 func (bc *BlockChain) Stop() {
 	bc.stopWithoutSaving()
 
+	// Nothing to persist if the database is owned by another process.
+	if bc.cacheConfig.Follower {
+		if bc.logger != nil && bc.logger.OnClose != nil {
+			bc.logger.OnClose()
+		}
+		if err := bc.triedb.Close(); err != nil {
+			log.Error("Failed to close trie database", "err", err)
+		}
+		log.Info("Blockchain stopped")
+		return
+	}
+
 	// Ensure that the entirety of the state snapshot is journaled to disk.
 	var snapBase common.Hash
 	if bc.snaps != nil {
@@ -1505,8 +1680,9 @@ func (bc *BlockChain) writeKnownBlock(block *types.Block) error {
 }
 
 // writeBlockWithState writes block, metadata and corresponding state data to the
-// database.
-func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, statedb *state.StateDB) error {
+// database. The trie database writes are traced within the span carried by the
+// given context.
+func (bc *BlockChain) writeBlockWithState(ctx context.Context, block *types.Block, receipts []*types.Receipt, statedb *state.StateDB) error {
 	if !bc.HasHeader(block.ParentHash(), block.NumberU64()-1) {
 		return consensus.ErrUnknownAncestor
 	}
@@ -1518,11 +1694,14 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
 	rawdb.WritePreimages(blockBatch, statedb.Preimages())
+	if bc.addrTracer != nil {
+		bc.addrTracer.store(blockBatch, block)
+	}
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
 	}
 	// Commit all cached state changes into underlying memory database.
-	root, err := statedb.Commit(block.NumberU64(), bc.chainConfig.IsEIP158(block.Number()), bc.chainConfig.IsCancun(block.Number(), block.Time()))
+	root, err := statedb.CommitContext(ctx, block.NumberU64(), bc.chainConfig.IsEIP158(block.Number()), bc.chainConfig.IsCancun(block.Number(), block.Time()))
 	if err != nil {
 		return err
 	}
@@ -1533,7 +1712,7 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	}
 	// If we're running an archive node, always flush
 	if bc.cacheConfig.TrieDirtyDisabled {
-		return bc.triedb.Commit(root, false)
+		return bc.triedb.CommitContext(ctx, root, false)
 	}
 	// Full but not archive node, do proper garbage collection
 	bc.triedb.Reference(root, common.Hash{}) // metadata reference to keep trie alive
@@ -1569,7 +1748,7 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 				log.Info("State in memory for too long, committing", "time", bc.gcproc, "allowance", flushInterval, "optimum", float64(chosen-bc.lastWrite)/state.TriesInMemory)
 			}
 			// Flush an entire trie and restart the counters
-			bc.triedb.Commit(header.Root, true)
+			bc.triedb.CommitContext(ctx, header.Root, true)
 			bc.lastWrite = chosen
 			bc.gcproc = 0
 		}
@@ -1588,8 +1767,8 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 
 // writeBlockAndSetHead is the internal implementation of WriteBlockAndSetHead.
 // This function expects the chain mutex to be held.
-func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB, emitHeadEvent bool) (status WriteStatus, err error) {
-	if err := bc.writeBlockWithState(block, receipts, state); err != nil {
+func (bc *BlockChain) writeBlockAndSetHead(ctx context.Context, block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB, emitHeadEvent bool) (status WriteStatus, err error) {
+	if err := bc.writeBlockWithState(ctx, block, receipts, state); err != nil {
 		return NonStatTy, err
 	}
 	currentBlock := bc.CurrentBlock()
@@ -1684,6 +1863,12 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 		stats     = insertStats{startTime: mclock.Now()}
 		lastCanon *types.Block
 	)
+	ctx, span := telemetry.Start(context.Background(), "core.BlockChain.insertChain", telemetry.WithAttributes(
+		telemetry.Uint64("first", chain[0].NumberU64()),
+		telemetry.Int64("blocks", int64(len(chain))),
+	))
+	defer span.End()
+
 	// Fire a single chain head event if we've progressed the chain
 	defer func() {
 		if lastCanon != nil && bc.CurrentBlock().Hash() == lastCanon.Hash() {
@@ -1875,9 +2060,10 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 		}
 
 		// The traced section of block import.
-		res, err := bc.processBlock(block, statedb, start, setHead)
+		res, err := bc.processBlock(ctx, block, statedb, start, setHead)
 		followupInterrupt.Store(true)
 		if err != nil {
+			span.RecordError(err)
 			return nil, it.index, err
 		}
 		// Report the import stats before returning the various results
@@ -1942,7 +2128,17 @@ type blockProcessingResult struct {
 
 // processBlock executes and validates the given block. If there was no error
 // it writes the block and associated state to database.
-func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, start time.Time, setHead bool) (_ *blockProcessingResult, blockEndErr error) {
+func (bc *BlockChain) processBlock(ctx context.Context, block *types.Block, statedb *state.StateDB, start time.Time, setHead bool) (_ *blockProcessingResult, blockEndErr error) {
+	ctx, span := telemetry.Start(ctx, "core.BlockChain.processBlock", telemetry.WithAttributes(
+		telemetry.Uint64("number", block.NumberU64()),
+		telemetry.String("hash", block.Hash().Hex()),
+		telemetry.Int64("txs", int64(len(block.Transactions()))),
+		telemetry.Uint64("gasUsed", block.GasUsed()),
+	))
+	defer func() {
+		span.RecordError(blockEndErr)
+		span.End()
+	}()
 	if bc.logger != nil && bc.logger.OnBlockStart != nil {
 		bc.logger.OnBlockStart(tracing.BlockEvent{
 			Block:     block,
@@ -1958,7 +2154,15 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 
 	// Process block using the parent state as reference point
 	pstart := time.Now()
-	res, err := bc.processor.Process(block, statedb, bc.vmConfig)
+	// The chain logger might carry the hooks of internal indexers, which are only
+	// attached when processing blocks.
+	vmConfig := bc.vmConfig
+	vmConfig.Tracer = bc.logger
+
+	_, pspan := telemetry.Start(ctx, "core.BlockChain.execution")
+	res, err := bc.processor.Process(block, statedb, vmConfig)
+	pspan.RecordError(err)
+	pspan.End()
 	if err != nil {
 		bc.reportBlock(block, res, err)
 		return nil, err
@@ -1966,7 +2170,11 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 	ptime := time.Since(pstart)
 
 	vstart := time.Now()
-	if err := bc.validator.ValidateState(block, statedb, res, false); err != nil {
+	_, vspan := telemetry.Start(ctx, "core.BlockChain.validation")
+	err = bc.validator.ValidateState(block, statedb, res, false)
+	vspan.RecordError(err)
+	vspan.End()
+	if err != nil {
 		bc.reportBlock(block, res, err)
 		return nil, err
 	}
@@ -2026,12 +2234,21 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 		wstart = time.Now()
 		status WriteStatus
 	)
+	wctx, wspan := telemetry.Start(ctx, "core.BlockChain.commit")
 	if !setHead {
 		// Don't set the head, only insert the block
-		err = bc.writeBlockWithState(block, res.Receipts, statedb)
+		err = bc.writeBlockWithState(wctx, block, res.Receipts, statedb)
 	} else {
-		status, err = bc.writeBlockAndSetHead(block, res.Receipts, res.Logs, statedb, false)
+		status, err = bc.writeBlockAndSetHead(wctx, block, res.Receipts, res.Logs, statedb, false)
 	}
+	wspan.SetAttributes(
+		telemetry.Int64("accountCommitNs", int64(statedb.AccountCommits)),
+		telemetry.Int64("storageCommitNs", int64(statedb.StorageCommits)),
+		telemetry.Int64("snapshotCommitNs", int64(statedb.SnapshotCommits)),
+		telemetry.Int64("triedbCommitNs", int64(statedb.TrieDBCommits)),
+	)
+	wspan.RecordError(err)
+	wspan.End()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,218 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// followerTest is a chain writing into a database, and a follower chain on top
+// of the same database.
+type followerTest struct {
+	gspec    *Genesis
+	engine   *ethash.Ethash
+	key      *ecdsa.PrivateKey
+	chain    *BlockChain
+	db       *rawdb.FollowerDatabase
+	follower *BlockChain
+}
+
+func newFollowerTest(t *testing.T, config *CacheConfig) *followerTest {
+	var (
+		datadir = t.TempDir()
+		ancient = filepath.Join(datadir, "ancient")
+
+		checkpoints = filepath.Join(t.TempDir(), "checkpoints")
+
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = ethash.NewFaker()
+	)
+	// The writes of the primary are synced, otherwise they might be buffered
+	// and not yet observable by the follower.
+	kvdb, err := pebble.New(datadir, 0, 0, "", false, false)
+	if err != nil {
+		t.Fatalf("Failed to create pebble database: %v", err)
+	}
+	db, err := rawdb.NewDatabaseWithFreezer(kvdb, ancient, "", false)
+	if err != nil {
+		t.Fatalf("Failed to create chain database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	chain, err := NewBlockChain(db, config, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	t.Cleanup(chain.Stop)
+
+	// Open the follower on top of the initialized database
+	followerDb, err := rawdb.NewFollowerDatabase(func() (ethdb.KeyValueStore, error) {
+		return pebble.NewFollower(datadir, checkpoints, 0, 0, "")
+	}, ancient, "")
+	if err != nil {
+		t.Fatalf("Failed to open follower database: %v", err)
+	}
+	t.Cleanup(func() { followerDb.Close() })
+
+	followerConfig := DefaultCacheConfigWithScheme(config.StateScheme)
+	followerConfig.SnapshotLimit = 0
+	followerConfig.Follower = true
+	follower, err := NewBlockChain(followerDb, followerConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create follower chain: %v", err)
+	}
+	t.Cleanup(follower.Stop)
+
+	return &followerTest{gspec: gspec, engine: engine, key: key, chain: chain, db: followerDb, follower: follower}
+}
+
+// reload refreshes the follower and ensures it switched to the wanted head,
+// announcing it if it changed.
+func (ft *followerTest) reload(t *testing.T, heads chan ChainHeadEvent, want *types.Block) {
+	t.Helper()
+
+	old := ft.follower.CurrentBlock()
+	if err := ft.db.Refresh(); err != nil {
+		t.Fatalf("Failed to refresh follower database: %v", err)
+	}
+	if err := ft.follower.Reload(); err != nil {
+		t.Fatalf("Failed to reload follower chain: %v", err)
+	}
+	if head := ft.follower.CurrentBlock(); head.Hash() != want.Hash() {
+		t.Fatalf("Unexpected follower head: have #%d [%x], want #%d [%x]", head.Number, head.Hash(), want.Number(), want.Hash())
+	}
+	select {
+	case ev := <-heads:
+		if ev.Header.Hash() != want.Hash() {
+			t.Fatalf("Unexpected head event: have %x, want %x", ev.Header.Hash(), want.Hash())
+		}
+	default:
+		if old.Hash() != want.Hash() {
+			t.Fatal("No head event posted")
+		}
+	}
+	if _, err := ft.follower.StateAt(want.Root()); err != nil {
+		t.Fatalf("Head state is not available: %v", err)
+	}
+}
+
+// makeBlocks generates a chain of blocks with a transfer each on top of the
+// genesis block.
+func (ft *followerTest) makeBlocks(n int) []*types.Block {
+	var (
+		addr   = crypto.PubkeyToAddress(ft.key.PublicKey)
+		signer = types.LatestSigner(ft.gspec.Config)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(ft.gspec, ft.engine, n, func(i int, b *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(addr), common.Address{0x01}, big.NewInt(1000), params.TxGas, b.header.BaseFee, nil), signer, ft.key)
+		b.AddTx(tx)
+	})
+	return blocks
+}
+
+// Tests that a follower chain tracks the head of the chain written by another
+// instance into the shared database, including reorgs.
+func TestFollowerReload(t *testing.T) {
+	config := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	config.TrieDirtyDisabled = true
+	config.SnapshotLimit = 0
+	ft := newFollowerTest(t, config)
+
+	heads := make(chan ChainHeadEvent, 10)
+	sub := ft.follower.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	// Import a few blocks into the primary and ensure the follower picks them up
+	blocks := ft.makeBlocks(4)
+	if _, err := ft.chain.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to insert chain: %v", err)
+	}
+	if head := ft.follower.CurrentBlock(); head.Number.Uint64() != 0 {
+		t.Fatalf("Follower advanced without reload: #%d", head.Number)
+	}
+	ft.reload(t, heads, blocks[3])
+
+	if tx, _, _, _ := rawdb.ReadTransaction(ft.db, blocks[3].Transactions()[0].Hash()); tx == nil {
+		t.Fatal("Transaction of followed block is not found")
+	}
+	// Reorg the primary to a longer fork and ensure the follower switches too
+	_, forks, _ := GenerateChainWithGenesis(ft.gspec, ft.engine, 6, func(i int, b *BlockGen) {
+		b.SetCoinbase(common.Address{0x02})
+	})
+	if _, err := ft.chain.InsertChain(forks); err != nil {
+		t.Fatalf("Failed to insert fork: %v", err)
+	}
+	ft.reload(t, heads, forks[5])
+
+	if ft.follower.GetBlockByNumber(2).Hash() != forks[1].Hash() {
+		t.Fatal("Follower canonical chain not reorged")
+	}
+	// Ensure nothing is announced if the head is unchanged
+	if err := ft.follower.Reload(); err != nil {
+		t.Fatalf("Failed to reload follower chain: %v", err)
+	}
+	select {
+	case ev := <-heads:
+		t.Fatalf("Unexpected head event: %x", ev.Header.Hash())
+	default:
+	}
+}
+
+// Tests that a follower of a path-based database only advances to the blocks
+// whose state was persisted by the owner, which keeps the recent states in
+// memory.
+func TestFollowerReloadPathScheme(t *testing.T) {
+	config := DefaultCacheConfigWithScheme(rawdb.PathScheme)
+	config.SnapshotLimit = 0
+	ft := newFollowerTest(t, config)
+
+	heads := make(chan ChainHeadEvent, 10)
+	sub := ft.follower.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	// The states of the imported blocks are only held in memory by the owner
+	blocks := ft.makeBlocks(4)
+	if _, err := ft.chain.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to insert chain: %v", err)
+	}
+	ft.reload(t, heads, ft.chain.Genesis())
+
+	// Persisting the state of a block makes it the head of the follower
+	if err := ft.chain.TrieDB().Commit(blocks[2].Root(), false); err != nil {
+		t.Fatalf("Failed to persist state: %v", err)
+	}
+	ft.reload(t, heads, blocks[2])
+}
@@ -360,6 +360,16 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
 	return state.New(root, bc.statedb)
 }
 
+// HistoricState returns a read-only state of a recent historical point, which
+// is reconstructed from the retained state histories. It's only available in
+// the path scheme with a non-zero historic state window.
+func (bc *BlockChain) HistoricState(root common.Hash) (*state.StateDB, error) {
+	if bc.historicdb == nil {
+		return nil, errors.New("historic state is not available")
+	}
+	return state.New(root, bc.historicdb)
+}
+
 // Config retrieves the chain's fork configuration.
 func (bc *BlockChain) Config() *params.ChainConfig { return bc.chainConfig }
 
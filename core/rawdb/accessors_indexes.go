@@ -499,3 +499,261 @@ func DeleteBloomBitsDb(db ethdb.KeyValueStore, hashScheme bool, stopCallback fun
 	}
 	return deletePrefixRange(db, bloomBitsMetaPrefix, hashScheme, stopCallback)
 }
+
+// AddressAppearance identifies a transaction in which an address appears.
+type AddressAppearance struct {
+	BlockNumber uint64
+	TxIndex     uint32
+}
+
+// BlockAddressAppearance is an address appearing in a transaction of a block.
+type BlockAddressAppearance struct {
+	Address common.Address
+	TxIndex uint32
+}
+
+// addressIndexBlock is the per-block record of the address appearance index. It
+// allows removing the index entries of a block again, e.g. if the block is
+// reorged out of the canonical chain.
+type addressIndexBlock struct {
+	Hash        common.Hash
+	Appearances []BlockAddressAppearance
+}
+
+// ReadAddressIndexHead retrieves the number of the latest block whose address
+// appearances have been indexed.
+func ReadAddressIndexHead(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(addressIndexHeadKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteAddressIndexHead stores the number of the latest block whose address
+// appearances have been indexed.
+func WriteAddressIndexHead(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(addressIndexHeadKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the address index head", "err", err)
+	}
+}
+
+// DeleteAddressIndexHead deletes the number of the latest block whose address
+// appearances have been indexed.
+func DeleteAddressIndexHead(db ethdb.KeyValueWriter) {
+	if err := db.Delete(addressIndexHeadKey); err != nil {
+		log.Crit("Failed to delete the address index head", "err", err)
+	}
+}
+
+// ReadAddressIndexTail retrieves the number of the oldest block whose address
+// appearances have been indexed.
+func ReadAddressIndexTail(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(addressIndexTailKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteAddressIndexTail stores the number of the oldest block whose address
+// appearances have been indexed.
+func WriteAddressIndexTail(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(addressIndexTailKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the address index tail", "err", err)
+	}
+}
+
+// DeleteAddressIndexTail deletes the number of the oldest block whose address
+// appearances have been indexed.
+func DeleteAddressIndexTail(db ethdb.KeyValueWriter) {
+	if err := db.Delete(addressIndexTailKey); err != nil {
+		log.Crit("Failed to delete the address index tail", "err", err)
+	}
+}
+
+// ReadAddressIndexBlock retrieves the hash of an indexed block along with the
+// address appearances recorded for it.
+func ReadAddressIndexBlock(db ethdb.KeyValueReader, number uint64) (common.Hash, []BlockAddressAppearance, bool) {
+	data, _ := db.Get(addressIndexBlockKey(number))
+	if len(data) == 0 {
+		return common.Hash{}, nil, false
+	}
+	var record addressIndexBlock
+	if err := rlp.DecodeBytes(data, &record); err != nil {
+		log.Error("Invalid address index block record", "number", number, "err", err)
+		return common.Hash{}, nil, false
+	}
+	return record.Hash, record.Appearances, true
+}
+
+// WriteAddressIndexBlock indexes the given address appearances of a block.
+func WriteAddressIndexBlock(db ethdb.KeyValueWriter, number uint64, hash common.Hash, appearances []BlockAddressAppearance) {
+	data, err := rlp.EncodeToBytes(&addressIndexBlock{Hash: hash, Appearances: appearances})
+	if err != nil {
+		log.Crit("Failed to encode address index block record", "err", err)
+	}
+	for _, a := range appearances {
+		if err := db.Put(addressAppearanceKey(a.Address, number, a.TxIndex), nil); err != nil {
+			log.Crit("Failed to store address appearance", "err", err)
+		}
+	}
+	if err := db.Put(addressIndexBlockKey(number), data); err != nil {
+		log.Crit("Failed to store address index block record", "err", err)
+	}
+}
+
+// DeleteAddressIndexBlock removes the given address appearances of a block from
+// the index, along with the block record.
+func DeleteAddressIndexBlock(db ethdb.KeyValueWriter, number uint64, appearances []BlockAddressAppearance) {
+	for _, a := range appearances {
+		if err := db.Delete(addressAppearanceKey(a.Address, number, a.TxIndex)); err != nil {
+			log.Crit("Failed to delete address appearance", "err", err)
+		}
+	}
+	if err := db.Delete(addressIndexBlockKey(number)); err != nil {
+		log.Crit("Failed to delete address index block record", "err", err)
+	}
+}
+
+// HasAddressAppearance checks whether the given address appearance is indexed.
+func HasAddressAppearance(db ethdb.KeyValueReader, address common.Address, number uint64, txIndex uint32) bool {
+	if has, err := db.Has(addressAppearanceKey(address, number, txIndex)); !has || err != nil {
+		return false
+	}
+	return true
+}
+
+// ReadAddressInternalAppearances retrieves the addresses taking part in the
+// internal calls of the given block, as collected during its execution.
+func ReadAddressInternalAppearances(db ethdb.KeyValueReader, number uint64, hash common.Hash) []BlockAddressAppearance {
+	data, _ := db.Get(addressInternalKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var appearances []BlockAddressAppearance
+	if err := rlp.DecodeBytes(data, &appearances); err != nil {
+		log.Error("Invalid address internal call record", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	return appearances
+}
+
+// WriteAddressInternalAppearances stores the addresses taking part in the
+// internal calls of the given block.
+func WriteAddressInternalAppearances(db ethdb.KeyValueWriter, number uint64, hash common.Hash, appearances []BlockAddressAppearance) {
+	data, err := rlp.EncodeToBytes(appearances)
+	if err != nil {
+		log.Crit("Failed to encode address internal call record", "err", err)
+	}
+	if err := db.Put(addressInternalKey(number, hash), data); err != nil {
+		log.Crit("Failed to store address internal call record", "err", err)
+	}
+}
+
+// DeleteAddressInternalAppearances removes the internal call records of all the
+// blocks with the given number, canonical or not.
+func DeleteAddressInternalAppearances(db ethdb.Iteratee, batch ethdb.KeyValueWriter, number uint64) {
+	prefix := append(append([]byte{}, addressInternalPrefix...), encodeBlockNumber(number)...)
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if len(it.Key()) != len(prefix)+common.HashLength {
+			continue
+		}
+		if err := batch.Delete(it.Key()); err != nil {
+			log.Crit("Failed to delete address internal call record", "err", err)
+		}
+	}
+}
+
+// addressAppearancePosition encodes the position of an appearance within the
+// index of an address.
+func addressAppearancePosition(a AddressAppearance) []byte {
+	pos := make([]byte, 12)
+	binary.BigEndian.PutUint64(pos, a.BlockNumber)
+	binary.BigEndian.PutUint32(pos[8:], a.TxIndex)
+	return pos
+}
+
+// ReadAddressAppearanceRange retrieves at most limit appearances of the address
+// in the range [start, end), in ascending order. A zero limit means no limit.
+func ReadAddressAppearanceRange(db ethdb.Iteratee, address common.Address, start, end AddressAppearance, limit int) []AddressAppearance {
+	var (
+		prefix = append(append([]byte{}, addressAppearancePrefix...), address.Bytes()...)
+		last   = addressAppearancePosition(end)
+		it     = db.NewIterator(prefix, addressAppearancePosition(start))
+	)
+	defer it.Release()
+
+	var result []AddressAppearance
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+12 {
+			continue
+		}
+		if bytes.Compare(key[len(prefix):], last) >= 0 {
+			break
+		}
+		result = append(result, AddressAppearance{
+			BlockNumber: binary.BigEndian.Uint64(key[len(prefix):]),
+			TxIndex:     binary.BigEndian.Uint32(key[len(prefix)+8:]),
+		})
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result
+}
+
+// ReadAddressAppearances retrieves at most limit appearances of the address in
+// the block range [from, to), in ascending order. A zero limit means no limit.
+func ReadAddressAppearances(db ethdb.Iteratee, address common.Address, from, to uint64, limit int) []AddressAppearance {
+	return ReadAddressAppearanceRange(db, address, AddressAppearance{BlockNumber: from}, AddressAppearance{BlockNumber: to}, limit)
+}
+
+// addressSearchWindow is the initial number of blocks scanned at once when
+// searching address appearances backwards.
+const addressSearchWindow = 128
+
+// ReadAddressAppearanceRangeReverse retrieves at most limit appearances of the
+// address in the range [start, end), in descending order. A zero limit means no
+// limit.
+//
+// As the database only supports forward iteration, the range is scanned in windows
+// of exponentially growing size, moving backwards from its end.
+func ReadAddressAppearanceRangeReverse(db ethdb.Iteratee, address common.Address, start, end AddressAppearance, limit int) []AddressAppearance {
+	var (
+		result []AddressAppearance
+		window = uint64(addressSearchWindow)
+		first  = addressAppearancePosition(start)
+	)
+	full := func() bool { return limit > 0 && len(result) >= limit }
+	for bytes.Compare(addressAppearancePosition(end), first) > 0 && !full() {
+		from := start
+		if end.BlockNumber > start.BlockNumber+window {
+			from = AddressAppearance{BlockNumber: end.BlockNumber - window}
+		}
+		found := ReadAddressAppearanceRange(db, address, from, end, 0)
+		for i := len(found) - 1; i >= 0 && !full(); i-- {
+			result = append(result, found[i])
+		}
+		// Only widen the window if the address is sparse in the scanned range,
+		// avoiding to load excessive entries for busy addresses.
+		if limit == 0 || len(found) < limit {
+			window *= 2
+		}
+		end = from
+	}
+	return result
+}
+
+// ReadAddressAppearancesBefore retrieves at most limit appearances of the address
+// in blocks before the given number, in descending order. A zero limit means no
+// limit.
+func ReadAddressAppearancesBefore(db ethdb.Iteratee, address common.Address, before uint64, limit int) []AddressAppearance {
+	return ReadAddressAppearanceRangeReverse(db, address, AddressAppearance{}, AddressAppearance{BlockNumber: before}, limit)
+}
@@ -296,6 +296,40 @@ func NewDatabaseWithFreezer(db ethdb.KeyValueStore, ancient string, namespace st
 	}, nil
 }
 
+// NewDatabaseWithRemoteFreezer creates a high level database on top of a given
+// key-value data store with a read-only chain freezer served from an object
+// storage. Chain segments are never moved into the remote freezer, the blocks
+// beyond its range are kept in the key-value store. The given ancient directory
+// is the local root for the other ancient stores, e.g. the state freezer.
+func NewDatabaseWithRemoteFreezer(db ethdb.KeyValueStore, ancient string, config RemoteFreezerConfig) (ethdb.Database, error) {
+	frdb, err := NewRemoteFreezer(config, chainFreezerTableConfigs)
+	if err != nil {
+		return nil, err
+	}
+	// Ensure the remote freezer belongs to the same network as the key-value
+	// store, if both of them are initialized.
+	if kvgenesis, _ := db.Get(headerHashKey(0)); len(kvgenesis) > 0 {
+		if frozen, _ := frdb.Ancients(); frozen > 0 {
+			frgenesis, err := frdb.Ancient(ChainFreezerHashTable, 0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve genesis from remote ancient %v", err)
+			} else if !bytes.Equal(kvgenesis, frgenesis) {
+				return nil, fmt.Errorf("genesis mismatch: %#x (leveldb) != %#x (remote ancients)", kvgenesis, frgenesis)
+			}
+		}
+	}
+	return &freezerdb{
+		ancientRoot:   ancient,
+		KeyValueStore: db,
+		chainFreezer: &chainFreezer{
+			AncientStore: frdb,
+			quit:         make(chan struct{}),
+			trigger:      make(chan chan struct{}),
+		},
+		readOnly: true,
+	}, nil
+}
+
 // NewMemoryDatabase creates an ephemeral in-memory key-value database without a
 // freezer moving immutable chain segments into cold storage.
 func NewMemoryDatabase() ethdb.Database {
@@ -386,6 +420,9 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		filterMapRows      stat
 		filterMapLastBlock stat
 		filterMapBlockLV   stat
+		addrAppearances    stat
+		addrIndexBlocks    stat
+		addrInternals      stat
 
 		// Verkle statistics
 		verkleTries        stat
@@ -458,6 +495,14 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		case bytes.HasPrefix(key, filterMapBlockLVPrefix) && len(key) == len(filterMapBlockLVPrefix)+8:
 			filterMapBlockLV.Add(size)
 
+		// address appearance index
+		case bytes.HasPrefix(key, addressAppearancePrefix) && len(key) == len(addressAppearancePrefix)+common.AddressLength+12:
+			addrAppearances.Add(size)
+		case bytes.HasPrefix(key, addressIndexBlockPrefix) && len(key) == len(addressIndexBlockPrefix)+8:
+			addrIndexBlocks.Add(size)
+		case bytes.HasPrefix(key, addressInternalPrefix) && len(key) == len(addressInternalPrefix)+8+common.HashLength:
+			addrInternals.Add(size)
+
 		// old log index (deprecated)
 		case bytes.HasPrefix(key, bloomBitsPrefix) && len(key) == (len(bloomBitsPrefix)+10+common.HashLength):
 			bloomBits.Add(size)
@@ -514,6 +559,9 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		{"Key-Value store", "Log index last-block-of-map", filterMapLastBlock.Size(), filterMapLastBlock.Count()},
 		{"Key-Value store", "Log index block-lv", filterMapBlockLV.Size(), filterMapBlockLV.Count()},
 		{"Key-Value store", "Log bloombits (deprecated)", bloomBits.Size(), bloomBits.Count()},
+		{"Key-Value store", "Address appearance index", addrAppearances.Size(), addrAppearances.Count()},
+		{"Key-Value store", "Address index block records", addrIndexBlocks.Size(), addrIndexBlocks.Count()},
+		{"Key-Value store", "Address internal call records", addrInternals.Size(), addrInternals.Count()},
 		{"Key-Value store", "Contract codes", codes.Size(), codes.Count()},
 		{"Key-Value store", "Hash trie nodes", legacyTries.Size(), legacyTries.Count()},
 		{"Key-Value store", "Path trie state lookups", stateLookups.Size(), stateLookups.Count()},
@@ -566,7 +614,7 @@ var knownMetadataKeys = [][]byte{
 	snapshotGeneratorKey, snapshotRecoveryKey, txIndexTailKey, fastTxLookupLimitKey,
 	uncleanShutdownKey, badBlockKey, transitionStatusKey, skeletonSyncStatusKey,
 	persistentStateIDKey, trieJournalKey, snapshotSyncStatusKey, snapSyncStatusFlagKey,
-	filterMapsRangeKey,
+	filterMapsRangeKey, addressIndexHeadKey, addressIndexTailKey,
 }
 
 // printChainMetadata prints out chain metadata to stderr.
@@ -0,0 +1,442 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// errFollowerAncientDir is returned by the follower database in place of the
+// ancient directory, preventing other ancient stores (e.g. the state history
+// freezer) from being opened within the directory owned by another process.
+var errFollowerAncientDir = errors.New("ancient directory is not shared with followers")
+
+// followerSnapshot is a snapshot of the followed database, which is closed once
+// it has been replaced by a newer one and released by all of its users.
+type followerSnapshot struct {
+	ethdb.Database
+	kvdb    ethdb.KeyValueStore // Snapshot of the key-value store
+	freezer *followerFreezer    // Chain freezer, shared by the snapshots while unchanged
+	refs    atomic.Int64        // Number of users, including the follower database while current
+	close   sync.Once
+}
+
+// release drops a reference to the snapshot, closing it if it was the last one.
+func (snap *followerSnapshot) release() (err error) {
+	if snap.refs.Add(-1) == 0 {
+		snap.close.Do(func() {
+			err = snap.kvdb.Close()
+			if snap.freezer != nil {
+				if ferr := snap.freezer.release(); err == nil {
+					err = ferr
+				}
+			}
+		})
+	}
+	return err
+}
+
+// followerFreezer is the chain freezer of the followed database, opened without
+// acquiring its lock. It is closed once released by all the snapshots using it.
+type followerFreezer struct {
+	*chainFreezer
+	dir   string               // Directory of the freezer
+	files map[string]fileStamp // Files of the freezer before opening it
+	refs  atomic.Int64         // Number of snapshots using the freezer
+}
+
+// fileStamp identifies the content of a file of the followed freezer.
+type fileStamp struct {
+	size    int64
+	modTime int64 // Nanoseconds since the epoch
+}
+
+// openFollowerFreezer opens the chain freezer in the given directory.
+func openFollowerFreezer(dir string, namespace string) (*followerFreezer, error) {
+	// Stamp the files before opening the freezer, any change made afterwards is
+	// reported as a modification.
+	files, err := fileStamps(dir)
+	if err != nil {
+		return nil, err
+	}
+	frdb, err := newFreezer(dir, namespace, true, true, freezerTableSize, chainFreezerTableConfigs)
+	if err != nil {
+		return nil, err
+	}
+	f := &followerFreezer{
+		chainFreezer: &chainFreezer{
+			AncientStore: frdb,
+			quit:         make(chan struct{}),
+			trigger:      make(chan chan struct{}),
+		},
+		dir:   dir,
+		files: files,
+	}
+	f.refs.Store(1)
+	return f, nil
+}
+
+// modified reports whether the owner modified the freezer since it was opened.
+func (f *followerFreezer) modified() (bool, error) {
+	files, err := fileStamps(f.dir)
+	if err != nil {
+		return false, err
+	}
+	return !maps.Equal(f.files, files), nil
+}
+
+// release drops a reference to the freezer, closing it if it was the last one.
+func (f *followerFreezer) release() error {
+	if f.refs.Add(-1) == 0 {
+		return f.chainFreezer.Close()
+	}
+	return nil
+}
+
+// fileStamps returns the sizes and modification times of all the files of a
+// freezer, except its lock.
+func fileStamps(dir string) (map[string]fileStamp, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]fileStamp)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || entry.Name() == "FLOCK" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue // deleted meanwhile, reported as a modification later
+			}
+			return nil, err
+		}
+		files[entry.Name()] = fileStamp{size: info.Size(), modTime: info.ModTime().UnixNano()}
+	}
+	return files, nil
+}
+
+// modifiedStore is implemented by the key-value store snapshots which can tell
+// whether the followed store has been modified since they were taken.
+type modifiedStore interface {
+	Modified() (bool, error)
+}
+
+// followerIterator is an iterator over a snapshot, which holds a reference to it
+// until released.
+type followerIterator struct {
+	ethdb.Iterator
+	snap    *followerSnapshot
+	release sync.Once
+}
+
+// Release releases the iterator and its reference to the snapshot.
+func (it *followerIterator) Release() {
+	it.release.Do(func() {
+		it.Iterator.Release()
+		it.snap.release()
+	})
+}
+
+// followerBatch is a write-only batch, which fails to be written.
+type followerBatch struct {
+	ethdb.Batch
+}
+
+// Write implements ethdb.Batch, refusing to modify the followed database.
+func (b followerBatch) Write() error {
+	return errReadOnly
+}
+
+// FollowerDatabase is a read-only view of a chain database which is owned and
+// written by another process. The key-value store is accessed through snapshots
+// of it, taken at the time of opening, and the chain freezer is opened without
+// acquiring its lock. Refresh reopens them to observe the writes made by the
+// owner since.
+type FollowerDatabase struct {
+	open      func() (ethdb.KeyValueStore, error) // Opener of a key-value store snapshot
+	ancient   string                              // Path of the chain freezer, empty if none
+	namespace string                              // Namespace for the freezer metrics
+
+	db     *followerSnapshot // Latest snapshot of the database
+	closed bool
+	lock   sync.RWMutex
+}
+
+// NewFollowerDatabase creates a follower database on top of the key-value store
+// returned by the given opener and the chain freezer located in the specified
+// root ancient directory. The opener is invoked on every refresh and must return
+// a consistent snapshot of the key-value store, which is unaffected by the writes
+// of the owner.
+func NewFollowerDatabase(open func() (ethdb.KeyValueStore, error), ancient string, namespace string) (*FollowerDatabase, error) {
+	if ancient != "" {
+		ancient = resolveChainFreezerDir(ancient)
+	}
+	db := &FollowerDatabase{
+		open:      open,
+		ancient:   ancient,
+		namespace: namespace,
+	}
+	snap, err := db.snapshot(nil)
+	if err != nil {
+		return nil, err
+	}
+	db.db = snap
+	return db, nil
+}
+
+// snapshot opens a new snapshot of the followed database. The chain freezer of
+// the previous snapshot is reused if the owner didn't modify it since, otherwise
+// it's reopened. The freezer is checked after the key-value store has been
+// opened, so that it's never older than the key-value store, which the owner
+// only prunes after freezing.
+func (db *FollowerDatabase) snapshot(prev *followerSnapshot) (*followerSnapshot, error) {
+	kvdb, err := db.open()
+	if err != nil {
+		return nil, err
+	}
+	snap := &followerSnapshot{kvdb: kvdb}
+	snap.refs.Store(1)
+
+	if db.ancient == "" {
+		snap.Database = NewDatabase(kvdb)
+		return snap, nil
+	}
+	if prev != nil {
+		if modified, err := prev.freezer.modified(); err == nil && !modified {
+			prev.freezer.refs.Add(1)
+			snap.freezer = prev.freezer
+		}
+	}
+	if snap.freezer == nil {
+		if snap.freezer, err = openFollowerFreezer(db.ancient, db.namespace); err != nil {
+			kvdb.Close()
+			return nil, err
+		}
+	}
+	snap.Database = &freezerdb{
+		KeyValueStore: kvdb,
+		chainFreezer:  snap.freezer.chainFreezer,
+		readOnly:      true,
+	}
+	return snap, nil
+}
+
+// Refresh reopens the followed database, making the writes done by the owner
+// since the last refresh visible. Nothing is reopened if the owner didn't modify
+// the key-value store since. The replaced snapshot is closed as soon as the
+// in-flight reads and iterations using it are finished.
+func (db *FollowerDatabase) Refresh() error {
+	db.lock.RLock()
+	closed := db.closed
+	db.lock.RUnlock()
+	if closed {
+		return errors.New("database closed")
+	}
+	prev := db.acquire()
+	defer prev.release()
+
+	if store, ok := prev.kvdb.(modifiedStore); ok {
+		if modified, err := store.Modified(); err == nil && !modified {
+			return nil
+		}
+	}
+	snap, err := db.snapshot(prev)
+	if err != nil {
+		return err
+	}
+	db.lock.Lock()
+	if db.closed {
+		db.lock.Unlock()
+		snap.release()
+		return errors.New("database closed")
+	}
+	stale := db.db
+	db.db = snap
+	db.lock.Unlock()
+
+	return stale.release()
+}
+
+// acquire returns the latest snapshot of the database, which must be released
+// after use. Once the follower database is closed, the returned snapshot is
+// closed too, failing all operations.
+func (db *FollowerDatabase) acquire() *followerSnapshot {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	db.db.refs.Add(1)
+	return db.db
+}
+
+// Has retrieves if a key is present in the key-value data store.
+func (db *FollowerDatabase) Has(key []byte) (bool, error) {
+	snap := db.acquire()
+	defer snap.release()
+	return snap.Has(key)
+}
+
+// Get retrieves the given key if it's present in the key-value data store.
+func (db *FollowerDatabase) Get(key []byte) ([]byte, error) {
+	snap := db.acquire()
+	defer snap.release()
+	return snap.Get(key)
+}
+
+// Put is not supported by the follower database.
+func (db *FollowerDatabase) Put(key []byte, value []byte) error {
+	return errReadOnly
+}
+
+// Delete is not supported by the follower database.
+func (db *FollowerDatabase) Delete(key []byte) error {
+	return errReadOnly
+}
+
+// DeleteRange is not supported by the follower database.
+func (db *FollowerDatabase) DeleteRange(start, end []byte) error {
+	return errReadOnly
+}
+
+// Stat returns the statistic data of the key-value store.
+func (db *FollowerDatabase) Stat() (string, error) {
+	snap := db.acquire()
+	defer snap.release()
+	return snap.Stat()
+}
+
+// NewBatch creates a write-only batch, which fails to be written as the follower
+// database is read only.
+func (db *FollowerDatabase) NewBatch() ethdb.Batch {
+	return followerBatch{memorydb.New().NewBatch()}
+}
+
+// NewBatchWithSize creates a write-only batch with pre-allocated buffer, which
+// fails to be written as the follower database is read only.
+func (db *FollowerDatabase) NewBatchWithSize(size int) ethdb.Batch {
+	return followerBatch{memorydb.New().NewBatchWithSize(size)}
+}
+
+// NewIterator creates a binary-alphabetical iterator over a subset of database
+// content with a particular key prefix, starting at a particular initial key.
+// The snapshot iterated over is kept open until the iterator is released.
+func (db *FollowerDatabase) NewIterator(prefix []byte, start []byte) ethdb.Iterator {
+	snap := db.acquire()
+	return &followerIterator{Iterator: snap.NewIterator(prefix, start), snap: snap}
+}
+
+// Compact is not supported by the follower database.
+func (db *FollowerDatabase) Compact(start []byte, limit []byte) error {
+	return errReadOnly
+}
+
+// HasAncient returns an indicator whether the specified ancient data exists.
+func (db *FollowerDatabase) HasAncient(kind string, number uint64) (bool, error) {
+	snap := db.acquire()
+	defer snap.release()
+	return snap.HasAncient(kind, number)
+}
+
+// Ancient retrieves an ancient binary blob from the append-only immutable files.
+func (db *FollowerDatabase) Ancient(kind string, number uint64) ([]byte, error) {
+	snap := db.acquire()
+	defer snap.release()
+	return snap.Ancient(kind, number)
+}
+
+// AncientRange retrieves multiple items in sequence, starting from the index 'start'.
+func (db *FollowerDatabase) AncientRange(kind string, start, count, maxBytes uint64) ([][]byte, error) {
+	snap := db.acquire()
+	defer snap.release()
+	return snap.AncientRange(kind, start, count, maxBytes)
+}
+
+// Ancients returns the ancient item numbers in the ancient store.
+func (db *FollowerDatabase) Ancients() (uint64, error) {
+	snap := db.acquire()
+	defer snap.release()
+	return snap.Ancients()
+}
+
+// Tail returns the number of first stored item in the ancient store.
+func (db *FollowerDatabase) Tail() (uint64, error) {
+	snap := db.acquire()
+	defer snap.release()
+	return snap.Tail()
+}
+
+// AncientSize returns the ancient size of the specified category.
+func (db *FollowerDatabase) AncientSize(kind string) (uint64, error) {
+	snap := db.acquire()
+	defer snap.release()
+	return snap.AncientSize(kind)
+}
+
+// ReadAncients runs the given read operation on a single snapshot of the
+// ancient store.
+func (db *FollowerDatabase) ReadAncients(fn func(ethdb.AncientReaderOp) error) error {
+	snap := db.acquire()
+	defer snap.release()
+	return snap.ReadAncients(fn)
+}
+
+// ModifyAncients is not supported by the follower database.
+func (db *FollowerDatabase) ModifyAncients(func(ethdb.AncientWriteOp) error) (int64, error) {
+	return 0, errReadOnly
+}
+
+// TruncateHead is not supported by the follower database.
+func (db *FollowerDatabase) TruncateHead(n uint64) (uint64, error) {
+	return 0, errReadOnly
+}
+
+// TruncateTail is not supported by the follower database.
+func (db *FollowerDatabase) TruncateTail(n uint64) (uint64, error) {
+	return 0, errReadOnly
+}
+
+// Sync is not supported by the follower database.
+func (db *FollowerDatabase) Sync() error {
+	return errReadOnly
+}
+
+// AncientDatadir returns an error, the ancient directory of the followed database
+// is owned by another process.
+func (db *FollowerDatabase) AncientDatadir() (string, error) {
+	return "", errFollowerAncientDir
+}
+
+// Close closes the database. The latest snapshot is closed as soon as the
+// in-flight reads and iterations using it are finished.
+func (db *FollowerDatabase) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return nil
+	}
+	db.closed = true
+	return db.db.release()
+}
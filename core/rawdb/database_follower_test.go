@@ -0,0 +1,228 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+)
+
+// Tests that the follower database observes the writes of the owner only after
+// being refreshed, and rejects writes.
+func TestFollowerDatabase(t *testing.T) {
+	var (
+		datadir     = t.TempDir()
+		ancient     = filepath.Join(datadir, "ancient")
+		checkpoints = filepath.Join(t.TempDir(), "checkpoints")
+	)
+	kvdb, err := pebble.New(datadir, 16, 16, "", false, false)
+	if err != nil {
+		t.Fatalf("Failed to create pebble database: %v", err)
+	}
+	db, err := NewDatabaseWithFreezer(kvdb, ancient, "", false)
+	if err != nil {
+		t.Fatalf("Failed to create chain database: %v", err)
+	}
+	defer db.Close()
+
+	genesis := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0), Extra: []byte("genesis")})
+	WriteAncientBlocks(db, []*types.Block{genesis}, []types.Receipts{nil})
+	WriteHeadBlockHash(db, genesis.Hash())
+
+	follower, err := NewFollowerDatabase(func() (ethdb.KeyValueStore, error) {
+		return pebble.NewFollower(datadir, checkpoints, 16, 16, "")
+	}, ancient, "")
+	if err != nil {
+		t.Fatalf("Failed to open follower database: %v", err)
+	}
+	defer follower.Close()
+
+	if hash := ReadHeadBlockHash(follower); hash != genesis.Hash() {
+		t.Fatalf("Unexpected head: have %x, want %x", hash, genesis.Hash())
+	}
+	if blob := ReadHeaderRLP(follower, genesis.Hash(), 0); len(blob) == 0 {
+		t.Fatal("Ancient header is not readable")
+	}
+	// Write a new block by the owner, it should only be visible once refreshed
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), ParentHash: genesis.Hash()})
+	WriteAncientBlocks(db, []*types.Block{block}, []types.Receipts{nil})
+	WriteHeadBlockHash(db, block.Hash())
+
+	if hash := ReadHeadBlockHash(follower); hash != genesis.Hash() {
+		t.Fatalf("Unexpected head before refresh: have %x, want %x", hash, genesis.Hash())
+	}
+	if frozen, _ := follower.Ancients(); frozen != 1 {
+		t.Fatalf("Unexpected ancients before refresh: have %d, want %d", frozen, 1)
+	}
+	for i := 0; i < 3; i++ {
+		if err := follower.Refresh(); err != nil {
+			t.Fatalf("Failed to refresh follower database: %v", err)
+		}
+	}
+	if hash := ReadHeadBlockHash(follower); hash != block.Hash() {
+		t.Fatalf("Unexpected head after refresh: have %x, want %x", hash, block.Hash())
+	}
+	if frozen, _ := follower.Ancients(); frozen != 2 {
+		t.Fatalf("Unexpected ancients after refresh: have %d, want %d", frozen, 2)
+	}
+	if blob := ReadHeaderRLP(follower, block.Hash(), 1); len(blob) == 0 {
+		t.Fatal("Refreshed ancient header is not readable")
+	}
+	// Ensure the follower refuses to modify the database
+	if err := follower.Put([]byte("key"), []byte("value")); !errors.Is(err, errReadOnly) {
+		t.Fatalf("Unexpected write error: have %v, want %v", err, errReadOnly)
+	}
+	batch := follower.NewBatch()
+	batch.Put([]byte("key"), []byte("value"))
+	if err := batch.Write(); err == nil {
+		t.Fatal("Batch written to follower database")
+	}
+	if _, err := follower.TruncateHead(0); !errors.Is(err, errReadOnly) {
+		t.Fatalf("Unexpected truncation error: have %v, want %v", err, errReadOnly)
+	}
+	if _, err := follower.AncientDatadir(); err == nil {
+		t.Fatal("Ancient directory exposed by follower database")
+	}
+	if blob, _ := db.Get([]byte("key")); !bytes.Equal(blob, nil) {
+		t.Fatalf("Follower modified the database: %x", blob)
+	}
+}
+
+// Tests that refreshes only reopen the parts of the followed database which
+// have been modified by the owner.
+func TestFollowerDatabaseRefreshReuse(t *testing.T) {
+	var (
+		datadir     = t.TempDir()
+		ancient     = filepath.Join(datadir, "ancient")
+		checkpoints = filepath.Join(t.TempDir(), "checkpoints")
+	)
+	kvdb, err := pebble.New(datadir, 16, 16, "", false, false)
+	if err != nil {
+		t.Fatalf("Failed to create pebble database: %v", err)
+	}
+	db, err := NewDatabaseWithFreezer(kvdb, ancient, "", false)
+	if err != nil {
+		t.Fatalf("Failed to create chain database: %v", err)
+	}
+	defer db.Close()
+
+	follower, err := NewFollowerDatabase(func() (ethdb.KeyValueStore, error) {
+		return pebble.NewFollower(datadir, checkpoints, 16, 16, "")
+	}, ancient, "")
+	if err != nil {
+		t.Fatalf("Failed to open follower database: %v", err)
+	}
+	defer follower.Close()
+
+	// Nothing should be reopened if the owner didn't write anything
+	snap := follower.db
+	if err := follower.Refresh(); err != nil {
+		t.Fatalf("Failed to refresh follower database: %v", err)
+	}
+	if follower.db != snap {
+		t.Fatal("Unmodified database reopened")
+	}
+	// Only the key-value store should be reopened if the freezer is unchanged
+	db.Put([]byte("key"), []byte("value"))
+	if err := follower.Refresh(); err != nil {
+		t.Fatalf("Failed to refresh follower database: %v", err)
+	}
+	if follower.db == snap || follower.db.freezer != snap.freezer {
+		t.Fatal("Unmodified freezer reopened")
+	}
+	if blob, _ := follower.Get([]byte("key")); !bytes.Equal(blob, []byte("value")) {
+		t.Fatalf("Unexpected value: have %x, want %x", blob, []byte("value"))
+	}
+	// Both should be reopened if the owner froze data
+	snap = follower.db
+	genesis := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})
+	WriteAncientBlocks(db, []*types.Block{genesis}, []types.Receipts{nil})
+	db.Put([]byte("key"), []byte("other"))
+	if err := follower.Refresh(); err != nil {
+		t.Fatalf("Failed to refresh follower database: %v", err)
+	}
+	if follower.db.freezer == snap.freezer {
+		t.Fatal("Modified freezer not reopened")
+	}
+	if frozen, _ := follower.Ancients(); frozen != 1 {
+		t.Fatalf("Unexpected ancients after refresh: have %d, want %d", frozen, 1)
+	}
+}
+
+// Tests that the snapshots replaced by refreshes stay usable until released by
+// all of their readers.
+func TestFollowerDatabaseRefreshIteration(t *testing.T) {
+	var (
+		datadir     = t.TempDir()
+		checkpoints = filepath.Join(t.TempDir(), "checkpoints")
+	)
+	db, err := pebble.New(datadir, 16, 16, "", false, false)
+	if err != nil {
+		t.Fatalf("Failed to create pebble database: %v", err)
+	}
+	defer db.Close()
+
+	for i := byte(0); i < 10; i++ {
+		db.Put([]byte{'k', i}, []byte{i})
+	}
+	follower, err := NewFollowerDatabase(func() (ethdb.KeyValueStore, error) {
+		return pebble.NewFollower(datadir, checkpoints, 16, 16, "")
+	}, "", "")
+	if err != nil {
+		t.Fatalf("Failed to open follower database: %v", err)
+	}
+	it := follower.NewIterator([]byte{'k'}, nil)
+
+	for i := byte(0); i < 3; i++ {
+		db.Put([]byte{'x', i}, []byte{i})
+		if err := follower.Refresh(); err != nil {
+			t.Fatalf("Failed to refresh follower database: %v", err)
+		}
+	}
+	// Closing the follower must not close the snapshot of the iterator either
+	if err := follower.Close(); err != nil {
+		t.Fatalf("Failed to close follower database: %v", err)
+	}
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if err := it.Error(); err != nil {
+		t.Fatalf("Iteration failed: %v", err)
+	}
+	if count != 10 {
+		t.Fatalf("Unexpected number of entries: have %d, want %d", count, 10)
+	}
+	it.Release()
+
+	// All snapshots should be closed by now, deleting their checkpoints
+	entries, err := os.ReadDir(checkpoints)
+	if err != nil {
+		t.Fatalf("Failed to read checkpoint directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("Snapshots left open: %d", len(entries))
+	}
+}
@@ -79,6 +79,17 @@ type Freezer struct {
 // The 'tables' argument defines the data tables. If the value of a map
 // entry is true, snappy compression is disabled for the table.
 func NewFreezer(datadir string, namespace string, readonly bool, maxTableSize uint32, tables map[string]freezerTableConfig) (*Freezer, error) {
+	return newFreezer(datadir, namespace, readonly, false, maxTableSize, tables)
+}
+
+// newFreezer creates a freezer instance, optionally skipping the acquisition of
+// the instance lock in read only mode. The unlocked freezer can be opened while
+// another process is writing it, taking a snapshot of the tables at the time of
+// opening.
+func newFreezer(datadir string, namespace string, readonly bool, unlocked bool, maxTableSize uint32, tables map[string]freezerTableConfig) (*Freezer, error) {
+	if unlocked && !readonly {
+		return nil, errors.New("unlocked freezer must be read only")
+	}
 	// Create the initial freezer object
 	var (
 		readMeter  = metrics.NewRegisteredMeter(namespace+"ancient/read", nil)
@@ -102,15 +113,18 @@ func NewFreezer(datadir string, namespace string, readonly bool, maxTableSize ui
 	}
 	// Leveldb uses LOCK as the filelock filename. To prevent the
 	// name collision, we use FLOCK as the lock name.
-	lock := flock.New(flockFile)
-	tryLock := lock.TryLock
-	if readonly {
-		tryLock = lock.TryRLock
-	}
-	if locked, err := tryLock(); err != nil {
-		return nil, err
-	} else if !locked {
-		return nil, errors.New("locking failed")
+	var lock *flock.Flock
+	if !unlocked {
+		lock = flock.New(flockFile)
+		tryLock := lock.TryLock
+		if readonly {
+			tryLock = lock.TryRLock
+		}
+		if locked, err := tryLock(); err != nil {
+			return nil, err
+		} else if !locked {
+			return nil, errors.New("locking failed")
+		}
 	}
 	// Open all the supported data tables
 	freezer := &Freezer{
@@ -122,18 +136,22 @@ func NewFreezer(datadir string, namespace string, readonly bool, maxTableSize ui
 
 	// Create the tables.
 	for name, config := range tables {
-		table, err := newTable(datadir, name, readMeter, writeMeter, sizeGauge, maxTableSize, config, readonly)
+		table, err := openTable(datadir, name, readMeter, writeMeter, sizeGauge, maxTableSize, config, readonly, unlocked)
 		if err != nil {
 			for _, table := range freezer.tables {
 				table.Close()
 			}
-			lock.Unlock()
+			freezer.unlock()
 			return nil, err
 		}
 		freezer.tables[name] = table
 	}
 	var err error
-	if freezer.readonly {
+	if unlocked {
+		// The tables might be opened in the middle of a write, settle on the
+		// items present in all of them.
+		freezer.settle()
+	} else if freezer.readonly {
 		// In readonly mode only validate, don't truncate.
 		// validate also sets `freezer.frozen`.
 		err = freezer.validate()
@@ -145,14 +163,16 @@ func NewFreezer(datadir string, namespace string, readonly bool, maxTableSize ui
 		for _, table := range freezer.tables {
 			table.Close()
 		}
-		lock.Unlock()
+		freezer.unlock()
 		return nil, err
 	}
 
 	// Create the write batch.
 	freezer.writeBatch = newFreezerBatch(freezer)
 
-	log.Info("Opened ancient database", "database", datadir, "readonly", readonly)
+	if !unlocked {
+		log.Info("Opened ancient database", "database", datadir, "readonly", readonly)
+	}
 	return freezer, nil
 }
 
@@ -168,7 +188,7 @@ func (f *Freezer) Close() error {
 				errs = append(errs, err)
 			}
 		}
-		if err := f.instanceLock.Unlock(); err != nil {
+		if err := f.unlock(); err != nil {
 			errs = append(errs, err)
 		}
 	})
@@ -178,6 +198,14 @@ func (f *Freezer) Close() error {
 	return nil
 }
 
+// unlock releases the instance lock, if it was acquired.
+func (f *Freezer) unlock() error {
+	if f.instanceLock == nil {
+		return nil
+	}
+	return f.instanceLock.Unlock()
+}
+
 // AncientDatadir returns the path of the ancient store.
 func (f *Freezer) AncientDatadir() (string, error) {
 	return f.datadir, nil
@@ -339,6 +367,27 @@ func (f *Freezer) Sync() error {
 	return nil
 }
 
+// settle sets the boundary of the freezer to the items present in every table,
+// without modifying them. Used instead of `validate` for the tables written by
+// another process, which might differ in the middle of a write.
+func (f *Freezer) settle() {
+	var (
+		head = uint64(math.MaxUint64)
+		tail = uint64(0)
+	)
+	if len(f.tables) == 0 {
+		head = 0
+	}
+	for _, table := range f.tables {
+		head = min(head, table.items.Load())
+		if table.config.prunable {
+			tail = max(tail, table.itemHidden.Load())
+		}
+	}
+	f.frozen.Store(head)
+	f.tail.Store(tail)
+}
+
 // validate checks that every table has the same boundary.
 // Used instead of `repair` in readonly mode.
 func (f *Freezer) validate() error {
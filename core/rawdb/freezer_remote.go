@@ -0,0 +1,564 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/snappy"
+)
+
+const (
+	// remoteRequestTimeout is the maximum time allowed for a single request
+	// made to the object storage.
+	remoteRequestTimeout = 30 * time.Second
+
+	// emptyPayloadHash is the hex encoded sha256 hash of the empty request body,
+	// used for signing the requests.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// RemoteFreezerConfig contains the settings of a remote freezer.
+type RemoteFreezerConfig struct {
+	// Endpoint is the URL of the freezer directory within the bucket, e.g.
+	// https://bucket.s3.us-east-1.amazonaws.com/ancient/chain. The objects of
+	// the freezer tables are expected under it, named the same as the files
+	// of a file-based freezer.
+	Endpoint string
+
+	Region      string                  // Region of the bucket, used for signing the requests
+	Credentials aws.CredentialsProvider // Credentials for signing the requests, anonymous requests are made if nil
+
+	CacheSize int          // Memory allowance (bytes) for caching the retrieved items
+	Client    *http.Client // HTTP client for the requests, a default one is used if nil
+}
+
+// remoteStore is a minimal client of an S3-compatible object storage, which
+// supports reading objects and the byte ranges of them.
+type remoteStore struct {
+	endpoint string
+	region   string
+	creds    aws.CredentialsProvider
+	signer   *v4.Signer
+	client   *http.Client
+}
+
+func newRemoteStore(config RemoteFreezerConfig) (*remoteStore, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("remote freezer endpoint is not specified")
+	}
+	store := &remoteStore{
+		endpoint: strings.TrimSuffix(config.Endpoint, "/"),
+		region:   config.Region,
+		client:   config.Client,
+	}
+	if store.client == nil {
+		store.client = &http.Client{Timeout: remoteRequestTimeout}
+	}
+	if config.Credentials != nil {
+		if store.region == "" {
+			store.region = "us-east-1"
+		}
+		// Cache the credentials, avoiding to retrieve them for every request,
+		// while still refreshing them once they expire.
+		store.creds = config.Credentials
+		if _, ok := store.creds.(*aws.CredentialsCache); !ok {
+			store.creds = aws.NewCredentialsCache(store.creds)
+		}
+		store.signer = v4.NewSigner()
+	}
+	return store, nil
+}
+
+// request sends a request for the given object, signing it if credentials
+// are configured. The response is returned along with its body.
+func (s *remoteStore) request(method string, name string, header http.Header) (*http.Response, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint+"/"+name, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if s.signer != nil {
+		creds, err := s.creds.Retrieve(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to retrieve credentials: %w", err)
+		}
+		req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+		if err := s.signer.SignHTTP(ctx, creds, req, emptyPayloadHash, "s3", s.region, time.Now()); err != nil {
+			return nil, nil, err
+		}
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, body, nil
+}
+
+// size returns the size of the specified object.
+func (s *remoteStore) size(name string) (int64, error) {
+	res, _, err := s.request(http.MethodHead, name, nil)
+	if err != nil {
+		return 0, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to stat %s: %s", name, res.Status)
+	}
+	return res.ContentLength, nil
+}
+
+// get retrieves the whole content of the specified object.
+func (s *remoteStore) get(name string) ([]byte, error) {
+	res, body, err := s.request(http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s: %s", name, res.Status)
+	}
+	return body, nil
+}
+
+// getRange retrieves the specified byte range of the object.
+func (s *remoteStore) getRange(name string, offset, length int64) ([]byte, error) {
+	if length == 0 {
+		return nil, nil
+	}
+	header := make(http.Header)
+	header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+
+	res, body, err := s.request(http.MethodGet, name, header)
+	if err != nil {
+		return nil, err
+	}
+	switch res.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The range was ignored and the entire object is returned
+		if int64(len(body)) > offset {
+			body = body[offset:]
+		} else {
+			body = nil
+		}
+	default:
+		return nil, fmt.Errorf("failed to get %s [%d, %d): %s", name, offset, offset+length, res.Status)
+	}
+	if int64(len(body)) < length {
+		return nil, fmt.Errorf("short read of %s [%d, %d): %d bytes", name, offset, offset+length, len(body))
+	}
+	return body[:length], nil
+}
+
+// remoteTable is a freezer table whose files are stored in the object storage.
+// The table is immutable, its boundaries are determined when it's opened.
+type remoteTable struct {
+	name   string
+	config freezerTableConfig
+	store  *remoteStore
+
+	items  uint64 // Number of items stored in the table (including items removed from tail)
+	offset uint64 // Number of items removed from the table
+	hidden uint64 // Number of items marked as deleted
+	tailId uint32 // Number of the earliest data file
+	size   uint64 // Total data size of the table
+}
+
+// newRemoteTable opens the remote freezer table with the given name.
+func newRemoteTable(store *remoteStore, name string, config freezerTableConfig) (*remoteTable, error) {
+	t := &remoteTable{
+		name:   name,
+		config: config,
+		store:  store,
+	}
+	size, err := store.size(t.indexName())
+	if err != nil {
+		return nil, err
+	}
+	// Only the complete index entries are taken into account, there is always
+	// one more than the items stored.
+	entries := size / indexEntrySize
+	if entries == 0 {
+		return nil, fmt.Errorf("index of remote table %s is empty", name)
+	}
+	var first, last indexEntry
+	blob, err := store.getRange(t.indexName(), 0, indexEntrySize)
+	if err != nil {
+		return nil, err
+	}
+	first.unmarshalBinary(blob)
+	if entries > 1 {
+		blob, err = store.getRange(t.indexName(), (entries-1)*indexEntrySize, indexEntrySize)
+		if err != nil {
+			return nil, err
+		}
+		last.unmarshalBinary(blob)
+	} else {
+		last = indexEntry{filenum: first.filenum}
+	}
+	t.tailId = first.filenum
+	t.offset = uint64(first.offset)
+	t.items = t.offset + uint64(entries-1)
+
+	// Resolve the virtual tail from the metadata. The version specific fields
+	// are skipped, the tail is shared by all versions.
+	blob, err = store.get(fmt.Sprintf("%s.meta", name))
+	if err != nil {
+		return nil, err
+	}
+	var meta struct {
+		Version uint16
+		Tail    uint64
+		Rest    []rlp.RawValue `rlp:"tail"`
+	}
+	if err := rlp.DecodeBytes(blob, &meta); err != nil {
+		return nil, fmt.Errorf("invalid metadata of remote table %s: %w", name, err)
+	}
+	t.hidden = max(t.offset, meta.Tail)
+
+	// The data files are assumed to be full except the head one, the same as
+	// the size reported by the file-based freezer table.
+	t.size = uint64(freezerTableSize)*uint64(last.filenum-first.filenum) + uint64(last.offset) + uint64(size)
+	return t, nil
+}
+
+// indexName returns the object name of the index file.
+func (t *remoteTable) indexName() string {
+	if t.config.noSnappy {
+		return fmt.Sprintf("%s.ridx", t.name)
+	}
+	return fmt.Sprintf("%s.cidx", t.name)
+}
+
+// dataName returns the object name of the data file with the given number.
+func (t *remoteTable) dataName(num uint32) string {
+	if t.config.noSnappy {
+		return fmt.Sprintf("%s.%04d.rdat", t.name, num)
+	}
+	return fmt.Sprintf("%s.%04d.cdat", t.name, num)
+}
+
+// has returns an indicator whether the specified number data is accessible.
+func (t *remoteTable) has(number uint64) bool {
+	return t.items > number && t.hidden <= number
+}
+
+// retrieve reads up to 'count' items from the table, starting from the index
+// 'start'. It reads at least one item, but otherwise avoids reading more than
+// maxBytes bytes.
+func (t *remoteTable) retrieve(start, count, maxBytes uint64) ([][]byte, error) {
+	if t.items <= start || t.hidden > start || count == 0 {
+		return nil, errOutOfBounds
+	}
+	if start+count > t.items {
+		count = t.items - start
+	}
+	// Read all the indexes in one go. For reading N items, N+1 indices are needed.
+	from := start - t.offset
+	buffer, err := t.store.getRange(t.indexName(), int64(from*indexEntrySize), int64((count+1)*indexEntrySize))
+	if err != nil {
+		return nil, err
+	}
+	indices := make([]indexEntry, count+1)
+	for i := range indices {
+		indices[i].unmarshalBinary(buffer[i*indexEntrySize:])
+	}
+	if from == 0 {
+		// The first index entry carries the number of deleted items, the first
+		// item always starts from zero.
+		indices[0].offset = 0
+		indices[0].filenum = indices[1].filenum
+	}
+	// Determine the location of the items to read, respecting the byte limit
+	// on the stored data.
+	type span struct {
+		file       uint32
+		start, end uint32
+	}
+	var (
+		spans []span
+		sizes []int
+		total uint64
+	)
+	for i := 0; i < len(indices)-1; i++ {
+		offset1, offset2, file := indices[i].bounds(&indices[i+1])
+		size := uint64(offset2 - offset1)
+		if i > 0 && maxBytes != 0 && total+size > maxBytes {
+			break
+		}
+		if n := len(spans); n > 0 && spans[n-1].file == file {
+			spans[n-1].end = offset2
+		} else {
+			spans = append(spans, span{file: file, start: offset1, end: offset2})
+		}
+		sizes = append(sizes, int(size))
+		total += size
+	}
+	var data []byte
+	for _, s := range spans {
+		blob, err := t.store.getRange(t.dataName(s.file), int64(s.start), int64(s.end-s.start))
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, blob...)
+	}
+	// Slice up the data and decompress.
+	var (
+		output     = make([][]byte, 0, len(sizes))
+		outputSize uint64
+	)
+	for i, size := range sizes {
+		item := data[:size]
+		data = data[size:]
+
+		if !t.config.noSnappy {
+			if item, err = snappy.Decode(nil, item); err != nil {
+				return nil, err
+			}
+		}
+		if i > 0 && maxBytes != 0 && outputSize+uint64(len(item)) > maxBytes {
+			break
+		}
+		output = append(output, item)
+		outputSize += uint64(len(item))
+	}
+	return output, nil
+}
+
+// remoteItemKey is the cache key of a retrieved item.
+type remoteItemKey struct {
+	kind   string
+	number uint64
+}
+
+// RemoteFreezer is a read-only ancient store serving the immutable chain data
+// from an S3-compatible object storage, allowing many nodes to share a single
+// copy of the cold chain history. The tables are stored in the same format as
+// the file-based freezer, so that the directory of a freezer can be uploaded
+// as is. Items are retrieved with ranged requests and cached locally.
+type RemoteFreezer struct {
+	items  uint64 // Number of items stored
+	tail   uint64 // Number of the first stored item in the freezer
+	tables map[string]*remoteTable
+	cache  *lru.SizeConstrainedCache[remoteItemKey, []byte]
+
+	closed bool
+	lock   sync.RWMutex
+}
+
+// NewRemoteFreezer opens the remote freezer with the given tables, located at
+// the configured endpoint.
+func NewRemoteFreezer(config RemoteFreezerConfig, tables map[string]freezerTableConfig) (*RemoteFreezer, error) {
+	store, err := newRemoteStore(config)
+	if err != nil {
+		return nil, err
+	}
+	freezer := &RemoteFreezer{
+		tables: make(map[string]*remoteTable),
+		cache:  lru.NewSizeConstrainedCache[remoteItemKey, []byte](uint64(max(config.CacheSize, 0))),
+	}
+	var (
+		head uint64
+		tail uint64
+	)
+	for name, cfg := range tables {
+		table, err := newRemoteTable(store, name, cfg)
+		if err != nil {
+			return nil, err
+		}
+		freezer.tables[name] = table
+
+		// The tables might be uploaded at slightly different points, expose
+		// the items available in all of them.
+		if len(freezer.tables) == 1 || table.items < head {
+			head = table.items
+		}
+		if cfg.prunable && table.hidden > tail {
+			tail = table.hidden
+		}
+	}
+	freezer.items, freezer.tail = head, min(tail, head)
+	return freezer, nil
+}
+
+// HasAncient returns an indicator whether the specified data exists.
+func (f *RemoteFreezer) HasAncient(kind string, number uint64) (bool, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if table := f.tables[kind]; table != nil {
+		return number < f.items && table.has(number), nil
+	}
+	return false, nil
+}
+
+// Ancient retrieves an ancient binary blob from the remote freezer.
+func (f *RemoteFreezer) Ancient(kind string, number uint64) ([]byte, error) {
+	items, err := f.AncientRange(kind, number, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	return items[0], nil
+}
+
+// AncientRange retrieves multiple items in sequence, starting from the index 'start'.
+// It will return
+//   - at most 'count' items,
+//   - if maxBytes is specified: at least 1 item (even if exceeding the maxByteSize),
+//     but will otherwise return as many items as fit into maxByteSize.
+//   - if maxBytes is not specified, 'count' items will be returned if they are present
+func (f *RemoteFreezer) AncientRange(kind string, start, count, maxBytes uint64) ([][]byte, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if f.closed {
+		return nil, errClosed
+	}
+	t := f.tables[kind]
+	if t == nil {
+		return nil, errUnknownTable
+	}
+	if f.items <= start || !t.has(start) || count == 0 {
+		return nil, errOutOfBounds
+	}
+	if start+count > f.items {
+		count = f.items - start
+	}
+	// Serve the leading items from the cache as long as possible, retrieve
+	// the remaining ones in a single go.
+	var (
+		output [][]byte
+		size   uint64
+	)
+	for number := start; number < start+count; number++ {
+		item, ok := f.cache.Get(remoteItemKey{kind, number})
+		if !ok {
+			break
+		}
+		if len(output) > 0 && maxBytes != 0 && size+uint64(len(item)) > maxBytes {
+			return output, nil
+		}
+		output = append(output, item)
+		size += uint64(len(item))
+	}
+	next := start + uint64(len(output))
+	if next == start+count || (maxBytes != 0 && size >= maxBytes) {
+		return output, nil
+	}
+	var limit uint64
+	if maxBytes != 0 {
+		limit = maxBytes - size
+	}
+	items, err := t.retrieve(next, start+count-next, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		f.cache.Add(remoteItemKey{kind, next + uint64(i)}, item)
+
+		// The retrieval returns at least one item, which is only acceptable
+		// if no items were served from the cache.
+		if len(output) > 0 && maxBytes != 0 && size+uint64(len(item)) > maxBytes {
+			break
+		}
+		output = append(output, item)
+		size += uint64(len(item))
+	}
+	return output, nil
+}
+
+// Ancients returns the ancient item numbers in the freezer.
+func (f *RemoteFreezer) Ancients() (uint64, error) {
+	return f.items, nil
+}
+
+// Tail returns the number of first stored item in the freezer.
+func (f *RemoteFreezer) Tail() (uint64, error) {
+	return f.tail, nil
+}
+
+// AncientSize returns the ancient size of the specified category.
+func (f *RemoteFreezer) AncientSize(kind string) (uint64, error) {
+	if table := f.tables[kind]; table != nil {
+		return table.size, nil
+	}
+	return 0, errUnknownTable
+}
+
+// ReadAncients runs the given read operation on the freezer. The remote freezer
+// is immutable, no extra protection is required.
+func (f *RemoteFreezer) ReadAncients(fn func(ethdb.AncientReaderOp) error) (err error) {
+	return fn(f)
+}
+
+// ModifyAncients is not supported by the remote freezer.
+func (f *RemoteFreezer) ModifyAncients(func(ethdb.AncientWriteOp) error) (int64, error) {
+	return 0, errReadOnly
+}
+
+// TruncateHead is not supported by the remote freezer.
+func (f *RemoteFreezer) TruncateHead(items uint64) (uint64, error) {
+	return 0, errReadOnly
+}
+
+// TruncateTail is not supported by the remote freezer.
+func (f *RemoteFreezer) TruncateTail(tail uint64) (uint64, error) {
+	return 0, errReadOnly
+}
+
+// Sync is a noop, the remote freezer is immutable.
+func (f *RemoteFreezer) Sync() error {
+	return nil
+}
+
+// AncientDatadir returns an error, the remote freezer is not stored locally.
+func (f *RemoteFreezer) AncientDatadir() (string, error) {
+	return "", errNotSupported
+}
+
+// Close releases the items cached by the remote freezer. Any following retrieval
+// from a closed freezer fails.
+func (f *RemoteFreezer) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.closed = true
+	f.cache = nil
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+var remoteTestTableDef = map[string]freezerTableConfig{
+	"raw":        {noSnappy: true, prunable: true},
+	"compressed": {noSnappy: false, prunable: true},
+}
+
+// newRemoteTestServer creates a freezer populated with test items and serves
+// its directory over HTTP, counting the requests made.
+func newRemoteTestServer(t *testing.T, items uint64, tail uint64) (*Freezer, *httptest.Server, *atomic.Int64) {
+	t.Helper()
+
+	f, dir := newFreezerForTesting(t, remoteTestTableDef)
+	_, err := f.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		for i := uint64(0); i < items; i++ {
+			item := bytes.Repeat([]byte{byte(i)}, 20+int(i%30))
+			for kind := range remoteTestTableDef {
+				if err := op.AppendRaw(kind, i, item); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal("failed to write items", err)
+	}
+	if _, err := f.TruncateTail(tail); err != nil {
+		t.Fatal("failed to truncate tail", err)
+	}
+	if err := f.Sync(); err != nil {
+		t.Fatal("failed to sync freezer", err)
+	}
+	var (
+		requests atomic.Int64
+		files    = http.FileServer(http.Dir(dir))
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		files.ServeHTTP(w, r)
+	}))
+	t.Cleanup(func() {
+		srv.Close()
+		f.Close()
+	})
+	return f, srv, &requests
+}
+
+func TestRemoteFreezer(t *testing.T) {
+	local, srv, requests := newRemoteTestServer(t, 100, 10)
+
+	f, err := NewRemoteFreezer(RemoteFreezerConfig{Endpoint: srv.URL, CacheSize: 1024 * 1024}, remoteTestTableDef)
+	if err != nil {
+		t.Fatal("failed to open remote freezer", err)
+	}
+	defer f.Close()
+
+	if items, _ := f.Ancients(); items != 100 {
+		t.Fatalf("wrong number of items, want 100, got %d", items)
+	}
+	if tail, _ := f.Tail(); tail != 10 {
+		t.Fatalf("wrong tail, want 10, got %d", tail)
+	}
+	for _, n := range []uint64{0, 9, 100} {
+		if ok, _ := f.HasAncient("raw", n); ok {
+			t.Fatalf("unexpected item %d", n)
+		}
+		if _, err := f.Ancient("raw", n); !errors.Is(err, errOutOfBounds) {
+			t.Fatalf("item %d: wrong error %v", n, err)
+		}
+	}
+	// Compare the retrievals against the local freezer
+	for _, kind := range []string{"raw", "compressed"} {
+		for _, c := range []struct{ start, count, maxBytes uint64 }{
+			{10, 1, 0},
+			{10, 90, 0},
+			{25, 200, 0},
+			{40, 20, 100},
+			{99, 1, 0},
+			{50, 10, 1},
+		} {
+			want, err := local.AncientRange(kind, c.start, c.count, c.maxBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := f.AncientRange(kind, c.start, c.count, c.maxBytes)
+			if err != nil {
+				t.Fatalf("%s %v: failed to retrieve items: %v", kind, c, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s %v: items mismatch, want %d items, got %d", kind, c, len(want), len(got))
+			}
+		}
+	}
+	// Retrievals of the cached items should be served locally
+	before := requests.Load()
+	for i := uint64(10); i < 100; i++ {
+		if ok, _ := f.HasAncient("compressed", i); !ok {
+			t.Fatalf("item %d is missing", i)
+		}
+		want, _ := local.Ancient("compressed", i)
+		got, err := f.Ancient("compressed", i)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Fatalf("item %d mismatch: %v", i, err)
+		}
+	}
+	if after := requests.Load(); after != before {
+		t.Fatalf("cached items retrieved remotely, %d requests", after-before)
+	}
+	// Modifications must be rejected
+	if _, err := f.ModifyAncients(func(ethdb.AncientWriteOp) error { return nil }); !errors.Is(err, errReadOnly) {
+		t.Fatalf("unexpected modification error %v", err)
+	}
+	if _, err := f.TruncateHead(50); !errors.Is(err, errReadOnly) {
+		t.Fatalf("unexpected truncation error %v", err)
+	}
+}
+
+func TestRemoteFreezerSigned(t *testing.T) {
+	_, srv, _ := newRemoteTestServer(t, 10, 0)
+
+	// Reject the requests which are not signed
+	files := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=key/") || r.Header.Get("X-Amz-Date") == "" {
+			http.Error(w, "access denied", http.StatusForbidden)
+			return
+		}
+		files.ServeHTTP(w, r)
+	})
+	if _, err := NewRemoteFreezer(RemoteFreezerConfig{Endpoint: srv.URL}, remoteTestTableDef); err == nil {
+		t.Fatal("anonymous access is expected to be rejected")
+	}
+	f, err := NewRemoteFreezer(RemoteFreezerConfig{Endpoint: srv.URL, Region: "eu-west-1", Credentials: credentials.NewStaticCredentialsProvider("key", "secret", "")}, remoteTestTableDef)
+	if err != nil {
+		t.Fatal("failed to open remote freezer", err)
+	}
+	defer f.Close()
+
+	item, err := f.Ancient("compressed", 5)
+	if err != nil {
+		t.Fatal("failed to retrieve item", err)
+	}
+	if want := bytes.Repeat([]byte{5}, 25); !bytes.Equal(item, want) {
+		t.Fatalf("item mismatch, want %x, got %x", want, item)
+	}
+}
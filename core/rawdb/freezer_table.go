@@ -102,6 +102,7 @@ type freezerTable struct {
 
 	config      freezerTableConfig // if true, disables snappy compression. Note: does not work retroactively
 	readonly    bool
+	live        bool   // Whether the table is written by another process, only in read only mode
 	maxFileSize uint32 // Max file size for data-files
 	name        string
 	path        string
@@ -133,6 +134,16 @@ func newFreezerTable(path, name string, config freezerTableConfig, readonly bool
 // non-existent. Both files are truncated to the shortest common length to ensure
 // they don't go out of sync.
 func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, sizeGauge *metrics.Gauge, maxFilesize uint32, config freezerTableConfig, readonly bool) (*freezerTable, error) {
+	return openTable(path, name, readMeter, writeMeter, sizeGauge, maxFilesize, config, readonly, false)
+}
+
+// openTable opens a freezer table, optionally as a live table which is being
+// written by another process. A live table is opened in read only mode without
+// any repair, exposing the items completely written at the time of opening.
+func openTable(path string, name string, readMeter, writeMeter *metrics.Meter, sizeGauge *metrics.Gauge, maxFilesize uint32, config freezerTableConfig, readonly bool, live bool) (*freezerTable, error) {
+	if live && !readonly {
+		return nil, errors.New("live freezer table must be read only")
+	}
 	// Ensure the containing directory exists and open the indexEntry file
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return nil, err
@@ -188,6 +199,7 @@ func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, si
 		logger:      log.New("database", path, "table", name),
 		config:      config,
 		readonly:    readonly,
+		live:        live,
 		maxFileSize: maxFilesize,
 	}
 	if err := tab.repair(); err != nil {
@@ -222,7 +234,8 @@ func (t *freezerTable) repair() error {
 		}
 	}
 	// Ensure the index is a multiple of indexEntrySize bytes
-	if overflow := stat.Size() % indexEntrySize; overflow != 0 {
+	overflow := stat.Size() % indexEntrySize
+	if overflow != 0 && !t.live {
 		if t.readonly {
 			return fmt.Errorf("index file(path: %s, name: %s) size is not a multiple of %d", t.path, t.name, indexEntrySize)
 		}
@@ -230,15 +243,22 @@ func (t *freezerTable) repair() error {
 			return err
 		} // New file can't trigger this path
 	}
-	if err := t.repairIndex(); err != nil {
-		return err
-	}
-	// Retrieve the file sizes and prepare for truncation. Note the file size
-	// might be changed after index repair.
-	if stat, err = t.index.Stat(); err != nil {
-		return err
+	var offsetsSize int64
+	if t.live {
+		// The index of a live table is appended by its owner, only consider the
+		// entries completely written at the time of opening.
+		offsetsSize = stat.Size() - overflow
+	} else {
+		if err := t.repairIndex(); err != nil {
+			return err
+		}
+		// Retrieve the file sizes and prepare for truncation. Note the file size
+		// might be changed after index repair.
+		if stat, err = t.index.Stat(); err != nil {
+			return err
+		}
+		offsetsSize = stat.Size()
 	}
-	offsetsSize := stat.Size()
 
 	// Open the head file
 	var (
@@ -291,6 +311,12 @@ func (t *freezerTable) repair() error {
 
 	// Keep truncating both files until they come in sync
 	contentExp = int64(lastIndex.offset)
+
+	// The data of a live table is written ahead of the index, disregard the
+	// data of the items not indexed yet.
+	if t.live && contentExp < contentSize {
+		contentSize = contentExp
+	}
 	for contentExp != contentSize {
 		if t.readonly {
 			return fmt.Errorf("freezer table(path: %s, name: %s, num: %d) is corrupted", t.path, t.name, lastIndex.filenum)
@@ -79,6 +79,12 @@ var (
 	// txIndexTailKey tracks the oldest block whose transactions have been indexed.
 	txIndexTailKey = []byte("TransactionIndexTail")
 
+	// addressIndexHeadKey tracks the latest block whose address appearances have been indexed.
+	addressIndexHeadKey = []byte("TransactionAddressIndexHead")
+
+	// addressIndexTailKey tracks the oldest block whose address appearances have been indexed.
+	addressIndexTailKey = []byte("TransactionAddressIndexTail")
+
 	// fastTxLookupLimitKey tracks the transaction lookup limit during fast sync.
 	// This flag is deprecated, it's kept to avoid reporting errors when inspect
 	// database.
@@ -144,6 +150,11 @@ var (
 	// old log index
 	bloomBitsMetaPrefix = []byte("iB")
 
+	// address appearance index
+	addressAppearancePrefix = []byte("iA") // addressAppearancePrefix + address + num (uint64 big endian) + tx index (uint32 big endian) -> nil
+	addressIndexBlockPrefix = []byte("iP") // addressIndexBlockPrefix + num (uint64 big endian) -> address index block record
+	addressInternalPrefix   = []byte("iI") // addressInternalPrefix + num (uint64 big endian) + hash -> internal call appearances
+
 	preimageCounter     = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitsCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 	preimageMissCounter = metrics.NewRegisteredCounter("db/preimage/miss", nil)
@@ -362,3 +373,24 @@ func filterMapBlockLVKey(number uint64) []byte {
 	binary.BigEndian.PutUint64(key[l:], number)
 	return key
 }
+
+// addressAppearanceKey = addressAppearancePrefix + address + num (uint64 big endian) + tx index (uint32 big endian)
+func addressAppearanceKey(address common.Address, number uint64, txIndex uint32) []byte {
+	l := len(addressAppearancePrefix)
+	key := make([]byte, l+common.AddressLength+8+4)
+	copy(key[:l], addressAppearancePrefix)
+	copy(key[l:], address.Bytes())
+	binary.BigEndian.PutUint64(key[l+common.AddressLength:], number)
+	binary.BigEndian.PutUint32(key[l+common.AddressLength+8:], txIndex)
+	return key
+}
+
+// addressIndexBlockKey = addressIndexBlockPrefix + num (uint64 big endian)
+func addressIndexBlockKey(number uint64) []byte {
+	return append(append([]byte{}, addressIndexBlockPrefix...), encodeBlockNumber(number)...)
+}
+
+// addressInternalKey = addressInternalPrefix + num (uint64 big endian) + hash
+func addressInternalKey(number uint64, hash common.Hash) []byte {
+	return append(append(append([]byte{}, addressInternalPrefix...), encodeBlockNumber(number)...), hash.Bytes()...)
+}
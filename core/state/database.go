@@ -151,6 +151,7 @@ type CachingDB struct {
 	codeCache     *lru.SizeConstrainedCache[common.Hash, []byte]
 	codeSizeCache *lru.Cache[common.Hash, int]
 	pointCache    *utils.PointCache
+	fork          *forkState // Remote state to fall back to, nil if not forked
 }
 
 // NewDatabase creates a state database with the provided data sources.
@@ -165,6 +166,17 @@ func NewDatabase(triedb *triedb.Database, snap *snapshot.Tree) *CachingDB {
 	}
 }
 
+// WithFork configures the state database to fall back to the given source for
+// all accounts, storage slots and contract code missing locally. It is meant
+// for simulating a remote chain on top of its state at a pinned block. A nil
+// source leaves the database unchanged.
+func (db *CachingDB) WithFork(source ForkSource) *CachingDB {
+	if source != nil {
+		db.fork = newForkState(source, db.disk)
+	}
+	return db
+}
+
 // NewDatabaseForTesting is similar to NewDatabase, but it initializes the caching
 // db by using an ephemeral memory db with default config for testing.
 func NewDatabaseForTesting() *CachingDB {
@@ -205,7 +217,11 @@ func (db *CachingDB) Reader(stateRoot common.Hash) (Reader, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newReader(newCachingCodeReader(db.disk, db.codeCache, db.codeSizeCache), combined), nil
+	var reader Reader = newReader(newCachingCodeReader(db.disk, db.codeCache, db.codeSizeCache), combined)
+	if db.fork != nil {
+		reader = db.fork.reader(stateRoot, reader)
+	}
+	return reader, nil
 }
 
 // OpenTrie opens the main account trie at a specific root hash.
@@ -273,6 +289,8 @@ func mustCopyTrie(t Trie) Trie {
 		return t.Copy()
 	case *trie.VerkleTrie:
 		return t.Copy()
+	case *historicTrie:
+		return t // immutable
 	default:
 		panic(fmt.Errorf("unknown trie type %T", t))
 	}
@@ -0,0 +1,149 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/ethereum/go-ethereum/trie/utils"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// errHistoricTrie is returned when the trie of a historic state is accessed.
+var errHistoricTrie = errors.New("trie is not available for historic state")
+
+// HistoricDB is the implementation of Database interface for accessing the
+// historical states reconstructed from the state histories of the path-based
+// trie database. The tries of the historical states are not available, so the
+// states opened with it are read-only, can't be hashed or committed, and no
+// proofs can be generated for them.
+type HistoricDB struct {
+	disk          ethdb.KeyValueReader
+	triedb        *triedb.Database
+	codeCache     *lru.SizeConstrainedCache[common.Hash, []byte]
+	codeSizeCache *lru.Cache[common.Hash, int]
+	pointCache    *utils.PointCache
+}
+
+// NewHistoricDatabase creates a historic state database with the provided
+// trie database.
+func NewHistoricDatabase(triedb *triedb.Database) *HistoricDB {
+	return &HistoricDB{
+		disk:          triedb.Disk(),
+		triedb:        triedb,
+		codeCache:     lru.NewSizeConstrainedCache[common.Hash, []byte](codeCacheSize),
+		codeSizeCache: lru.NewCache[common.Hash, int](codeSizeCacheSize),
+		pointCache:    utils.NewPointCache(pointCacheSize),
+	}
+}
+
+// Reader returns a state reader associated with the specified state root.
+func (db *HistoricDB) Reader(stateRoot common.Hash) (Reader, error) {
+	hr, err := db.triedb.HistoricReader(stateRoot)
+	if err != nil {
+		return nil, err
+	}
+	return newReader(newCachingCodeReader(db.disk, db.codeCache, db.codeSizeCache), newFlatReader(hr)), nil
+}
+
+// OpenTrie returns a placeholder of the main account trie of the historic state.
+func (db *HistoricDB) OpenTrie(root common.Hash) (Trie, error) {
+	return &historicTrie{root: root}, nil
+}
+
+// OpenStorageTrie returns a placeholder of the storage trie of an account in
+// the historic state.
+func (db *HistoricDB) OpenStorageTrie(stateRoot common.Hash, address common.Address, root common.Hash, self Trie) (Trie, error) {
+	return &historicTrie{root: root}, nil
+}
+
+// PointCache returns the cache of evaluated curve points.
+func (db *HistoricDB) PointCache() *utils.PointCache {
+	return db.pointCache
+}
+
+// TrieDB retrieves the underlying trie database.
+func (db *HistoricDB) TrieDB() *triedb.Database {
+	return db.triedb
+}
+
+// Snapshot returns nil, the state snapshot is not available for historic states.
+func (db *HistoricDB) Snapshot() *snapshot.Tree {
+	return nil
+}
+
+// historicTrie is a placeholder of the trie of a historic state. All the state
+// reads are served by the historic state reader, the trie itself only reports
+// its root and rejects any modification.
+type historicTrie struct {
+	root common.Hash
+}
+
+func (t *historicTrie) GetKey([]byte) []byte { return nil }
+
+func (t *historicTrie) GetAccount(address common.Address) (*types.StateAccount, error) {
+	return nil, errHistoricTrie
+}
+
+func (t *historicTrie) GetStorage(addr common.Address, key []byte) ([]byte, error) {
+	return nil, errHistoricTrie
+}
+
+func (t *historicTrie) UpdateAccount(address common.Address, account *types.StateAccount, codeLen int) error {
+	return errHistoricTrie
+}
+
+func (t *historicTrie) UpdateStorage(addr common.Address, key, value []byte) error {
+	return errHistoricTrie
+}
+
+func (t *historicTrie) DeleteAccount(address common.Address) error {
+	return errHistoricTrie
+}
+
+func (t *historicTrie) DeleteStorage(addr common.Address, key []byte) error {
+	return errHistoricTrie
+}
+
+func (t *historicTrie) UpdateContractCode(address common.Address, codeHash common.Hash, code []byte) error {
+	return errHistoricTrie
+}
+
+func (t *historicTrie) Hash() common.Hash { return t.root }
+
+func (t *historicTrie) Commit(collectLeaf bool) (common.Hash, *trienode.NodeSet) {
+	return t.root, nil
+}
+
+func (t *historicTrie) Witness() map[string]struct{} { return nil }
+
+func (t *historicTrie) NodeIterator(startKey []byte) (trie.NodeIterator, error) {
+	return nil, errHistoricTrie
+}
+
+func (t *historicTrie) Prove(key []byte, proofDb ethdb.KeyValueWriter) error {
+	return errHistoricTrie
+}
+
+func (t *historicTrie) IsVerkle() bool { return false }
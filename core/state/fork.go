@@ -0,0 +1,314 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// forkStatesLimit is the number of most recently committed states whose deletions
+// are tracked. It exceeds the number of states kept available by the database.
+const forkStatesLimit = 2 * TriesInMemory
+
+// errForkStatePruned is returned when data missing locally is requested from a
+// state whose deletions are not tracked anymore.
+var errForkStatePruned = errors.New("forked state is too old")
+
+// ForkSource provides the state of a remote chain at a pinned block, which is
+// used as the base layer of a forked state database.
+type ForkSource interface {
+	// Account retrieves the account associated with a particular address, or
+	// nil if the account does not exist.
+	Account(addr common.Address) (*types.StateAccount, error)
+
+	// Storage retrieves the storage slot associated with a particular account
+	// address and slot key.
+	Storage(addr common.Address, slot common.Hash) (common.Hash, error)
+
+	// Code retrieves a particular contract's code.
+	Code(addr common.Address, codeHash common.Hash) ([]byte, error)
+}
+
+// forkDeletions is the set of accounts and storage slots deleted locally in a
+// particular state, which must not be resurrected from the fork source. The
+// accounts and slots are keyed by their hashes.
+type forkDeletions struct {
+	accounts map[common.Hash]struct{}
+	slots    map[common.Hash]map[common.Hash]struct{}
+}
+
+// account reports whether the account was deleted.
+func (d *forkDeletions) account(addrHash common.Hash) bool {
+	if d == nil {
+		return false
+	}
+	_, ok := d.accounts[addrHash]
+	return ok
+}
+
+// slot reports whether the storage slot was deleted.
+func (d *forkDeletions) slot(addrHash common.Hash, slotHash common.Hash) bool {
+	if d == nil {
+		return false
+	}
+	_, ok := d.slots[addrHash][slotHash]
+	return ok
+}
+
+// forkState is the data shared by all readers of a forked database. It caches
+// the data retrieved from the fork source and tracks the deletions of the most
+// recently committed states.
+type forkState struct {
+	source ForkSource
+	disk   ethdb.KeyValueWriter // Database to persist the retrieved code into
+
+	lock      sync.Mutex
+	accounts  map[common.Address]*types.StateAccount
+	storages  map[common.Address]map[common.Hash]common.Hash
+	deletions map[common.Hash]*forkDeletions // Deletions by state root, nil if nothing was deleted
+	roots     []common.Hash                  // Tracked state roots, in the order of their last commit
+	pruned    bool                           // Whether any tracked state was dropped
+}
+
+func newForkState(source ForkSource, disk ethdb.KeyValueWriter) *forkState {
+	return &forkState{
+		source:    source,
+		disk:      disk,
+		accounts:  make(map[common.Address]*types.StateAccount),
+		storages:  make(map[common.Address]map[common.Hash]common.Hash),
+		deletions: make(map[common.Hash]*forkDeletions),
+	}
+}
+
+// reader wraps the local reader of the given state with the fork source. States
+// which were never committed have no deletions, unless they were dropped from
+// the tracked ones, in which case the fork source is not accessible anymore.
+func (f *forkState) reader(root common.Hash, local Reader) *forkReader {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	deleted, ok := f.deletions[root]
+	return &forkReader{Reader: local, fork: f, deleted: deleted, pruned: !ok && f.pruned}
+}
+
+// track records the deletions of a state, dropping the least recently committed
+// states beyond the limit.
+//
+// Note, this method assumes the lock is held!
+func (f *forkState) track(root common.Hash, deleted *forkDeletions) {
+	if _, ok := f.deletions[root]; ok {
+		f.roots = slices.DeleteFunc(f.roots, func(r common.Hash) bool { return r == root })
+	}
+	f.deletions[root] = deleted
+	f.roots = append(f.roots, root)
+
+	for len(f.roots) > forkStatesLimit {
+		delete(f.deletions, f.roots[0])
+		f.roots = f.roots[1:]
+		f.pruned = true
+	}
+}
+
+// update tracks the deletions of a committed state transition, on top of the
+// ones of the parent state. Accounts destructed in the transition are deleted
+// along with all their slots, even if they are recreated afterwards.
+func (f *forkState) update(update *stateUpdate) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// Track the parent as a state without deletions, if it was never committed
+	parent, ok := f.deletions[update.originRoot]
+	if !ok && !f.pruned {
+		f.track(update.originRoot, nil)
+	}
+	var deleted *forkDeletions
+
+	// Lazily copy the parent deletions if the transition deletes anything
+	extend := func() {
+		if deleted != nil {
+			return
+		}
+		deleted = &forkDeletions{
+			accounts: make(map[common.Hash]struct{}),
+			slots:    make(map[common.Hash]map[common.Hash]struct{}),
+		}
+		if parent != nil {
+			maps.Copy(deleted.accounts, parent.accounts)
+			for addrHash, slots := range parent.slots {
+				deleted.slots[addrHash] = maps.Clone(slots)
+			}
+		}
+	}
+	for addrHash := range update.destructs {
+		if !parent.account(addrHash) {
+			extend()
+			deleted.accounts[addrHash] = struct{}{}
+		}
+	}
+	for addrHash, slots := range update.storages {
+		for slotHash, data := range slots {
+			if len(data) == 0 && !parent.slot(addrHash, slotHash) {
+				extend()
+				if deleted.slots[addrHash] == nil {
+					deleted.slots[addrHash] = make(map[common.Hash]struct{})
+				}
+				deleted.slots[addrHash][slotHash] = struct{}{}
+			}
+		}
+	}
+	if deleted == nil {
+		deleted = parent
+	}
+	f.track(update.root, deleted)
+}
+
+// account retrieves the account from the fork source. The storage root of the
+// returned account is reset as the storage trie is not available locally, the
+// slots are retrieved from the source one by one.
+func (f *forkState) account(addr common.Address) (*types.StateAccount, error) {
+	f.lock.Lock()
+	account, ok := f.accounts[addr]
+	f.lock.Unlock()
+
+	if !ok {
+		var err error
+		if account, err = f.source.Account(addr); err != nil {
+			return nil, err
+		}
+		if account != nil {
+			account.Root = types.EmptyRootHash
+		}
+		f.lock.Lock()
+		f.accounts[addr] = account
+		f.lock.Unlock()
+	}
+	if account == nil {
+		return nil, nil
+	}
+	return account.Copy(), nil
+}
+
+// storage retrieves the storage slot from the fork source.
+func (f *forkState) storage(addr common.Address, slot common.Hash) (common.Hash, error) {
+	if account, err := f.account(addr); account == nil || err != nil {
+		return common.Hash{}, err
+	}
+	f.lock.Lock()
+	value, ok := f.storages[addr][slot]
+	f.lock.Unlock()
+
+	if !ok {
+		var err error
+		if value, err = f.source.Storage(addr, slot); err != nil {
+			return common.Hash{}, err
+		}
+		f.lock.Lock()
+		if f.storages[addr] == nil {
+			f.storages[addr] = make(map[common.Hash]common.Hash)
+		}
+		f.storages[addr][slot] = value
+		f.lock.Unlock()
+	}
+	return value, nil
+}
+
+// code retrieves the contract code from the fork source and persists it into
+// the local database, so subsequent reads are served locally.
+func (f *forkState) code(addr common.Address, codeHash common.Hash) ([]byte, error) {
+	code, err := f.source.Code(addr, codeHash)
+	if err != nil {
+		return nil, err
+	}
+	if hash := crypto.Keccak256Hash(code); hash != codeHash {
+		return nil, fmt.Errorf("fork code mismatch for %x: have %x, want %x", addr, hash, codeHash)
+	}
+	rawdb.WriteCode(f.disk, codeHash, code)
+	return code, nil
+}
+
+// forkReader is a state reader falling back to the fork source for any data
+// missing from the local state, unless it was deleted locally.
+type forkReader struct {
+	Reader
+	fork    *forkState
+	deleted *forkDeletions
+	pruned  bool // Whether the deletions of the state are not tracked anymore
+}
+
+// Account implements StateReader, retrieving the account from the fork source
+// if it does not exist locally.
+func (r *forkReader) Account(addr common.Address) (*types.StateAccount, error) {
+	account, err := r.Reader.Account(addr)
+	if account != nil || err != nil {
+		return account, err
+	}
+	if r.pruned {
+		return nil, errForkStatePruned
+	}
+	if r.deleted.account(crypto.Keccak256Hash(addr.Bytes())) {
+		return nil, nil
+	}
+	return r.fork.account(addr)
+}
+
+// Storage implements StateReader, retrieving the storage slot from the fork
+// source if it is empty locally.
+func (r *forkReader) Storage(addr common.Address, slot common.Hash) (common.Hash, error) {
+	value, err := r.Reader.Storage(addr, slot)
+	if value != (common.Hash{}) || err != nil {
+		return value, err
+	}
+	if r.pruned {
+		return common.Hash{}, errForkStatePruned
+	}
+	addrHash := crypto.Keccak256Hash(addr.Bytes())
+	if r.deleted.account(addrHash) || r.deleted.slot(addrHash, crypto.Keccak256Hash(slot.Bytes())) {
+		return common.Hash{}, nil
+	}
+	return r.fork.storage(addr, slot)
+}
+
+// Code implements ContractCodeReader, retrieving the contract code from the
+// fork source if it does not exist locally.
+func (r *forkReader) Code(addr common.Address, codeHash common.Hash) ([]byte, error) {
+	code, err := r.Reader.Code(addr, codeHash)
+	if len(code) > 0 || err != nil || codeHash == types.EmptyCodeHash {
+		return code, err
+	}
+	return r.fork.code(addr, codeHash)
+}
+
+// CodeSize implements ContractCodeReader, retrieving the contract code from the
+// fork source if it does not exist locally.
+func (r *forkReader) CodeSize(addr common.Address, codeHash common.Hash) (int, error) {
+	size, err := r.Reader.CodeSize(addr, codeHash)
+	if size > 0 || err != nil || codeHash == types.EmptyCodeHash {
+		return size, err
+	}
+	code, err := r.fork.code(addr, codeHash)
+	return len(code), err
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// testForkSource is a fork source backed by in-memory maps, counting the
+// number of retrievals.
+type testForkSource struct {
+	accounts map[common.Address]*types.StateAccount
+	storages map[common.Address]map[common.Hash]common.Hash
+	codes    map[common.Hash][]byte
+	requests int
+}
+
+func (s *testForkSource) Account(addr common.Address) (*types.StateAccount, error) {
+	s.requests++
+	if account := s.accounts[addr]; account != nil {
+		return account.Copy(), nil
+	}
+	return nil, nil
+}
+
+func (s *testForkSource) Storage(addr common.Address, slot common.Hash) (common.Hash, error) {
+	s.requests++
+	return s.storages[addr][slot], nil
+}
+
+func (s *testForkSource) Code(addr common.Address, codeHash common.Hash) ([]byte, error) {
+	s.requests++
+	return s.codes[codeHash], nil
+}
+
+func TestForkState(t *testing.T) {
+	var (
+		addr     = common.Address{0x1}
+		code     = []byte{0x60, 0x00}
+		codeHash = crypto.Keccak256Hash(code)
+		slot0    = common.Hash{0x1}
+		slot1    = common.Hash{0x2}
+		value    = common.Hash{0xff}
+		source   = &testForkSource{
+			accounts: map[common.Address]*types.StateAccount{
+				addr: {Nonce: 1, Balance: uint256.NewInt(100), Root: common.Hash{0xaa}, CodeHash: codeHash.Bytes()},
+			},
+			storages: map[common.Address]map[common.Hash]common.Hash{
+				addr: {slot0: value, slot1: value},
+			},
+			codes: map[common.Hash][]byte{codeHash: code},
+		}
+		db = NewDatabaseForTesting().WithFork(source)
+	)
+	state, _ := New(types.EmptyRootHash, db)
+	if nonce := state.GetNonce(addr); nonce != 1 {
+		t.Fatalf("forked nonce mismatch: have %d, want 1", nonce)
+	}
+	if have := state.GetCode(addr); !bytes.Equal(have, code) {
+		t.Fatalf("forked code mismatch: have %x, want %x", have, code)
+	}
+	if have := state.GetState(addr, slot0); have != value {
+		t.Fatalf("forked slot mismatch: have %x, want %x", have, value)
+	}
+	// Modify the forked account, clearing one of its slots
+	state.AddBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	state.SetState(addr, slot0, common.Hash{})
+	root, err := state.Commit(1, true, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	state, _ = New(root, db)
+	if have := state.GetBalance(addr); have.Uint64() != 101 {
+		t.Fatalf("local balance mismatch: have %v, want 101", have)
+	}
+	if have := state.GetState(addr, slot0); have != (common.Hash{}) {
+		t.Fatalf("deleted slot resurrected: %x", have)
+	}
+	if have := state.GetState(addr, slot1); have != value {
+		t.Fatalf("untouched slot mismatch: have %x, want %x", have, value)
+	}
+	// The deletion must not leak into the parent state, nor into its other children
+	state, _ = New(types.EmptyRootHash, db)
+	if have := state.GetState(addr, slot0); have != value {
+		t.Fatalf("deletion leaked into parent: %x", have)
+	}
+	state.SetNonce(addr, 2, tracing.NonceChangeUnspecified)
+	sibling, err := state.Commit(1, true, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	state, _ = New(sibling, db)
+	if have := state.GetState(addr, slot0); have != value {
+		t.Fatalf("deletion leaked into sibling: %x", have)
+	}
+	// Retrieved data must be cached, code persisted locally
+	requests := source.requests
+	state, _ = New(sibling, db)
+	state.GetState(addr, slot1)
+	state.GetCode(addr)
+	if source.requests != requests {
+		t.Fatalf("cached data retrieved again: %d requests", source.requests-requests)
+	}
+	// Destructing and recreating the account must wipe its forked storage
+	state, _ = New(sibling, db)
+	state.SelfDestruct(addr)
+	state.Finalise(true)
+	state.CreateAccount(addr)
+	state.SetNonce(addr, 3, tracing.NonceChangeUnspecified)
+	recreated, err := state.Commit(2, true, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	state, _ = New(recreated, db)
+	if have := state.GetNonce(addr); have != 3 {
+		t.Fatalf("recreated nonce mismatch: have %d, want 3", have)
+	}
+	if have := state.GetState(addr, slot1); have != (common.Hash{}) {
+		t.Fatalf("wiped slot resurrected: %x", have)
+	}
+}
+
+// Tests that the deletions of only the most recent states are tracked, and the
+// older states refuse to fall back to the fork source.
+func TestForkStatePruning(t *testing.T) {
+	var (
+		addr   = common.Address{0x1}
+		source = &testForkSource{
+			accounts: map[common.Address]*types.StateAccount{
+				addr: {Nonce: 1, Balance: uint256.NewInt(100), CodeHash: types.EmptyCodeHash.Bytes()},
+			},
+		}
+		db   = NewDatabaseForTesting().WithFork(source)
+		root = types.EmptyRootHash
+	)
+	for i := 0; i <= forkStatesLimit; i++ {
+		state, _ := New(root, db)
+		state.SetNonce(addr, uint64(i+2), tracing.NonceChangeUnspecified)
+
+		var err error
+		if root, err = state.Commit(uint64(i+1), true, false); err != nil {
+			t.Fatalf("failed to commit state %d: %v", i, err)
+		}
+	}
+	if len(db.fork.deletions) != forkStatesLimit || len(db.fork.roots) != forkStatesLimit {
+		t.Fatalf("wrong number of tracked states: have %d, want %d", len(db.fork.deletions), forkStatesLimit)
+	}
+	state, _ := New(types.EmptyRootHash, db)
+	state.GetNonce(addr)
+	if err := state.Error(); !errors.Is(err, errForkStatePruned) {
+		t.Fatalf("pruned state fell back to the fork source: %v", err)
+	}
+	state, _ = New(root, db)
+	if have := state.GetNonce(addr); have != forkStatesLimit+2 {
+		t.Fatalf("nonce mismatch: have %d, want %d", have, forkStatesLimit+2)
+	}
+}
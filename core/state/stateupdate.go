@@ -68,6 +68,7 @@ type stateUpdate struct {
 	root           common.Hash               // hash of the state after applying mutation
 	accounts       map[common.Hash][]byte    // accounts stores mutated accounts in 'slim RLP' encoding
 	accountsOrigin map[common.Address][]byte // accountsOrigin stores the original values of mutated accounts in 'slim RLP' encoding
+	destructs      map[common.Hash]struct{}  // destructs stores the deleted accounts, including the ones recreated afterwards
 
 	// storages stores mutated slots in 'prefix-zero-trimmed' RLP format.
 	// The value is keyed by account hash and **storage slot key hash**.
@@ -99,6 +100,7 @@ func newStateUpdate(rawStorageKey bool, originRoot common.Hash, root common.Hash
 	var (
 		accounts       = make(map[common.Hash][]byte)
 		accountsOrigin = make(map[common.Address][]byte)
+		destructs      = make(map[common.Hash]struct{})
 		storages       = make(map[common.Hash]map[common.Hash][]byte)
 		storagesOrigin = make(map[common.Address]map[common.Hash][]byte)
 		codes          = make(map[common.Address]contractCode)
@@ -109,6 +111,7 @@ func newStateUpdate(rawStorageKey bool, originRoot common.Hash, root common.Hash
 		addr := op.address
 		accounts[addrHash] = nil
 		accountsOrigin[addr] = op.origin
+		destructs[addrHash] = struct{}{}
 
 		// If storage wiping exists, the hash of the storage slot key must be used
 		if len(op.storages) > 0 {
@@ -166,6 +169,7 @@ func newStateUpdate(rawStorageKey bool, originRoot common.Hash, root common.Hash
 		root:           root,
 		accounts:       accounts,
 		accountsOrigin: accountsOrigin,
+		destructs:      destructs,
 		storages:       storages,
 		storagesOrigin: storagesOrigin,
 		rawStorageKey:  rawStorageKey,
@@ -17,8 +17,11 @@
 package core
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -78,3 +81,34 @@ func ExecuteStateless(config *params.ChainConfig, vmconfig vm.Config, block *typ
 	stateRoot := db.IntermediateRoot(config.IsEIP158(block.Number()))
 	return stateRoot, receiptRoot, nil
 }
+
+// ExecutionWitness re-executes an already imported block on top of its parent
+// state and collects the witness required to execute it statelessly. Nothing
+// is written to the database; the parent state needs to be available.
+func (bc *BlockChain) ExecutionWitness(block *types.Block) (*stateless.Witness, error) {
+	parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	statedb, err := state.New(parent.Root, bc.statedb)
+	if err != nil {
+		return nil, fmt.Errorf("parent state of block #%d unavailable: %w", block.NumberU64(), err)
+	}
+	witness, err := stateless.NewWitness(block.Header(), bc)
+	if err != nil {
+		return nil, err
+	}
+	statedb.StartPrefetcher("witness", witness)
+	defer statedb.StopPrefetcher()
+
+	res, err := bc.processor.Process(block, statedb, vm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	// Validating the state computes the post-state root, which pulls in the
+	// trie nodes needed for hashing into the witness.
+	if err := bc.validator.ValidateState(block, statedb, res, false); err != nil {
+		return nil, err
+	}
+	return witness, nil
+}
@@ -17,8 +17,12 @@
 package stateless
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
+	"slices"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -74,3 +78,51 @@ type extWitness struct {
 	Codes   [][]byte
 	State   [][]byte
 }
+
+// MarshalJSON serializes a witness as JSON. The codes and trie nodes are sorted
+// to make the output deterministic.
+func (w *Witness) MarshalJSON() ([]byte, error) {
+	ext := w.toExtWitness()
+	slices.SortFunc(ext.Codes, bytes.Compare)
+	slices.SortFunc(ext.State, bytes.Compare)
+
+	enc := &jsonWitness{
+		Headers: ext.Headers,
+		Codes:   make([]hexutil.Bytes, len(ext.Codes)),
+		State:   make([]hexutil.Bytes, len(ext.State)),
+	}
+	for i, code := range ext.Codes {
+		enc.Codes[i] = code
+	}
+	for i, node := range ext.State {
+		enc.State[i] = node
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON decodes a witness from JSON.
+func (w *Witness) UnmarshalJSON(input []byte) error {
+	var dec jsonWitness
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	ext := &extWitness{
+		Headers: dec.Headers,
+		Codes:   make([][]byte, len(dec.Codes)),
+		State:   make([][]byte, len(dec.State)),
+	}
+	for i, code := range dec.Codes {
+		ext.Codes[i] = code
+	}
+	for i, node := range dec.State {
+		ext.State[i] = node
+	}
+	return w.fromExtWitness(ext)
+}
+
+// jsonWitness is a witness JSON encoding for serving it over RPC.
+type jsonWitness struct {
+	Headers []*types.Header `json:"headers"`
+	Codes   []hexutil.Bytes `json:"codes"`
+	State   []hexutil.Bytes `json:"state"`
+}
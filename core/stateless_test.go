@@ -0,0 +1,118 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that the witness collected by re-executing an imported block is enough
+// to execute the block statelessly, also after a roundtrip through the JSON and
+// RLP encodings.
+func TestExecutionWitness(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		store   = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				address: {Balance: big.NewInt(1000000000000000000)},
+				// Accumulates the block numbers in slot 0
+				store: {Code: []byte{
+					byte(vm.PUSH1), 0x0,
+					byte(vm.SLOAD),
+					byte(vm.NUMBER),
+					byte(vm.ADD),
+					byte(vm.PUSH1), 0x0,
+					byte(vm.SSTORE),
+				}},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 8, func(i int, block *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(address), store, common.Big0, 100000, block.header.BaseFee, nil), signer, key)
+		block.AddTx(tx)
+		tx, _ = types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{byte(i)}, big.NewInt(1000), params.TxGas, block.header.BaseFee, nil), signer, key)
+		block.AddTx(tx)
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), DefaultCacheConfigWithScheme(rawdb.HashScheme), gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert block %d: %v", n, err)
+	}
+	block := blocks[len(blocks)-1]
+	witness, err := chain.ExecutionWitness(block)
+	if err != nil {
+		t.Fatalf("failed to create witness: %v", err)
+	}
+	if len(witness.Headers) == 0 || witness.Headers[0].Hash() != block.ParentHash() {
+		t.Fatal("parent header missing from the witness")
+	}
+	// Execute the block statelessly from the decoded witnesses
+	blob, err := json.Marshal(witness)
+	if err != nil {
+		t.Fatalf("failed to encode witness: %v", err)
+	}
+	fromJSON := new(stateless.Witness)
+	if err := json.Unmarshal(blob, fromJSON); err != nil {
+		t.Fatalf("failed to decode witness: %v", err)
+	}
+	blob, err = rlp.EncodeToBytes(witness)
+	if err != nil {
+		t.Fatalf("failed to encode witness: %v", err)
+	}
+	fromRLP := new(stateless.Witness)
+	if err := rlp.DecodeBytes(blob, fromRLP); err != nil {
+		t.Fatalf("failed to decode witness: %v", err)
+	}
+	context := block.Header()
+	context.Root = common.Hash{}
+	context.ReceiptHash = common.Hash{}
+	task := types.NewBlockWithHeader(context).WithBody(*block.Body())
+
+	for i, w := range []*stateless.Witness{fromJSON, fromRLP} {
+		stateRoot, receiptRoot, err := ExecuteStateless(gspec.Config, vm.Config{}, task, w)
+		if err != nil {
+			t.Fatalf("witness %d: stateless execution failed: %v", i, err)
+		}
+		if stateRoot != block.Root() {
+			t.Fatalf("witness %d: state root mismatch: have %x, want %x", i, stateRoot, block.Root())
+		}
+		if receiptRoot != block.ReceiptHash() {
+			t.Fatalf("witness %d: receipt root mismatch: have %x, want %x", i, receiptRoot, block.ReceiptHash())
+		}
+	}
+}
@@ -77,6 +77,10 @@ const (
 	// limboedTransactionStore is the subfolder containing the currently included
 	// but not yet finalized transaction blobs.
 	limboedTransactionStore = "limbo"
+
+	// lifecycleQueueSize is the number of lifecycle event batches queued for
+	// delivery to the subscribers, before further ones are dropped.
+	lifecycleQueueSize = 64
 )
 
 // blobTxMeta is the minimal subset of types.BlobTx necessary to validate and
@@ -87,9 +91,10 @@ type blobTxMeta struct {
 	hash    common.Hash   // Transaction hash to maintain the lookup table
 	vhashes []common.Hash // Blob versioned hashes to maintain the lookup table
 
-	id          uint64 // Storage ID in the pool's persistent store
-	storageSize uint32 // Byte size in the pool's persistent store
-	size        uint64 // RLP-encoded size of transaction including the attached blob
+	id          uint64    // Storage ID in the pool's persistent store
+	storageSize uint32    // Byte size in the pool's persistent store
+	size        uint64    // RLP-encoded size of transaction including the attached blob
+	arrival     time.Time // Time when the transaction was first seen (or loaded from disk)
 
 	nonce      uint64       // Needed to prioritize inclusion order within an account
 	costCap    *uint256.Int // Needed to validate cumulative balance sufficiency
@@ -116,6 +121,7 @@ func newBlobTxMeta(id uint64, size uint64, storageSize uint32, tx *types.Transac
 		id:          id,
 		storageSize: storageSize,
 		size:        size,
+		arrival:     tx.Time(),
 		nonce:       tx.Nonce(),
 		costCap:     uint256.MustFromBig(tx.Cost()),
 		execTipCap:  uint256.MustFromBig(tx.GasTipCap()),
@@ -321,6 +327,14 @@ type BlobPool struct {
 	discoverFeed event.Feed // Event feed to send out new tx events on pool discovery (reorg excluded)
 	insertFeed   event.Feed // Event feed to send out new tx events on pool inclusion (reorg included)
 
+	lifecycleFeed  event.Feed                      // Event feed to send out the transaction state transitions
+	lifecycleScope event.SubscriptionScope         // Subscriptions to the lifecycle feed
+	lifecycleLock  sync.Mutex                      // Lock serializing the lifecycle event queueing
+	lifecycle      []*txpool.TxLifecycleEvent      // Lifecycle events waiting for delivery (protected by lock)
+	lifecycleQueue chan []*txpool.TxLifecycleEvent // Lifecycle events queued for delivery to the feed
+	lifecycleQuit  chan struct{}                   // Quit channel to tear down the lifecycle loop
+	lifecycleWg    sync.WaitGroup                  // Tracks the lifecycle loop
+
 	// txValidationFn defaults to txpool.ValidateTransaction, but can be
 	// overridden for testing purposes.
 	txValidationFn txpool.ValidationFunction
@@ -343,6 +357,8 @@ func New(config Config, chain BlockChain, hasPendingAuth func(common.Address) bo
 		lookup:         newLookup(),
 		index:          make(map[common.Address][]*blobTxMeta),
 		spent:          make(map[common.Address]*uint256.Int),
+		lifecycleQueue: make(chan []*txpool.TxLifecycleEvent, lifecycleQueueSize),
+		lifecycleQuit:  make(chan struct{}),
 		txValidationFn: txpool.ValidateTransaction,
 	}
 }
@@ -358,6 +374,9 @@ func (p *BlobPool) Filter(tx *types.Transaction) bool {
 func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserver txpool.Reserver) error {
 	p.reserver = reserver
 
+	p.lifecycleWg.Add(1)
+	go p.lifecycleLoop()
+
 	var (
 		queuedir string
 		limbodir string
@@ -449,6 +468,12 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserver txpool.Reser
 
 // Close closes down the underlying persistent store.
 func (p *BlobPool) Close() error {
+	// Unsubscribe anyone still listening for lifecycle events first, so that the
+	// pending delivery doesn't block the shutdown
+	p.lifecycleScope.Close()
+	close(p.lifecycleQuit)
+	p.lifecycleWg.Wait()
+
 	var errs []error
 	if p.limbo != nil { // Close might be invoked due to error in constructor, before p,limbo is set
 		if err := p.limbo.Close(); err != nil {
@@ -546,6 +571,12 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.stored -= uint64(txs[i].storageSize)
 			p.lookup.untrack(txs[i])
 
+			// Gapped transactions are evicted, filled ones are reported as included
+			// from the chain events
+			if gapped {
+				p.notifyDropped(addr, txs[i], txpool.DropReasonInvalid)
+			}
+
 			// Included transactions blobs need to be moved to the limbo
 			if filled && inclusions != nil {
 				p.offload(addr, txs[i].nonce, txs[i].id, inclusions)
@@ -642,6 +673,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[i].costCap)
 			p.stored -= uint64(txs[i].storageSize)
 			p.lookup.untrack(txs[i])
+			p.notifyDropped(addr, txs[i], txpool.DropReasonInvalid)
 
 			if err := p.store.Delete(id); err != nil {
 				log.Error("Failed to delete blob transaction", "from", addr, "id", id, "err", err)
@@ -664,6 +696,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[j].costCap)
 			p.stored -= uint64(txs[j].storageSize)
 			p.lookup.untrack(txs[j])
+			p.notifyDropped(addr, txs[j], txpool.DropReasonInvalid)
 		}
 		txs = txs[:i]
 
@@ -702,6 +735,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], last.costCap)
 			p.stored -= uint64(last.storageSize)
 			p.lookup.untrack(last)
+			p.notifyDropped(addr, last, txpool.DropReasonNoFunds)
 		}
 		if len(txs) == 0 {
 			delete(p.index, addr)
@@ -742,6 +776,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], last.costCap)
 			p.stored -= uint64(last.storageSize)
 			p.lookup.untrack(last)
+			p.notifyDropped(addr, last, txpool.DropReasonAccountLimit)
 		}
 		p.index[addr] = txs
 
@@ -793,6 +828,8 @@ func (p *BlobPool) offload(addr common.Address, nonce uint64, id uint64, inclusi
 // Reset implements txpool.SubPool, allowing the blob pool's internal state to be
 // kept in sync with the main transaction pool's internal state.
 func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
+	defer p.sendLifecycle()
+
 	waitStart := time.Now()
 	p.lock.Lock()
 	resetwaitHist.Update(time.Since(waitStart).Nanoseconds())
@@ -819,6 +856,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 			for _, tx := range txs {
 				if err := p.reinject(addr, tx.Hash()); err == nil {
 					adds = append(adds, tx.WithoutBlobTxSidecar())
+					p.notifyLifecycle(addr, tx.Hash(), tx.Nonce(), txpool.TxLifecyclePending, common.Hash{}, "")
 				}
 			}
 			// Recheck the account's pooled transactions to drop included and
@@ -1027,6 +1065,8 @@ func (p *BlobPool) reinject(addr common.Address, txhash common.Hash) error {
 // SetGasTip implements txpool.SubPool, allowing the blob pool's gas requirements
 // to be kept in sync with the main transaction pool's gas requirements.
 func (p *BlobPool) SetGasTip(tip *big.Int) {
+	defer p.sendLifecycle()
+
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -1047,6 +1087,7 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 					p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[i].costCap)
 					p.stored -= uint64(tx.storageSize)
 					p.lookup.untrack(tx)
+					p.notifyDropped(addr, tx, txpool.DropReasonUnderpriced)
 					txs[i] = nil
 
 					// Drop everything afterwards, no gaps allowed
@@ -1057,6 +1098,7 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 						p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], tx.costCap)
 						p.stored -= uint64(tx.storageSize)
 						p.lookup.untrack(tx)
+						p.notifyDropped(addr, tx, txpool.DropReasonUnderpriced)
 						txs[i+1+j] = nil
 					}
 					// Clear out the dropped transactions from the index
@@ -1289,9 +1331,11 @@ func (p *BlobPool) GetMetadata(hash common.Hash) *txpool.TxMetadata {
 	if !ok {
 		return nil
 	}
+	arrival, _ := p.lookup.arrivalOfTx(hash)
 	return &txpool.TxMetadata{
 		Type: types.BlobTxType,
 		Size: size,
+		Time: arrival,
 	}
 }
 
@@ -1367,6 +1411,7 @@ func (p *BlobPool) Add(txs []*types.Transaction, sync bool) []error {
 		p.discoverFeed.Send(core.NewTxsEvent{Txs: adds})
 		p.insertFeed.Send(core.NewTxsEvent{Txs: adds})
 	}
+	p.sendLifecycle()
 	return errs
 }
 
@@ -1468,6 +1513,8 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 		p.lookup.untrack(prev)
 		p.lookup.track(meta)
 		p.stored += uint64(meta.storageSize) - uint64(prev.storageSize)
+
+		p.notifyLifecycle(from, prev.hash, prev.nonce, txpool.TxLifecycleReplaced, meta.hash, "")
 	} else {
 		// Transaction extends previously scheduled ones
 		p.index[from] = append(p.index[from], meta)
@@ -1479,6 +1526,8 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 		p.lookup.track(meta)
 		p.stored += uint64(meta.storageSize)
 	}
+	p.notifyLifecycle(from, meta.hash, meta.nonce, txpool.TxLifecyclePending, common.Hash{}, "")
+
 	// Recompute the rolling eviction fields. In case of a replacement, this will
 	// recompute all subsequent fields. In case of an append, this will only do
 	// the fresh calculation.
@@ -1585,6 +1634,7 @@ func (p *BlobPool) drop() {
 	// Remove the transaction from the data store
 	log.Debug("Evicting overflown blob transaction", "from", from, "evicted", drop.nonce, "id", drop.id)
 	dropOverflownMeter.Mark(1)
+	p.notifyDropped(from, drop, txpool.DropReasonOverflow)
 
 	if err := p.store.Delete(drop.id); err != nil {
 		log.Error("Failed to drop evicted transaction", "id", drop.id, "err", err)
@@ -1642,7 +1692,7 @@ func (p *BlobPool) Pending(filter txpool.PendingFilter) map[common.Address][]*tx
 			lazies = append(lazies, &txpool.LazyTransaction{
 				Pool:      p,
 				Hash:      tx.hash,
-				Time:      execStart, // TODO(karalabe): Maybe save these and use that?
+				Time:      tx.arrival,
 				GasFeeCap: tx.execFeeCap,
 				GasTipCap: tx.execTipCap,
 				Gas:       tx.execGas,
@@ -1742,6 +1792,75 @@ func (p *BlobPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool
 	}
 }
 
+// SubscribeLifecycle registers a subscription for the state transitions of the
+// transactions tracked by the pool.
+func (p *BlobPool) SubscribeLifecycle(ch chan<- []*txpool.TxLifecycleEvent) event.Subscription {
+	return p.lifecycleScope.Track(p.lifecycleFeed.Subscribe(ch))
+}
+
+// notifyLifecycle records a state transition of a transaction, to be delivered
+// to the subscribers once the pool lock is released. It's a noop if nobody is
+// subscribed.
+//
+// Note, this method assumes the pool lock is held!
+func (p *BlobPool) notifyLifecycle(from common.Address, hash common.Hash, nonce uint64, status txpool.TxLifecycleStatus, replacement common.Hash, reason string) {
+	if p.lifecycleScope.Count() == 0 {
+		return
+	}
+	p.lifecycle = append(p.lifecycle, &txpool.TxLifecycleEvent{
+		Hash:       hash,
+		From:       from,
+		Nonce:      nonce,
+		Status:     status,
+		ReplacedBy: replacement,
+		Reason:     reason,
+	})
+}
+
+// notifyDropped records the eviction of a transaction.
+//
+// Note, this method assumes the pool lock is held!
+func (p *BlobPool) notifyDropped(from common.Address, tx *blobTxMeta, reason string) {
+	p.notifyLifecycle(from, tx.hash, tx.nonce, txpool.TxLifecycleDropped, common.Hash{}, reason)
+}
+
+// sendLifecycle queues the lifecycle events recorded since the last call for
+// delivery. The delivery is done by the lifecycle loop, so that slow subscribers
+// can't hold up the pool; if they lag too far behind, the events are dropped.
+func (p *BlobPool) sendLifecycle() {
+	p.lifecycleLock.Lock()
+	defer p.lifecycleLock.Unlock()
+
+	p.lock.Lock()
+	events := p.lifecycle
+	p.lifecycle = nil
+	p.lock.Unlock()
+
+	if len(events) == 0 {
+		return
+	}
+	select {
+	case p.lifecycleQueue <- events:
+	default:
+		lifecycleDropMeter.Mark(int64(len(events)))
+		log.Debug("Dropping blob transaction lifecycle events", "count", len(events))
+	}
+}
+
+// lifecycleLoop delivers the queued lifecycle events to the subscribers.
+func (p *BlobPool) lifecycleLoop() {
+	defer p.lifecycleWg.Done()
+
+	for {
+		select {
+		case events := <-p.lifecycleQueue:
+			p.lifecycleFeed.Send(events)
+		case <-p.lifecycleQuit:
+			return
+		}
+	}
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *BlobPool) Nonce(addr common.Address) uint64 {
@@ -28,6 +28,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
@@ -289,9 +290,9 @@ func verifyPoolInternals(t *testing.T, pool *BlobPool) {
 			seen[tx.hash] = struct{}{}
 		}
 	}
-	for hash, id := range pool.lookup.txIndex {
+	for hash, meta := range pool.lookup.txIndex {
 		if _, ok := seen[hash]; !ok {
-			t.Errorf("tx lookup entry missing from transaction index: hash #%x, id %d", hash, id)
+			t.Errorf("tx lookup entry missing from transaction index: hash #%x, id %d", hash, meta.id)
 		}
 		delete(seen, hash)
 	}
@@ -1697,3 +1698,68 @@ func benchmarkPoolPending(b *testing.B, datacap uint64) {
 		}
 	}
 }
+
+// Tests that a subscriber not consuming the lifecycle events doesn't hold up the
+// pool.
+func TestLifecycleEventsStalled(t *testing.T) {
+	var (
+		basefee    = uint64(1050)
+		blobfee    = uint64(105)
+		signer     = types.LatestSigner(params.MainnetChainConfig)
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		chain      = &testBlockChain{
+			config:  params.MainnetChainConfig,
+			basefee: uint256.NewInt(basefee),
+			blobfee: uint256.NewInt(blobfee),
+			statedb: statedb,
+		}
+		pool = New(Config{Datadir: ""}, chain, nil)
+	)
+	if err := pool.Init(1, chain.CurrentBlock(), newReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	// Avoid validation, the blob proofs are irrelevant for the test
+	pool.txValidationFn = func(tx *types.Transaction, head *types.Header, signer types.Signer, opts *txpool.ValidationOptions) error {
+		return nil
+	}
+	events := make(chan []*txpool.TxLifecycleEvent)
+	sub := pool.SubscribeLifecycle(events)
+	defer sub.Unsubscribe()
+
+	// Add a transaction from a fresh account at a time, each of them emitting
+	// a separate batch of lifecycle events
+	var txs []*types.Transaction
+	for i := 0; i < 2*lifecycleQueueSize; i++ {
+		blobtx := makeUnsignedTx(0, 10, basefee+10, blobfee)
+		blobtx.R = uint256.NewInt(1)
+		blobtx.S = uint256.NewInt(uint64(100 + i))
+		blobtx.V = uint256.NewInt(0)
+		tx := types.NewTx(blobtx)
+		addr, err := types.Sender(signer, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		statedb.AddBalance(addr, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+		txs = append(txs, tx)
+	}
+	done := make(chan error, 1)
+	go func() {
+		for _, tx := range txs {
+			if errs := pool.Add([]*types.Transaction{tx}, true); errs[0] != nil {
+				done <- errs[0]
+				return
+			}
+		}
+		done <- nil
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("pool blocked by stalled lifecycle subscriber")
+	}
+}
@@ -17,12 +17,15 @@
 package blobpool
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type txMetadata struct {
-	id   uint64 // the billy id of transction
-	size uint64 // the RLP encoded size of transaction (blobs are included)
+	id      uint64    // the billy id of transction
+	size    uint64    // the RLP encoded size of transaction (blobs are included)
+	arrival time.Time // the time when the transaction was first seen
 }
 
 // lookup maps blob versioned hashes to transaction hashes that include them,
@@ -79,6 +82,15 @@ func (l *lookup) sizeOfTx(txhash common.Hash) (uint64, bool) {
 	return meta.size, true
 }
 
+// arrivalOfTx returns the time when the transaction was first seen.
+func (l *lookup) arrivalOfTx(txhash common.Hash) (time.Time, bool) {
+	meta, ok := l.txIndex[txhash]
+	if !ok {
+		return time.Time{}, false
+	}
+	return meta.arrival, true
+}
+
 // track inserts a new set of mappings from blob versioned hashes to transaction
 // hashes; and from transaction hashes to datastore storage item ids.
 func (l *lookup) track(tx *blobTxMeta) {
@@ -91,8 +103,9 @@ func (l *lookup) track(tx *blobTxMeta) {
 	}
 	// Map the transaction hash to the datastore id and RLP-encoded transaction size
 	l.txIndex[tx.hash] = &txMetadata{
-		id:   tx.id,
-		size: tx.size,
+		id:      tx.id,
+		size:    tx.size,
+		arrival: tx.arrival,
 	}
 }
 
@@ -102,4 +102,7 @@ var (
 	addNoreplaceMeter    = metrics.NewRegisteredMeter("blobpool/add/noreplace", nil)    // Replacement fees or tips too low, neutral
 	addNonExclusiveMeter = metrics.NewRegisteredMeter("blobpool/add/nonexclusive", nil) // Plain transaction from same account exists, reject, neutral
 	addValidMeter        = metrics.NewRegisteredMeter("blobpool/add/valid", nil)        // Valid transaction, add, neutral
+
+	// lifecycleDropMeter counts the lifecycle events dropped due to slow subscribers
+	lifecycleDropMeter = metrics.NewRegisteredMeter("blobpool/lifecycle/dropped", nil)
 )
@@ -67,4 +67,24 @@ var (
 	// ErrInflightTxLimitReached is returned when the maximum number of in-flight
 	// transactions is reached for specific accounts.
 	ErrInflightTxLimitReached = errors.New("in-flight transaction limit reached for delegated accounts")
+
+	// ErrSenderDenied is returned if the sender of a transaction is rejected by
+	// the allow- or deny-list of the admission policy.
+	ErrSenderDenied = errors.New("sender denied by policy")
+
+	// ErrRecipientDenied is returned if the recipient of a transaction is rejected
+	// by the allow- or deny-list of the admission policy.
+	ErrRecipientDenied = errors.New("recipient denied by policy")
+
+	// ErrContractCreationDenied is returned if a contract creation transaction is
+	// received while contract creations are disabled by the admission policy.
+	ErrContractCreationDenied = errors.New("contract creation denied by policy")
+
+	// ErrCallDataTooLarge is returned if the input data of a transaction exceeds
+	// the limit of the admission policy.
+	ErrCallDataTooLarge = errors.New("call data exceeds policy limit")
+
+	// ErrSenderRateLimited is returned if a sender submits transactions faster
+	// than permitted by the admission policy.
+	ErrSenderRateLimited = errors.New("sender rate limit exceeded")
 )
@@ -56,6 +56,10 @@ const (
 	// more expensive to propagate; larger transactions also take more resources
 	// to validate whether they fit into the pool or not.
 	txMaxSize = 4 * txSlotSize // 128KB
+
+	// lifecycleQueueSize is the number of lifecycle event batches queued for
+	// delivery to the subscribers, before further ones are dropped.
+	lifecycleQueueSize = 64
 )
 
 var (
@@ -117,6 +121,9 @@ var (
 	slotsGauge   = metrics.NewRegisteredGauge("txpool/slots", nil)
 
 	reheapTimer = metrics.NewRegisteredTimer("txpool/reheap", nil)
+
+	// lifecycleDropMeter counts the lifecycle events dropped due to slow subscribers
+	lifecycleDropMeter = metrics.NewRegisteredMeter("txpool/lifecycle/dropped", nil)
 )
 
 // BlockChain defines the minimal set of methods needed to back a tx pool with
@@ -234,6 +241,12 @@ type LegacyPool struct {
 	signer      types.Signer
 	mu          sync.RWMutex
 
+	lifecycleFeed  event.Feed                      // Feed of the transaction state transitions
+	lifecycleScope event.SubscriptionScope         // Subscriptions to the lifecycle feed
+	lifecycleLock  sync.Mutex                      // Lock serializing the lifecycle event queueing
+	lifecycle      []*txpool.TxLifecycleEvent      // Lifecycle events waiting for delivery (protected by mu)
+	lifecycleQueue chan []*txpool.TxLifecycleEvent // Lifecycle events queued for delivery to the feed
+
 	currentHead   atomic.Pointer[types.Header] // Current head of the blockchain
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
@@ -250,7 +263,7 @@ type LegacyPool struct {
 	queueTxEventCh  chan *types.Transaction
 	reorgDoneCh     chan chan struct{}
 	reorgShutdownCh chan struct{}  // requests shutdown of scheduleReorgLoop
-	wg              sync.WaitGroup // tracks loop, scheduleReorgLoop
+	wg              sync.WaitGroup // tracks loop, scheduleReorgLoop, lifecycleLoop
 	initDoneCh      chan struct{}  // is closed once the pool is initialized (for tests)
 
 	changesSinceReorg int // A counter for how many drops we've performed in-between reorg.
@@ -282,6 +295,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		reorgDoneCh:     make(chan chan struct{}),
 		reorgShutdownCh: make(chan struct{}),
 		initDoneCh:      make(chan struct{}),
+		lifecycleQueue:  make(chan []*txpool.TxLifecycleEvent, lifecycleQueueSize),
 	}
 	pool.priced = newPricedList(pool.all)
 
@@ -328,6 +342,9 @@ func (pool *LegacyPool) Init(gasTip uint64, head *types.Header, reserver txpool.
 
 	pool.wg.Add(1)
 	go pool.loop()
+
+	pool.wg.Add(1)
+	go pool.lifecycleLoop()
 	return nil
 }
 
@@ -377,18 +394,23 @@ func (pool *LegacyPool) loop() {
 					for _, tx := range list {
 						pool.removeTx(tx.Hash(), true, true)
 					}
+					pool.notifyDropped(list, txpool.DropReasonExpired)
 					queuedEvictionMeter.Mark(int64(len(list)))
 				}
 			}
 			pool.mu.Unlock()
+			pool.sendLifecycle()
 		}
 	}
 }
 
 // Close terminates the transaction pool.
 func (pool *LegacyPool) Close() error {
-	// Terminate the pool reorger and return
+	// Terminate the pool reorger and return. Anyone still listening for
+	// lifecycle events is unsubscribed first, so that the pending delivery
+	// doesn't block the shutdown.
 	close(pool.reorgShutdownCh)
+	pool.lifecycleScope.Close()
 	pool.wg.Wait()
 
 	log.Info("Transaction pool stopped")
@@ -412,9 +434,83 @@ func (pool *LegacyPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs
 	return pool.txFeed.Subscribe(ch)
 }
 
+// SubscribeLifecycle registers a subscription for the state transitions of the
+// transactions tracked by the pool.
+func (pool *LegacyPool) SubscribeLifecycle(ch chan<- []*txpool.TxLifecycleEvent) event.Subscription {
+	return pool.lifecycleScope.Track(pool.lifecycleFeed.Subscribe(ch))
+}
+
+// notifyLifecycle records a state transition of a transaction, to be delivered
+// to the subscribers once the pool lock is released. It's a noop if nobody is
+// subscribed.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) notifyLifecycle(tx *types.Transaction, status txpool.TxLifecycleStatus, replacement common.Hash, reason string) {
+	if pool.lifecycleScope.Count() == 0 {
+		return
+	}
+	from, _ := types.Sender(pool.signer, tx) // already validated
+	pool.lifecycle = append(pool.lifecycle, &txpool.TxLifecycleEvent{
+		Hash:       tx.Hash(),
+		From:       from,
+		Nonce:      tx.Nonce(),
+		Status:     status,
+		ReplacedBy: replacement,
+		Reason:     reason,
+	})
+}
+
+// notifyDropped records the eviction of a batch of transactions.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) notifyDropped(txs []*types.Transaction, reason string) {
+	for _, tx := range txs {
+		pool.notifyLifecycle(tx, txpool.TxLifecycleDropped, common.Hash{}, reason)
+	}
+}
+
+// sendLifecycle queues the lifecycle events recorded since the last call for
+// delivery. The delivery is done by the lifecycle loop, so that slow subscribers
+// can't hold up the pool; if they lag too far behind, the events are dropped.
+func (pool *LegacyPool) sendLifecycle() {
+	pool.lifecycleLock.Lock()
+	defer pool.lifecycleLock.Unlock()
+
+	pool.mu.Lock()
+	events := pool.lifecycle
+	pool.lifecycle = nil
+	pool.mu.Unlock()
+
+	if len(events) == 0 {
+		return
+	}
+	select {
+	case pool.lifecycleQueue <- events:
+	default:
+		lifecycleDropMeter.Mark(int64(len(events)))
+		log.Debug("Dropping transaction lifecycle events", "count", len(events))
+	}
+}
+
+// lifecycleLoop delivers the queued lifecycle events to the subscribers.
+func (pool *LegacyPool) lifecycleLoop() {
+	defer pool.wg.Done()
+
+	for {
+		select {
+		case events := <-pool.lifecycleQueue:
+			pool.lifecycleFeed.Send(events)
+		case <-pool.reorgShutdownCh:
+			return
+		}
+	}
+}
+
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (pool *LegacyPool) SetGasTip(tip *big.Int) {
+	defer pool.sendLifecycle()
+
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
@@ -430,6 +526,7 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 		for _, tx := range drop {
 			pool.removeTx(tx.Hash(), false, true)
 		}
+		pool.notifyDropped(drop, txpool.DropReasonUnderpriced)
 		pool.priced.Removed(len(drop))
 	}
 	log.Info("Legacy pool tip threshold updated", "tip", newTip)
@@ -769,6 +866,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 
 			sender, _ := types.Sender(pool.signer, tx)
 			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
+			pool.notifyLifecycle(tx, txpool.TxLifecycleDropped, common.Hash{}, txpool.DropReasonUnderpriced)
 
 			pool.changesSinceReorg += dropped
 		}
@@ -787,10 +885,12 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.notifyLifecycle(old, txpool.TxLifecycleReplaced, hash, "")
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
 		pool.queueTxEvent(tx)
+		pool.notifyLifecycle(tx, txpool.TxLifecyclePending, common.Hash{}, "")
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
 		// Successful promotion, bump the heartbeat
@@ -851,10 +951,12 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, addAl
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		queuedReplaceMeter.Mark(1)
+		pool.notifyLifecycle(old, txpool.TxLifecycleReplaced, hash, "")
 	} else {
 		// Nothing was replaced, bump the queued counter
 		queuedGauge.Inc(1)
 	}
+	pool.notifyLifecycle(tx, txpool.TxLifecycleQueued, common.Hash{}, "")
 	// If the transaction isn't in lookup set but it's expected to be there,
 	// show the error log.
 	if pool.all.Get(hash) == nil && !addAll {
@@ -888,6 +990,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(hash)
 		pool.priced.Removed(1)
 		pendingDiscardMeter.Mark(1)
+		pool.notifyLifecycle(tx, txpool.TxLifecycleDropped, common.Hash{}, txpool.DropReasonUnderpriced)
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -895,10 +998,12 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		pendingReplaceMeter.Mark(1)
+		pool.notifyLifecycle(old, txpool.TxLifecycleReplaced, hash, "")
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
 	}
+	pool.notifyLifecycle(tx, txpool.TxLifecyclePending, common.Hash{}, "")
 	// Set the potentially new pending nonce and notify any subsystems of the new tx
 	pool.pendingNonces.set(addr, tx.Nonce()+1)
 
@@ -1060,6 +1165,7 @@ func (pool *LegacyPool) GetMetadata(hash common.Hash) *txpool.TxMetadata {
 	return &txpool.TxMetadata{
 		Type: tx.Type(),
 		Size: tx.Size(),
+		Time: tx.Time(),
 	}
 }
 
@@ -1325,6 +1431,7 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 		}
 		pool.txFeed.Send(core.NewTxsEvent{Txs: txs})
 	}
+	pool.sendLifecycle()
 }
 
 // reset retrieves the current state of the blockchain and ensures the content
@@ -1453,6 +1560,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		}
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
+		pool.notifyDropped(drops, txpool.DropReasonNoFunds)
 
 		// Gather all executable transactions and promote them
 		readies := list.Ready(pool.pendingNonces.get(addr))
@@ -1473,6 +1581,8 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 			log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 		}
 		queuedRateLimitMeter.Mark(int64(len(caps)))
+		pool.notifyDropped(caps, txpool.DropReasonAccountLimit)
+
 		// Mark all the items dropped as removed
 		pool.priced.Removed(len(forwards) + len(drops) + len(caps))
 		queuedGauge.Dec(int64(len(forwards) + len(drops) + len(caps)))
@@ -1539,6 +1649,7 @@ func (pool *LegacyPool) truncatePending() {
 					}
 					pool.priced.Removed(len(caps))
 					pendingGauge.Dec(int64(len(caps)))
+					pool.notifyDropped(caps, txpool.DropReasonOverflow)
 
 					pending--
 				}
@@ -1564,6 +1675,7 @@ func (pool *LegacyPool) truncatePending() {
 				}
 				pool.priced.Removed(len(caps))
 				pendingGauge.Dec(int64(len(caps)))
+				pool.notifyDropped(caps, txpool.DropReasonOverflow)
 				pending--
 			}
 		}
@@ -1599,6 +1711,7 @@ func (pool *LegacyPool) truncateQueue() {
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
 				pool.removeTx(tx.Hash(), true, true)
+				pool.notifyLifecycle(tx, txpool.TxLifecycleDropped, common.Hash{}, txpool.DropReasonOverflow)
 			}
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
@@ -1608,6 +1721,7 @@ func (pool *LegacyPool) truncateQueue() {
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
 			pool.removeTx(txs[i].Hash(), true, true)
+			pool.notifyLifecycle(txs[i], txpool.TxLifecycleDropped, common.Hash{}, txpool.DropReasonOverflow)
 			drop--
 			queuedRateLimitMeter.Mark(1)
 		}
@@ -1642,6 +1756,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 		}
 		pendingNofundsMeter.Mark(int64(len(drops)))
+		pool.notifyDropped(drops, txpool.DropReasonNoFunds)
 
 		for _, tx := range invalids {
 			hash := tx.Hash()
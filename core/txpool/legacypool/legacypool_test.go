@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"reflect"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -2047,6 +2048,112 @@ func TestReplacement(t *testing.T) {
 	}
 }
 
+// Tests that the lifecycle transitions of transactions entering, moving around
+// and being replaced within the pool are reported to subscribers.
+func TestLifecycleEvents(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	events := make(chan []*txpool.TxLifecycleEvent, 32)
+	sub := pool.SubscribeLifecycle(events)
+	defer sub.Unsubscribe()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+
+	// transition identifies a delivered lifecycle event.
+	type transition struct {
+		hash     common.Hash
+		status   txpool.TxLifecycleStatus
+		replaced common.Hash
+	}
+	check := func(want []transition) {
+		t.Helper()
+
+		var have []transition
+		for len(have) < len(want) {
+			select {
+			case batch := <-events:
+				for _, ev := range batch {
+					if ev.From != from {
+						t.Fatalf("wrong sender: have %v, want %v", ev.From, from)
+					}
+					have = append(have, transition{ev.Hash, ev.Status, ev.ReplacedBy})
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("lifecycle event #%d not fired", len(have))
+			}
+		}
+		if !reflect.DeepEqual(have, want) {
+			t.Fatalf("wrong lifecycle events:\nhave %v\nwant %v", have, want)
+		}
+	}
+	// Gapped transactions are queued, then promoted together once the gap fills
+	var (
+		tx0 = pricedTransaction(0, 100000, big.NewInt(1), key)
+		tx1 = pricedTransaction(1, 100000, big.NewInt(1), key)
+		tx2 = pricedTransaction(0, 100000, big.NewInt(2), key)
+	)
+	if err := pool.addRemoteSync(tx1); err != nil {
+		t.Fatalf("failed to add gapped transaction: %v", err)
+	}
+	check([]transition{{tx1.Hash(), txpool.TxLifecycleQueued, common.Hash{}}})
+
+	if err := pool.addRemoteSync(tx0); err != nil {
+		t.Fatalf("failed to add gap filling transaction: %v", err)
+	}
+	check([]transition{
+		{tx0.Hash(), txpool.TxLifecycleQueued, common.Hash{}},
+		{tx0.Hash(), txpool.TxLifecyclePending, common.Hash{}},
+		{tx1.Hash(), txpool.TxLifecyclePending, common.Hash{}},
+	})
+	// Replacing a pending transaction reports both of them
+	if err := pool.addRemoteSync(tx2); err != nil {
+		t.Fatalf("failed to replace pending transaction: %v", err)
+	}
+	check([]transition{
+		{tx0.Hash(), txpool.TxLifecycleReplaced, tx2.Hash()},
+		{tx2.Hash(), txpool.TxLifecyclePending, common.Hash{}},
+	})
+}
+
+// Tests that a subscriber not consuming the lifecycle events doesn't hold up the
+// pool.
+func TestLifecycleEventsStalled(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	events := make(chan []*txpool.TxLifecycleEvent)
+	sub := pool.SubscribeLifecycle(events)
+	defer sub.Unsubscribe()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; i < 2*lifecycleQueueSize; i++ {
+			if err := pool.addRemoteSync(transaction(uint64(i), 100000, key)); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("pool blocked by stalled lifecycle subscriber")
+	}
+}
+
 // Tests that the pool rejects replacement dynamic fee transactions that don't
 // meet the minimum price bump required.
 func TestReplacementDynamicFee(t *testing.T) {
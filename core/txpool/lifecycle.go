@@ -0,0 +1,74 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxLifecycleStatus is a state transition of a transaction.
+type TxLifecycleStatus string
+
+const (
+	// TxLifecycleQueued is reported when a transaction enters the pool as non-
+	// executable, or it is demoted back from the executable set.
+	TxLifecycleQueued TxLifecycleStatus = "queued"
+
+	// TxLifecyclePending is reported when a transaction becomes executable.
+	TxLifecyclePending TxLifecycleStatus = "pending"
+
+	// TxLifecycleReplaced is reported when a transaction is replaced by another
+	// one of the same sender and nonce, paying a higher fee.
+	TxLifecycleReplaced TxLifecycleStatus = "replaced"
+
+	// TxLifecycleDropped is reported when a transaction is evicted from the pool
+	// without being included in the chain.
+	TxLifecycleDropped TxLifecycleStatus = "dropped"
+
+	// TxLifecycleIncluded is reported when a transaction is included in a block
+	// of the canonical chain.
+	TxLifecycleIncluded TxLifecycleStatus = "included"
+
+	// TxLifecycleReorged is reported when a block including the transaction is
+	// reorged out of the canonical chain.
+	TxLifecycleReorged TxLifecycleStatus = "reorged"
+)
+
+// Reasons of the dropped transaction lifecycle events.
+const (
+	DropReasonUnderpriced  = "underpriced"        // Evicted for a better paying transaction, or below the minimum tip
+	DropReasonOverflow     = "pool overflow"      // Evicted to keep the pool within its capacity
+	DropReasonExpired      = "expired"            // Non-executable for longer than the configured lifetime
+	DropReasonNoFunds      = "insufficient funds" // Sender cannot pay for the transaction anymore
+	DropReasonAccountLimit = "account limit"      // Sender has more transactions than permitted
+	DropReasonInvalid      = "invalid"            // Not valid anymore with regard to the chain state
+)
+
+// TxLifecycleEvent is posted when a transaction moves through its lifecycle,
+// from entering the pool until its inclusion in the chain.
+type TxLifecycleEvent struct {
+	Hash   common.Hash       // Hash of the transaction
+	From   common.Address    // Sender of the transaction
+	Nonce  uint64            // Nonce of the transaction
+	Status TxLifecycleStatus // New status of the transaction
+
+	ReplacedBy common.Hash // Hash of the replacement, if the transaction was replaced
+	Reason     string      // Reason of the eviction, if the transaction was dropped
+
+	BlockHash   common.Hash // Block including the transaction, if included or reorged
+	BlockNumber uint64      // Number of the block including the transaction
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// policyReloadInterval is the minimum time between two checks of the list
+	// file for modifications.
+	policyReloadInterval = 3 * time.Second
+
+	// maxRateLimitedSenders is the number of distinct senders for which the rate
+	// limiting state is retained. Least recently seen senders are dropped beyond.
+	maxRateLimitedSenders = 16384
+)
+
+var (
+	// Metrics of the transactions rejected by the admission policy
+	policySenderMeter    = metrics.NewRegisteredMeter("txpool/policy/sender", nil)
+	policyRecipientMeter = metrics.NewRegisteredMeter("txpool/policy/recipient", nil)
+	policyRateMeter      = metrics.NewRegisteredMeter("txpool/policy/ratelimit", nil)
+	policyDataMeter      = metrics.NewRegisteredMeter("txpool/policy/calldata", nil)
+	policyCreateMeter    = metrics.NewRegisteredMeter("txpool/policy/create", nil)
+)
+
+// PolicyConfig are the configuration parameters of the transaction admission
+// policy, applied to all transactions before they are handed to the subpools.
+type PolicyConfig struct {
+	ListFile           string  `toml:",omitempty"` // JSON file of sender and recipient allow- and deny-lists, reloaded on change
+	MaxCallDataSize    uint64  `toml:",omitempty"` // Maximum size of the transaction input data (0 = unlimited)
+	NoContractCreation bool    `toml:",omitempty"` // Whether to reject contract creation transactions
+	SenderRate         float64 `toml:",omitempty"` // Sustained number of transactions accepted per second from a single sender (0 = unlimited)
+	SenderBurst        int     `toml:",omitempty"` // Number of transactions accepted at once from a single sender (defaults to 1)
+}
+
+// enabled reports whether the policy restricts anything.
+func (config *PolicyConfig) enabled() bool {
+	return config.ListFile != "" || config.MaxCallDataSize > 0 || config.NoContractCreation || config.SenderRate > 0
+}
+
+// addressList is an allow- and deny-list pair of accounts. An empty allow-list
+// permits all accounts, whereas the deny-list takes precedence over it.
+type addressList struct {
+	Allow []common.Address `json:"allow,omitempty"`
+	Deny  []common.Address `json:"deny,omitempty"`
+}
+
+// policyLists is the content of the list file.
+type policyLists struct {
+	Senders    addressList `json:"senders"`
+	Recipients addressList `json:"recipients"`
+}
+
+// addressSet is the indexed form of an addressList.
+type addressSet struct {
+	allow map[common.Address]struct{}
+	deny  map[common.Address]struct{}
+}
+
+func newAddressSet(list addressList) addressSet {
+	set := addressSet{
+		allow: make(map[common.Address]struct{}, len(list.Allow)),
+		deny:  make(map[common.Address]struct{}, len(list.Deny)),
+	}
+	for _, addr := range list.Allow {
+		set.allow[addr] = struct{}{}
+	}
+	for _, addr := range list.Deny {
+		set.deny[addr] = struct{}{}
+	}
+	return set
+}
+
+// permits reports whether the address passes the lists.
+func (set addressSet) permits(addr common.Address) bool {
+	if _, ok := set.deny[addr]; ok {
+		return false
+	}
+	if len(set.allow) == 0 {
+		return true
+	}
+	_, ok := set.allow[addr]
+	return ok
+}
+
+// Policy is a set of operator defined rules deciding which transactions are
+// admitted into the pool on top of the protocol level validation.
+type Policy struct {
+	config PolicyConfig
+
+	lock       sync.Mutex
+	senders    addressSet
+	recipients addressSet
+	modtime    time.Time // Modification time of the loaded list file
+	checked    time.Time // Last time the list file was checked for changes
+
+	limiters lru.BasicLRU[common.Address, *rate.Limiter]
+}
+
+// NewPolicy creates an admission policy from the given configuration, loading
+// the allow- and deny-lists if a list file is configured.
+func NewPolicy(config PolicyConfig) (*Policy, error) {
+	p := &Policy{
+		config:     config,
+		senders:    newAddressSet(addressList{}),
+		recipients: newAddressSet(addressList{}),
+		limiters:   lru.NewBasicLRU[common.Address, *rate.Limiter](maxRateLimitedSenders),
+	}
+	if config.ListFile != "" {
+		if err := p.load(time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// load reads the list file and replaces the active lists with its content.
+func (p *Policy) load(now time.Time) error {
+	p.checked = now
+
+	stat, err := os.Stat(p.config.ListFile)
+	if err != nil {
+		return err
+	}
+	blob, err := os.ReadFile(p.config.ListFile)
+	if err != nil {
+		return err
+	}
+	var lists policyLists
+	if err := json.Unmarshal(blob, &lists); err != nil {
+		return fmt.Errorf("invalid txpool policy file %s: %v", p.config.ListFile, err)
+	}
+	p.senders = newAddressSet(lists.Senders)
+	p.recipients = newAddressSet(lists.Recipients)
+	p.modtime = stat.ModTime()
+	return nil
+}
+
+// maybeReload reloads the list file if it was modified since it was last read.
+// Failures are only logged, retaining the previously loaded lists.
+func (p *Policy) maybeReload(now time.Time) {
+	if p.config.ListFile == "" || now.Sub(p.checked) < policyReloadInterval {
+		return
+	}
+	p.checked = now
+
+	stat, err := os.Stat(p.config.ListFile)
+	if err != nil {
+		log.Warn("Failed to check txpool policy file", "path", p.config.ListFile, "err", err)
+		return
+	}
+	if stat.ModTime().Equal(p.modtime) {
+		return
+	}
+	if err := p.load(now); err != nil {
+		log.Warn("Failed to reload txpool policy file", "path", p.config.ListFile, "err", err)
+		return
+	}
+	log.Info("Reloaded txpool policy file", "path", p.config.ListFile,
+		"senders", len(p.senders.allow)+len(p.senders.deny), "recipients", len(p.recipients.allow)+len(p.recipients.deny))
+}
+
+// check verifies whether the transaction issued by the given sender may enter
+// the pool, given the number of transactions of the sender already admitted in
+// the same batch. The rate limit is not charged, that is done via charge once
+// the transaction was accepted by a subpool.
+func (p *Policy) check(tx *types.Transaction, from common.Address, pending int) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+	p.maybeReload(now)
+
+	if !p.senders.permits(from) {
+		policySenderMeter.Mark(1)
+		return fmt.Errorf("%w: %v", ErrSenderDenied, from)
+	}
+	if to := tx.To(); to == nil {
+		if p.config.NoContractCreation {
+			policyCreateMeter.Mark(1)
+			return ErrContractCreationDenied
+		}
+	} else if !p.recipients.permits(*to) {
+		policyRecipientMeter.Mark(1)
+		return fmt.Errorf("%w: %v", ErrRecipientDenied, *to)
+	}
+	if limit := p.config.MaxCallDataSize; limit > 0 && uint64(len(tx.Data())) > limit {
+		policyDataMeter.Mark(1)
+		return fmt.Errorf("%w: size %d, limit %d", ErrCallDataTooLarge, len(tx.Data()), limit)
+	}
+	if p.config.SenderRate > 0 && p.limiter(from).TokensAt(now) < float64(pending+1) {
+		policyRateMeter.Mark(1)
+		return fmt.Errorf("%w: %v", ErrSenderRateLimited, from)
+	}
+	return nil
+}
+
+// charge counts a transaction accepted into the pool against the rate limit of
+// its sender.
+func (p *Policy) charge(from common.Address) {
+	if p.config.SenderRate == 0 {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.limiter(from).AllowN(time.Now(), 1)
+}
+
+// limiter retrieves the rate limiter of the sender, creating it if not yet
+// tracked. The lock must be held by the caller.
+func (p *Policy) limiter(from common.Address) *rate.Limiter {
+	limiter, ok := p.limiters.Get(from)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(p.config.SenderRate), max(p.config.SenderBurst, 1))
+		p.limiters.Add(from, limiter)
+	}
+	return limiter
+}
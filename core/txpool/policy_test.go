@@ -0,0 +1,169 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func policyTx(to *common.Address, data []byte) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{To: to, Gas: 100000, Data: data})
+}
+
+func TestPolicyLists(t *testing.T) {
+	var (
+		alice   = common.Address{0xa}
+		bob     = common.Address{0xb}
+		carol   = common.Address{0xc}
+		path    = filepath.Join(t.TempDir(), "policy.json")
+		content = `{
+			"senders":    {"allow": ["0x0a00000000000000000000000000000000000000", "0x0b00000000000000000000000000000000000000"], "deny": ["0x0b00000000000000000000000000000000000000"]},
+			"recipients": {"deny": ["0x0c00000000000000000000000000000000000000"]}
+		}`
+	)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := NewPolicy(PolicyConfig{ListFile: path})
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	tests := []struct {
+		tx   *types.Transaction
+		from common.Address
+		err  error
+	}{
+		{policyTx(&bob, nil), alice, nil},
+		{policyTx(nil, nil), alice, nil},
+		{policyTx(&alice, nil), bob, ErrSenderDenied},   // denied despite allowed
+		{policyTx(&alice, nil), carol, ErrSenderDenied}, // not allowed
+		{policyTx(&carol, nil), alice, ErrRecipientDenied},
+	}
+	for i, test := range tests {
+		if err := policy.check(test.tx, test.from, 0); !errors.Is(err, test.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, test.err)
+		}
+	}
+	// Update the lists and ensure the changes are picked up
+	if err := os.WriteFile(path, []byte(`{"recipients": {"allow": ["0x0c00000000000000000000000000000000000000"]}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	policy.checked = time.Time{}
+
+	if err := policy.check(policyTx(&carol, nil), carol, 0); err != nil {
+		t.Fatalf("reloaded lists not applied: %v", err)
+	}
+	if err := policy.check(policyTx(&bob, nil), alice, 0); !errors.Is(err, ErrRecipientDenied) {
+		t.Fatalf("reloaded allow-list not applied: %v", err)
+	}
+	// Invalid updates must retain the previous lists
+	if err := os.WriteFile(path, []byte(`{`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	future = future.Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	policy.checked = time.Time{}
+
+	if err := policy.check(policyTx(&bob, nil), alice, 0); !errors.Is(err, ErrRecipientDenied) {
+		t.Fatalf("lists dropped on invalid update: %v", err)
+	}
+}
+
+func TestPolicyLimits(t *testing.T) {
+	var (
+		alice = common.Address{0xa}
+		bob   = common.Address{0xb}
+	)
+	policy, err := NewPolicy(PolicyConfig{
+		MaxCallDataSize:    4,
+		NoContractCreation: true,
+		SenderRate:         0.001,
+		SenderBurst:        2,
+	})
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	tests := []struct {
+		tx   *types.Transaction
+		from common.Address
+		err  error
+	}{
+		{policyTx(nil, nil), alice, ErrContractCreationDenied},
+		{policyTx(&bob, make([]byte, 5)), alice, ErrCallDataTooLarge},
+		{policyTx(&bob, make([]byte, 4)), alice, nil},
+		{policyTx(&bob, nil), alice, nil},
+		{policyTx(&bob, nil), alice, ErrSenderRateLimited}, // burst exhausted
+		{policyTx(&alice, nil), bob, nil},                  // independent limit
+	}
+	for i, test := range tests {
+		err := policy.check(test.tx, test.from, 0)
+		if !errors.Is(err, test.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, test.err)
+		}
+		if err == nil {
+			policy.charge(test.from)
+		}
+	}
+}
+
+// Tests that only the transactions accepted into the pool are charged against
+// the rate limit of the sender.
+func TestPolicyRateCharge(t *testing.T) {
+	var (
+		alice = common.Address{0xa}
+		bob   = common.Address{0xb}
+	)
+	policy, err := NewPolicy(PolicyConfig{SenderRate: 0.001, SenderBurst: 2})
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	// Admitted transactions which are not accepted don't consume the limit
+	for i := 0; i < 5; i++ {
+		if err := policy.check(policyTx(&bob, nil), alice, 0); err != nil {
+			t.Fatalf("check %d: uncharged transaction rate limited: %v", i, err)
+		}
+	}
+	// Transactions admitted in the same batch count against the limit
+	if err := policy.check(policyTx(&bob, nil), alice, 1); err != nil {
+		t.Fatalf("second transaction of batch rejected: %v", err)
+	}
+	if err := policy.check(policyTx(&bob, nil), alice, 2); !errors.Is(err, ErrSenderRateLimited) {
+		t.Fatalf("batch exceeding burst admitted: %v", err)
+	}
+	// Accepted transactions consume the limit
+	policy.charge(alice)
+	if err := policy.check(policyTx(&bob, nil), alice, 1); !errors.Is(err, ErrSenderRateLimited) {
+		t.Fatalf("charged transaction not counted: %v", err)
+	}
+	policy.charge(alice)
+	if err := policy.check(policyTx(&bob, nil), alice, 0); !errors.Is(err, ErrSenderRateLimited) {
+		t.Fatalf("exhausted burst not limited: %v", err)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// snapshotMagic is the header of the pool snapshot files, identifying both
+	// the content and the version of the record format following it.
+	snapshotMagic = "gethpool\x00\x01"
+
+	// snapshotMaxRecord is the maximum size of a single transaction record. It
+	// needs to fit blob transactions along with their sidecars. A larger record
+	// length can only result from corruption, after which no further records
+	// can be located.
+	snapshotMaxRecord = 16 * 1024 * 1024
+
+	// snapshotLoadBatch is the number of transactions re-added into the pool at
+	// once when loading a snapshot.
+	snapshotLoadBatch = 1024
+)
+
+var snapshotTable = crc32.MakeTable(crc32.Castagnoli)
+
+// SnapshotConfig are the configuration parameters of the pool snapshots, which
+// retain the full content of the pool across node restarts.
+type SnapshotConfig struct {
+	Path     string        `toml:",omitempty"` // File to store the pool content in (empty = disabled)
+	Interval time.Duration `toml:",omitempty"` // Time interval to regenerate the snapshot (0 = on shutdown only)
+	MaxSize  uint64        `toml:",omitempty"` // Maximum size of the snapshot file in bytes (0 = unlimited)
+}
+
+// DefaultSnapshotConfig contains the default configurations for the pool
+// snapshots, which are disabled unless a file is configured.
+var DefaultSnapshotConfig = SnapshotConfig{
+	Interval: time.Hour,
+	MaxSize:  256 * 1024 * 1024,
+}
+
+// Snapshotter periodically dumps the content of all subpools into a file and
+// reinjects it into the pool on startup. Reloaded transactions are added just
+// like any other remote transaction, getting revalidated against the current
+// chain state, whereas those already known by a subpool are skipped.
+type Snapshotter struct {
+	config SnapshotConfig
+	pool   *TxPool
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewSnapshotter creates a snapshotter persisting the content of the pool.
+func NewSnapshotter(config SnapshotConfig, pool *TxPool) *Snapshotter {
+	return &Snapshotter{
+		config: config,
+		pool:   pool,
+		quit:   make(chan struct{}),
+	}
+}
+
+// Start implements node.Lifecycle, loading the last snapshot into the pool and
+// starting the periodic regeneration of it.
+func (s *Snapshotter) Start() error {
+	s.wg.Add(1)
+	go s.loop()
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the background regeneration and
+// writing out the final snapshot of the pool.
+func (s *Snapshotter) Stop() error {
+	close(s.quit)
+	s.wg.Wait()
+
+	return s.write()
+}
+
+func (s *Snapshotter) loop() {
+	defer s.wg.Done()
+
+	s.load()
+	if s.config.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.write(); err != nil {
+				log.Warn("Failed to write transaction pool snapshot", "err", err)
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// load reads the snapshot file and adds its content to the pool.
+func (s *Snapshotter) load() {
+	start := time.Now()
+
+	txs, corrupted, err := readSnapshot(s.config.Path)
+	if err != nil {
+		log.Warn("Failed to read transaction pool snapshot", "path", s.config.Path, "err", err)
+	}
+	var added, known, dropped int
+	for len(txs) > 0 {
+		batch := txs[:min(len(txs), snapshotLoadBatch)]
+		txs = txs[len(batch):]
+
+		for _, err := range s.pool.Add(batch, false) {
+			switch {
+			case err == nil:
+				added++
+			case errors.Is(err, ErrAlreadyKnown):
+				known++
+			default:
+				log.Trace("Failed to add snapshotted transaction", "err", err)
+				dropped++
+			}
+		}
+	}
+	if added+known+dropped+corrupted > 0 {
+		log.Info("Loaded transaction pool snapshot", "added", added, "known", known, "dropped", dropped, "corrupted", corrupted, "elapsed", time.Since(start))
+	}
+}
+
+// write dumps the current content of the pool into the snapshot file. Plain
+// transactions are stored ahead of blob ones, so the size limit cuts the more
+// expensive ones first.
+func (s *Snapshotter) write() error {
+	var (
+		start           = time.Now()
+		pending, queued = s.pool.Content()
+		txs             []*types.Transaction
+	)
+	for _, list := range pending {
+		txs = append(txs, list...)
+	}
+	for _, list := range queued {
+		txs = append(txs, list...)
+	}
+	for _, list := range s.pool.Pending(PendingFilter{OnlyBlobTxs: true}) {
+		for _, lazy := range list {
+			if tx := lazy.Resolve(); tx != nil {
+				txs = append(txs, tx)
+			}
+		}
+	}
+	stored, err := writeSnapshot(s.config.Path, txs, s.config.MaxSize)
+	if err != nil {
+		return err
+	}
+	log.Info("Wrote transaction pool snapshot", "transactions", stored, "skipped", len(txs)-stored, "elapsed", time.Since(start))
+	return nil
+}
+
+// writeSnapshot atomically replaces the snapshot file with the given transactions,
+// storing as many of them as fit within the size limit. Each transaction is put
+// into its own length prefixed and checksummed record. The number of written
+// transactions is returned.
+func writeSnapshot(path string, txs []*types.Transaction, limit uint64) (int, error) {
+	file, err := os.OpenFile(path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		out    = bufio.NewWriter(file)
+		size   = uint64(len(snapshotMagic))
+		stored int
+		header [8]byte
+	)
+	out.WriteString(snapshotMagic)
+	for _, tx := range txs {
+		blob, err := tx.MarshalBinary()
+		if err != nil || len(blob) > snapshotMaxRecord {
+			continue
+		}
+		if limit > 0 && size+uint64(len(header)+len(blob)) > limit {
+			continue
+		}
+		binary.BigEndian.PutUint32(header[:4], uint32(len(blob)))
+		binary.BigEndian.PutUint32(header[4:], crc32.Checksum(blob, snapshotTable))
+		out.Write(header[:])
+		out.Write(blob)
+
+		size += uint64(len(header) + len(blob))
+		stored++
+	}
+	if err := out.Flush(); err != nil {
+		file.Close()
+		return 0, err
+	}
+	if err := file.Close(); err != nil {
+		return 0, err
+	}
+	return stored, os.Rename(path+".new", path)
+}
+
+// readSnapshot parses the transactions out of a snapshot file. Records failing
+// their checksum or decoding are skipped and counted, whereas a truncated file
+// yields all the records up to the damaged one. A missing file is not an error.
+func readSnapshot(path string) ([]*types.Transaction, int, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	in := bufio.NewReader(file)
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(in, magic); err != nil || !bytes.Equal(magic, []byte(snapshotMagic)) {
+		return nil, 0, errors.New("unknown snapshot format")
+	}
+	var (
+		txs       []*types.Transaction
+		corrupted int
+		header    [8]byte
+	)
+	for {
+		if _, err := io.ReadFull(in, header[:]); err != nil {
+			if err == io.EOF {
+				return txs, corrupted, nil
+			}
+			return txs, corrupted, fmt.Errorf("truncated snapshot: %v", err)
+		}
+		length := binary.BigEndian.Uint32(header[:4])
+		if length > snapshotMaxRecord {
+			return txs, corrupted, fmt.Errorf("corrupted snapshot: record of %d bytes", length)
+		}
+		blob := make([]byte, length)
+		if _, err := io.ReadFull(in, blob); err != nil {
+			return txs, corrupted, fmt.Errorf("truncated snapshot: %v", err)
+		}
+		if crc32.Checksum(blob, snapshotTable) != binary.BigEndian.Uint32(header[4:]) {
+			corrupted++
+			continue
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(blob); err != nil {
+			corrupted++
+			continue
+		}
+		txs = append(txs, tx)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func snapshotTxs(n int) []*types.Transaction {
+	txs := make([]*types.Transaction, n)
+	for i := range txs {
+		txs[i] = types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &common.Address{0xa}, Gas: 21000, Data: make([]byte, 100)})
+	}
+	return txs
+}
+
+func checkSnapshotTxs(t *testing.T, have, want []*types.Transaction) {
+	t.Helper()
+
+	if len(have) != len(want) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(have), len(want))
+	}
+	for i := range have {
+		if have[i].Hash() != want[i].Hash() {
+			t.Fatalf("transaction %d mismatch: have %x, want %x", i, have[i].Hash(), want[i].Hash())
+		}
+	}
+}
+
+func TestSnapshotRoundtrip(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "txpool.snap")
+		txs  = snapshotTxs(10)
+	)
+	// Missing snapshots are not an error
+	if loaded, _, err := readSnapshot(path); err != nil || len(loaded) != 0 {
+		t.Fatalf("missing snapshot: have %d txs, err %v", len(loaded), err)
+	}
+	stored, err := writeSnapshot(path, txs, 0)
+	if err != nil || stored != len(txs) {
+		t.Fatalf("failed to write snapshot: stored %d, err %v", stored, err)
+	}
+	loaded, corrupted, err := readSnapshot(path)
+	if err != nil || corrupted != 0 {
+		t.Fatalf("failed to read snapshot: corrupted %d, err %v", corrupted, err)
+	}
+	checkSnapshotTxs(t, loaded, txs)
+
+	// Size limits must cut the transactions not fitting anymore
+	info, _ := os.Stat(path)
+	record := (info.Size() - int64(len(snapshotMagic))) / int64(len(txs))
+	if stored, err = writeSnapshot(path, txs, uint64(int64(len(snapshotMagic))+record*int64(len(txs)/2))); err != nil || stored != len(txs)/2 {
+		t.Fatalf("failed to write limited snapshot: stored %d, err %v", stored, err)
+	}
+	loaded, _, _ = readSnapshot(path)
+	checkSnapshotTxs(t, loaded, txs[:len(txs)/2])
+}
+
+func TestSnapshotCorruption(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "txpool.snap")
+		txs  = snapshotTxs(10)
+	)
+	if _, err := writeSnapshot(path, txs, 0); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+	blob, _ := os.ReadFile(path)
+	record := (len(blob) - len(snapshotMagic)) / len(txs)
+
+	// Damaged records must be skipped, retaining the rest
+	damaged := common.CopyBytes(blob)
+	damaged[len(snapshotMagic)+record+record/2] ^= 0xff
+	os.WriteFile(path, damaged, 0644)
+
+	loaded, corrupted, err := readSnapshot(path)
+	if err != nil || corrupted != 1 {
+		t.Fatalf("damaged record not skipped: corrupted %d, err %v", corrupted, err)
+	}
+	checkSnapshotTxs(t, loaded, append(txs[:1:1], txs[2:]...))
+
+	// Truncated files must yield the records before the cut
+	os.WriteFile(path, blob[:len(blob)-record/2], 0644)
+
+	loaded, _, err = readSnapshot(path)
+	if err == nil {
+		t.Fatal("truncation not reported")
+	}
+	checkSnapshotTxs(t, loaded, txs[:len(txs)-1])
+
+	// Unknown files must not be loaded
+	os.WriteFile(path, []byte("garbage"), 0644)
+	if loaded, _, err = readSnapshot(path); err == nil || len(loaded) != 0 {
+		t.Fatalf("unknown file loaded: %d txs, err %v", len(loaded), err)
+	}
+}
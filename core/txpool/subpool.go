@@ -84,8 +84,9 @@ type PendingFilter struct {
 
 // TxMetadata denotes the metadata of a transaction.
 type TxMetadata struct {
-	Type uint8  // The type of the transaction
-	Size uint64 // The length of the 'rlp encoding' of a transaction
+	Type uint8     // The type of the transaction
+	Size uint64    // The length of the 'rlp encoding' of a transaction
+	Time time.Time // The time when the transaction was first seen by the pool
 }
 
 // SubPool represents a specialized transaction pool that lives on its own (e.g.
@@ -161,6 +162,10 @@ type SubPool interface {
 	// or also for reorged out ones.
 	SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription
 
+	// SubscribeLifecycle subscribes to the state transitions of the transactions
+	// tracked by the subpool, from entering it until being included or dropped.
+	SubscribeLifecycle(ch chan<- []*TxLifecycleEvent) event.Subscription
+
 	// Nonce returns the next nonce of an account, with all transactions executable
 	// by the pool already applied on top.
 	Nonce(addr common.Address) uint64
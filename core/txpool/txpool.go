@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -76,6 +77,8 @@ type TxPool struct {
 	term chan struct{}           // Termination channel to detect a closed pool
 
 	sync chan chan error // Testing / simulator channel to block until internal reset is done
+
+	policy atomic.Pointer[Policy] // Admission policy applied before the subpools
 }
 
 // New creates a new transaction pool to gather, sort and filter inbound
@@ -339,11 +342,32 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 	// so we can piece back the returned errors into the original order.
 	txsets := make([][]*types.Transaction, len(p.subpools))
 	splits := make([]int, len(txs))
+	errs := make([]error, len(txs))
 
+	var (
+		policy  = p.policy.Load()
+		senders map[int]common.Address // Senders of the transactions admitted by the policy
+		pending map[common.Address]int // Number of transactions admitted per sender
+	)
+	if policy != nil {
+		senders = make(map[int]common.Address)
+		pending = make(map[common.Address]int)
+	}
 	for i, tx := range txs {
 		// Mark this transaction belonging to no-subpool
 		splits[i] = -1
 
+		// Reject the transaction early if the admission policy forbids it. Known
+		// transactions are skipped, the subpools will reject them anyway.
+		if policy != nil && !p.Has(tx.Hash()) {
+			if from, err := types.Sender(p.signer, tx); err == nil {
+				if errs[i] = policy.check(tx, from, pending[from]); errs[i] != nil {
+					continue
+				}
+				senders[i] = from
+				pending[from]++
+			}
+		}
 		// Try to find a subpool that accepts the transaction
 		for j, subpool := range p.subpools {
 			if subpool.Filter(tx) {
@@ -359,8 +383,11 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 	for i := 0; i < len(p.subpools); i++ {
 		errsets[i] = p.subpools[i].Add(txsets[i], sync)
 	}
-	errs := make([]error, len(txs))
 	for i, split := range splits {
+		// If the transaction was rejected by the policy, keep the error
+		if errs[i] != nil {
+			continue
+		}
 		// If the transaction was rejected by all subpools, mark it unsupported
 		if split == -1 {
 			errs[i] = fmt.Errorf("%w: received type %d", core.ErrTxTypeNotSupported, txs[i].Type())
@@ -370,9 +397,25 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 		errs[i] = errsets[split][0]
 		errsets[split] = errsets[split][1:]
 	}
+	// Charge the rate limits of the senders only for the transactions that were
+	// accepted by the subpools
+	for i, from := range senders {
+		if errs[i] == nil {
+			policy.charge(from)
+		}
+	}
 	return errs
 }
 
+// SetPolicy sets the admission policy applied to all transactions before they
+// are added to the subpools. A nil policy admits every transaction.
+func (p *TxPool) SetPolicy(policy *Policy) {
+	if policy != nil && !policy.config.enabled() {
+		policy = nil
+	}
+	p.policy.Store(policy)
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 //
@@ -398,6 +441,17 @@ func (p *TxPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool)
 	return p.subs.Track(event.JoinSubscriptions(subs...))
 }
 
+// SubscribeLifecycle registers a subscription for the state transitions of the
+// transactions tracked by any of the subpools. The inclusion of transactions in
+// the chain is not reported by the pool.
+func (p *TxPool) SubscribeLifecycle(ch chan<- []*TxLifecycleEvent) event.Subscription {
+	subs := make([]event.Subscription, len(p.subpools))
+	for i, subpool := range p.subpools {
+		subs[i] = subpool.SubscribeLifecycle(ch)
+	}
+	return p.subs.Track(event.JoinSubscriptions(subs...))
+}
+
 // PoolNonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *TxPool) PoolNonce(addr common.Address) uint64 {
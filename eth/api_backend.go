@@ -234,13 +234,27 @@ func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 	if header == nil {
 		return nil, nil, errors.New("header not found")
 	}
-	stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+	stateDb, err := b.stateAt(header.Root)
 	if err != nil {
 		return nil, nil, err
 	}
 	return stateDb, header, nil
 }
 
+// stateAt returns the state with the given root, falling back to reconstructing
+// it from the state histories if it's no longer persisted but still within the
+// historic state window.
+func (b *EthAPIBackend) stateAt(root common.Hash) (*state.StateDB, error) {
+	stateDb, err := b.eth.BlockChain().StateAt(root)
+	if err == nil {
+		return stateDb, nil
+	}
+	if historic, herr := b.eth.BlockChain().HistoricState(root); herr == nil {
+		return historic, nil
+	}
+	return nil, err
+}
+
 func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
 	if blockNr, ok := blockNrOrHash.Number(); ok {
 		return b.StateAndHeaderByNumber(ctx, blockNr)
@@ -256,7 +270,7 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 		if blockNrOrHash.RequireCanonical && b.eth.blockchain.GetCanonicalHash(header.Number.Uint64()) != hash {
 			return nil, nil, errors.New("hash is not currently canonical")
 		}
-		stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+		stateDb, err := b.stateAt(header.Root)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -389,6 +403,10 @@ func (b *EthAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.S
 	return b.eth.txPool.SubscribeTransactions(ch, true)
 }
 
+func (b *EthAPIBackend) SubscribeTxLifecycleEvent(ch chan<- []*txpool.TxLifecycleEvent) event.Subscription {
+	return b.eth.txPool.SubscribeLifecycle(ch)
+}
+
 func (b *EthAPIBackend) SyncProgress(ctx context.Context) ethereum.SyncProgress {
 	prog := b.eth.Downloader().Progress()
 	if txProg, err := b.eth.blockchain.TxIndexProgress(); err == nil {
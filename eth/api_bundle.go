@@ -0,0 +1,166 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/miner"
+)
+
+// BundleAPI provides the API to submit and simulate transaction bundles, which
+// are included atomically at the top of the built payloads. The methods follow
+// the eth_sendBundle and eth_callBundle conventions of the Flashbots relays.
+type BundleAPI struct {
+	e *Ethereum
+}
+
+// NewBundleAPI creates a new BundleAPI instance.
+func NewBundleAPI(e *Ethereum) *BundleAPI {
+	return &BundleAPI{e}
+}
+
+// BundleArgs represents the arguments of a bundle submission or simulation.
+type BundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	MinTimestamp      *hexutil.Uint64 `json:"minTimestamp"`
+	MaxTimestamp      *hexutil.Uint64 `json:"maxTimestamp"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes"`
+}
+
+// toBundle decodes the transactions and assembles the bundle.
+func (args *BundleArgs) toBundle() (*miner.Bundle, error) {
+	bundle := &miner.Bundle{
+		BlockNumber:       uint64(args.BlockNumber),
+		RevertingTxHashes: args.RevertingTxHashes,
+	}
+	if args.MinTimestamp != nil {
+		bundle.MinTimestamp = uint64(*args.MinTimestamp)
+	}
+	if args.MaxTimestamp != nil {
+		bundle.MaxTimestamp = uint64(*args.MaxTimestamp)
+	}
+	for i, blob := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(blob); err != nil {
+			return nil, fmt.Errorf("invalid transaction %d: %v", i, err)
+		}
+		bundle.Txs = append(bundle.Txs, tx)
+	}
+	return bundle, nil
+}
+
+// SendBundleResult is the result of a bundle submission.
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// SendBundle submits a bundle for inclusion in the block with the given number.
+// The bundle is only included if none of its transactions fail or revert,
+// except for the ones marked as revertible.
+func (api *BundleAPI) SendBundle(args BundleArgs) (*SendBundleResult, error) {
+	bundle, err := args.toBundle()
+	if err != nil {
+		return nil, err
+	}
+	if err := api.e.Miner().SendBundle(bundle); err != nil {
+		return nil, err
+	}
+	return &SendBundleResult{BundleHash: bundle.Hash()}, nil
+}
+
+// CallBundleTxResult is the outcome of a single transaction of a simulated
+// bundle.
+type CallBundleTxResult struct {
+	TxHash       common.Hash     `json:"txHash"`
+	From         common.Address  `json:"fromAddress"`
+	To           *common.Address `json:"toAddress,omitempty"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	GasPrice     *hexutil.Big    `json:"gasPrice"`
+	GasFees      *hexutil.Big    `json:"gasFees"`
+	CoinbaseDiff *hexutil.Big    `json:"coinbaseDiff"`
+	Reverted     bool            `json:"reverted"`
+}
+
+// CallBundleResult is the outcome of a simulated bundle.
+type CallBundleResult struct {
+	BundleHash       common.Hash           `json:"bundleHash"`
+	StateBlockNumber hexutil.Uint64        `json:"stateBlockNumber"`
+	GasUsed          hexutil.Uint64        `json:"totalGasUsed"`
+	GasFees          *hexutil.Big          `json:"gasFees"`
+	CoinbaseDiff     *hexutil.Big          `json:"coinbaseDiff"`
+	Results          []*CallBundleTxResult `json:"results"`
+}
+
+// CallBundle simulates a bundle in a new block on top of the current chain head,
+// reporting the gas used and the payments to the fee recipient of each
+// transaction. The bundle must target the next block, and its timestamp bounds
+// must admit the simulated block. The transactions of the pool are not executed
+// before the bundle. The execution is limited by the RPC gas cap and the RPC EVM
+// timeout.
+func (api *BundleAPI) CallBundle(ctx context.Context, args BundleArgs) (*CallBundleResult, error) {
+	bundle, err := args.toBundle()
+	if err != nil {
+		return nil, err
+	}
+	// Setup context so it may be cancelled when the call has completed
+	// or, in case of unmetered gas, setup a context with a timeout.
+	var (
+		cancel  context.CancelFunc
+		timeout = api.e.config.RPCEVMTimeout
+	)
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	res, err := api.e.Miner().CallBundle(ctx, bundle, api.e.config.RPCGasCap)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &CallBundleResult{
+		BundleHash:       res.BundleHash,
+		StateBlockNumber: hexutil.Uint64(res.StateBlockNumber),
+		GasUsed:          hexutil.Uint64(res.GasUsed),
+		GasFees:          (*hexutil.Big)(res.GasFees),
+		CoinbaseDiff:     (*hexutil.Big)(res.CoinbaseDiff),
+	}
+	for _, tx := range res.Txs {
+		result.Results = append(result.Results, &CallBundleTxResult{
+			TxHash:       tx.TxHash,
+			From:         tx.From,
+			To:           tx.To,
+			GasUsed:      hexutil.Uint64(tx.GasUsed),
+			GasPrice:     (*hexutil.Big)(tx.GasPrice),
+			GasFees:      (*hexutil.Big)(tx.GasFees),
+			CoinbaseDiff: (*hexutil.Big)(tx.CoinbaseDiff),
+			Reverted:     tx.Reverted,
+		})
+	}
+	return result, nil
+}
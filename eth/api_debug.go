@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/stateless"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -87,6 +88,30 @@ func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
 	return stateDb.RawDump(opts), nil
 }
 
+// ExecutionWitness re-executes the block with the given number and returns the
+// witness (pre-state trie nodes, bytecodes and ancestor headers) required to
+// execute it statelessly.
+func (api *DebugAPI) ExecutionWitness(ctx context.Context, blockNr rpc.BlockNumber) (*stateless.Witness, error) {
+	block, err := api.eth.APIBackend.BlockByNumber(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block #%d not found", blockNr)
+	}
+	return api.eth.blockchain.ExecutionWitness(block)
+}
+
+// ExecutionWitnessByBlockHash re-executes the block with the given hash and
+// returns the witness required to execute it statelessly.
+func (api *DebugAPI) ExecutionWitnessByBlockHash(hash common.Hash) (*stateless.Witness, error) {
+	block := api.eth.blockchain.GetBlockByHash(hash)
+	if block == nil {
+		return nil, fmt.Errorf("block %x not found", hash)
+	}
+	return api.eth.blockchain.ExecutionWitness(block)
+}
+
 // Preimage is a debug API function that returns the preimage for a sha3 hash, if known.
 func (api *DebugAPI) Preimage(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
 	if preimage := rawdb.ReadPreimage(api.eth.ChainDb(), hash); preimage != nil {
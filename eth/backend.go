@@ -79,7 +79,8 @@ type Ethereum struct {
 	dropper *dropper
 
 	// DB interfaces
-	chainDb ethdb.Database // Block chain database
+	chainDb    ethdb.Database          // Block chain database
+	followerDb *rawdb.FollowerDatabase // Database of the followed node, nil if not following
 
 	eventMux       *event.TypeMux
 	engine         consensus.Engine
@@ -87,6 +88,7 @@ type Ethereum struct {
 
 	filterMaps      *filtermaps.FilterMaps
 	closeFilterMaps chan chan struct{}
+	closeFollower   chan chan struct{}
 
 	APIBackend *EthAPIBackend
 
@@ -128,16 +130,47 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	}
 	log.Info("Allocated trie memory caches", "clean", common.StorageSize(config.TrieCleanCache)*1024*1024, "dirty", common.StorageSize(config.TrieDirtyCache)*1024*1024)
 
-	chainDb, err := stack.OpenDatabaseWithFreezer("chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, "eth/db/chaindata/", false)
-	if err != nil {
-		return nil, err
+	var (
+		chainDb    ethdb.Database
+		followerDb *rawdb.FollowerDatabase
+		err        error
+	)
+	if config.Follow != "" {
+		// The chain database of the followed node is opened read-only, the log
+		// index and the snapshot can't be maintained on top.
+		followerDb, err = stack.OpenFollowerDatabase(config.Follow, "chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, "eth/db/chaindata/")
+		if err != nil {
+			return nil, err
+		}
+		chainDb = followerDb
+		config.SnapshotCache = 0
+		config.LogNoHistory = true
+		config.TxPool.NoLocals = true
+		config.TxPool.Journal = ""
+		config.BlobPool.Datadir = ""
+		config.TxPoolSnapshot.Path = ""
+		log.Info("Following chain database", "datadir", config.Follow, "interval", config.FollowInterval)
+	} else if config.DatabaseRemoteFreezer != "" {
+		remote, err := remoteFreezerConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		chainDb, err = stack.OpenDatabaseWithRemoteFreezer("chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, remote, "eth/db/chaindata/")
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		chainDb, err = stack.OpenDatabaseWithFreezer("chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, "eth/db/chaindata/", false)
+		if err != nil {
+			return nil, err
+		}
 	}
 	scheme, err := rawdb.ParseStateScheme(config.StateScheme, chainDb)
 	if err != nil {
 		return nil, err
 	}
 	// Try to recover offline state pruning only in hash-based.
-	if scheme == rawdb.HashScheme {
+	if scheme == rawdb.HashScheme && followerDb == nil {
 		if err := pruner.RecoverPruning(stack.ResolvePath(""), chainDb); err != nil {
 			log.Error("Failed to recover state", "error", err)
 		}
@@ -163,6 +196,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	eth := &Ethereum{
 		config:          config,
 		chainDb:         chainDb,
+		followerDb:      followerDb,
 		eventMux:        stack.EventMux(),
 		accountManager:  stack.AccountManager(),
 		engine:          engine,
@@ -183,6 +217,10 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if !config.SkipBcVersionCheck {
 		if bcVersion != nil && *bcVersion > core.BlockChainVersion {
 			return nil, fmt.Errorf("database version is v%d, Geth %s only supports v%d", *bcVersion, version.WithMeta, core.BlockChainVersion)
+		} else if followerDb != nil {
+			if bcVersion == nil || *bcVersion < core.BlockChainVersion {
+				return nil, fmt.Errorf("followed database version is v%s, Geth %s requires v%d", dbVer, version.WithMeta, core.BlockChainVersion)
+			}
 		} else if bcVersion == nil || *bcVersion < core.BlockChainVersion {
 			if bcVersion != nil { // only print warning on upgrade, not on init
 				log.Warn("Upgrade blockchain database version", "from", dbVer, "to", core.BlockChainVersion)
@@ -195,16 +233,22 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			EnablePreimageRecording: config.EnablePreimageRecording,
 		}
 		cacheConfig = &core.CacheConfig{
-			TrieCleanLimit:      config.TrieCleanCache,
-			TrieCleanNoPrefetch: config.NoPrefetch,
-			TrieDirtyLimit:      config.TrieDirtyCache,
-			TrieDirtyDisabled:   config.NoPruning,
-			TrieTimeLimit:       config.TrieTimeout,
-			SnapshotLimit:       config.SnapshotCache,
-			Preimages:           config.Preimages,
-			StateHistory:        config.StateHistory,
-			StateScheme:         scheme,
-			ChainHistoryMode:    config.HistoryMode,
+			TrieCleanLimit:       config.TrieCleanCache,
+			TrieCleanNoPrefetch:  config.NoPrefetch,
+			TrieDirtyLimit:       config.TrieDirtyCache,
+			TrieDirtyDisabled:    config.NoPruning,
+			TrieTimeLimit:        config.TrieTimeout,
+			SnapshotLimit:        config.SnapshotCache,
+			Preimages:            config.Preimages,
+			StateHistory:         config.StateHistory,
+			HistoricStateWindow:  config.HistoricStateWindow,
+			StateScheme:          scheme,
+			ChainHistoryMode:     config.HistoryMode,
+			AddressIndex:         config.AddressIndex,
+			AddressIndexLimit:    config.AddressIndexLimit,
+			AddressIndexInternal: config.AddressIndexInternal,
+			Follower:             followerDb != nil,
+			StateFork:            config.StateFork,
 		}
 	)
 	if config.VMTrace != "" {
@@ -244,10 +288,23 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if fb := eth.blockchain.CurrentFinalBlock(); fb != nil {
 		finalBlock = fb.Number.Uint64()
 	}
-	eth.filterMaps = filtermaps.NewFilterMaps(chainDb, chainView, historyCutoff, finalBlock, filtermaps.DefaultParams, fmConfig)
+	indexDb := chainDb
+	if followerDb != nil {
+		// Disabled log index is wiped from the database, keep it off the followed one.
+		indexDb = rawdb.NewMemoryDatabase()
+	}
+	eth.filterMaps = filtermaps.NewFilterMaps(indexDb, chainView, historyCutoff, finalBlock, filtermaps.DefaultParams, fmConfig)
 	eth.closeFilterMaps = make(chan chan struct{})
 
-	// TxPool
+	// TxPool, the admission policy is loaded first to not leak the pool if the
+	// configuration is invalid
+	if config.TxPoolPolicy.ListFile != "" {
+		config.TxPoolPolicy.ListFile = stack.ResolvePath(config.TxPoolPolicy.ListFile)
+	}
+	policy, err := txpool.NewPolicy(config.TxPoolPolicy)
+	if err != nil {
+		return nil, err
+	}
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
@@ -262,6 +319,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+	eth.txPool.SetPolicy(policy)
 
 	if !config.TxPool.NoLocals {
 		rejournal := config.TxPool.Rejournal
@@ -285,7 +343,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		BloomCache:     uint64(cacheLimit),
 		EventMux:       eth.eventMux,
 		RequiredBlocks: config.RequiredBlocks,
+		Follower:       followerDb != nil,
 	}); err != nil {
+		eth.txPool.Close()
 		return nil, err
 	}
 
@@ -306,12 +366,23 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 
 	// Register the backend on the node
 	stack.RegisterAPIs(eth.APIs())
-	stack.RegisterProtocols(eth.Protocols())
+	if followerDb == nil {
+		stack.RegisterProtocols(eth.Protocols())
+	}
 	stack.RegisterLifecycle(eth)
 
-	// Successful startup; push a marker and check previous unclean shutdowns.
-	eth.shutdownTracker.MarkStartup()
+	// The pool snapshot is registered after the backend, so it's stopped first,
+	// while the pool is still running.
+	if config.TxPoolSnapshot.Path != "" {
+		config.TxPoolSnapshot.Path = stack.ResolvePath(config.TxPoolSnapshot.Path)
+		stack.RegisterLifecycle(txpool.NewSnapshotter(config.TxPoolSnapshot, eth.txPool))
+	}
 
+	// Successful startup; push a marker and check previous unclean shutdowns.
+	// The markers of a followed database are maintained by its owner.
+	if followerDb == nil {
+		eth.shutdownTracker.MarkStartup()
+	}
 	return eth, nil
 }
 
@@ -348,6 +419,9 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "eth",
 			Service:   downloader.NewDownloaderAPI(s.handler.downloader, s.blockchain, s.eventMux),
+		}, {
+			Namespace: "eth",
+			Service:   NewBundleAPI(s),
 		}, {
 			Namespace: "admin",
 			Service:   NewAdminAPI(s),
@@ -391,19 +465,24 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 // Start implements node.Lifecycle, starting all internal goroutines needed by the
 // Ethereum protocol implementation.
 func (s *Ethereum) Start() error {
-	if err := s.setupDiscovery(); err != nil {
-		return err
-	}
-
-	// Regularly update shutdown marker
-	s.shutdownTracker.Start()
+	if s.followerDb != nil {
+		// Followers don't connect to the network, tail the followed database.
+		s.closeFollower = make(chan chan struct{})
+		go s.follow()
+	} else {
+		if err := s.setupDiscovery(); err != nil {
+			return err
+		}
 
-	// Start the networking layer
-	s.handler.Start(s.p2pServer.MaxPeers)
+		// Regularly update shutdown marker
+		s.shutdownTracker.Start()
 
-	// Start the connection manager
-	s.dropper.Start(s.p2pServer, func() bool { return !s.Synced() })
+		// Start the networking layer
+		s.handler.Start(s.p2pServer.MaxPeers)
 
+		// Start the connection manager
+		s.dropper.Start(s.p2pServer, func() bool { return !s.Synced() })
+	}
 	// start log indexer
 	s.filterMaps.Start()
 	go s.updateFilterMapsHeads()
@@ -504,9 +583,15 @@ func (s *Ethereum) setupDiscovery() error {
 // Ethereum protocol.
 func (s *Ethereum) Stop() error {
 	// Stop all the peer-related stuff first.
-	s.discmix.Close()
-	s.dropper.Stop()
-	s.handler.Stop()
+	if s.followerDb != nil {
+		ch := make(chan struct{})
+		s.closeFollower <- ch
+		<-ch
+	} else {
+		s.discmix.Close()
+		s.dropper.Stop()
+		s.handler.Stop()
+	}
 
 	// Then stop everything else.
 	ch := make(chan struct{})
@@ -518,7 +603,9 @@ func (s *Ethereum) Stop() error {
 	s.engine.Close()
 
 	// Clean shutdown marker as the last thing before closing db
-	s.shutdownTracker.Stop()
+	if s.followerDb == nil {
+		s.shutdownTracker.Stop()
+	}
 
 	s.chainDb.Close()
 	s.eventMux.Stop()
@@ -63,6 +63,12 @@ type Peer interface {
 	RequestReceipts([]common.Hash, chan *eth.Response) (*eth.Request, error)
 }
 
+// blockRangePeer is implemented by the peers announcing the range of blocks they
+// are able to serve (eth/69 and newer).
+type blockRangePeer interface {
+	BlockRange() *eth.BlockRangeUpdatePacket
+}
+
 // newPeerConnection creates a new downloader peer.
 func newPeerConnection(id string, version uint, peer Peer, logger log.Logger) *peerConnection {
 	return &peerConnection{
@@ -156,6 +162,18 @@ func (p *peerConnection) Lacks(hash common.Hash) bool {
 	return ok
 }
 
+// Serves retrieves whether the peer is able to serve the bodies and receipts of
+// the block with the given number, based on the block range it announced. Peers
+// not announcing any range are assumed to serve the entire history.
+func (p *peerConnection) Serves(number uint64) bool {
+	peer, ok := p.peer.(blockRangePeer)
+	if !ok {
+		return true
+	}
+	blockRange := peer.BlockRange()
+	return blockRange == nil || number >= blockRange.EarliestBlock
+}
+
 // peeringEvent is sent on the peer event feed when a remote peer connects or
 // disconnects.
 type peeringEvent struct {
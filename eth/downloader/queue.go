@@ -457,8 +457,9 @@ func (q *queue) reserveHeaders(p *peerConnection, count int, taskPool map[common
 		}
 		// Remove it from the task queue
 		taskQueue.PopItem()
-		// Otherwise unless the peer is known not to have the data, add to the retrieve list
-		if p.Lacks(header.Hash()) {
+		// Otherwise unless the peer is known not to have the data (or announced
+		// to have pruned it), add to the retrieve list
+		if p.Lacks(header.Hash()) || !p.Serves(header.Number.Uint64()) {
 			skip = append(skip, header)
 		} else {
 			send = append(send, header)
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
@@ -193,6 +194,53 @@ func TestBasics(t *testing.T) {
 	}
 }
 
+// rangedPeer is a download peer announcing the range of blocks it serves.
+type rangedPeer struct {
+	Peer
+	blockRange *eth.BlockRangeUpdatePacket
+}
+
+func (p *rangedPeer) BlockRange() *eth.BlockRangeUpdatePacket { return p.blockRange }
+
+// Tests that peers are not asked for the data of the blocks they announced to
+// have pruned.
+func TestPrunedPeerSkipped(t *testing.T) {
+	q := newQueue(128, 128)
+	q.Prepare(1, SnapSync)
+
+	headers := chain.headers()
+	hashes := make([]common.Hash, len(headers))
+	for i, header := range headers {
+		hashes[i] = header.Hash()
+	}
+	q.Schedule(headers, hashes, 1)
+
+	// A peer with pruned history must only be asked for the blocks it has
+	pruned := dummyPeer("pruned")
+	pruned.peer = &rangedPeer{blockRange: &eth.BlockRangeUpdatePacket{EarliestBlock: 64, LatestBlock: 128}}
+
+	fetchReq, _, _ := q.ReserveBodies(pruned, 16)
+	if fetchReq == nil {
+		t.Fatal("pruned peer should get a fetch request")
+	}
+	for _, header := range fetchReq.Headers {
+		if header.Number.Uint64() < 64 {
+			t.Fatalf("pruned peer asked for block %d", header.Number.Uint64())
+		}
+	}
+	// The skipped blocks must be retained for other peers
+	full := dummyPeer("full")
+	full.peer = &rangedPeer{blockRange: &eth.BlockRangeUpdatePacket{EarliestBlock: 0, LatestBlock: 128}}
+
+	fetchReq, _, _ = q.ReserveBodies(full, 16)
+	if fetchReq == nil {
+		t.Fatal("full peer should get a fetch request")
+	}
+	if got, exp := fetchReq.Headers[0].Number.Uint64(), uint64(1); got != exp {
+		t.Fatalf("expected header %d, got %d", exp, got)
+	}
+}
+
 func TestEmptyBlocks(t *testing.T) {
 	numOfBlocks := len(emptyChain.blocks)
 
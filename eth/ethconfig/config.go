@@ -28,6 +28,8 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
@@ -60,15 +62,19 @@ var Defaults = Config{
 	TrieCleanCache:     154,
 	TrieDirtyCache:     256,
 	TrieTimeout:        60 * time.Minute,
+	FollowInterval:     4 * time.Second,
 	SnapshotCache:      102,
 	FilterLogCacheSize: 32,
 	Miner:              miner.DefaultConfig,
 	TxPool:             legacypool.DefaultConfig,
 	BlobPool:           blobpool.DefaultConfig,
+	TxPoolSnapshot:     txpool.DefaultSnapshotConfig,
 	RPCGasCap:          50000000,
 	RPCEVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
 	RPCTxFeeCap:        1, // 1 ether
+
+	DatabaseRemoteFreezerCache: 256,
 }
 
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
@@ -104,12 +110,25 @@ type Config struct {
 	LogNoHistory         bool   `toml:",omitempty"` // No log search index is maintained.
 	LogExportCheckpoints string // export log index checkpoints to file
 	StateHistory         uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
+	HistoricStateWindow  uint64 `toml:",omitempty"` // The number of recent blocks whose state is served from the state histories (path scheme only).
+	AddressIndex         bool   `toml:",omitempty"` // Whether to maintain an index of the transactions each address appears in.
+	AddressIndexLimit    uint64 `toml:",omitempty"` // The maximum number of blocks from head whose address appearances are indexed.
+	AddressIndexInternal bool   `toml:",omitempty"` // Whether to index the participants of internal calls too.
 
 	// State scheme represents the scheme used to store ethereum states and trie
 	// nodes on top. It can be 'hash', 'path', or none which means use the scheme
 	// consistent with persistent state.
 	StateScheme string `toml:",omitempty"`
 
+	// StateFork is the state of a remote chain at a pinned block, which is used
+	// as the base layer of the local state. It is meant for simulated chains.
+	StateFork state.ForkSource `toml:"-"`
+
+	// Follow is the data directory of another node whose chain database is opened
+	// read-only and tailed every FollowInterval, instead of syncing with the network.
+	Follow         string        `toml:",omitempty"`
+	FollowInterval time.Duration `toml:",omitempty"`
+
 	// RequiredBlocks is a set of block number -> hash mappings which must be in the
 	// canonical chain of all remote peers. Setting the option makes geth verify the
 	// presence of these blocks for every new peer connection.
@@ -121,6 +140,13 @@ type Config struct {
 	DatabaseCache      int
 	DatabaseFreezer    string
 
+	// Remote chain freezer, served read-only from an S3-compatible object storage.
+	// The credentials are resolved via the default credential chain of the AWS
+	// SDK. No chain segments are moved into the remote freezer, the blocks beyond
+	// its range are kept in the key-value store.
+	DatabaseRemoteFreezer      string `toml:",omitempty"` // URL of the chain freezer directory in the bucket
+	DatabaseRemoteFreezerCache int    `toml:",omitempty"` // Memory allowance (MB) for caching remote ancient items
+
 	TrieCleanCache int
 	TrieDirtyCache int
 	TrieTimeout    time.Duration
@@ -134,8 +160,10 @@ type Config struct {
 	Miner miner.Config
 
 	// Transaction pool options
-	TxPool   legacypool.Config
-	BlobPool blobpool.Config
+	TxPool         legacypool.Config
+	BlobPool       blobpool.Config
+	TxPoolPolicy   txpool.PolicyConfig
+	TxPoolSnapshot txpool.SnapshotConfig
 
 	// Gas Price Oracle options
 	GPO gasprice.Config
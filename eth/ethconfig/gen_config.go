@@ -8,6 +8,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
@@ -17,44 +19,55 @@ import (
 // MarshalTOML marshals as TOML.
 func (c Config) MarshalTOML() (interface{}, error) {
 	type Config struct {
-		Genesis                 *core.Genesis `toml:",omitempty"`
-		NetworkId               uint64
-		SyncMode                SyncMode
-		HistoryMode             history.HistoryMode
-		EthDiscoveryURLs        []string
-		SnapDiscoveryURLs       []string
-		NoPruning               bool
-		NoPrefetch              bool
-		TxLookupLimit           uint64 `toml:",omitempty"`
-		TransactionHistory      uint64 `toml:",omitempty"`
-		LogHistory              uint64 `toml:",omitempty"`
-		LogNoHistory            bool   `toml:",omitempty"`
-		LogExportCheckpoints    string
-		StateHistory            uint64                 `toml:",omitempty"`
-		StateScheme             string                 `toml:",omitempty"`
-		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
-		SkipBcVersionCheck      bool                   `toml:"-"`
-		DatabaseHandles         int                    `toml:"-"`
-		DatabaseCache           int
-		DatabaseFreezer         string
-		TrieCleanCache          int
-		TrieDirtyCache          int
-		TrieTimeout             time.Duration
-		SnapshotCache           int
-		Preimages               bool
-		FilterLogCacheSize      int
-		Miner                   miner.Config
-		TxPool                  legacypool.Config
-		BlobPool                blobpool.Config
-		GPO                     gasprice.Config
-		EnablePreimageRecording bool
-		VMTrace                 string
-		VMTraceJsonConfig       string
-		RPCGasCap               uint64
-		RPCEVMTimeout           time.Duration
-		RPCTxFeeCap             float64
-		OverridePrague          *uint64 `toml:",omitempty"`
-		OverrideVerkle          *uint64 `toml:",omitempty"`
+		Genesis                    *core.Genesis `toml:",omitempty"`
+		NetworkId                  uint64
+		SyncMode                   SyncMode
+		HistoryMode                history.HistoryMode
+		EthDiscoveryURLs           []string
+		SnapDiscoveryURLs          []string
+		NoPruning                  bool
+		NoPrefetch                 bool
+		TxLookupLimit              uint64 `toml:",omitempty"`
+		TransactionHistory         uint64 `toml:",omitempty"`
+		LogHistory                 uint64 `toml:",omitempty"`
+		LogNoHistory               bool   `toml:",omitempty"`
+		LogExportCheckpoints       string
+		StateHistory               uint64                 `toml:",omitempty"`
+		HistoricStateWindow        uint64                 `toml:",omitempty"`
+		AddressIndex               bool                   `toml:",omitempty"`
+		AddressIndexLimit          uint64                 `toml:",omitempty"`
+		AddressIndexInternal       bool                   `toml:",omitempty"`
+		StateScheme                string                 `toml:",omitempty"`
+		StateFork                  state.ForkSource       `toml:"-"`
+		Follow                     string                 `toml:",omitempty"`
+		FollowInterval             time.Duration          `toml:",omitempty"`
+		RequiredBlocks             map[uint64]common.Hash `toml:"-"`
+		SkipBcVersionCheck         bool                   `toml:"-"`
+		DatabaseHandles            int                    `toml:"-"`
+		DatabaseCache              int
+		DatabaseFreezer            string
+		DatabaseRemoteFreezer      string `toml:",omitempty"`
+		DatabaseRemoteFreezerCache int    `toml:",omitempty"`
+		TrieCleanCache             int
+		TrieDirtyCache             int
+		TrieTimeout                time.Duration
+		SnapshotCache              int
+		Preimages                  bool
+		FilterLogCacheSize         int
+		Miner                      miner.Config
+		TxPool                     legacypool.Config
+		BlobPool                   blobpool.Config
+		TxPoolPolicy               txpool.PolicyConfig
+		TxPoolSnapshot             txpool.SnapshotConfig
+		GPO                        gasprice.Config
+		EnablePreimageRecording    bool
+		VMTrace                    string
+		VMTraceJsonConfig          string
+		RPCGasCap                  uint64
+		RPCEVMTimeout              time.Duration
+		RPCTxFeeCap                float64
+		OverridePrague             *uint64 `toml:",omitempty"`
+		OverrideVerkle             *uint64 `toml:",omitempty"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -71,12 +84,21 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.LogNoHistory = c.LogNoHistory
 	enc.LogExportCheckpoints = c.LogExportCheckpoints
 	enc.StateHistory = c.StateHistory
+	enc.HistoricStateWindow = c.HistoricStateWindow
+	enc.AddressIndex = c.AddressIndex
+	enc.AddressIndexLimit = c.AddressIndexLimit
+	enc.AddressIndexInternal = c.AddressIndexInternal
 	enc.StateScheme = c.StateScheme
+	enc.StateFork = c.StateFork
+	enc.Follow = c.Follow
+	enc.FollowInterval = c.FollowInterval
 	enc.RequiredBlocks = c.RequiredBlocks
 	enc.SkipBcVersionCheck = c.SkipBcVersionCheck
 	enc.DatabaseHandles = c.DatabaseHandles
 	enc.DatabaseCache = c.DatabaseCache
 	enc.DatabaseFreezer = c.DatabaseFreezer
+	enc.DatabaseRemoteFreezer = c.DatabaseRemoteFreezer
+	enc.DatabaseRemoteFreezerCache = c.DatabaseRemoteFreezerCache
 	enc.TrieCleanCache = c.TrieCleanCache
 	enc.TrieDirtyCache = c.TrieDirtyCache
 	enc.TrieTimeout = c.TrieTimeout
@@ -86,6 +108,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.TxPoolPolicy = c.TxPoolPolicy
+	enc.TxPoolSnapshot = c.TxPoolSnapshot
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.VMTrace = c.VMTrace
@@ -101,44 +125,55 @@ func (c Config) MarshalTOML() (interface{}, error) {
 // UnmarshalTOML unmarshals from TOML.
 func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	type Config struct {
-		Genesis                 *core.Genesis `toml:",omitempty"`
-		NetworkId               *uint64
-		SyncMode                *SyncMode
-		HistoryMode             *history.HistoryMode
-		EthDiscoveryURLs        []string
-		SnapDiscoveryURLs       []string
-		NoPruning               *bool
-		NoPrefetch              *bool
-		TxLookupLimit           *uint64 `toml:",omitempty"`
-		TransactionHistory      *uint64 `toml:",omitempty"`
-		LogHistory              *uint64 `toml:",omitempty"`
-		LogNoHistory            *bool   `toml:",omitempty"`
-		LogExportCheckpoints    *string
-		StateHistory            *uint64                `toml:",omitempty"`
-		StateScheme             *string                `toml:",omitempty"`
-		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
-		SkipBcVersionCheck      *bool                  `toml:"-"`
-		DatabaseHandles         *int                   `toml:"-"`
-		DatabaseCache           *int
-		DatabaseFreezer         *string
-		TrieCleanCache          *int
-		TrieDirtyCache          *int
-		TrieTimeout             *time.Duration
-		SnapshotCache           *int
-		Preimages               *bool
-		FilterLogCacheSize      *int
-		Miner                   *miner.Config
-		TxPool                  *legacypool.Config
-		BlobPool                *blobpool.Config
-		GPO                     *gasprice.Config
-		EnablePreimageRecording *bool
-		VMTrace                 *string
-		VMTraceJsonConfig       *string
-		RPCGasCap               *uint64
-		RPCEVMTimeout           *time.Duration
-		RPCTxFeeCap             *float64
-		OverridePrague          *uint64 `toml:",omitempty"`
-		OverrideVerkle          *uint64 `toml:",omitempty"`
+		Genesis                    *core.Genesis `toml:",omitempty"`
+		NetworkId                  *uint64
+		SyncMode                   *SyncMode
+		HistoryMode                *history.HistoryMode
+		EthDiscoveryURLs           []string
+		SnapDiscoveryURLs          []string
+		NoPruning                  *bool
+		NoPrefetch                 *bool
+		TxLookupLimit              *uint64 `toml:",omitempty"`
+		TransactionHistory         *uint64 `toml:",omitempty"`
+		LogHistory                 *uint64 `toml:",omitempty"`
+		LogNoHistory               *bool   `toml:",omitempty"`
+		LogExportCheckpoints       *string
+		StateHistory               *uint64                `toml:",omitempty"`
+		HistoricStateWindow        *uint64                `toml:",omitempty"`
+		AddressIndex               *bool                  `toml:",omitempty"`
+		AddressIndexLimit          *uint64                `toml:",omitempty"`
+		AddressIndexInternal       *bool                  `toml:",omitempty"`
+		StateScheme                *string                `toml:",omitempty"`
+		StateFork                  state.ForkSource       `toml:"-"`
+		Follow                     *string                `toml:",omitempty"`
+		FollowInterval             *time.Duration         `toml:",omitempty"`
+		RequiredBlocks             map[uint64]common.Hash `toml:"-"`
+		SkipBcVersionCheck         *bool                  `toml:"-"`
+		DatabaseHandles            *int                   `toml:"-"`
+		DatabaseCache              *int
+		DatabaseFreezer            *string
+		DatabaseRemoteFreezer      *string `toml:",omitempty"`
+		DatabaseRemoteFreezerCache *int    `toml:",omitempty"`
+		TrieCleanCache             *int
+		TrieDirtyCache             *int
+		TrieTimeout                *time.Duration
+		SnapshotCache              *int
+		Preimages                  *bool
+		FilterLogCacheSize         *int
+		Miner                      *miner.Config
+		TxPool                     *legacypool.Config
+		BlobPool                   *blobpool.Config
+		TxPoolPolicy               *txpool.PolicyConfig
+		TxPoolSnapshot             *txpool.SnapshotConfig
+		GPO                        *gasprice.Config
+		EnablePreimageRecording    *bool
+		VMTrace                    *string
+		VMTraceJsonConfig          *string
+		RPCGasCap                  *uint64
+		RPCEVMTimeout              *time.Duration
+		RPCTxFeeCap                *float64
+		OverridePrague             *uint64 `toml:",omitempty"`
+		OverrideVerkle             *uint64 `toml:",omitempty"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -186,9 +221,30 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.StateHistory != nil {
 		c.StateHistory = *dec.StateHistory
 	}
+	if dec.HistoricStateWindow != nil {
+		c.HistoricStateWindow = *dec.HistoricStateWindow
+	}
+	if dec.AddressIndex != nil {
+		c.AddressIndex = *dec.AddressIndex
+	}
+	if dec.AddressIndexLimit != nil {
+		c.AddressIndexLimit = *dec.AddressIndexLimit
+	}
+	if dec.AddressIndexInternal != nil {
+		c.AddressIndexInternal = *dec.AddressIndexInternal
+	}
 	if dec.StateScheme != nil {
 		c.StateScheme = *dec.StateScheme
 	}
+	if dec.StateFork != nil {
+		c.StateFork = dec.StateFork
+	}
+	if dec.Follow != nil {
+		c.Follow = *dec.Follow
+	}
+	if dec.FollowInterval != nil {
+		c.FollowInterval = *dec.FollowInterval
+	}
 	if dec.RequiredBlocks != nil {
 		c.RequiredBlocks = dec.RequiredBlocks
 	}
@@ -204,6 +260,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.DatabaseFreezer != nil {
 		c.DatabaseFreezer = *dec.DatabaseFreezer
 	}
+	if dec.DatabaseRemoteFreezer != nil {
+		c.DatabaseRemoteFreezer = *dec.DatabaseRemoteFreezer
+	}
+	if dec.DatabaseRemoteFreezerCache != nil {
+		c.DatabaseRemoteFreezerCache = *dec.DatabaseRemoteFreezerCache
+	}
 	if dec.TrieCleanCache != nil {
 		c.TrieCleanCache = *dec.TrieCleanCache
 	}
@@ -231,6 +293,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.TxPoolPolicy != nil {
+		c.TxPoolPolicy = *dec.TxPoolPolicy
+	}
+	if dec.TxPoolSnapshot != nil {
+		c.TxPoolSnapshot = *dec.TxPoolSnapshot
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -40,6 +41,11 @@ var (
 	errInvalidBlockRange      = errors.New("invalid block range params")
 	errPendingLogsUnsupported = errors.New("pending logs are not supported")
 	errExceedMaxTopics        = errors.New("exceed max topics")
+
+	errExceedMaxReceiptCriteria = errors.New("exceed max transaction hashes or addresses")
+	errInvalidLogsCursor        = errors.New("invalid logs cursor")
+	errLogsCursorReorged        = errors.New("logs cursor invalidated by chain reorg")
+	errLogsCursorMismatch       = errors.New("logs cursor does not match the filter criteria")
 )
 
 // The maximum number of topic criteria allowed, vm.LOG4 - vm.LOG0
@@ -48,6 +54,15 @@ const maxTopics = 4
 // The maximum number of allowed topics within a topic criteria
 const maxSubTopics = 1000
 
+// The maximum number of transaction hashes or addresses within a receipt criteria
+const maxReceiptCriteria = 1000
+
+// The default and maximum number of logs returned by a single eth_getLogsPage call
+const (
+	defaultLogsPageLimit = 1000
+	maxLogsPageLimit     = 10000
+)
+
 // filter is a helper struct that holds meta information over the filter type
 // and associated subscription in the event system.
 type filter struct {
@@ -289,6 +304,146 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 	return rpcSub, nil
 }
 
+// TransactionReceiptsQuery represents the criteria of a transaction receipts
+// subscription. A receipt is delivered if it matches all the non-empty criteria,
+// an empty query matching the receipts of all transactions.
+type TransactionReceiptsQuery struct {
+	TransactionHashes []common.Hash    `json:"transactionHashes"` // Transactions to deliver the receipts of
+	From              []common.Address `json:"from"`              // Senders of the transactions
+	To                []common.Address `json:"to"`                // Recipients of the transactions, or the created contracts
+}
+
+// TransactionReceipts creates a subscription that fires with the receipts of the
+// transactions included in each imported block, optionally filtered by the given
+// criteria. Each notification holds the matching receipts of a single block.
+//
+// In case blocks are reorged out of the canonical chain, the previously sent
+// receipts are sent again with the removed property set to true, latest block
+// first, before the receipts of the blocks of the new chain.
+func (api *FilterAPI) TransactionReceipts(ctx context.Context, crit *TransactionReceiptsQuery) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	if crit == nil {
+		crit = new(TransactionReceiptsQuery)
+	}
+	var (
+		rpcSub   = notifier.CreateSubscription()
+		receipts = make(chan []*ReceiptWithTx)
+	)
+	receiptsSub, err := api.events.SubscribeTransactionReceipts(*crit, receipts)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer receiptsSub.Unsubscribe()
+
+		signer := types.LatestSigner(api.sys.backend.ChainConfig())
+		for {
+			select {
+			case receipts := <-receipts:
+				marshalled := make([]map[string]interface{}, 0, len(receipts))
+				for _, r := range receipts {
+					fields := ethapi.MarshalReceipt(r.Receipt, r.Receipt.BlockHash, r.Receipt.BlockNumber.Uint64(), signer, r.Transaction, int(r.Receipt.TransactionIndex))
+					fields["removed"] = r.Removed
+					marshalled = append(marshalled, fields)
+				}
+				notifier.Notify(rpcSub.ID, marshalled)
+			case <-rpcSub.Err(): // client send an unsubscribe request
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// TransactionLifecycleQuery represents the criteria of a transaction lifecycle
+// subscription. An empty query matches the transactions of all senders.
+type TransactionLifecycleQuery struct {
+	From []common.Address `json:"from"` // Senders of the transactions
+}
+
+// TransactionLifecycleEvent is a state transition of a transaction delivered to
+// the lifecycle subscribers.
+type TransactionLifecycleEvent struct {
+	Hash        common.Hash              `json:"hash"`
+	From        common.Address           `json:"from"`
+	Nonce       hexutil.Uint64           `json:"nonce"`
+	Status      txpool.TxLifecycleStatus `json:"status"`
+	ReplacedBy  *common.Hash             `json:"replacedBy,omitempty"`
+	Reason      string                   `json:"reason,omitempty"`
+	BlockHash   *common.Hash             `json:"blockHash,omitempty"`
+	BlockNumber *hexutil.Uint64          `json:"blockNumber,omitempty"`
+}
+
+// newTransactionLifecycleEvent converts a lifecycle event into its RPC form.
+func newTransactionLifecycleEvent(ev *txpool.TxLifecycleEvent) *TransactionLifecycleEvent {
+	result := &TransactionLifecycleEvent{
+		Hash:   ev.Hash,
+		From:   ev.From,
+		Nonce:  hexutil.Uint64(ev.Nonce),
+		Status: ev.Status,
+		Reason: ev.Reason,
+	}
+	if ev.Status == txpool.TxLifecycleReplaced {
+		result.ReplacedBy = &ev.ReplacedBy
+	}
+	if ev.Status == txpool.TxLifecycleIncluded || ev.Status == txpool.TxLifecycleReorged {
+		number := hexutil.Uint64(ev.BlockNumber)
+		result.BlockHash, result.BlockNumber = &ev.BlockHash, &number
+	}
+	return result
+}
+
+// TransactionLifecycle creates a subscription that fires with the state
+// transitions of transactions, optionally filtered by sender: entering the pool
+// queued or pending, being replaced or dropped, and being included in or reorged
+// out of the canonical chain.
+//
+// Inclusions are reported for all transactions of the imported blocks, even if
+// they were never seen by the pool. Transactions invalidated by the inclusion of
+// another one with the same nonce leave the pool silently, the inclusion of the
+// other transaction being reported instead.
+func (api *FilterAPI) TransactionLifecycle(ctx context.Context, crit *TransactionLifecycleQuery) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	if crit == nil {
+		crit = new(TransactionLifecycleQuery)
+	}
+	var (
+		rpcSub = notifier.CreateSubscription()
+		events = make(chan []*txpool.TxLifecycleEvent)
+	)
+	lifecycleSub, err := api.events.SubscribeTransactionLifecycle(*crit, events)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer lifecycleSub.Unsubscribe()
+
+		for {
+			select {
+			case events := <-events:
+				marshalled := make([]*TransactionLifecycleEvent, len(events))
+				for i, ev := range events {
+					marshalled[i] = newTransactionLifecycleEvent(ev)
+				}
+				notifier.Notify(rpcSub.ID, marshalled)
+			case <-rpcSub.Err(): // client send an unsubscribe request
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // FilterCriteria represents a request to create a new filter.
 // Same as ethereum.FilterQuery but with UnmarshalJSON() method.
 type FilterCriteria ethereum.FilterQuery
@@ -373,6 +528,69 @@ func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*type
 	return returnLogs(logs), err
 }
 
+// LogsPageOptions are the pagination options of GetLogsPage.
+type LogsPageOptions struct {
+	Cursor hexutil.Bytes `json:"cursor"`
+	Limit  *hexutil.Uint `json:"limit"`
+}
+
+// LogsPage is a page of logs returned by GetLogsPage.
+type LogsPage struct {
+	Logs   []*types.Log  `json:"logs"`
+	Cursor hexutil.Bytes `json:"cursor,omitempty"`
+}
+
+// GetLogsPage returns a page of logs matching the given range criteria, along
+// with an opaque cursor which can be passed in a subsequent call with the same
+// criteria to retrieve the next page. The cursor is omitted once the whole range
+// has been searched. A cursor is invalidated if the chain reorganises past the
+// position it refers to.
+func (api *FilterAPI) GetLogsPage(ctx context.Context, crit FilterCriteria, opts *LogsPageOptions) (*LogsPage, error) {
+	if len(crit.Topics) > maxTopics {
+		return nil, errExceedMaxTopics
+	}
+	if crit.BlockHash != nil {
+		return nil, errors.New("block hash criteria cannot be paginated")
+	}
+	var (
+		cursor []byte
+		limit  = defaultLogsPageLimit
+	)
+	if opts != nil {
+		if opts.Limit != nil {
+			if *opts.Limit == 0 || *opts.Limit > maxLogsPageLimit {
+				return nil, fmt.Errorf("page limit must be between 1 and %d", maxLogsPageLimit)
+			}
+			limit = int(*opts.Limit)
+		}
+		if opts.Cursor != nil {
+			cursor = opts.Cursor
+		}
+	}
+	// Convert the RPC block numbers into internal representations
+	begin := rpc.LatestBlockNumber.Int64()
+	if crit.FromBlock != nil {
+		begin = crit.FromBlock.Int64()
+	}
+	end := rpc.LatestBlockNumber.Int64()
+	if crit.ToBlock != nil {
+		end = crit.ToBlock.Int64()
+	}
+	// Block numbers below 0 are special cases.
+	if begin > 0 && end > 0 && begin > end {
+		return nil, errInvalidBlockRange
+	}
+	if begin > 0 && begin < int64(api.events.backend.HistoryPruningCutoff()) {
+		return nil, &history.PrunedHistoryError{}
+	}
+	filter := api.sys.NewRangeFilter(begin, end, crit.Addresses, crit.Topics)
+	logs, next, err := filter.LogsPage(ctx, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &LogsPage{Logs: returnLogs(logs), Cursor: next}, nil
+}
+
 // UninstallFilter removes the filter with the given filter id.
 func (api *FilterAPI) UninstallFilter(id rpc.ID) bool {
 	api.filtersMu.Lock()
@@ -18,6 +18,7 @@ package filters
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"math"
 	"math/big"
@@ -28,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -98,51 +100,292 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 		return nil, errPendingLogsUnsupported
 	}
 
-	resolveSpecial := func(number int64) (uint64, error) {
-		switch number {
-		case rpc.LatestBlockNumber.Int64():
-			// when searching from and/or until the current head, we resolve it
-			// to MaxUint64 which is translated by rangeLogs to the actual head
-			// in each iteration, ensuring that the head block will be searched
-			// even if the chain is updated during search.
-			return math.MaxUint64, nil
-		case rpc.FinalizedBlockNumber.Int64():
-			hdr, _ := f.sys.backend.HeaderByNumber(ctx, rpc.FinalizedBlockNumber)
-			if hdr == nil {
-				return 0, errors.New("finalized header not found")
+	// range query need to resolve the special begin/end block number
+	begin, err := f.resolveBlockNumber(ctx, f.begin)
+	if err != nil {
+		return nil, err
+	}
+	end, err := f.resolveBlockNumber(ctx, f.end)
+	if err != nil {
+		return nil, err
+	}
+	return f.rangeLogs(ctx, begin, end)
+}
+
+// resolveBlockNumber resolves the special block numbers of a range query.
+func (f *Filter) resolveBlockNumber(ctx context.Context, number int64) (uint64, error) {
+	switch number {
+	case rpc.LatestBlockNumber.Int64():
+		// when searching from and/or until the current head, we resolve it
+		// to MaxUint64 which is translated by rangeLogs to the actual head
+		// in each iteration, ensuring that the head block will be searched
+		// even if the chain is updated during search.
+		return math.MaxUint64, nil
+	case rpc.FinalizedBlockNumber.Int64():
+		hdr, _ := f.sys.backend.HeaderByNumber(ctx, rpc.FinalizedBlockNumber)
+		if hdr == nil {
+			return 0, errors.New("finalized header not found")
+		}
+		return hdr.Number.Uint64(), nil
+	case rpc.SafeBlockNumber.Int64():
+		hdr, _ := f.sys.backend.HeaderByNumber(ctx, rpc.SafeBlockNumber)
+		if hdr == nil {
+			return 0, errors.New("safe header not found")
+		}
+		return hdr.Number.Uint64(), nil
+	case rpc.EarliestBlockNumber.Int64():
+		earliest := f.sys.backend.HistoryPruningCutoff()
+		hdr, _ := f.sys.backend.HeaderByNumber(ctx, rpc.BlockNumber(earliest))
+		if hdr == nil {
+			return 0, errors.New("earliest header not found")
+		}
+		return hdr.Number.Uint64(), nil
+	default:
+		if number < 0 {
+			return 0, errors.New("negative block number")
+		}
+		return uint64(number), nil
+	}
+}
+
+const (
+	logsCursorVersion = 2
+	logsCursorLength  = 94 // version, flags, block, hash, log index, lv pointer, end block, criteria hash
+
+	logsCursorInBlock   = 1 << 0 // hash belongs to the cursor block instead of its parent
+	logsCursorLvPointer = 1 << 1 // log value pointer of the cursor block is present
+
+	logsPageWindow     = 1000  // number of blocks searched in a single iteration
+	logsPageBlockLimit = 10000 // number of blocks searched during a single page request
+)
+
+// logsCursor is the decoded form of the continuation token returned by LogsPage.
+// It points at the first log which has not been returned yet, identified by the
+// block number and the index of the log within the block. The hash of either the
+// cursor block (when resuming in the middle of a block) or its parent is stored
+// in order to detect reorgs between page requests. If the cursor block was
+// covered by the log index, its log value pointer is also stored so that the
+// position in the filter maps can be verified on resumption. The cursor is bound
+// to the criteria of the filter it was created by.
+type logsCursor struct {
+	flags     byte
+	block     uint64
+	hash      common.Hash
+	logIndex  uint32
+	lvPointer uint64
+	end       uint64      // last block of the search range, fixed by the first request
+	criteria  common.Hash // hash of the filter criteria, see Filter.criteriaHash
+}
+
+// encode serializes the cursor into an opaque token.
+func (c *logsCursor) encode() []byte {
+	enc := make([]byte, logsCursorLength)
+	enc[0] = logsCursorVersion
+	enc[1] = c.flags
+	binary.BigEndian.PutUint64(enc[2:10], c.block)
+	copy(enc[10:42], c.hash[:])
+	binary.BigEndian.PutUint32(enc[42:46], c.logIndex)
+	binary.BigEndian.PutUint64(enc[46:54], c.lvPointer)
+	binary.BigEndian.PutUint64(enc[54:62], c.end)
+	copy(enc[62:94], c.criteria[:])
+	return enc
+}
+
+// decodeLogsCursor parses a token created by logsCursor.encode.
+func decodeLogsCursor(enc []byte) (*logsCursor, error) {
+	if len(enc) != logsCursorLength || enc[0] != logsCursorVersion {
+		return nil, errInvalidLogsCursor
+	}
+	c := &logsCursor{
+		flags:     enc[1],
+		block:     binary.BigEndian.Uint64(enc[2:10]),
+		hash:      common.BytesToHash(enc[10:42]),
+		logIndex:  binary.BigEndian.Uint32(enc[42:46]),
+		lvPointer: binary.BigEndian.Uint64(enc[46:54]),
+		end:       binary.BigEndian.Uint64(enc[54:62]),
+		criteria:  common.BytesToHash(enc[62:94]),
+	}
+	if c.block > c.end || (c.flags&logsCursorInBlock == 0 && (c.block == 0 || c.logIndex != 0)) {
+		return nil, errInvalidLogsCursor
+	}
+	return c, nil
+}
+
+// criteriaHash returns a hash of the range and the address and topic criteria
+// of the filter, which binds a logs cursor to the filter it was created by.
+func (f *Filter) criteriaHash() common.Hash {
+	var (
+		hasher = crypto.NewKeccakState()
+		buf    [8]byte
+	)
+	putUint := func(v uint64) {
+		binary.BigEndian.PutUint64(buf[:], v)
+		hasher.Write(buf[:])
+	}
+	putUint(uint64(f.begin))
+	putUint(uint64(f.end))
+	putUint(uint64(len(f.addresses)))
+	for _, addr := range f.addresses {
+		hasher.Write(addr[:])
+	}
+	putUint(uint64(len(f.topics)))
+	for _, sub := range f.topics {
+		putUint(uint64(len(sub)))
+		for _, topic := range sub {
+			hasher.Write(topic[:])
+		}
+	}
+	var hash common.Hash
+	hasher.Read(hash[:])
+	return hash
+}
+
+// LogsPage searches the range of a range filter for matching log entries,
+// returning at most limit of them along with a cursor to resume the search
+// from. If cursor is nil, the search starts at the beginning of the range and
+// the end of the range is fixed to the current head in case it refers to the
+// latest block. The returned cursor is nil once the end of the range is reached,
+// and is rejected by filters with different criteria.
+//
+// A single request searches a limited number of blocks, so the returned page
+// might be shorter than the limit even if there are more matches in the range.
+func (f *Filter) LogsPage(ctx context.Context, cursor []byte, limit int) ([]*types.Log, []byte, error) {
+	if f.block != nil {
+		return nil, nil, errors.New("block filters cannot be paginated")
+	}
+	if f.begin == rpc.PendingBlockNumber.Int64() || f.end == rpc.PendingBlockNumber.Int64() {
+		return nil, nil, errPendingLogsUnsupported
+	}
+	if limit <= 0 {
+		return nil, nil, errors.New("invalid page limit")
+	}
+	view := f.sys.backend.CurrentView()
+	if view == nil {
+		return nil, nil, errors.New("head block not available")
+	}
+	mb := f.sys.backend.NewMatcherBackend()
+	defer mb.Close()
+
+	syncRange, err := mb.SyncLogIndex(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Determine the position to start from, either based on the filter range or
+	// by resuming from a previously returned cursor.
+	var (
+		criteria  = f.criteriaHash()
+		next, end uint64
+		skip      uint32 // number of leading logs to skip in the first block
+	)
+	if cursor == nil {
+		if next, err = f.resolveBlockNumber(ctx, f.begin); err != nil {
+			return nil, nil, err
+		}
+		if end, err = f.resolveBlockNumber(ctx, f.end); err != nil {
+			return nil, nil, err
+		}
+		if next == math.MaxUint64 {
+			next = view.HeadNumber()
+		}
+		if end == math.MaxUint64 {
+			end = view.HeadNumber()
+		}
+		if next > end {
+			return nil, nil, errInvalidBlockRange
+		}
+	} else {
+		c, err := decodeLogsCursor(cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		if c.criteria != criteria {
+			return nil, nil, errLogsCursorMismatch
+		}
+		anchor := c.block - 1
+		if c.flags&logsCursorInBlock != 0 {
+			anchor = c.block
+		}
+		if anchor > view.HeadNumber() || view.BlockHash(anchor) != c.hash {
+			return nil, nil, errLogsCursorReorged
+		}
+		if c.flags&logsCursorLvPointer != 0 && syncRange.IndexedBlocks.Includes(c.block) {
+			lvPointer, err := mb.GetBlockLvPointer(ctx, c.block)
+			if err != nil {
+				return nil, nil, err
 			}
-			return hdr.Number.Uint64(), nil
-		case rpc.SafeBlockNumber.Int64():
-			hdr, _ := f.sys.backend.HeaderByNumber(ctx, rpc.SafeBlockNumber)
-			if hdr == nil {
-				return 0, errors.New("safe header not found")
+			if lvPointer != c.lvPointer {
+				return nil, nil, errLogsCursorReorged
 			}
-			return hdr.Number.Uint64(), nil
-		case rpc.EarliestBlockNumber.Int64():
-			earliest := f.sys.backend.HistoryPruningCutoff()
-			hdr, _ := f.sys.backend.HeaderByNumber(ctx, rpc.BlockNumber(earliest))
-			if hdr == nil {
-				return 0, errors.New("earliest header not found")
+		}
+		next, end, skip = c.block, c.end, c.logIndex
+	}
+	// Search the range window by window until the page is filled up or the block
+	// limit of the request is reached.
+	var (
+		logs   []*types.Log
+		budget = uint64(logsPageBlockLimit)
+	)
+	for next <= end && budget > 0 {
+		last := min(end, next+min(budget, logsPageWindow)-1)
+		found, err := f.rangeLogs(ctx, next, last)
+		if err != nil {
+			return nil, nil, err
+		}
+		for len(found) > 0 && found[0].BlockNumber == next && found[0].Index < uint(skip) {
+			found = found[1:]
+		}
+		skip = 0
+
+		if len(logs)+len(found) > limit {
+			found = found[:limit-len(logs)+1]
+			logs = append(logs, found[:len(found)-1]...)
+
+			first := found[len(found)-1]
+			c := &logsCursor{
+				flags:    logsCursorInBlock,
+				block:    first.BlockNumber,
+				hash:     first.BlockHash,
+				logIndex: uint32(first.Index),
+				end:      end,
+				criteria: criteria,
 			}
-			return hdr.Number.Uint64(), nil
-		default:
-			if number < 0 {
-				return 0, errors.New("negative block number")
+			if err := c.setLvPointer(ctx, mb, syncRange); err != nil {
+				return nil, nil, err
 			}
-			return uint64(number), nil
+			return logs, c.encode(), nil
 		}
+		logs = append(logs, found...)
+		budget -= last + 1 - next
+		next = last + 1
+	}
+	if next > end {
+		return logs, nil, nil
+	}
+	c := &logsCursor{
+		block:    next,
+		hash:     view.BlockHash(next - 1),
+		end:      end,
+		criteria: criteria,
 	}
+	if err := c.setLvPointer(ctx, mb, syncRange); err != nil {
+		return nil, nil, err
+	}
+	return logs, c.encode(), nil
+}
 
-	// range query need to resolve the special begin/end block number
-	begin, err := resolveSpecial(f.begin)
-	if err != nil {
-		return nil, err
+// setLvPointer stores the log value pointer of the cursor block if it is covered
+// by the log index.
+func (c *logsCursor) setLvPointer(ctx context.Context, mb filtermaps.MatcherBackend, syncRange filtermaps.SyncRange) error {
+	if !syncRange.IndexedBlocks.Includes(c.block) {
+		return nil
 	}
-	end, err := resolveSpecial(f.end)
+	lvPointer, err := mb.GetBlockLvPointer(ctx, c.block)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return f.rangeLogs(ctx, begin, end)
+	c.flags |= logsCursorLvPointer
+	c.lvPointer = lvPointer
+	return nil
 }
 
 const (
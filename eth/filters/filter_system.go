@@ -21,6 +21,7 @@ package filters
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
@@ -70,6 +72,7 @@ type Backend interface {
 	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
 	SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
+	SubscribeTxLifecycleEvent(ch chan<- []*txpool.TxLifecycleEvent) event.Subscription
 
 	CurrentView() *filtermaps.ChainView
 	NewMatcherBackend() filtermaps.MatcherBackend
@@ -156,6 +159,12 @@ const (
 	PendingTransactionsSubscription
 	// BlocksSubscription queries hashes for blocks that are imported
 	BlocksSubscription
+	// TransactionReceiptsSubscription queries for the receipts of transactions
+	// included in new blocks, or removed (chain reorg)
+	TransactionReceiptsSubscription
+	// TransactionLifecycleSubscription queries for the state transitions of
+	// transactions in the pool and the chain
+	TransactionLifecycleSubscription
 	// LastIndexSubscription keeps track of the last index
 	LastIndexSubscription
 )
@@ -170,18 +179,58 @@ const (
 	logsChanSize = 10
 	// chainEvChanSize is the size of channel listening to ChainEvent.
 	chainEvChanSize = 10
+	// receiptsReorgLimit is the number of recently delivered blocks tracked for
+	// reporting the removed receipts and transactions on a reorg, and the maximum
+	// number of blocks of a new chain which are delivered.
+	receiptsReorgLimit = 128
+	// receiptReqChanSize is the number of chain heads queued for assembling the
+	// blocks delivered to the receipt and lifecycle subscriptions.
+	receiptReqChanSize = 16
 )
 
 type subscription struct {
-	id        rpc.ID
-	typ       Type
-	created   time.Time
-	logsCrit  ethereum.FilterQuery
-	logs      chan []*types.Log
-	txs       chan []*types.Transaction
-	headers   chan *types.Header
-	installed chan struct{} // closed when the filter is installed
-	err       chan error    // closed when the filter is uninstalled
+	id           rpc.ID
+	typ          Type
+	created      time.Time
+	logsCrit     ethereum.FilterQuery
+	logs         chan []*types.Log
+	txs          chan []*types.Transaction
+	headers      chan *types.Header
+	receiptsCrit TransactionReceiptsQuery
+	receipts     chan []*ReceiptWithTx
+	lifeCrit     TransactionLifecycleQuery
+	lifecycle    chan []*txpool.TxLifecycleEvent
+	chainFrom    uint64        // number of the first block whose receipts or transactions were delivered (0 = none yet)
+	installed    chan struct{} // closed when the filter is installed
+	err          chan error    // closed when the filter is uninstalled
+}
+
+// ReceiptWithTx is a receipt delivered to the subscribers, along with the
+// transaction it belongs to. Receipts of blocks reorged out of the canonical
+// chain are delivered again, marked as removed.
+type ReceiptWithTx struct {
+	Receipt     *types.Receipt
+	Transaction *types.Transaction
+	Removed     bool
+}
+
+// receiptRequest asks the receipt loop to assemble the blocks to deliver for a
+// new chain head.
+type receiptRequest struct {
+	head     *types.Header
+	receipts bool   // whether the receipts are needed besides the transactions
+	epoch    uint64 // incremented whenever the tracked blocks are to be discarded
+}
+
+// receiptBlock is a block prepared for delivery to the receipt and lifecycle
+// subscriptions.
+type receiptBlock struct {
+	header    *types.Header
+	removed   bool
+	signer    types.Signer
+	txs       []*types.Transaction
+	receipts  types.Receipts             // nil if not requested or unavailable
+	lifecycle []*txpool.TxLifecycleEvent // inclusion or removal of the transactions
 }
 
 // EventSystem creates subscriptions, processes events and broadcasts them to the
@@ -191,18 +240,28 @@ type EventSystem struct {
 	sys     *FilterSystem
 
 	// Subscriptions
-	txsSub    event.Subscription // Subscription for new transaction event
-	logsSub   event.Subscription // Subscription for new log event
-	rmLogsSub event.Subscription // Subscription for removed log event
-	chainSub  event.Subscription // Subscription for new chain event
+	txsSub       event.Subscription // Subscription for new transaction event
+	logsSub      event.Subscription // Subscription for new log event
+	rmLogsSub    event.Subscription // Subscription for removed log event
+	chainSub     event.Subscription // Subscription for new chain event
+	lifecycleSub event.Subscription // Subscription for transaction lifecycle event, only while filtered (owned by the event loop)
 
 	// Channels
-	install   chan *subscription         // install filter for event notification
-	uninstall chan *subscription         // remove filter for event notification
-	txsCh     chan core.NewTxsEvent      // Channel to receive new transactions event
-	logsCh    chan []*types.Log          // Channel to receive new log event
-	rmLogsCh  chan core.RemovedLogsEvent // Channel to receive removed log event
-	chainCh   chan core.ChainEvent       // Channel to receive new chain event
+	install     chan *subscription              // install filter for event notification
+	uninstall   chan *subscription              // remove filter for event notification
+	txsCh       chan core.NewTxsEvent           // Channel to receive new transactions event
+	logsCh      chan []*types.Log               // Channel to receive new log event
+	rmLogsCh    chan core.RemovedLogsEvent      // Channel to receive removed log event
+	chainCh     chan core.ChainEvent            // Channel to receive new chain event
+	lifecycleCh chan []*txpool.TxLifecycleEvent // Channel to receive transaction lifecycle event
+
+	// Receipt and lifecycle subscriptions need to retrieve the blocks to deliver
+	// from the database, which is done by a separate loop.
+	receiptReqCh chan receiptRequest  // Channel to request the blocks of a new chain head
+	receiptCh    chan []*receiptBlock // Channel to receive the blocks to deliver
+	receiptEpoch uint64               // Epoch of the tracked blocks, owned by the event loop
+	receiptHeads []*types.Header      // Recently delivered blocks, oldest first, owned by the receipt loop
+	quit         chan struct{}        // Closed when the event loop terminates
 }
 
 // NewEventSystem creates a new manager that listens for event on the given mux,
@@ -213,14 +272,19 @@ type EventSystem struct {
 // or by stopping the given mux.
 func NewEventSystem(sys *FilterSystem) *EventSystem {
 	m := &EventSystem{
-		sys:       sys,
-		backend:   sys.backend,
-		install:   make(chan *subscription),
-		uninstall: make(chan *subscription),
-		txsCh:     make(chan core.NewTxsEvent, txChanSize),
-		logsCh:    make(chan []*types.Log, logsChanSize),
-		rmLogsCh:  make(chan core.RemovedLogsEvent, rmLogsChanSize),
-		chainCh:   make(chan core.ChainEvent, chainEvChanSize),
+		sys:         sys,
+		backend:     sys.backend,
+		install:     make(chan *subscription),
+		uninstall:   make(chan *subscription),
+		txsCh:       make(chan core.NewTxsEvent, txChanSize),
+		logsCh:      make(chan []*types.Log, logsChanSize),
+		rmLogsCh:    make(chan core.RemovedLogsEvent, rmLogsChanSize),
+		chainCh:     make(chan core.ChainEvent, chainEvChanSize),
+		lifecycleCh: make(chan []*txpool.TxLifecycleEvent, txChanSize),
+
+		receiptReqCh: make(chan receiptRequest, receiptReqChanSize),
+		receiptCh:    make(chan []*receiptBlock),
+		quit:         make(chan struct{}),
 	}
 
 	// Subscribe events
@@ -229,12 +293,15 @@ func NewEventSystem(sys *FilterSystem) *EventSystem {
 	m.rmLogsSub = m.backend.SubscribeRemovedLogsEvent(m.rmLogsCh)
 	m.chainSub = m.backend.SubscribeChainEvent(m.chainCh)
 
-	// Make sure none of the subscriptions are empty
+	// Make sure none of the subscriptions are empty. The lifecycle events are
+	// only subscribed to while filtered, as the pool skips assembling them if
+	// nobody is listening.
 	if m.txsSub == nil || m.logsSub == nil || m.rmLogsSub == nil || m.chainSub == nil {
 		log.Crit("Subscribe for event system failed")
 	}
 
 	go m.eventLoop()
+	go m.receiptLoop()
 	return m
 }
 
@@ -266,6 +333,8 @@ func (sub *Subscription) Unsubscribe() {
 			case <-sub.f.logs:
 			case <-sub.f.txs:
 			case <-sub.f.headers:
+			case <-sub.f.receipts:
+			case <-sub.f.lifecycle:
 			}
 		}
 
@@ -341,6 +410,8 @@ func (es *EventSystem) subscribeLogs(crit ethereum.FilterQuery, logs chan []*typ
 		logs:      logs,
 		txs:       make(chan []*types.Transaction),
 		headers:   make(chan *types.Header),
+		receipts:  make(chan []*ReceiptWithTx),
+		lifecycle: make(chan []*txpool.TxLifecycleEvent),
 		installed: make(chan struct{}),
 		err:       make(chan error),
 	}
@@ -357,6 +428,8 @@ func (es *EventSystem) SubscribeNewHeads(headers chan *types.Header) *Subscripti
 		logs:      make(chan []*types.Log),
 		txs:       make(chan []*types.Transaction),
 		headers:   headers,
+		receipts:  make(chan []*ReceiptWithTx),
+		lifecycle: make(chan []*txpool.TxLifecycleEvent),
 		installed: make(chan struct{}),
 		err:       make(chan error),
 	}
@@ -373,12 +446,61 @@ func (es *EventSystem) SubscribePendingTxs(txs chan []*types.Transaction) *Subsc
 		logs:      make(chan []*types.Log),
 		txs:       txs,
 		headers:   make(chan *types.Header),
+		receipts:  make(chan []*ReceiptWithTx),
+		lifecycle: make(chan []*txpool.TxLifecycleEvent),
 		installed: make(chan struct{}),
 		err:       make(chan error),
 	}
 	return es.subscribe(sub)
 }
 
+// SubscribeTransactionReceipts creates a subscription that writes the receipts of
+// the transactions matching the given criteria to the given channel, as blocks
+// are imported. If blocks are reorged out of the chain, their receipts are
+// written again, marked as removed.
+func (es *EventSystem) SubscribeTransactionReceipts(crit TransactionReceiptsQuery, receipts chan []*ReceiptWithTx) (*Subscription, error) {
+	if len(crit.TransactionHashes) > maxReceiptCriteria || len(crit.From) > maxReceiptCriteria || len(crit.To) > maxReceiptCriteria {
+		return nil, errExceedMaxReceiptCriteria
+	}
+	sub := &subscription{
+		id:           rpc.NewID(),
+		typ:          TransactionReceiptsSubscription,
+		created:      time.Now(),
+		logs:         make(chan []*types.Log),
+		txs:          make(chan []*types.Transaction),
+		headers:      make(chan *types.Header),
+		receiptsCrit: crit,
+		receipts:     receipts,
+		lifecycle:    make(chan []*txpool.TxLifecycleEvent),
+		installed:    make(chan struct{}),
+		err:          make(chan error),
+	}
+	return es.subscribe(sub), nil
+}
+
+// SubscribeTransactionLifecycle creates a subscription that writes the state
+// transitions of the transactions matching the given criteria to the given
+// channel, as they move through the transaction pool and into the chain.
+func (es *EventSystem) SubscribeTransactionLifecycle(crit TransactionLifecycleQuery, events chan []*txpool.TxLifecycleEvent) (*Subscription, error) {
+	if len(crit.From) > maxReceiptCriteria {
+		return nil, errExceedMaxReceiptCriteria
+	}
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       TransactionLifecycleSubscription,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		txs:       make(chan []*types.Transaction),
+		headers:   make(chan *types.Header),
+		receipts:  make(chan []*ReceiptWithTx),
+		lifeCrit:  crit,
+		lifecycle: events,
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub), nil
+}
+
 type filterIndex map[Type]map[rpc.ID]*subscription
 
 func (es *EventSystem) handleLogs(filters filterIndex, ev []*types.Log) {
@@ -403,16 +525,299 @@ func (es *EventSystem) handleChainEvent(filters filterIndex, ev core.ChainEvent)
 	for _, f := range filters[BlocksSubscription] {
 		f.headers <- ev.Header
 	}
+	if len(filters[TransactionReceiptsSubscription]) > 0 || len(filters[TransactionLifecycleSubscription]) > 0 {
+		req := receiptRequest{
+			head:     ev.Header,
+			receipts: len(filters[TransactionReceiptsSubscription]) > 0,
+			epoch:    es.receiptEpoch,
+		}
+		select {
+		case es.receiptReqCh <- req:
+		default:
+			// The receipt loop is lagging behind. The skipped blocks are
+			// delivered along with the next head.
+			log.Debug("Receipt subscriptions lagging behind", "number", ev.Header.Number, "hash", ev.Header.Hash())
+		}
+	}
+}
+
+// handleLifecycle delivers the transaction state transitions reported by the
+// pool to the lifecycle subscriptions.
+func (es *EventSystem) handleLifecycle(filters filterIndex, ev []*txpool.TxLifecycleEvent) {
+	for _, f := range filters[TransactionLifecycleSubscription] {
+		if matched := filterLifecycle(ev, f.lifeCrit); len(matched) > 0 {
+			f.lifecycle <- matched
+		}
+	}
+}
+
+// handleReceipts delivers the receipts and transactions of the blocks assembled
+// by the receipt loop to the receipt and lifecycle subscriptions.
+func (es *EventSystem) handleReceipts(filters filterIndex, blocks []*receiptBlock) {
+	for _, block := range blocks {
+		es.deliverReceipts(filters, block)
+		es.deliverLifecycle(filters, block)
+	}
+}
+
+// receiptLoop assembles the blocks to deliver to the receipt and lifecycle
+// subscriptions. Retrieving them from the database is kept out of the event
+// loop, so that it doesn't hold up the delivery of other events.
+func (es *EventSystem) receiptLoop() {
+	var epoch uint64
+	for {
+		select {
+		case req := <-es.receiptReqCh:
+			// Stop tracking the delivered blocks if all subscriptions were
+			// removed in the meantime.
+			if req.epoch != epoch {
+				es.receiptHeads, epoch = nil, req.epoch
+			}
+			blocks := es.assembleReceipts(req)
+			if len(blocks) == 0 {
+				continue
+			}
+			select {
+			case es.receiptCh <- blocks:
+			case <-es.quit:
+				return
+			}
+		case <-es.quit:
+			return
+		}
+	}
+}
+
+// assembleReceipts retrieves the blocks to deliver for a new chain head. If the
+// head does not extend the previously delivered chain, the blocks reorged out
+// come first, marked as removed and latest first, followed by the blocks of the
+// new chain in forward order.
+func (es *EventSystem) assembleReceipts(req receiptRequest) []*receiptBlock {
+	removed, added := es.updateReceiptHeads(req.head)
+
+	blocks := make([]*receiptBlock, 0, len(removed)+len(added))
+	for _, header := range removed {
+		if block := es.loadReceiptBlock(header, true, req.receipts); block != nil {
+			blocks = append(blocks, block)
+		}
+	}
+	for _, header := range added {
+		if block := es.loadReceiptBlock(header, false, req.receipts); block != nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// loadReceiptBlock retrieves the transactions, and optionally the receipts, of
+// the given block.
+func (es *EventSystem) loadReceiptBlock(header *types.Header, removed bool, receipts bool) *receiptBlock {
+	var (
+		ctx    = context.Background()
+		hash   = header.Hash()
+		number = header.Number.Uint64()
+	)
+	body, err := es.backend.GetBody(ctx, hash, rpc.BlockNumber(number))
+	if err != nil {
+		log.Warn("Failed to retrieve block body for receipt subscriptions", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	block := &receiptBlock{
+		header:  header,
+		removed: removed,
+		signer:  types.MakeSigner(es.backend.ChainConfig(), header.Number, header.Time),
+		txs:     body.Transactions,
+	}
+	if receipts {
+		block.receipts, err = es.backend.GetReceipts(ctx, hash)
+		if err != nil || len(block.receipts) != len(block.txs) {
+			log.Warn("Failed to retrieve receipts for receipt subscriptions", "number", number, "hash", hash, "err", err)
+			block.receipts = nil
+		}
+	}
+	// Derive the senders here, they are cached in the transactions for matching
+	// the subscriptions in the event loop.
+	core.SenderCacher().Recover(block.signer, block.txs)
+
+	status := txpool.TxLifecycleIncluded
+	if removed {
+		status = txpool.TxLifecycleReorged
+	}
+	block.lifecycle = make([]*txpool.TxLifecycleEvent, 0, len(block.txs))
+	for _, tx := range block.txs {
+		from, err := types.Sender(block.signer, tx)
+		if err != nil {
+			continue
+		}
+		block.lifecycle = append(block.lifecycle, &txpool.TxLifecycleEvent{
+			Hash:        tx.Hash(),
+			From:        from,
+			Nonce:       tx.Nonce(),
+			Status:      status,
+			BlockHash:   hash,
+			BlockNumber: number,
+		})
+	}
+	return block
+}
+
+// updateReceiptHeads adds a new chain head to the tracked delivered blocks. It
+// returns the blocks which are no longer canonical, and the blocks of the new
+// chain which have not been delivered yet.
+func (es *EventSystem) updateReceiptHeads(head *types.Header) (removed []*types.Header, added []*types.Header) {
+	var (
+		heads  = es.receiptHeads
+		number = head.Number.Uint64()
+		chain  = []*types.Header{head}
+		fork   = -1 // Index of the last delivered block on the new chain
+	)
+	if len(heads) > 0 {
+		var (
+			first  = heads[0].Number.Uint64()
+			last   = heads[len(heads)-1].Number.Uint64()
+			joined bool
+		)
+		// Ignore the head if it was already delivered
+		if number >= first && number <= last && heads[number-first].Hash() == head.Hash() {
+			return nil, nil
+		}
+		// Walk the new chain back until it joins the delivered one
+		for cur := head; ; {
+			if cur.Number.Uint64() <= first {
+				joined = true // all delivered blocks were reorged out
+				break
+			}
+			if parent := cur.Number.Uint64() - 1; parent <= last && heads[parent-first].Hash() == cur.ParentHash {
+				fork, joined = int(parent-first), true
+				break
+			}
+			if len(chain) >= receiptsReorgLimit {
+				break
+			}
+			parent, _ := es.backend.HeaderByHash(context.Background(), cur.ParentHash)
+			if parent == nil {
+				break
+			}
+			chain = append(chain, parent)
+			cur = parent
+		}
+		if joined {
+			removed = slices.Clone(heads[fork+1:])
+			slices.Reverse(removed)
+			heads = heads[:fork+1]
+		} else {
+			log.Debug("Receipt subscriptions lost track of the chain", "number", number, "hash", head.Hash())
+			heads = nil
+		}
+	}
+	slices.Reverse(chain)
+	heads = append(heads, chain...)
+	if len(heads) > receiptsReorgLimit {
+		heads = slices.Clone(heads[len(heads)-receiptsReorgLimit:])
+	}
+	es.receiptHeads = heads
+	return removed, chain
+}
+
+// deliverReceipts sends the receipts of the given block, which match the criteria
+// of the receipt subscriptions.
+func (es *EventSystem) deliverReceipts(filters filterIndex, block *receiptBlock) {
+	if len(filters[TransactionReceiptsSubscription]) == 0 || block.receipts == nil {
+		return
+	}
+	number := block.header.Number.Uint64()
+	for _, f := range filters[TransactionReceiptsSubscription] {
+		// Only report the removal of blocks delivered to the subscriber
+		if block.removed && (f.chainFrom == 0 || number < f.chainFrom) {
+			continue
+		}
+		if !block.removed && f.chainFrom == 0 {
+			f.chainFrom = number
+		}
+		if matched := filterReceipts(block.signer, block.txs, block.receipts, f.receiptsCrit, block.removed); len(matched) > 0 {
+			f.receipts <- matched
+		}
+	}
+}
+
+// filterReceipts returns the receipts matching the given criteria.
+func filterReceipts(signer types.Signer, txs []*types.Transaction, receipts types.Receipts, crit TransactionReceiptsQuery, removed bool) []*ReceiptWithTx {
+	var matched []*ReceiptWithTx
+	for i, receipt := range receipts {
+		tx := txs[i]
+		if len(crit.TransactionHashes) > 0 && !slices.Contains(crit.TransactionHashes, tx.Hash()) {
+			continue
+		}
+		if len(crit.From) > 0 {
+			from, err := types.Sender(signer, tx)
+			if err != nil || !slices.Contains(crit.From, from) {
+				continue
+			}
+		}
+		if len(crit.To) > 0 {
+			to := receipt.ContractAddress
+			if tx.To() != nil {
+				to = *tx.To()
+			}
+			if !slices.Contains(crit.To, to) {
+				continue
+			}
+		}
+		matched = append(matched, &ReceiptWithTx{Receipt: receipt, Transaction: tx, Removed: removed})
+	}
+	return matched
+}
+
+// deliverLifecycle sends the inclusion, or the removal on a reorg, of the
+// transactions of the given block to the lifecycle subscriptions.
+func (es *EventSystem) deliverLifecycle(filters filterIndex, block *receiptBlock) {
+	if len(filters[TransactionLifecycleSubscription]) == 0 {
+		return
+	}
+	var (
+		number  = block.header.Number.Uint64()
+		removed = block.removed
+	)
+	for _, f := range filters[TransactionLifecycleSubscription] {
+		// Only report the removal of blocks delivered to the subscriber
+		if removed && (f.chainFrom == 0 || number < f.chainFrom) {
+			continue
+		}
+		if !removed && f.chainFrom == 0 {
+			f.chainFrom = number
+		}
+		if matched := filterLifecycle(block.lifecycle, f.lifeCrit); len(matched) > 0 {
+			f.lifecycle <- matched
+		}
+	}
+}
+
+// filterLifecycle returns the lifecycle events matching the given criteria.
+func filterLifecycle(events []*txpool.TxLifecycleEvent, crit TransactionLifecycleQuery) []*txpool.TxLifecycleEvent {
+	if len(crit.From) == 0 {
+		return events
+	}
+	var matched []*txpool.TxLifecycleEvent
+	for _, ev := range events {
+		if slices.Contains(crit.From, ev.From) {
+			matched = append(matched, ev)
+		}
+	}
+	return matched
 }
 
 // eventLoop (un)installs filters and processes mux events.
 func (es *EventSystem) eventLoop() {
 	// Ensure all subscriptions get cleaned up
 	defer func() {
+		close(es.quit)
 		es.txsSub.Unsubscribe()
 		es.logsSub.Unsubscribe()
 		es.rmLogsSub.Unsubscribe()
 		es.chainSub.Unsubscribe()
+		if es.lifecycleSub != nil {
+			es.lifecycleSub.Unsubscribe()
+		}
 	}()
 
 	index := make(filterIndex)
@@ -420,6 +825,10 @@ func (es *EventSystem) eventLoop() {
 		index[i] = make(map[rpc.ID]*subscription)
 	}
 
+	// lifecycleErr is the error channel of the lifecycle subscription, nil while
+	// not subscribed.
+	var lifecycleErr <-chan error
+
 	for {
 		select {
 		case ev := <-es.txsCh:
@@ -430,15 +839,38 @@ func (es *EventSystem) eventLoop() {
 			es.handleLogs(index, ev.Logs)
 		case ev := <-es.chainCh:
 			es.handleChainEvent(index, ev)
+		case ev := <-es.lifecycleCh:
+			es.handleLifecycle(index, ev)
+		case blocks := <-es.receiptCh:
+			es.handleReceipts(index, blocks)
 
 		case f := <-es.install:
+			// Start listening for lifecycle events with the first filter
+			if f.typ == TransactionLifecycleSubscription && es.lifecycleSub == nil {
+				if es.lifecycleSub = es.backend.SubscribeTxLifecycleEvent(es.lifecycleCh); es.lifecycleSub != nil {
+					lifecycleErr = es.lifecycleSub.Err()
+				} else {
+					log.Error("Subscribe for transaction lifecycle events failed")
+				}
+			}
 			index[f.typ][f.id] = f
 			close(f.installed)
 
 		case f := <-es.uninstall:
 			delete(index[f.typ], f.id)
+
+			// Stop listening for lifecycle events with the last filter
+			if len(index[TransactionLifecycleSubscription]) == 0 && es.lifecycleSub != nil {
+				es.lifecycleSub.Unsubscribe()
+				es.lifecycleSub, lifecycleErr = nil, nil
+			}
 			close(f.err)
 
+			// Stop tracking the delivered blocks if nobody is interested.
+			if len(index[TransactionReceiptsSubscription]) == 0 && len(index[TransactionLifecycleSubscription]) == 0 {
+				es.receiptEpoch++
+			}
+
 		// System stopped
 		case <-es.txsSub.Err():
 			return
@@ -448,6 +880,8 @@ func (es *EventSystem) eventLoop() {
 			return
 		case <-es.chainSub.Err():
 			return
+		case <-lifecycleErr:
+			return
 		}
 	}
 }
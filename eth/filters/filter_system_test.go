@@ -30,7 +30,10 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -45,6 +48,7 @@ type testBackend struct {
 	logsFeed        event.Feed
 	rmLogsFeed      event.Feed
 	chainFeed       event.Feed
+	lifecycleFeed   event.Feed
 	pendingBlock    *types.Block
 	pendingReceipts types.Receipts
 }
@@ -149,6 +153,10 @@ func (b *testBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Sub
 	return b.txFeed.Subscribe(ch)
 }
 
+func (b *testBackend) SubscribeTxLifecycleEvent(ch chan<- []*txpool.TxLifecycleEvent) event.Subscription {
+	return b.lifecycleFeed.Subscribe(ch)
+}
+
 func (b *testBackend) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription {
 	return b.rmLogsFeed.Subscribe(ch)
 }
@@ -654,3 +662,257 @@ func TestPendingTxFilterDeadlock(t *testing.T) {
 		}
 	}
 }
+
+// TestTransactionReceiptsSubscription tests that receipt subscriptions receive
+// the receipts of imported blocks matching their criteria, and the receipts of
+// reorged out blocks marked as removed.
+func TestTransactionReceiptsSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		api          = NewFilterAPI(sys)
+
+		key, _  = crypto.GenerateKey()
+		sender  = crypto.PubkeyToAddress(key.PublicKey)
+		recvA   = common.HexToAddress("0xaaaa")
+		recvB   = common.HexToAddress("0xbbbb")
+		signer  = types.LatestSigner(params.TestChainConfig)
+		engine  = ethash.NewFaker()
+		genesis = &core.Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	transfer := func(to common.Address) func(int, *core.BlockGen) {
+		return func(i int, gen *core.BlockGen) {
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), to, big.NewInt(1000), params.TxGas, gen.BaseFee(), nil), signer, key)
+			gen.AddTx(tx)
+		}
+	}
+	genDb, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 4, transfer(recvA))
+	fork, _ := core.GenerateChain(genesis.Config, blocks[1], engine, genDb, 3, transfer(recvB))
+
+	chain, err := core.NewBlockChain(db, nil, genesis, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// receipt identifies a delivered receipt by the block it is included in.
+	type receipt struct {
+		hash    common.Hash
+		removed bool
+	}
+	subscribe := func(crit TransactionReceiptsQuery) (chan []*ReceiptWithTx, *Subscription) {
+		ch := make(chan []*ReceiptWithTx, 16)
+		sub, err := api.events.SubscribeTransactionReceipts(crit, ch)
+		if err != nil {
+			t.Fatalf("failed to subscribe: %v", err)
+		}
+		return ch, sub
+	}
+	collect := func(ch chan []*ReceiptWithTx, n int) []receipt {
+		var result []receipt
+		for len(result) < n {
+			select {
+			case batch := <-ch:
+				for _, r := range batch {
+					result = append(result, receipt{r.Receipt.BlockHash, r.Removed})
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("timeout waiting for receipts, have %d, want %d", len(result), n)
+			}
+		}
+		return result
+	}
+	var (
+		allCh, allSub   = subscribe(TransactionReceiptsQuery{})
+		toCh, toSub     = subscribe(TransactionReceiptsQuery{To: []common.Address{recvB}})
+		hashCh, hashSub = subscribe(TransactionReceiptsQuery{TransactionHashes: []common.Hash{blocks[2].Transactions()[0].Hash()}})
+	)
+	defer allSub.Unsubscribe()
+	defer toSub.Unsubscribe()
+	defer hashSub.Unsubscribe()
+
+	if _, err := api.events.SubscribeTransactionReceipts(TransactionReceiptsQuery{From: make([]common.Address, maxReceiptCriteria+1)}, nil); err != errExceedMaxReceiptCriteria {
+		t.Fatalf("wrong error for excessive criteria: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for _, block := range blocks {
+		backend.chainFeed.Send(core.ChainEvent{Header: block.Header()})
+	}
+	// Reorg to the longer fork, only announcing its head.
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	backend.chainFeed.Send(core.ChainEvent{Header: fork[2].Header()})
+
+	want := []receipt{
+		{blocks[0].Hash(), false}, {blocks[1].Hash(), false}, {blocks[2].Hash(), false}, {blocks[3].Hash(), false},
+		{blocks[3].Hash(), true}, {blocks[2].Hash(), true},
+		{fork[0].Hash(), false}, {fork[1].Hash(), false}, {fork[2].Hash(), false},
+	}
+	if have := collect(allCh, len(want)); !reflect.DeepEqual(have, want) {
+		t.Errorf("wrong receipts delivered without criteria:\nhave %v\nwant %v", have, want)
+	}
+	want = []receipt{{fork[0].Hash(), false}, {fork[1].Hash(), false}, {fork[2].Hash(), false}}
+	if have := collect(toCh, len(want)); !reflect.DeepEqual(have, want) {
+		t.Errorf("wrong receipts delivered by recipient:\nhave %v\nwant %v", have, want)
+	}
+	want = []receipt{{blocks[2].Hash(), false}, {blocks[2].Hash(), true}}
+	if have := collect(hashCh, len(want)); !reflect.DeepEqual(have, want) {
+		t.Errorf("wrong receipts delivered by hash:\nhave %v\nwant %v", have, want)
+	}
+}
+
+// Tests that the pool lifecycle events are only subscribed to while lifecycle
+// filters are installed.
+func TestTransactionLifecycleSubscriptionOnDemand(t *testing.T) {
+	t.Parallel()
+
+	var (
+		backend, sys = newTestFilterSystem(rawdb.NewMemoryDatabase(), Config{})
+		api          = NewFilterAPI(sys)
+	)
+	if n := backend.lifecycleFeed.Send([]*txpool.TxLifecycleEvent{}); n != 0 {
+		t.Fatalf("lifecycle events subscribed without filters: %d subscribers", n)
+	}
+	subs := make([]*Subscription, 2)
+	for i := range subs {
+		sub, err := api.events.SubscribeTransactionLifecycle(TransactionLifecycleQuery{}, make(chan []*txpool.TxLifecycleEvent))
+		if err != nil {
+			t.Fatalf("failed to subscribe: %v", err)
+		}
+		subs[i] = sub
+	}
+	if n := backend.lifecycleFeed.Send([]*txpool.TxLifecycleEvent{}); n != 1 {
+		t.Fatalf("wrong number of lifecycle subscribers: have %d, want 1", n)
+	}
+	subs[0].Unsubscribe()
+	if n := backend.lifecycleFeed.Send([]*txpool.TxLifecycleEvent{}); n != 1 {
+		t.Fatalf("wrong number of lifecycle subscribers: have %d, want 1", n)
+	}
+	subs[1].Unsubscribe()
+	if n := backend.lifecycleFeed.Send([]*txpool.TxLifecycleEvent{}); n != 0 {
+		t.Fatalf("lifecycle events subscribed without filters: %d subscribers", n)
+	}
+}
+
+func TestTransactionLifecycleSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		api          = NewFilterAPI(sys)
+
+		key, _  = crypto.GenerateKey()
+		sender  = crypto.PubkeyToAddress(key.PublicKey)
+		other   = common.HexToAddress("0xcccc")
+		signer  = types.LatestSigner(params.TestChainConfig)
+		engine  = ethash.NewFaker()
+		genesis = &core.Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	transfer := func(to common.Address) func(int, *core.BlockGen) {
+		return func(i int, gen *core.BlockGen) {
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), to, big.NewInt(1000), params.TxGas, gen.BaseFee(), nil), signer, key)
+			gen.AddTx(tx)
+		}
+	}
+	genDb, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 2, transfer(common.HexToAddress("0xaaaa")))
+	fork, _ := core.GenerateChain(genesis.Config, blocks[0], engine, genDb, 2, transfer(common.HexToAddress("0xbbbb")))
+
+	chain, err := core.NewBlockChain(db, nil, genesis, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// transition identifies a delivered lifecycle event.
+	type transition struct {
+		hash   common.Hash
+		status txpool.TxLifecycleStatus
+		block  uint64
+	}
+	subscribe := func(crit TransactionLifecycleQuery) (chan []*txpool.TxLifecycleEvent, *Subscription) {
+		ch := make(chan []*txpool.TxLifecycleEvent, 16)
+		sub, err := api.events.SubscribeTransactionLifecycle(crit, ch)
+		if err != nil {
+			t.Fatalf("failed to subscribe: %v", err)
+		}
+		return ch, sub
+	}
+	collect := func(ch chan []*txpool.TxLifecycleEvent, n int) []transition {
+		var result []transition
+		for len(result) < n {
+			select {
+			case batch := <-ch:
+				for _, ev := range batch {
+					result = append(result, transition{ev.Hash, ev.Status, ev.BlockNumber})
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("timeout waiting for lifecycle events, have %d, want %d", len(result), n)
+			}
+		}
+		return result
+	}
+	var (
+		allCh, allSub       = subscribe(TransactionLifecycleQuery{})
+		senderCh, senderSub = subscribe(TransactionLifecycleQuery{From: []common.Address{sender}})
+	)
+	defer allSub.Unsubscribe()
+	defer senderSub.Unsubscribe()
+
+	if _, err := api.events.SubscribeTransactionLifecycle(TransactionLifecycleQuery{From: make([]common.Address, maxReceiptCriteria+1)}, nil); err != errExceedMaxReceiptCriteria {
+		t.Fatalf("wrong error for excessive criteria: %v", err)
+	}
+	// Feed some pool events from both the tracked and another sender
+	var (
+		tx       = blocks[0].Transactions()[0]
+		foreign  = common.Hash{0x01}
+		poolEvts = []*txpool.TxLifecycleEvent{
+			{Hash: tx.Hash(), From: sender, Status: txpool.TxLifecycleQueued},
+			{Hash: foreign, From: other, Status: txpool.TxLifecycleDropped, Reason: txpool.DropReasonExpired},
+			{Hash: tx.Hash(), From: sender, Status: txpool.TxLifecyclePending},
+		}
+	)
+	backend.lifecycleFeed.Send(poolEvts)
+
+	// Import the chain and reorg it to the longer fork, only announcing its head
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for _, block := range blocks {
+		backend.chainFeed.Send(core.ChainEvent{Header: block.Header()})
+	}
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	backend.chainFeed.Send(core.ChainEvent{Header: fork[1].Header()})
+
+	want := []transition{
+		{tx.Hash(), txpool.TxLifecycleQueued, 0},
+		{tx.Hash(), txpool.TxLifecyclePending, 0},
+		{tx.Hash(), txpool.TxLifecycleIncluded, 1},
+		{blocks[1].Transactions()[0].Hash(), txpool.TxLifecycleIncluded, 2},
+		{blocks[1].Transactions()[0].Hash(), txpool.TxLifecycleReorged, 2},
+		{fork[0].Transactions()[0].Hash(), txpool.TxLifecycleIncluded, 2},
+		{fork[1].Transactions()[0].Hash(), txpool.TxLifecycleIncluded, 3},
+	}
+	if have := collect(senderCh, len(want)); !reflect.DeepEqual(have, want) {
+		t.Errorf("wrong events delivered by sender:\nhave %v\nwant %v", have, want)
+	}
+	want = append(want[:1:1], append([]transition{{foreign, txpool.TxLifecycleDropped, 0}}, want[1:]...)...)
+	if have := collect(allCh, len(want)); !reflect.DeepEqual(have, want) {
+		t.Errorf("wrong events delivered without criteria:\nhave %v\nwant %v", have, want)
+	}
+}
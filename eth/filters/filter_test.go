@@ -602,3 +602,110 @@ func TestRangeLogs(t *testing.T) {
 	expEvent(rangeLogsTestReorg, 400, 901)
 	expEvent(rangeLogsTestDone, 0, 0)
 }
+
+func TestLogsPageIndexed(t *testing.T) {
+	testLogsPage(t, false)
+}
+
+func TestLogsPageUnindexed(t *testing.T) {
+	testLogsPage(t, true)
+}
+
+func testLogsPage(t *testing.T, noHistory bool) {
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		addr         = common.BytesToAddress([]byte("jeff"))
+		gspec        = &core.Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	defer db.Close()
+
+	_, chain, receipts := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 300, func(i int, gen *core.BlockGen) {
+		if i%7 != 0 {
+			return
+		}
+		// Add two receipts with a varying number of logs to the block
+		for j := 0; j < 2; j++ {
+			receipt := types.NewReceipt(nil, false, 0)
+			for k := 0; k <= (i+j)%3; k++ {
+				receipt.Logs = append(receipt.Logs, &types.Log{Address: addr, Topics: []common.Hash{{byte(k)}}})
+			}
+			receipt.Bloom = types.CreateBloom(receipt)
+			gen.AddUncheckedReceipt(receipt)
+			gen.AddUncheckedTx(types.NewTransaction(uint64(j), common.HexToAddress("0x999"), big.NewInt(999), 999, gen.BaseFee(), nil))
+		}
+	})
+	gspec.MustCommit(db, triedb.NewDatabase(db, triedb.HashDefaults))
+	for i, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+	}
+	backend.startFilterMaps(0, noHistory, filtermaps.RangeTestParams)
+	defer backend.stopFilterMaps()
+
+	for _, limit := range []int{1, 2, 5, 1000} {
+		filter := sys.NewRangeFilter(10, int64(rpc.LatestBlockNumber), []common.Address{addr}, nil)
+		want, err := filter.Logs(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var (
+			have   []*types.Log
+			cursor []byte
+		)
+		for {
+			logs, next, err := filter.LogsPage(context.Background(), cursor, limit)
+			if err != nil {
+				t.Fatalf("limit %d: failed to retrieve page: %v", limit, err)
+			}
+			if len(logs) > limit {
+				t.Fatalf("limit %d: page too large: %d", limit, len(logs))
+			}
+			have = append(have, logs...)
+			if next == nil {
+				break
+			}
+			cursor = next
+		}
+		if len(have) != len(want) {
+			t.Fatalf("limit %d: log count mismatch: have %d, want %d", limit, len(have), len(want))
+		}
+		for i := range want {
+			if have[i].BlockNumber != want[i].BlockNumber || have[i].Index != want[i].Index {
+				t.Fatalf("limit %d: log %d mismatch: have %d/%d, want %d/%d", limit, i, have[i].BlockNumber, have[i].Index, want[i].BlockNumber, want[i].Index)
+			}
+		}
+	}
+	// Resuming after the referenced block was reorged out must fail.
+	filter := sys.NewRangeFilter(0, int64(rpc.LatestBlockNumber), []common.Address{addr}, nil)
+	_, cursor, err := filter.LogsPage(context.Background(), nil, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := decodeLogsCursor(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Resuming with different criteria must fail.
+	for _, other := range []*Filter{
+		sys.NewRangeFilter(1, int64(rpc.LatestBlockNumber), []common.Address{addr}, nil),
+		sys.NewRangeFilter(0, int64(rpc.LatestBlockNumber), []common.Address{{0x01}}, nil),
+		sys.NewRangeFilter(0, int64(rpc.LatestBlockNumber), []common.Address{addr}, [][]common.Hash{nil}),
+	} {
+		if _, _, err := other.LogsPage(context.Background(), cursor, 3); err != errLogsCursorMismatch {
+			t.Fatalf("expected criteria mismatch error, got %v", err)
+		}
+	}
+	rawdb.WriteCanonicalHash(db, common.Hash{0xff}, c.block)
+	if _, _, err := filter.LogsPage(context.Background(), cursor, 3); err != errLogsCursorReorged {
+		t.Fatalf("expected reorg error, got %v", err)
+	}
+	if _, _, err := filter.LogsPage(context.Background(), cursor[1:], 3); err != errInvalidLogsCursor {
+		t.Fatalf("expected invalid cursor error, got %v", err)
+	}
+}
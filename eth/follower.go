@@ -0,0 +1,55 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// minFollowInterval is the lowest accepted interval between two reloads of the
+// followed database, as every reload reopens the database if it was modified.
+const minFollowInterval = 100 * time.Millisecond
+
+// follow periodically refreshes the followed database and reloads the chain on
+// top, picking up the blocks imported by the owner of the database.
+func (s *Ethereum) follow() {
+	interval := s.config.FollowInterval
+	if interval < minFollowInterval {
+		log.Warn("Sanitizing invalid follow interval", "provided", interval, "updated", minFollowInterval)
+		interval = minFollowInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.followerDb.Refresh(); err != nil {
+				log.Warn("Failed to refresh followed database", "err", err)
+				continue
+			}
+			if err := s.blockchain.Reload(); err != nil {
+				log.Warn("Failed to reload followed chain", "err", err)
+			}
+		case ch := <-s.closeFollower:
+			close(ch)
+			return
+		}
+	}
+}
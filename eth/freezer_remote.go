@@ -0,0 +1,61 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// remoteCredentialsTimeout is the maximum time allowed for resolving the
+// credentials of the remote chain freezer on startup.
+const remoteCredentialsTimeout = 10 * time.Second
+
+// remoteFreezerConfig assembles the configuration of the remote chain freezer.
+// The region and the credentials are resolved via the default credential chain
+// of the AWS SDK: environment variables, shared configuration and credentials
+// files, and the roles of the container or the instance. If no credentials are
+// found, the bucket is accessed anonymously.
+func remoteFreezerConfig(cfg *ethconfig.Config) (rawdb.RemoteFreezerConfig, error) {
+	remote := rawdb.RemoteFreezerConfig{
+		Endpoint:  cfg.DatabaseRemoteFreezer,
+		CacheSize: cfg.DatabaseRemoteFreezerCache * 1024 * 1024,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), remoteCredentialsTimeout)
+	defer cancel()
+
+	awscfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return rawdb.RemoteFreezerConfig{}, err
+	}
+	remote.Region = awscfg.Region
+	if awscfg.Credentials == nil {
+		log.Info("No credentials found for remote chain freezer, accessing it anonymously")
+		return remote, nil
+	}
+	if _, err := awscfg.Credentials.Retrieve(ctx); err != nil {
+		log.Info("No credentials found for remote chain freezer, accessing it anonymously", "err", err)
+		return remote, nil
+	}
+	remote.Credentials = awscfg.Credentials
+	return remote, nil
+}
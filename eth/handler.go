@@ -52,6 +52,13 @@ const (
 	// All transactions with a higher size will be announced and need to be fetched
 	// by the peer.
 	txMaxBroadcastSize = 4096
+
+	// chainHeadChanSize is the size of channel listening to ChainHeadEvent.
+	chainHeadChanSize = 10
+
+	// blockRangeUpdateInterval is the number of blocks the local chain needs to
+	// progress before the available block range is re-announced to the peers.
+	blockRangeUpdateInterval = 32
 )
 
 var syncChallengeTimeout = 15 * time.Second // Time allowance for a node to reply to the sync progress challenge
@@ -100,6 +107,7 @@ type handlerConfig struct {
 	BloomCache     uint64                 // Megabytes to alloc for snap sync bloom
 	EventMux       *event.TypeMux         // Legacy event mux, deprecate for `feed`
 	RequiredBlocks map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
+	Follower       bool                   // Whether the chain is driven by another process, never synced
 }
 
 type handler struct {
@@ -154,7 +162,10 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		handlerDoneCh:  make(chan struct{}),
 		handlerStartCh: make(chan struct{}),
 	}
-	if config.Sync == ethconfig.FullSync {
+	if config.Follower {
+		// The chain is imported by the owner of the database, consider it synced.
+		h.synced.Store(true)
+	} else if config.Sync == ethconfig.FullSync {
 		// The database seems empty as the current block is the genesis. Yet the snap
 		// block is ahead, so snap sync was enabled for this node at a certain point.
 		// The scenarios where this can happen is
@@ -259,11 +270,10 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 	var (
 		genesis = h.chain.Genesis()
 		head    = h.chain.CurrentHeader()
-		hash    = head.Hash()
 		number  = head.Number.Uint64()
 	)
 	forkID := forkid.NewID(h.chain.Config(), genesis, number, head.Time)
-	if err := peer.Handshake(h.networkID, hash, genesis.Hash(), forkID, h.forkFilter); err != nil {
+	if err := peer.Handshake(h.networkID, h.blockRange(), genesis.Hash(), forkID, h.forkFilter); err != nil {
 		peer.Log().Debug("Ethereum handshake failed", "err", err)
 		return err
 	}
@@ -434,6 +444,10 @@ func (h *handler) Start(maxPeers int) {
 	h.txsSub = h.txpool.SubscribeTransactions(h.txsCh, false)
 	go h.txBroadcastLoop()
 
+	// announce the changes of the locally available block range
+	h.wg.Add(1)
+	go h.blockRangeLoop()
+
 	// start sync handlers
 	h.txFetcher.Start()
 
@@ -552,6 +566,61 @@ func (h *handler) txBroadcastLoop() {
 	}
 }
 
+// blockRange returns the range of blocks available locally, starting at the
+// history pruning point and ending at the current head block.
+func (h *handler) blockRange() eth.BlockRangeUpdatePacket {
+	var (
+		head        = h.chain.CurrentBlock()
+		earliest, _ = h.chain.HistoryPruningCutoff()
+	)
+	// While syncing, the head might still be below the pruning point
+	if latest := head.Number.Uint64(); earliest > latest {
+		earliest = latest
+	}
+	return eth.BlockRangeUpdatePacket{
+		EarliestBlock:   earliest,
+		LatestBlock:     head.Number.Uint64(),
+		LatestBlockHash: head.Hash(),
+	}
+}
+
+// blockRangeLoop announces the changes of the locally available block range to
+// the connected peers. To avoid spamming the network, the range is only announced
+// if the head progressed significantly, or if the history was pruned or rewound.
+func (h *handler) blockRangeLoop() {
+	defer h.wg.Done()
+
+	var (
+		headCh = make(chan core.ChainHeadEvent, chainHeadChanSize)
+		sub    = h.chain.SubscribeChainHeadEvent(headCh)
+		last   = h.blockRange()
+	)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-headCh:
+			current := h.blockRange()
+			if current.EarliestBlock == last.EarliestBlock && current.LatestBlock >= last.LatestBlock &&
+				current.LatestBlock < last.LatestBlock+blockRangeUpdateInterval {
+				continue
+			}
+			last = current
+			for _, peer := range h.peers.all() {
+				go func(peer *ethPeer) {
+					if err := peer.SendBlockRangeUpdate(current); err != nil {
+						peer.Log().Debug("Failed to announce block range", "err", err)
+					}
+				}(peer)
+			}
+		case <-sub.Err():
+			return
+		case <-h.quitSync:
+			return
+		}
+	}
+}
+
 // enableSyncedFeatures enables the post-sync functionalities when the initial
 // sync is finished.
 func (h *handler) enableSyncedFeatures() {
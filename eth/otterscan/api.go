@@ -0,0 +1,613 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package otterscan implements the ots RPC namespace used by the Otterscan block
+// explorer.
+package otterscan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+
+	// Force-load the native tracers, the call tracer is used to inspect transactions.
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+)
+
+// apiLevel is the version of the Otterscan API specification implemented.
+const apiLevel = 8
+
+// maxPageSize is the maximum number of transactions returned by a search.
+const maxPageSize = 1000
+
+var (
+	errAddressIndexUnavailable = errors.New("address index is not available, enable it with --history.addresses")
+	errBlockNotFound           = errors.New("block not found")
+	errPageSizeTooLarge        = fmt.Errorf("page size exceeds limit of %d", maxPageSize)
+)
+
+// Backend is the collection of methods required by the ots API.
+type Backend interface {
+	tracers.Backend
+	GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error)
+	StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error)
+}
+
+// API implements the ots namespace.
+type API struct {
+	backend Backend
+	tracer  *tracers.API
+}
+
+// NewAPI creates a new API definition for the Otterscan methods.
+func NewAPI(backend Backend) *API {
+	return &API{backend: backend, tracer: tracers.NewAPI(backend)}
+}
+
+// APIs return the collection of RPC services the otterscan package offers.
+func APIs(backend Backend) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "ots",
+			Service:   NewAPI(backend),
+		},
+	}
+}
+
+// GetApiLevel returns the version of the Otterscan API implemented by the node.
+func (api *API) GetApiLevel() uint64 {
+	return apiLevel
+}
+
+// HasCode returns whether the given address has code at the given block.
+func (api *API) HasCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (bool, error) {
+	statedb, _, err := api.backend.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return false, err
+	}
+	return statedb.GetCodeSize(address) > 0, nil
+}
+
+// Issuance describes the ether created by a block.
+type Issuance struct {
+	BlockReward *hexutil.Big `json:"blockReward"`
+	UncleReward *hexutil.Big `json:"uncleReward"`
+	Issuance    *hexutil.Big `json:"issuance"`
+}
+
+// GetBlockDetails returns the header fields of a block along with its issuance
+// and the total fees paid by its transactions.
+func (api *API) GetBlockDetails(ctx context.Context, number rpc.BlockNumber) (map[string]interface{}, error) {
+	block, err := api.backend.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+	return api.blockDetails(ctx, block)
+}
+
+// GetBlockDetailsByHash is the same as GetBlockDetails, but the block is
+// identified by its hash.
+func (api *API) GetBlockDetailsByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	block, err := api.backend.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+	return api.blockDetails(ctx, block)
+}
+
+func (api *API) blockDetails(ctx context.Context, block *types.Block) (map[string]interface{}, error) {
+	receipts, err := api.backend.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	fields := ethapi.RPCMarshalBlock(block, false, false, api.backend.ChainConfig())
+	delete(fields, "logsBloom")
+	fields["transactionCount"] = hexutil.Uint64(len(block.Transactions()))
+
+	totalFees := new(big.Int)
+	for _, receipt := range receipts {
+		if receipt.EffectiveGasPrice != nil {
+			totalFees.Add(totalFees, new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed)))
+		}
+	}
+	return map[string]interface{}{
+		"block":     fields,
+		"issuance":  blockIssuance(api.backend.ChainConfig(), block),
+		"totalFees": (*hexutil.Big)(totalFees),
+	}, nil
+}
+
+// blockIssuance computes the mining rewards of a proof-of-work block. Blocks
+// sealed by other consensus engines don't issue any ether.
+func blockIssuance(config *params.ChainConfig, block *types.Block) *Issuance {
+	var (
+		blockReward = new(uint256.Int)
+		uncleReward = new(uint256.Int)
+	)
+	if config.Ethash != nil && block.Difficulty().Sign() > 0 {
+		reward := ethash.FrontierBlockReward
+		if config.IsByzantium(block.Number()) {
+			reward = ethash.ByzantiumBlockReward
+		}
+		if config.IsConstantinople(block.Number()) {
+			reward = ethash.ConstantinopleBlockReward
+		}
+		blockReward.Set(reward)
+
+		number := uint256.NewInt(block.NumberU64())
+		for _, uncle := range block.Uncles() {
+			r := uint256.NewInt(uncle.Number.Uint64() + 8)
+			r.Sub(r, number)
+			r.Mul(r, reward)
+			r.Rsh(r, 3)
+			uncleReward.Add(uncleReward, r)
+
+			blockReward.Add(blockReward, new(uint256.Int).Rsh(reward, 5))
+		}
+	}
+	return &Issuance{
+		BlockReward: (*hexutil.Big)(blockReward.ToBig()),
+		UncleReward: (*hexutil.Big)(uncleReward.ToBig()),
+		Issuance:    (*hexutil.Big)(new(uint256.Int).Add(blockReward, uncleReward).ToBig()),
+	}
+}
+
+// GetBlockTransactions returns a page of the transactions of a block along with
+// their receipts. Pages are counted from the end of the block.
+func (api *API) GetBlockTransactions(ctx context.Context, number rpc.BlockNumber, pageNumber uint, pageSize uint) (map[string]interface{}, error) {
+	if pageSize > maxPageSize {
+		return nil, errPageSizeTooLarge
+	}
+	block, err := api.backend.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+	receipts, err := api.backend.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		config = api.backend.ChainConfig()
+		txs    = block.Transactions()
+		end    = max(len(txs)-int(pageNumber*pageSize), 0)
+		start  = max(end-int(pageSize), 0)
+		signer = types.MakeSigner(config, block.Number(), block.Time())
+	)
+	fields := ethapi.RPCMarshalBlock(block, false, false, config)
+	delete(fields, "logsBloom")
+	fields["transactionCount"] = hexutil.Uint64(len(txs))
+
+	pageTxs := make([]*ethapi.RPCTransaction, 0, end-start)
+	pageReceipts := make([]map[string]interface{}, 0, end-start)
+	for i := start; i < end; i++ {
+		pageTxs = append(pageTxs, ethapi.NewRPCTransactionFromBlockIndex(block, uint64(i), config))
+		if i < len(receipts) {
+			receipt := ethapi.MarshalReceipt(receipts[i], block.Hash(), block.NumberU64(), signer, txs[i], i)
+			receipt["logs"] = nil
+			receipt["logsBloom"] = nil
+			pageReceipts = append(pageReceipts, receipt)
+		}
+	}
+	fields["transactions"] = pageTxs
+	return map[string]interface{}{
+		"fullblock": fields,
+		"receipts":  pageReceipts,
+	}, nil
+}
+
+// TransactionsWithReceipts is a page of transactions touching an address, in
+// descending chain order.
+type TransactionsWithReceipts struct {
+	Txs       []*ethapi.RPCTransaction `json:"txs"`
+	Receipts  []map[string]interface{} `json:"receipts"`
+	FirstPage bool                     `json:"firstPage"`
+	LastPage  bool                     `json:"lastPage"`
+}
+
+// SearchTransactionsBefore returns the transactions touching the given address
+// in blocks before the given one, latest first. A zero block number starts the
+// search from the chain head.
+//
+// The page contains at least pageSize transactions, unless the beginning of the
+// chain is reached. The transactions of the last block are always included in
+// full, so the page may be larger.
+func (api *API) SearchTransactionsBefore(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint16) (*TransactionsWithReceipts, error) {
+	if pageSize > maxPageSize {
+		return nil, errPageSizeTooLarge
+	}
+	indexed := rawdb.ReadAddressIndexHead(api.backend.ChainDb())
+	if indexed == nil {
+		return nil, errAddressIndexUnavailable
+	}
+	db := api.backend.ChainDb()
+	before := *indexed + 1
+	if blockNumber != 0 && blockNumber < before {
+		before = blockNumber
+	}
+	found := rawdb.ReadAddressAppearancesBefore(db, address, before, int(pageSize))
+
+	lastPage := len(found) < int(pageSize)
+	if len(found) > 0 && !lastPage {
+		// Complete the last block and check for more results beyond it.
+		last := found[len(found)-1]
+		rest := rawdb.ReadAddressAppearances(db, address, last.BlockNumber, last.BlockNumber+1, 0)
+		for i := len(rest) - 1; i >= 0; i-- {
+			if rest[i].TxIndex < last.TxIndex {
+				found = append(found, rest[i])
+			}
+		}
+		lastPage = len(rawdb.ReadAddressAppearancesBefore(db, address, last.BlockNumber, 1)) == 0
+	}
+	result, err := api.loadTransactions(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+	result.FirstPage = blockNumber == 0
+	result.LastPage = lastPage
+	return result, nil
+}
+
+// SearchTransactionsAfter returns the transactions touching the given address
+// in blocks after the given one, latest first. A zero block number starts the
+// search from genesis.
+//
+// The page contains at least pageSize transactions, unless the chain head is
+// reached. The transactions of the last block are always included in full, so
+// the page may be larger.
+func (api *API) SearchTransactionsAfter(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint16) (*TransactionsWithReceipts, error) {
+	if pageSize > maxPageSize {
+		return nil, errPageSizeTooLarge
+	}
+	indexed := rawdb.ReadAddressIndexHead(api.backend.ChainDb())
+	if indexed == nil {
+		return nil, errAddressIndexUnavailable
+	}
+	var (
+		db   = api.backend.ChainDb()
+		from = uint64(0)
+		to   = *indexed + 1
+	)
+	if blockNumber != 0 {
+		from = blockNumber + 1
+	}
+	found := rawdb.ReadAddressAppearances(db, address, from, to, int(pageSize))
+
+	firstPage := len(found) < int(pageSize)
+	if len(found) > 0 && !firstPage {
+		// Complete the last block and check for more results beyond it.
+		last := found[len(found)-1]
+		for _, a := range rawdb.ReadAddressAppearances(db, address, last.BlockNumber, last.BlockNumber+1, 0) {
+			if a.TxIndex > last.TxIndex {
+				found = append(found, a)
+			}
+		}
+		firstPage = len(rawdb.ReadAddressAppearances(db, address, last.BlockNumber+1, to, 1)) == 0
+	}
+	// Results are always returned in descending order.
+	for i, j := 0, len(found)-1; i < j; i, j = i+1, j-1 {
+		found[i], found[j] = found[j], found[i]
+	}
+	result, err := api.loadTransactions(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+	result.FirstPage = firstPage
+	result.LastPage = blockNumber == 0
+	return result, nil
+}
+
+// loadTransactions retrieves the transactions and receipts at the given positions.
+func (api *API) loadTransactions(ctx context.Context, appearances []rawdb.AddressAppearance) (*TransactionsWithReceipts, error) {
+	var (
+		config   = api.backend.ChainConfig()
+		block    *types.Block
+		receipts types.Receipts
+		result   = &TransactionsWithReceipts{
+			Txs:      make([]*ethapi.RPCTransaction, 0, len(appearances)),
+			Receipts: make([]map[string]interface{}, 0, len(appearances)),
+		}
+	)
+	for _, a := range appearances {
+		if block == nil || block.NumberU64() != a.BlockNumber {
+			var err error
+			block, err = api.backend.BlockByNumber(ctx, rpc.BlockNumber(a.BlockNumber))
+			if err != nil {
+				return nil, err
+			}
+			if block == nil {
+				return nil, fmt.Errorf("block #%d not found", a.BlockNumber)
+			}
+			receipts, err = api.backend.GetReceipts(ctx, block.Hash())
+			if err != nil {
+				return nil, err
+			}
+		}
+		txs := block.Transactions()
+		if int(a.TxIndex) >= len(txs) || int(a.TxIndex) >= len(receipts) {
+			return nil, fmt.Errorf("transaction %d not found in block #%d", a.TxIndex, a.BlockNumber)
+		}
+		signer := types.MakeSigner(config, block.Number(), block.Time())
+		receipt := ethapi.MarshalReceipt(receipts[a.TxIndex], block.Hash(), block.NumberU64(), signer, txs[a.TxIndex], int(a.TxIndex))
+		receipt["timestamp"] = block.Time()
+
+		result.Txs = append(result.Txs, ethapi.NewRPCTransactionFromBlockIndex(block, uint64(a.TxIndex), config))
+		result.Receipts = append(result.Receipts, receipt)
+	}
+	return result, nil
+}
+
+// GetTransactionBySenderAndNonce returns the hash of the transaction sent by the
+// given address with the given nonce, or nil if there is no such transaction.
+//
+// As the nonces of the transactions sent by an address increase along the chain,
+// the blocks the address appears in are binary searched, only loading the ones
+// probed until the block whose nonce range covers the requested one is found.
+func (api *API) GetTransactionBySenderAndNonce(ctx context.Context, address common.Address, nonce uint64) (*common.Hash, error) {
+	indexed := rawdb.ReadAddressIndexHead(api.backend.ChainDb())
+	if indexed == nil {
+		return nil, errAddressIndexUnavailable
+	}
+	lo, hi := uint64(0), *indexed+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		number, txs, err := api.sentTransactions(ctx, address, mid, hi)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(txs) == 0 || nonce < txs[0].Nonce():
+			hi = mid
+		case nonce > txs[len(txs)-1].Nonce():
+			lo = number + 1
+		default:
+			for _, tx := range txs {
+				if tx.Nonce() == nonce {
+					hash := tx.Hash()
+					return &hash, nil
+				}
+			}
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+// sentTransactions returns the transactions sent by the address in the first
+// block of the range [from, to) it sent any in, along with the number of that
+// block. No transactions are returned if the address sent none in the range.
+func (api *API) sentTransactions(ctx context.Context, address common.Address, from, to uint64) (uint64, []*types.Transaction, error) {
+	var (
+		db     = api.backend.ChainDb()
+		config = api.backend.ChainConfig()
+		cursor = rawdb.AddressAppearance{BlockNumber: from}
+		end    = rawdb.AddressAppearance{BlockNumber: to}
+		block  *types.Block
+		sent   []*types.Transaction
+	)
+	for {
+		found := rawdb.ReadAddressAppearanceRange(db, address, cursor, end, maxPageSize)
+		for _, a := range found {
+			if block == nil || block.NumberU64() != a.BlockNumber {
+				// Moving on to the next block, return the transactions of the
+				// previous one if the address sent any.
+				if len(sent) > 0 {
+					return block.NumberU64(), sent, nil
+				}
+				var err error
+				block, err = api.backend.BlockByNumber(ctx, rpc.BlockNumber(a.BlockNumber))
+				if err != nil {
+					return 0, nil, err
+				}
+				if block == nil {
+					return 0, nil, fmt.Errorf("block #%d not found", a.BlockNumber)
+				}
+			}
+			txs := block.Transactions()
+			if int(a.TxIndex) >= len(txs) {
+				return 0, nil, fmt.Errorf("transaction %d not found in block #%d", a.TxIndex, a.BlockNumber)
+			}
+			tx := txs[a.TxIndex]
+			if sender, err := types.Sender(types.MakeSigner(config, block.Number(), block.Time()), tx); err == nil && sender == address {
+				sent = append(sent, tx)
+			}
+		}
+		if len(found) < maxPageSize {
+			if len(sent) > 0 {
+				return block.NumberU64(), sent, nil
+			}
+			return 0, nil, nil
+		}
+		// Resume right after the last visited appearance, which might be in
+		// the middle of a block.
+		cursor = found[len(found)-1]
+		if cursor.TxIndex == math.MaxUint32 {
+			cursor = rawdb.AddressAppearance{BlockNumber: cursor.BlockNumber + 1}
+		} else {
+			cursor.TxIndex++
+		}
+	}
+}
+
+// ContractCreator describes the transaction which deployed a contract.
+type ContractCreator struct {
+	Tx      common.Hash    `json:"hash"`
+	Creator common.Address `json:"creator"`
+}
+
+// GetContractCreator returns the transaction which deployed the contract at the
+// given address, along with the address of its deployer. It returns nil if the
+// address is not a contract or its creation cannot be located.
+//
+// The creation is expected to be the first transaction the contract appears in.
+// Contracts deployed by other contracts are only found if internal calls are
+// indexed.
+func (api *API) GetContractCreator(ctx context.Context, address common.Address) (*ContractCreator, error) {
+	hasCode, err := api.HasCode(ctx, address, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	if err != nil || !hasCode {
+		return nil, err
+	}
+	if rawdb.ReadAddressIndexHead(api.backend.ChainDb()) == nil {
+		return nil, errAddressIndexUnavailable
+	}
+	found := rawdb.ReadAddressAppearances(api.backend.ChainDb(), address, 0, math.MaxUint64, 1)
+	if len(found) == 0 {
+		return nil, nil
+	}
+	block, err := api.backend.BlockByNumber(ctx, rpc.BlockNumber(found[0].BlockNumber))
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errBlockNotFound
+	}
+	txs := block.Transactions()
+	if int(found[0].TxIndex) >= len(txs) {
+		return nil, fmt.Errorf("transaction %d not found in block #%d", found[0].TxIndex, found[0].BlockNumber)
+	}
+	tx := txs[found[0].TxIndex]
+	frame, err := api.traceCalls(ctx, tx.Hash(), false)
+	if err != nil {
+		return nil, err
+	}
+	var creator *ContractCreator
+	frame.walk(0, func(f *callFrame, depth int) bool {
+		if (f.Type == "CREATE" || f.Type == "CREATE2") && f.To != nil && *f.To == address {
+			creator = &ContractCreator{Tx: tx.Hash(), Creator: f.From}
+			return false
+		}
+		return true
+	})
+	return creator, nil
+}
+
+// TraceEntry is a single call frame of a transaction.
+type TraceEntry struct {
+	Type   string          `json:"type"`
+	Depth  int             `json:"depth"`
+	From   common.Address  `json:"from"`
+	To     *common.Address `json:"to"`
+	Value  *hexutil.Big    `json:"value"`
+	Input  hexutil.Bytes   `json:"input"`
+	Output hexutil.Bytes   `json:"output"`
+}
+
+// TraceTransaction returns the flattened list of call frames of a transaction.
+func (api *API) TraceTransaction(ctx context.Context, hash common.Hash) ([]*TraceEntry, error) {
+	frame, err := api.traceCalls(ctx, hash, false)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*TraceEntry
+	frame.walk(0, func(f *callFrame, depth int) bool {
+		entries = append(entries, &TraceEntry{
+			Type:   f.Type,
+			Depth:  depth,
+			From:   f.From,
+			To:     f.To,
+			Value:  f.Value,
+			Input:  f.Input,
+			Output: f.Output,
+		})
+		return true
+	})
+	return entries, nil
+}
+
+// GetTransactionError returns the revert data of a failed transaction. The result
+// is empty if the transaction succeeded.
+func (api *API) GetTransactionError(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
+	frame, err := api.traceCalls(ctx, hash, true)
+	if err != nil {
+		return nil, err
+	}
+	if frame.Error == "" {
+		return hexutil.Bytes{}, nil
+	}
+	return frame.Output, nil
+}
+
+// callFrame is the JSON representation of a call produced by the call tracer.
+type callFrame struct {
+	Type   string          `json:"type"`
+	From   common.Address  `json:"from"`
+	To     *common.Address `json:"to"`
+	Value  *hexutil.Big    `json:"value"`
+	Input  hexutil.Bytes   `json:"input"`
+	Output hexutil.Bytes   `json:"output"`
+	Error  string          `json:"error"`
+	Calls  []*callFrame    `json:"calls"`
+}
+
+// walk visits the frame and its children depth-first, until fn returns false.
+func (f *callFrame) walk(depth int, fn func(*callFrame, int) bool) bool {
+	if !fn(f, depth) {
+		return false
+	}
+	for _, call := range f.Calls {
+		if !call.walk(depth+1, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// traceCalls runs the call tracer on the given transaction.
+func (api *API) traceCalls(ctx context.Context, hash common.Hash, onlyTopCall bool) (*callFrame, error) {
+	var (
+		tracer = "callTracer"
+		config = &tracers.TraceConfig{Tracer: &tracer}
+	)
+	if onlyTopCall {
+		config.TracerConfig = json.RawMessage(`{"onlyTopCall":true}`)
+	}
+	res, err := api.tracer.TraceTransaction(ctx, hash, config)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := res.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected trace result type %T", res)
+	}
+	frame := new(callFrame)
+	if err := json.Unmarshal(raw, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
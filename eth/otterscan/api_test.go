@@ -0,0 +1,505 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package otterscan
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type testBackend struct {
+	chainConfig *params.ChainConfig
+	engine      consensus.Engine
+	chaindb     ethdb.Database
+	chain       *core.BlockChain
+	blockLoads  int // Number of blocks retrieved by number
+}
+
+// newTestBackend creates a chain with the address index enabled and waits for
+// the index to catch up with the head.
+func newTestBackend(t *testing.T, n int, gspec *core.Genesis, generator func(i int, b *core.BlockGen)) *testBackend {
+	backend := &testBackend{
+		chainConfig: gspec.Config,
+		engine:      ethash.NewFaker(),
+		chaindb:     rawdb.NewMemoryDatabase(),
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, backend.engine, n, generator)
+
+	cacheConfig := &core.CacheConfig{
+		TrieCleanLimit:    256,
+		TrieDirtyLimit:    256,
+		TrieTimeLimit:     5 * time.Minute,
+		TrieDirtyDisabled: true, // Archive mode
+		AddressIndex:      true,
+	}
+	chain, err := core.NewBlockChain(backend.chaindb, cacheConfig, gspec, nil, backend.engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	t.Cleanup(chain.Stop)
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	backend.chain = chain
+
+	for i := 0; ; i++ {
+		if head := rawdb.ReadAddressIndexHead(backend.chaindb); head != nil && *head == uint64(n) {
+			break
+		}
+		if i == 500 {
+			t.Fatal("address index did not catch up with the chain")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return backend
+}
+
+func (b *testBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return b.chain.GetHeaderByHash(hash), nil
+}
+
+func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if number == rpc.PendingBlockNumber || number == rpc.LatestBlockNumber {
+		return b.chain.CurrentHeader(), nil
+	}
+	return b.chain.GetHeaderByNumber(uint64(number)), nil
+}
+
+func (b *testBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return b.chain.GetBlockByHash(hash), nil
+}
+
+func (b *testBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	b.blockLoads++
+	if number == rpc.PendingBlockNumber || number == rpc.LatestBlockNumber {
+		return b.chain.GetBlockByNumber(b.chain.CurrentBlock().Number.Uint64()), nil
+	}
+	return b.chain.GetBlockByNumber(uint64(number)), nil
+}
+
+func (b *testBackend) GetTransaction(txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64) {
+	tx, hash, blockNumber, index := rawdb.ReadTransaction(b.chaindb, txHash)
+	return tx != nil, tx, hash, blockNumber, index
+}
+
+func (b *testBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
+	return b.chain.GetReceiptsByHash(hash), nil
+}
+
+func (b *testBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	var header *types.Header
+	if number, ok := blockNrOrHash.Number(); ok {
+		header, _ = b.HeaderByNumber(ctx, number)
+	} else if hash, ok := blockNrOrHash.Hash(); ok {
+		header = b.chain.GetHeaderByHash(hash)
+	}
+	if header == nil {
+		return nil, nil, errors.New("header not found")
+	}
+	statedb, err := b.chain.StateAt(header.Root)
+	return statedb, header, err
+}
+
+func (b *testBackend) TxIndexDone() bool                { return true }
+func (b *testBackend) RPCGasCap() uint64                { return 25000000 }
+func (b *testBackend) ChainConfig() *params.ChainConfig { return b.chainConfig }
+func (b *testBackend) Engine() consensus.Engine         { return b.engine }
+func (b *testBackend) ChainDb() ethdb.Database          { return b.chaindb }
+
+func (b *testBackend) StateAtBlock(ctx context.Context, block *types.Block, reexec uint64, base *state.StateDB, readOnly bool, preferDisk bool) (*state.StateDB, tracers.StateReleaseFunc, error) {
+	statedb, err := b.chain.StateAt(block.Root())
+	if err != nil {
+		return nil, nil, err
+	}
+	return statedb, func() {}, nil
+}
+
+func (b *testBackend) StateAtTransaction(ctx context.Context, block *types.Block, txIndex int, reexec uint64) (*types.Transaction, vm.BlockContext, *state.StateDB, tracers.StateReleaseFunc, error) {
+	parent := b.chain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, vm.BlockContext{}, nil, nil, errBlockNotFound
+	}
+	statedb, release, err := b.StateAtBlock(ctx, parent, reexec, nil, true, false)
+	if err != nil {
+		return nil, vm.BlockContext{}, nil, nil, err
+	}
+	signer := types.MakeSigner(b.chainConfig, block.Number(), block.Time())
+	context := core.NewEVMBlockContext(block.Header(), b.chain, nil)
+	evm := vm.NewEVM(context, statedb, b.chainConfig, vm.Config{})
+	for idx, tx := range block.Transactions() {
+		if idx == txIndex {
+			return tx, context, statedb, release, nil
+		}
+		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
+		if _, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(tx.Gas())); err != nil {
+			return nil, vm.BlockContext{}, nil, nil, fmt.Errorf("transaction %#x failed: %v", tx.Hash(), err)
+		}
+		statedb.Finalise(evm.ChainConfig().IsEIP158(block.Number()))
+	}
+	return nil, vm.BlockContext{}, nil, nil, fmt.Errorf("transaction index %d out of range for block %#x", txIndex, block.Hash())
+}
+
+var (
+	testKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr    = crypto.PubkeyToAddress(testKey.PublicKey)
+	testRecv    = common.HexToAddress("0x1111")
+	testReverts = common.HexToAddress("0x2222")
+	testCreated = crypto.CreateAddress(testAddr, 0)
+)
+
+// newTestAPI creates a chain of four blocks sent from testAddr:
+//
+//	block 1: contract creation (nonce 0), transfer to testRecv (nonce 1)
+//	block 2: failing call to testReverts (nonce 2)
+//	block 3: transfer to testRecv (nonce 3)
+//	block 4: two transfers to testRecv (nonces 4, 5)
+func newTestAPI(t *testing.T) (*API, *testBackend, []common.Hash) {
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			testAddr: {Balance: big.NewInt(params.Ether)},
+			// Reverts with 0x2a as a 32 byte word.
+			testReverts: {Code: common.FromHex("602a60005260206000fd")},
+		},
+	}
+	var (
+		signer = types.LatestSigner(gspec.Config)
+		hashes []common.Hash
+	)
+	send := func(b *core.BlockGen, to *common.Address, data []byte) {
+		tx, _ := types.SignNewTx(testKey, signer, &types.LegacyTx{
+			Nonce:    b.TxNonce(testAddr),
+			To:       to,
+			Value:    big.NewInt(1),
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+			Data:     data,
+		})
+		b.AddTx(tx)
+		hashes = append(hashes, tx.Hash())
+	}
+	backend := newTestBackend(t, 4, gspec, func(i int, b *core.BlockGen) {
+		switch i {
+		case 0:
+			// Deploys a contract consisting of a single STOP opcode.
+			send(b, nil, common.FromHex("600060005360016000f3"))
+			send(b, &testRecv, nil)
+		case 1:
+			send(b, &testReverts, nil)
+		case 2:
+			send(b, &testRecv, nil)
+		case 3:
+			send(b, &testRecv, nil)
+			send(b, &testRecv, nil)
+		}
+	})
+	return NewAPI(backend), backend, hashes
+}
+
+func txHashes(res *TransactionsWithReceipts) []common.Hash {
+	var hashes []common.Hash
+	for _, tx := range res.Txs {
+		hashes = append(hashes, tx.Hash)
+	}
+	return hashes
+}
+
+func checkPage(t *testing.T, res *TransactionsWithReceipts, want []common.Hash, firstPage, lastPage bool) {
+	t.Helper()
+	have := txHashes(res)
+	if len(have) != len(want) {
+		t.Fatalf("wrong number of transactions: have %d, want %d", len(have), len(want))
+	}
+	for i := range want {
+		if have[i] != want[i] {
+			t.Fatalf("transaction %d mismatch: have %x, want %x", i, have[i], want[i])
+		}
+	}
+	if len(res.Receipts) != len(want) {
+		t.Fatalf("wrong number of receipts: have %d, want %d", len(res.Receipts), len(want))
+	}
+	if res.FirstPage != firstPage || res.LastPage != lastPage {
+		t.Fatalf("wrong page flags: have first=%v last=%v, want first=%v last=%v", res.FirstPage, res.LastPage, firstPage, lastPage)
+	}
+}
+
+func TestSearchTransactions(t *testing.T) {
+	api, _, hashes := newTestAPI(t)
+	ctx := context.Background()
+
+	// The latest page is completed with all transactions of its last block.
+	res, err := api.SearchTransactionsBefore(ctx, testAddr, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPage(t, res, []common.Hash{hashes[5], hashes[4]}, true, false)
+	if res.Receipts[0]["timestamp"] == nil {
+		t.Fatal("receipt is missing the block timestamp")
+	}
+	res, err = api.SearchTransactionsBefore(ctx, testAddr, 4, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPage(t, res, []common.Hash{hashes[3], hashes[2], hashes[1], hashes[0]}, false, true)
+
+	// Searching forward still returns the transactions in descending order.
+	res, err = api.SearchTransactionsAfter(ctx, testAddr, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPage(t, res, []common.Hash{hashes[2], hashes[1], hashes[0]}, false, true)
+
+	res, err = api.SearchTransactionsAfter(ctx, testAddr, 2, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPage(t, res, []common.Hash{hashes[5], hashes[4], hashes[3]}, true, false)
+
+	// Recipients and created contracts are indexed as well.
+	res, err = api.SearchTransactionsBefore(ctx, testRecv, 4, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPage(t, res, []common.Hash{hashes[3], hashes[1]}, false, true)
+
+	res, err = api.SearchTransactionsBefore(ctx, testCreated, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPage(t, res, []common.Hash{hashes[0]}, true, true)
+}
+
+func TestGetTransactionBySenderAndNonce(t *testing.T) {
+	api, _, hashes := newTestAPI(t)
+
+	for nonce, want := range hashes {
+		have, err := api.GetTransactionBySenderAndNonce(context.Background(), testAddr, uint64(nonce))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have == nil || *have != want {
+			t.Fatalf("nonce %d: have %v, want %x", nonce, have, want)
+		}
+	}
+	have, err := api.GetTransactionBySenderAndNonce(context.Background(), testAddr, uint64(len(hashes)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have != nil {
+		t.Fatalf("unexpected transaction for unused nonce: %x", *have)
+	}
+}
+
+// Tests that the transaction of a sender is looked up without loading all the
+// blocks the sender appears in.
+func TestGetTransactionBySenderAndNonceSearch(t *testing.T) {
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  types.GenesisAlloc{testAddr: {Balance: big.NewInt(params.Ether)}},
+	}
+	var (
+		signer = types.LatestSigner(gspec.Config)
+		hashes []common.Hash
+	)
+	backend := newTestBackend(t, 128, gspec, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignNewTx(testKey, signer, &types.LegacyTx{
+			Nonce:    b.TxNonce(testAddr),
+			To:       &testRecv,
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		})
+		b.AddTx(tx)
+		hashes = append(hashes, tx.Hash())
+	})
+	api := NewAPI(backend)
+
+	for _, nonce := range []uint64{0, 57, 100, 127, 128} {
+		backend.blockLoads = 0
+		have, err := api.GetTransactionBySenderAndNonce(context.Background(), testAddr, nonce)
+		if err != nil {
+			t.Fatalf("nonce %d: %v", nonce, err)
+		}
+		if nonce < uint64(len(hashes)) {
+			if have == nil || *have != hashes[nonce] {
+				t.Fatalf("nonce %d: have %v, want %x", nonce, have, hashes[nonce])
+			}
+		} else if have != nil {
+			t.Fatalf("nonce %d: unexpected transaction %x", nonce, *have)
+		}
+		if backend.blockLoads > 10 {
+			t.Fatalf("nonce %d: too many blocks loaded: %d", nonce, backend.blockLoads)
+		}
+	}
+	// Recipients of the transactions are not mistaken for the sender.
+	if have, err := api.GetTransactionBySenderAndNonce(context.Background(), testRecv, 0); err != nil || have != nil {
+		t.Fatalf("unexpected result for recipient: %v, %v", have, err)
+	}
+}
+
+// Tests that stale index entries, e.g. of a reorged block, are reported as an
+// error instead of crashing the handler.
+func TestGetTransactionBySenderAndNonceStaleIndex(t *testing.T) {
+	api, backend, hashes := newTestAPI(t)
+
+	head := backend.chain.CurrentBlock().Number.Uint64()
+	rawdb.WriteAddressIndexBlock(backend.chaindb, head, common.Hash{}, []rawdb.BlockAddressAppearance{{Address: testAddr, TxIndex: 99}})
+
+	if _, err := api.GetTransactionBySenderAndNonce(context.Background(), testAddr, uint64(len(hashes))); err == nil {
+		t.Fatal("expected error for stale index entry")
+	}
+}
+
+func TestGetContractCreator(t *testing.T) {
+	api, _, hashes := newTestAPI(t)
+
+	creator, err := api.GetContractCreator(context.Background(), testCreated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creator == nil || creator.Tx != hashes[0] || creator.Creator != testAddr {
+		t.Fatalf("wrong contract creator: %+v", creator)
+	}
+	// Accounts without code have no creator.
+	creator, err = api.GetContractCreator(context.Background(), testRecv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creator != nil {
+		t.Fatalf("unexpected creator of plain account: %+v", creator)
+	}
+}
+
+func TestTransactionTraces(t *testing.T) {
+	api, _, hashes := newTestAPI(t)
+	ctx := context.Background()
+
+	entries, err := api.TraceTransaction(ctx, hashes[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Type != "CREATE" || entries[0].Depth != 0 || *entries[0].To != testCreated {
+		t.Fatalf("wrong trace of contract creation: %+v", entries)
+	}
+	revert, err := api.GetTransactionError(ctx, hashes[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.LeftPadBytes([]byte{0x2a}, 32); !bytes.Equal(revert, want) {
+		t.Fatalf("wrong revert data: have %x, want %x", revert, want)
+	}
+	revert, err = api.GetTransactionError(ctx, hashes[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revert) != 0 {
+		t.Fatalf("unexpected revert data of successful transaction: %x", revert)
+	}
+}
+
+func TestBlockDetails(t *testing.T) {
+	api, backend, hashes := newTestAPI(t)
+	ctx := context.Background()
+
+	details, err := api.GetBlockDetails(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := details["block"].(map[string]interface{})
+	if _, ok := block["logsBloom"]; ok {
+		t.Fatal("block details contain the logs bloom")
+	}
+	if count := block["transactionCount"].(hexutil.Uint64); count != 2 {
+		t.Fatalf("wrong transaction count: %d", count)
+	}
+	var (
+		receipts = backend.chain.GetReceiptsByHash(backend.chain.GetCanonicalHash(4))
+		fees     = new(big.Int)
+	)
+	for _, r := range receipts {
+		fees.Add(fees, new(big.Int).Mul(r.EffectiveGasPrice, new(big.Int).SetUint64(r.GasUsed)))
+	}
+	if have := details["totalFees"].(*hexutil.Big).ToInt(); have.Cmp(fees) != 0 {
+		t.Fatalf("wrong total fees: have %v, want %v", have, fees)
+	}
+	issuance := details["issuance"].(*Issuance)
+	if issuance.BlockReward.ToInt().Cmp(ethash.ConstantinopleBlockReward.ToBig()) != 0 {
+		t.Fatalf("wrong block reward: %v", issuance.BlockReward)
+	}
+	byHash, err := api.GetBlockDetailsByHash(ctx, backend.chain.GetCanonicalHash(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byHash["block"].(map[string]interface{})["hash"] != block["hash"] {
+		t.Fatal("block details by hash mismatch")
+	}
+	// Block transactions are paginated from the end of the block.
+	for page, want := range []common.Hash{hashes[5], hashes[4]} {
+		res, err := api.GetBlockTransactions(ctx, 4, uint(page), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs := res["fullblock"].(map[string]interface{})["transactions"].([]*ethapi.RPCTransaction)
+		if len(txs) != 1 || txs[0].Hash != want {
+			t.Fatalf("page %d: wrong transactions", page)
+		}
+		if len(res["receipts"].([]map[string]interface{})) != 1 {
+			t.Fatalf("page %d: wrong number of receipts", page)
+		}
+	}
+}
+
+func TestAddressIndexUnavailable(t *testing.T) {
+	gspec := &core.Genesis{Config: params.TestChainConfig}
+	backend := &testBackend{
+		chainConfig: gspec.Config,
+		engine:      ethash.NewFaker(),
+		chaindb:     rawdb.NewMemoryDatabase(),
+	}
+	chain, err := core.NewBlockChain(backend.chaindb, nil, gspec, nil, backend.engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chain.Stop()
+	backend.chain = chain
+
+	api := NewAPI(backend)
+	if _, err := api.SearchTransactionsBefore(context.Background(), testAddr, 0, 10); err != errAddressIndexUnavailable {
+		t.Fatalf("wrong error: have %v, want %v", err, errAddressIndexUnavailable)
+	}
+}
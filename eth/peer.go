@@ -17,6 +17,7 @@
 package eth
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 )
@@ -25,6 +26,10 @@ import (
 // about a connected peer.
 type ethPeerInfo struct {
 	Version uint `json:"version"` // Ethereum protocol version negotiated
+
+	EarliestBlock   *uint64      `json:"earliestBlock,omitempty"`   // Earliest block announced to be served (eth/69+)
+	LatestBlock     *uint64      `json:"latestBlock,omitempty"`     // Latest block announced to be served (eth/69+)
+	LatestBlockHash *common.Hash `json:"latestBlockHash,omitempty"` // Hash of the latest announced block (eth/69+)
 }
 
 // ethPeer is a wrapper around eth.Peer to maintain a few extra metadata.
@@ -35,9 +40,15 @@ type ethPeer struct {
 
 // info gathers and returns some `eth` protocol metadata known about a peer.
 func (p *ethPeer) info() *ethPeerInfo {
-	return &ethPeerInfo{
+	info := &ethPeerInfo{
 		Version: p.Version(),
 	}
+	if blockRange := p.BlockRange(); blockRange != nil {
+		info.EarliestBlock = &blockRange.EarliestBlock
+		info.LatestBlock = &blockRange.LatestBlock
+		info.LatestBlockHash = &blockRange.LatestBlockHash
+	}
+	return info
 }
 
 // snapPeerInfo represents a short summary of the `snap` sub-protocol metadata known
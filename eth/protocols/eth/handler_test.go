@@ -43,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
 )
 
@@ -173,6 +174,7 @@ func (b *testBackend) Handle(*Peer, Packet) error {
 
 // Tests that block headers can be retrieved from a remote chain based on user queries.
 func TestGetBlockHeaders68(t *testing.T) { testGetBlockHeaders(t, ETH68) }
+func TestGetBlockHeaders69(t *testing.T) { testGetBlockHeaders(t, ETH69) }
 
 func testGetBlockHeaders(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -386,6 +388,7 @@ func testGetBlockHeaders(t *testing.T, protocol uint) {
 
 // Tests that block contents can be retrieved from a remote chain based on their hashes.
 func TestGetBlockBodies68(t *testing.T) { testGetBlockBodies(t, ETH68) }
+func TestGetBlockBodies69(t *testing.T) { testGetBlockBodies(t, ETH69) }
 
 func testGetBlockBodies(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -480,6 +483,7 @@ func testGetBlockBodies(t *testing.T, protocol uint) {
 
 // Tests that the transaction receipts can be retrieved based on hashes.
 func TestGetBlockReceipts68(t *testing.T) { testGetBlockReceipts(t, ETH68) }
+func TestGetBlockReceipts69(t *testing.T) { testGetBlockReceipts(t, ETH69) }
 
 func testGetBlockReceipts(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -542,6 +546,33 @@ func testGetBlockReceipts(t *testing.T, protocol uint) {
 		RequestId:          123,
 		GetReceiptsRequest: hashes,
 	})
+	if protocol >= ETH69 {
+		// Receipts are sent without blooms, make sure they can be restored
+		response := make(ReceiptsResponse69, len(receipts))
+		for i, list := range receipts {
+			response[i] = make([]*Receipt69, len(list))
+			for j, receipt := range list {
+				response[i][j] = newReceipt69(receipt)
+			}
+		}
+		if err := p2p.ExpectMsg(peer.app, ReceiptsMsg, &ReceiptsPacket69{
+			RequestId:          123,
+			ReceiptsResponse69: response,
+		}); err != nil {
+			t.Errorf("receipts mismatch: %v", err)
+		}
+		unpacked, err := response.Unpack()
+		if err != nil {
+			t.Fatalf("failed to unpack receipts: %v", err)
+		}
+		for i, list := range unpacked {
+			block := backend.chain.GetBlockByNumber(uint64(i))
+			if hash := types.DeriveSha(types.Receipts(list), trie.NewStackTrie(nil)); hash != block.ReceiptHash() {
+				t.Errorf("block %d: receipt root mismatch: have %x, want %x", i, hash, block.ReceiptHash())
+			}
+		}
+		return
+	}
 	if err := p2p.ExpectMsg(peer.app, ReceiptsMsg, &ReceiptsPacket{
 		RequestId:        123,
 		ReceiptsResponse: receipts,
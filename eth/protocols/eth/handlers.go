@@ -249,13 +249,39 @@ func handleGetReceipts(backend Backend, msg Decoder, peer *Peer) error {
 	if err := msg.Decode(&query); err != nil {
 		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 	}
-	response := ServiceGetReceiptsQuery(backend.Chain(), query.GetReceiptsRequest)
+	var response []rlp.RawValue
+	if peer.Version() >= ETH69 {
+		response = ServiceGetReceiptsQuery69(backend.Chain(), query.GetReceiptsRequest)
+	} else {
+		response = ServiceGetReceiptsQuery(backend.Chain(), query.GetReceiptsRequest)
+	}
 	return peer.ReplyReceiptsRLP(query.RequestId, response)
 }
 
 // ServiceGetReceiptsQuery assembles the response to a receipt query. It is
 // exposed to allow external packages to test protocol behavior.
 func ServiceGetReceiptsQuery(chain *core.BlockChain, query GetReceiptsRequest) []rlp.RawValue {
+	return serviceGetReceiptsQuery(chain, query, func(receipts types.Receipts) ([]byte, error) {
+		return rlp.EncodeToBytes(receipts)
+	})
+}
+
+// ServiceGetReceiptsQuery69 assembles the response to a receipt query on eth/69
+// and newer, where the receipts are encoded without their bloom filters. It is
+// exposed to allow external packages to test protocol behavior.
+func ServiceGetReceiptsQuery69(chain *core.BlockChain, query GetReceiptsRequest) []rlp.RawValue {
+	return serviceGetReceiptsQuery(chain, query, func(receipts types.Receipts) ([]byte, error) {
+		list := make([]*Receipt69, len(receipts))
+		for i, receipt := range receipts {
+			list[i] = newReceipt69(receipt)
+		}
+		return rlp.EncodeToBytes(list)
+	})
+}
+
+// serviceGetReceiptsQuery assembles the response to a receipt query, using the
+// given encoder to serialize the receipts of a block.
+func serviceGetReceiptsQuery(chain *core.BlockChain, query GetReceiptsRequest, encode func(types.Receipts) ([]byte, error)) []rlp.RawValue {
 	// Gather state data until the fetch or network limits is reached
 	var (
 		bytes    int
@@ -274,7 +300,7 @@ func ServiceGetReceiptsQuery(chain *core.BlockChain, query GetReceiptsRequest) [
 			}
 		}
 		// If known, encode and queue for response packet
-		if encoded, err := rlp.EncodeToBytes(results); err != nil {
+		if encoded, err := encode(results); err != nil {
 			log.Error("Failed to encode receipt", "err", err)
 		} else {
 			receipts = append(receipts, encoded)
@@ -362,6 +388,46 @@ func handleReceipts(backend Backend, msg Decoder, peer *Peer) error {
 	}, metadata)
 }
 
+func handleReceipts69(backend Backend, msg Decoder, peer *Peer) error {
+	// A batch of receipts arrived to one of our previous requests
+	res := new(ReceiptsPacket69)
+	if err := msg.Decode(res); err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	// Restore the bloom filters, so the receipts can be delivered the same way
+	// as the ones retrieved via eth/68
+	receipts, err := res.ReceiptsResponse69.Unpack()
+	if err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	metadata := func() interface{} {
+		hasher := trie.NewStackTrie(nil)
+		hashes := make([]common.Hash, len(receipts))
+		for i, receipt := range receipts {
+			hashes[i] = types.DeriveSha(types.Receipts(receipt), hasher)
+		}
+		return hashes
+	}
+	return peer.dispatchResponse(&Response{
+		id:   res.RequestId,
+		code: ReceiptsMsg,
+		Res:  &receipts,
+	}, metadata)
+}
+
+func handleBlockRangeUpdate(backend Backend, msg Decoder, peer *Peer) error {
+	// A peer announced a change in the range of blocks it is able to serve
+	update := new(BlockRangeUpdatePacket)
+	if err := msg.Decode(update); err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	if err := update.Validate(); err != nil {
+		return err
+	}
+	peer.blockRange.Store(update)
+	return nil
+}
+
 func handleNewPooledTransactionHashes(backend Backend, msg Decoder, peer *Peer) error {
 	// New transaction announcement arrived, make sure we have
 	// a valid and fresh chain to handle them
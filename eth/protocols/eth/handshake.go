@@ -35,25 +35,36 @@ const (
 )
 
 // Handshake executes the eth protocol handshake, negotiating version number,
-// network IDs, difficulties, head and genesis blocks.
-func (p *Peer) Handshake(network uint64, head common.Hash, genesis common.Hash, forkID forkid.ID, forkFilter forkid.Filter) error {
+// network IDs, head and genesis blocks. On eth/69 and newer, the range of blocks
+// available locally is exchanged too.
+func (p *Peer) Handshake(network uint64, blockRange BlockRangeUpdatePacket, genesis common.Hash, forkID forkid.ID, forkFilter forkid.Filter) error {
 	// Send out own handshake in a new thread
 	errc := make(chan error, 2)
 
-	var status StatusPacket // safe to read after two values have been received from errc
-
 	go func() {
+		if p.version >= ETH69 {
+			errc <- p2p.Send(p.rw, StatusMsg, &StatusPacket69{
+				ProtocolVersion: uint32(p.version),
+				NetworkID:       network,
+				Genesis:         genesis,
+				ForkID:          forkID,
+				EarliestBlock:   blockRange.EarliestBlock,
+				LatestBlock:     blockRange.LatestBlock,
+				LatestBlockHash: blockRange.LatestBlockHash,
+			})
+			return
+		}
 		errc <- p2p.Send(p.rw, StatusMsg, &StatusPacket{
 			ProtocolVersion: uint32(p.version),
 			NetworkID:       network,
 			TD:              new(big.Int), // unknown for post-merge tail=pruned networks
-			Head:            head,
+			Head:            blockRange.LatestBlockHash,
 			Genesis:         genesis,
 			ForkID:          forkID,
 		})
 	}()
 	go func() {
-		errc <- p.readStatus(network, &status, genesis, forkFilter)
+		errc <- p.readStatus(network, genesis, forkFilter)
 	}()
 	timeout := time.NewTimer(handshakeTimeout)
 	defer timeout.Stop()
@@ -73,7 +84,7 @@ func (p *Peer) Handshake(network uint64, head common.Hash, genesis common.Hash,
 }
 
 // readStatus reads the remote handshake message.
-func (p *Peer) readStatus(network uint64, status *StatusPacket, genesis common.Hash, forkFilter forkid.Filter) error {
+func (p *Peer) readStatus(network uint64, genesis common.Hash, forkFilter forkid.Filter) error {
 	msg, err := p.rw.ReadMsg()
 	if err != nil {
 		return err
@@ -85,19 +96,45 @@ func (p *Peer) readStatus(network uint64, status *StatusPacket, genesis common.H
 		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
 	}
 	// Decode the handshake and make sure everything matches
-	if err := msg.Decode(&status); err != nil {
-		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	var (
+		version   uint32
+		networkID uint64
+		gen       common.Hash
+		forkID    forkid.ID
+	)
+	if p.version >= ETH69 {
+		var status StatusPacket69
+		if err := msg.Decode(&status); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		version, networkID, gen, forkID = status.ProtocolVersion, status.NetworkID, status.Genesis, status.ForkID
+
+		blockRange := &BlockRangeUpdatePacket{
+			EarliestBlock:   status.EarliestBlock,
+			LatestBlock:     status.LatestBlock,
+			LatestBlockHash: status.LatestBlockHash,
+		}
+		if err := blockRange.Validate(); err != nil {
+			return err
+		}
+		p.blockRange.Store(blockRange)
+	} else {
+		var status StatusPacket
+		if err := msg.Decode(&status); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		version, networkID, gen, forkID = status.ProtocolVersion, status.NetworkID, status.Genesis, status.ForkID
 	}
-	if status.NetworkID != network {
-		return fmt.Errorf("%w: %d (!= %d)", errNetworkIDMismatch, status.NetworkID, network)
+	if networkID != network {
+		return fmt.Errorf("%w: %d (!= %d)", errNetworkIDMismatch, networkID, network)
 	}
-	if uint(status.ProtocolVersion) != p.version {
-		return fmt.Errorf("%w: %d (!= %d)", errProtocolVersionMismatch, status.ProtocolVersion, p.version)
+	if uint(version) != p.version {
+		return fmt.Errorf("%w: %d (!= %d)", errProtocolVersionMismatch, version, p.version)
 	}
-	if status.Genesis != genesis {
-		return fmt.Errorf("%w: %x (!= %x)", errGenesisMismatch, status.Genesis, genesis)
+	if gen != genesis {
+		return fmt.Errorf("%w: %x (!= %x)", errGenesisMismatch, gen, genesis)
 	}
-	if err := forkFilter(status.ForkID); err != nil {
+	if err := forkFilter(forkID); err != nil {
 		return fmt.Errorf("%w: %v", errForkIDRejected, err)
 	}
 	return nil
@@ -25,10 +25,12 @@ import (
 	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 // Tests that handshake failures are detected and reported correctly.
 func TestHandshake68(t *testing.T) { testHandshake(t, ETH68) }
+func TestHandshake69(t *testing.T) { testHandshake(t, ETH69) }
 
 func testHandshake(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -41,32 +43,63 @@ func testHandshake(t *testing.T, protocol uint) {
 		genesis = backend.chain.Genesis()
 		head    = backend.chain.CurrentBlock()
 		forkID  = forkid.NewID(backend.chain.Config(), backend.chain.Genesis(), backend.chain.CurrentHeader().Number.Uint64(), backend.chain.CurrentHeader().Time)
+		number  = head.Number.Uint64()
+
+		blockRange = BlockRangeUpdatePacket{LatestBlock: number, LatestBlockHash: head.Hash()}
 	)
-	tests := []struct {
+	type handshakeTest struct {
 		code uint64
 		data interface{}
 		want error
-	}{
+	}
+	tests := []handshakeTest{
 		{
 			code: TransactionsMsg, data: []interface{}{},
 			want: errNoStatusMsg,
 		},
-		{
-			code: StatusMsg, data: StatusPacket{10, 1, new(big.Int), head.Hash(), genesis.Hash(), forkID},
-			want: errProtocolVersionMismatch,
-		},
-		{
-			code: StatusMsg, data: StatusPacket{uint32(protocol), 999, new(big.Int), head.Hash(), genesis.Hash(), forkID},
-			want: errNetworkIDMismatch,
-		},
-		{
-			code: StatusMsg, data: StatusPacket{uint32(protocol), 1, new(big.Int), head.Hash(), common.Hash{3}, forkID},
-			want: errGenesisMismatch,
-		},
-		{
-			code: StatusMsg, data: StatusPacket{uint32(protocol), 1, new(big.Int), head.Hash(), genesis.Hash(), forkid.ID{Hash: [4]byte{0x00, 0x01, 0x02, 0x03}}},
-			want: errForkIDRejected,
-		},
+	}
+	if protocol >= ETH69 {
+		tests = append(tests, []handshakeTest{
+			{
+				code: StatusMsg, data: StatusPacket69{10, 1, genesis.Hash(), forkID, 0, number, head.Hash()},
+				want: errProtocolVersionMismatch,
+			},
+			{
+				code: StatusMsg, data: StatusPacket69{uint32(protocol), 999, genesis.Hash(), forkID, 0, number, head.Hash()},
+				want: errNetworkIDMismatch,
+			},
+			{
+				code: StatusMsg, data: StatusPacket69{uint32(protocol), 1, common.Hash{3}, forkID, 0, number, head.Hash()},
+				want: errGenesisMismatch,
+			},
+			{
+				code: StatusMsg, data: StatusPacket69{uint32(protocol), 1, genesis.Hash(), forkid.ID{Hash: [4]byte{0x00, 0x01, 0x02, 0x03}}, 0, number, head.Hash()},
+				want: errForkIDRejected,
+			},
+			{
+				code: StatusMsg, data: StatusPacket69{uint32(protocol), 1, genesis.Hash(), forkID, number + 1, number, head.Hash()},
+				want: errInvalidBlockRange,
+			},
+		}...)
+	} else {
+		tests = append(tests, []handshakeTest{
+			{
+				code: StatusMsg, data: StatusPacket{10, 1, new(big.Int), head.Hash(), genesis.Hash(), forkID},
+				want: errProtocolVersionMismatch,
+			},
+			{
+				code: StatusMsg, data: StatusPacket{uint32(protocol), 999, new(big.Int), head.Hash(), genesis.Hash(), forkID},
+				want: errNetworkIDMismatch,
+			},
+			{
+				code: StatusMsg, data: StatusPacket{uint32(protocol), 1, new(big.Int), head.Hash(), common.Hash{3}, forkID},
+				want: errGenesisMismatch,
+			},
+			{
+				code: StatusMsg, data: StatusPacket{uint32(protocol), 1, new(big.Int), head.Hash(), genesis.Hash(), forkid.ID{Hash: [4]byte{0x00, 0x01, 0x02, 0x03}}},
+				want: errForkIDRejected,
+			},
+		}...)
 	}
 	for i, test := range tests {
 		// Create the two peers to shake with each other
@@ -80,7 +113,7 @@ func testHandshake(t *testing.T, protocol uint) {
 		// Send the junk test with one peer, check the handshake failure
 		go p2p.Send(app, test.code, test.data)
 
-		err := peer.Handshake(1, head.Hash(), genesis.Hash(), forkID, forkid.NewFilter(backend.chain))
+		err := peer.Handshake(1, blockRange, genesis.Hash(), forkID, forkid.NewFilter(backend.chain))
 		if err == nil {
 			t.Errorf("test %d: protocol returned nil error, want %q", i, test.want)
 		} else if !errors.Is(err, test.want) {
@@ -88,3 +121,54 @@ func testHandshake(t *testing.T, protocol uint) {
 		}
 	}
 }
+
+// Tests that the block range announced in the eth/69 handshake and updated later
+// on is tracked for the remote peer.
+func TestHandshakeBlockRange69(t *testing.T) {
+	t.Parallel()
+
+	backend := newTestBackend(3)
+	defer backend.close()
+
+	var (
+		genesis = backend.chain.Genesis()
+		head    = backend.chain.CurrentBlock()
+		forkID  = forkid.NewID(backend.chain.Config(), genesis, head.Number.Uint64(), head.Time)
+	)
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	defer net.Close()
+
+	peer := NewPeer(ETH69, p2p.NewPeer(enode.ID{}, "peer", nil), net, nil)
+	defer peer.Close()
+
+	// Run the handshake with a remote announcing a pruned history
+	go p2p.Send(app, StatusMsg, &StatusPacket69{ETH69, 1, genesis.Hash(), forkID, 2, 3, head.Hash()})
+	go func() {
+		msg, err := app.ReadMsg()
+		if err == nil {
+			msg.Discard()
+		}
+	}()
+	local := BlockRangeUpdatePacket{LatestBlock: head.Number.Uint64(), LatestBlockHash: head.Hash()}
+	if err := peer.Handshake(1, local, genesis.Hash(), forkID, forkid.NewFilter(backend.chain)); err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+	if have, want := peer.BlockRange(), (&BlockRangeUpdatePacket{2, 3, head.Hash()}); *have != *want {
+		t.Fatalf("block range mismatch: have %v, want %v", have, want)
+	}
+	// Announce an update and check that it's tracked
+	update := &BlockRangeUpdatePacket{3, 40, common.Hash{0x40}}
+	payload, _ := rlp.EncodeToBytes(update)
+	if err := handleBlockRangeUpdate(backend, decoder{msg: payload}, peer); err != nil {
+		t.Fatalf("failed to handle block range update: %v", err)
+	}
+	if have := peer.BlockRange(); *have != *update {
+		t.Fatalf("block range mismatch: have %v, want %v", have, update)
+	}
+	// Invalid updates must be rejected
+	payload, _ = rlp.EncodeToBytes(&BlockRangeUpdatePacket{41, 40, common.Hash{0x40}})
+	if err := handleBlockRangeUpdate(backend, decoder{msg: payload}, peer); !errors.Is(err, errInvalidBlockRange) {
+		t.Fatalf("invalid block range accepted: %v", err)
+	}
+}
@@ -18,6 +18,7 @@ package eth
 
 import (
 	"math/rand"
+	"sync/atomic"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/ethereum/go-ethereum/common"
@@ -48,6 +49,8 @@ type Peer struct {
 	rw        p2p.MsgReadWriter // Input/output streams for snap
 	version   uint              // Protocol version negotiated
 
+	blockRange atomic.Pointer[BlockRangeUpdatePacket] // Range of blocks announced by the peer (eth/69+)
+
 	txpool      TxPool             // Transaction pool used by the broadcasters for liveness checks
 	knownTxs    *knownCache        // Set of transaction hashes known to be known by this peer
 	txBroadcast chan []common.Hash // Channel used to queue transaction propagation requests
@@ -102,6 +105,21 @@ func (p *Peer) Version() uint {
 	return p.version
 }
 
+// BlockRange retrieves the range of blocks the peer last announced to be able
+// to serve. Nil is returned if the peer didn't announce any, i.e. before eth/69.
+func (p *Peer) BlockRange() *BlockRangeUpdatePacket {
+	return p.blockRange.Load()
+}
+
+// SendBlockRangeUpdate announces the range of locally available blocks to the
+// peer. It's a noop if the negotiated protocol version predates eth/69.
+func (p *Peer) SendBlockRangeUpdate(blockRange BlockRangeUpdatePacket) error {
+	if p.version < ETH69 {
+		return nil
+	}
+	return p2p.Send(p.rw, BlockRangeUpdateMsg, &blockRange)
+}
+
 // KnownTransaction returns whether peer is known to already have a transaction.
 func (p *Peer) KnownTransaction(hash common.Hash) bool {
 	return p.knownTxs.Contains(hash)
@@ -31,6 +31,7 @@ import (
 // Constants to match up protocol versions and messages
 const (
 	ETH68 = 68
+	ETH69 = 69
 )
 
 // ProtocolName is the official short name of the `eth` protocol used during
@@ -39,11 +40,11 @@ const ProtocolName = "eth"
 
 // ProtocolVersions are the supported versions of the `eth` protocol (first
 // is primary).
-var ProtocolVersions = []uint{ETH68}
+var ProtocolVersions = []uint{ETH69, ETH68}
 
 // protocolLengths are the number of implemented message corresponding to
 // different protocol versions.
-var protocolLengths = map[uint]uint64{ETH68: 17}
+var protocolLengths = map[uint]uint64{ETH69: 18, ETH68: 17}
 
 // maxMessageSize is the maximum cap on the size of a protocol message.
 const maxMessageSize = 10 * 1024 * 1024
@@ -62,6 +63,7 @@ const (
 	PooledTransactionsMsg         = 0x0a
 	GetReceiptsMsg                = 0x0f
 	ReceiptsMsg                   = 0x10
+	BlockRangeUpdateMsg           = 0x11
 )
 
 var (
@@ -73,6 +75,7 @@ var (
 	errNetworkIDMismatch       = errors.New("network ID mismatch")
 	errGenesisMismatch         = errors.New("genesis mismatch")
 	errForkIDRejected          = errors.New("fork ID rejected")
+	errInvalidBlockRange       = errors.New("invalid block range")
 )
 
 // Packet represents a p2p message in the `eth` protocol.
@@ -81,7 +84,7 @@ type Packet interface {
 	Kind() byte   // Kind returns the message type.
 }
 
-// StatusPacket is the network packet for the status message.
+// StatusPacket is the network packet for the status message on eth/68.
 type StatusPacket struct {
 	ProtocolVersion uint32
 	NetworkID       uint64
@@ -91,6 +94,38 @@ type StatusPacket struct {
 	ForkID          forkid.ID
 }
 
+// StatusPacket69 is the network packet for the status message on eth/69 and
+// newer. The total difficulty is replaced by the range of blocks the node is
+// able to serve.
+type StatusPacket69 struct {
+	ProtocolVersion uint32
+	NetworkID       uint64
+	Genesis         common.Hash
+	ForkID          forkid.ID
+	EarliestBlock   uint64
+	LatestBlock     uint64
+	LatestBlockHash common.Hash
+}
+
+// BlockRangeUpdatePacket is the network packet announcing the range of blocks
+// a node is able to serve, sent on eth/69 and newer whenever it changes.
+type BlockRangeUpdatePacket struct {
+	EarliestBlock   uint64
+	LatestBlock     uint64
+	LatestBlockHash common.Hash
+}
+
+// Validate checks the sanity of the announced block range.
+func (p *BlockRangeUpdatePacket) Validate() error {
+	if p.EarliestBlock > p.LatestBlock {
+		return fmt.Errorf("%w: earliest %d > latest %d", errInvalidBlockRange, p.EarliestBlock, p.LatestBlock)
+	}
+	if p.LatestBlockHash == (common.Hash{}) {
+		return fmt.Errorf("%w: zero latest block hash", errInvalidBlockRange)
+	}
+	return nil
+}
+
 // NewBlockHashesPacket is the network packet for the block announcements.
 type NewBlockHashesPacket []struct {
 	Hash   common.Hash // Hash of one particular block being announced
@@ -259,6 +294,85 @@ type ReceiptsPacket struct {
 	ReceiptsResponse
 }
 
+// Receipt69 is the eth/69 network encoding of a receipt. Unlike the consensus
+// encoding, it omits the bloom filter (which can be recomputed from the logs)
+// and carries the transaction type as a plain field instead of an envelope.
+type Receipt69 struct {
+	Type              uint8
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Logs              []*types.Log
+}
+
+// newReceipt69 converts a consensus receipt into its eth/69 network encoding.
+func newReceipt69(receipt *types.Receipt) *Receipt69 {
+	r := &Receipt69{
+		Type:              receipt.Type,
+		PostStateOrStatus: receipt.PostState,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		Logs:              receipt.Logs,
+	}
+	if len(receipt.PostState) == 0 {
+		r.PostStateOrStatus = []byte{}
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			r.PostStateOrStatus = []byte{0x01}
+		}
+	}
+	if r.Logs == nil {
+		r.Logs = []*types.Log{}
+	}
+	return r
+}
+
+// toReceipt converts an eth/69 network receipt into a consensus receipt,
+// recomputing the bloom filter from the logs.
+func (r *Receipt69) toReceipt() (*types.Receipt, error) {
+	receipt := &types.Receipt{
+		Type:              r.Type,
+		CumulativeGasUsed: r.CumulativeGasUsed,
+		Logs:              r.Logs,
+	}
+	switch {
+	case len(r.PostStateOrStatus) == 0:
+		receipt.Status = types.ReceiptStatusFailed
+	case len(r.PostStateOrStatus) == 1 && r.PostStateOrStatus[0] == 0x01:
+		receipt.Status = types.ReceiptStatusSuccessful
+	case len(r.PostStateOrStatus) == len(common.Hash{}):
+		receipt.PostState = r.PostStateOrStatus
+	default:
+		return nil, fmt.Errorf("invalid receipt status %x", r.PostStateOrStatus)
+	}
+	receipt.Bloom = types.CreateBloom(receipt)
+	return receipt, nil
+}
+
+// ReceiptsResponse69 is the network packet for block receipts distribution on
+// eth/69 and newer.
+type ReceiptsResponse69 [][]*Receipt69
+
+// Unpack converts the eth/69 network receipts into consensus receipts.
+func (p *ReceiptsResponse69) Unpack() (ReceiptsResponse, error) {
+	receipts := make(ReceiptsResponse, len(*p))
+	for i, list := range *p {
+		receipts[i] = make([]*types.Receipt, len(list))
+		for j, r := range list {
+			receipt, err := r.toReceipt()
+			if err != nil {
+				return nil, err
+			}
+			receipts[i][j] = receipt
+		}
+	}
+	return receipts, nil
+}
+
+// ReceiptsPacket69 is the network packet for block receipts distribution with
+// request ID wrapping on eth/69 and newer.
+type ReceiptsPacket69 struct {
+	RequestId uint64
+	ReceiptsResponse69
+}
+
 // ReceiptsRLPResponse is used for receipts, when we already have it encoded
 type ReceiptsRLPResponse []rlp.RawValue
 
@@ -307,6 +421,9 @@ type PooledTransactionsRLPPacket struct {
 func (*StatusPacket) Name() string { return "Status" }
 func (*StatusPacket) Kind() byte   { return StatusMsg }
 
+func (*StatusPacket69) Name() string { return "Status" }
+func (*StatusPacket69) Kind() byte   { return StatusMsg }
+
 func (*NewBlockHashesPacket) Name() string { return "NewBlockHashes" }
 func (*NewBlockHashesPacket) Kind() byte   { return NewBlockHashesMsg }
 
@@ -342,3 +459,9 @@ func (*GetReceiptsRequest) Kind() byte   { return GetReceiptsMsg }
 
 func (*ReceiptsResponse) Name() string { return "Receipts" }
 func (*ReceiptsResponse) Kind() byte   { return ReceiptsMsg }
+
+func (*ReceiptsResponse69) Name() string { return "Receipts" }
+func (*ReceiptsResponse69) Kind() byte   { return ReceiptsMsg }
+
+func (*BlockRangeUpdatePacket) Name() string { return "BlockRangeUpdate" }
+func (*BlockRangeUpdatePacket) Kind() byte   { return BlockRangeUpdateMsg }
@@ -86,6 +86,7 @@ func TestEmptyMessages(t *testing.T) {
 		// Receipts
 		GetReceiptsPacket{1111, nil},
 		ReceiptsPacket{1111, nil},
+		ReceiptsPacket69{1111, nil},
 		// Transactions
 		GetPooledTransactionsPacket{1111, nil},
 		PooledTransactionsPacket{1111, nil},
@@ -100,6 +101,7 @@ func TestEmptyMessages(t *testing.T) {
 		// Receipts
 		GetReceiptsPacket{1111, GetReceiptsRequest([]common.Hash{})},
 		ReceiptsPacket{1111, ReceiptsResponse([][]*types.Receipt{})},
+		ReceiptsPacket69{1111, ReceiptsResponse69([][]*Receipt69{})},
 		// Transactions
 		GetPooledTransactionsPacket{1111, GetPooledTransactionsRequest([]common.Hash{})},
 		PooledTransactionsPacket{1111, PooledTransactionsResponse([]*types.Transaction{})},
@@ -228,6 +230,10 @@ func TestMessages(t *testing.T) {
 			ReceiptsRLPPacket{1111, ReceiptsRLPResponse([]rlp.RawValue{receiptsRlp})},
 			common.FromHex("f90172820457f9016cf90169f901668001b9010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000f85ff85d940000000000000000000000000000000000000011f842a0000000000000000000000000000000000000000000000000000000000000deada0000000000000000000000000000000000000000000000000000000000000beef830100ff"),
 		},
+		{
+			ReceiptsPacket69{1111, ReceiptsResponse69([][]*Receipt69{{newReceipt69(receipts[0])}})},
+			common.FromHex("f86d820457f868f866f864808001f85ff85d940000000000000000000000000000000000000011f842a0000000000000000000000000000000000000000000000000000000000000deada0000000000000000000000000000000000000000000000000000000000000beef830100ff"),
+		},
 		{
 			GetPooledTransactionsPacket{1111, GetPooledTransactionsRequest(hashes)},
 			common.FromHex("f847820457f842a000000000000000000000000000000000000000000000000000000000deadc0dea000000000000000000000000000000000000000000000000000000000feedbeef"),
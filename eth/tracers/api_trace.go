@@ -0,0 +1,468 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Trace types which can be requested from the replay and call methods.
+const (
+	traceTypeTrace     = "trace"
+	traceTypeStateDiff = "stateDiff"
+	traceTypeVMTrace   = "vmTrace"
+)
+
+// maxTraceFilterBlocks is the maximum number of blocks trace_filter is willing
+// to trace in a single request.
+const maxTraceFilterBlocks = 10000
+
+// TraceAPI implements the trace namespace, compatible with the tracing API of
+// OpenEthereum (formerly Parity). The traces are produced by the flatCallTracer,
+// stateDiffTracer and vmTracer native tracers.
+type TraceAPI struct {
+	api *API
+}
+
+// NewTraceAPI creates a new API definition for the trace namespace.
+func NewTraceAPI(backend Backend) *TraceAPI {
+	return &TraceAPI{api: NewAPI(backend)}
+}
+
+// flatTraceConfig returns the configuration of the tracer producing the call
+// traces of transactions.
+func flatTraceConfig() *TraceConfig {
+	tracer := "flatCallTracer"
+	return &TraceConfig{Tracer: &tracer, TracerConfig: json.RawMessage(`{"convertParityErrors":true}`)}
+}
+
+// replayTraceConfig returns the configuration of the tracer producing the given
+// trace types in a single execution.
+func replayTraceConfig(traceTypes []string) (*TraceConfig, error) {
+	// The top call is always traced, to report the output of the execution.
+	config := map[string]json.RawMessage{
+		"callTracer": json.RawMessage(`{"onlyTopCall":true}`),
+	}
+	for _, typ := range traceTypes {
+		switch typ {
+		case traceTypeTrace:
+			config["flatCallTracer"] = json.RawMessage(`{"convertParityErrors":true}`)
+		case traceTypeStateDiff:
+			config["stateDiffTracer"] = json.RawMessage(`{}`)
+		case traceTypeVMTrace:
+			config["vmTracer"] = json.RawMessage(`{}`)
+		default:
+			return nil, fmt.Errorf("invalid trace type %q", typ)
+		}
+	}
+	blob, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	tracer := "muxTracer"
+	return &TraceConfig{Tracer: &tracer, TracerConfig: blob}, nil
+}
+
+// TraceResults is the result of replaying a transaction or call.
+type TraceResults struct {
+	Output          hexutil.Bytes     `json:"output"`
+	StateDiff       json.RawMessage   `json:"stateDiff"`
+	Trace           []json.RawMessage `json:"trace"`
+	VMTrace         json.RawMessage   `json:"vmTrace"`
+	TransactionHash *common.Hash      `json:"transactionHash,omitempty"`
+}
+
+// newTraceResults assembles the replay results from the output of the tracer
+// configured by replayTraceConfig.
+func newTraceResults(result interface{}) (*TraceResults, error) {
+	blob, ok := result.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected trace result type %T", result)
+	}
+	var traces map[string]json.RawMessage
+	if err := json.Unmarshal(blob, &traces); err != nil {
+		return nil, err
+	}
+	var top struct {
+		Output hexutil.Bytes `json:"output"`
+	}
+	if err := json.Unmarshal(traces["callTracer"], &top); err != nil {
+		return nil, err
+	}
+	res := &TraceResults{
+		Output:    top.Output,
+		StateDiff: traces["stateDiffTracer"],
+		Trace:     []json.RawMessage{},
+		VMTrace:   traces["vmTracer"],
+	}
+	if flat, ok := traces["flatCallTracer"]; ok {
+		var frames []map[string]json.RawMessage
+		if err := json.Unmarshal(flat, &frames); err != nil {
+			return nil, err
+		}
+		// Replayed traces don't carry their position in the chain.
+		for _, frame := range frames {
+			delete(frame, "blockHash")
+			delete(frame, "blockNumber")
+			delete(frame, "transactionHash")
+			delete(frame, "transactionPosition")
+
+			blob, err := json.Marshal(frame)
+			if err != nil {
+				return nil, err
+			}
+			res.Trace = append(res.Trace, blob)
+		}
+	}
+	return res, nil
+}
+
+// flatTraces decodes the output of the flatCallTracer.
+func flatTraces(result interface{}) ([]json.RawMessage, error) {
+	blob, ok := result.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected trace result type %T", result)
+	}
+	var frames []json.RawMessage
+	if err := json.Unmarshal(blob, &frames); err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// Block returns the call traces of all transactions in the given block.
+func (t *TraceAPI) Block(ctx context.Context, number rpc.BlockNumber) ([]json.RawMessage, error) {
+	block, err := t.api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return t.blockTraces(ctx, block)
+}
+
+// blockTraces returns the call traces of all transactions in the given block.
+func (t *TraceAPI) blockTraces(ctx context.Context, block *types.Block) ([]json.RawMessage, error) {
+	traces := []json.RawMessage{}
+	if block.NumberU64() == 0 {
+		return traces, nil
+	}
+	results, err := t.api.traceBlock(ctx, block, flatTraceConfig())
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("tracing transaction %#x failed: %s", result.TxHash, result.Error)
+		}
+		frames, err := flatTraces(result.Result)
+		if err != nil {
+			return nil, err
+		}
+		traces = append(traces, frames...)
+	}
+	return traces, nil
+}
+
+// Transaction returns the call traces of the given transaction.
+func (t *TraceAPI) Transaction(ctx context.Context, hash common.Hash) ([]json.RawMessage, error) {
+	result, err := t.api.TraceTransaction(ctx, hash, flatTraceConfig())
+	if err != nil {
+		return nil, err
+	}
+	return flatTraces(result)
+}
+
+// ReplayTransaction re-executes the given transaction, returning the requested
+// trace types. Valid trace types are "trace", "stateDiff" and "vmTrace".
+func (t *TraceAPI) ReplayTransaction(ctx context.Context, hash common.Hash, traceTypes []string) (*TraceResults, error) {
+	config, err := replayTraceConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	result, err := t.api.TraceTransaction(ctx, hash, config)
+	if err != nil {
+		return nil, err
+	}
+	return newTraceResults(result)
+}
+
+// ReplayBlockTransactions re-executes all transactions in the given block,
+// returning the requested trace types for each of them.
+func (t *TraceAPI) ReplayBlockTransactions(ctx context.Context, number rpc.BlockNumber, traceTypes []string) ([]*TraceResults, error) {
+	config, err := replayTraceConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	block, err := t.api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if block.NumberU64() == 0 {
+		return []*TraceResults{}, nil
+	}
+	results, err := t.api.traceBlock(ctx, block, config)
+	if err != nil {
+		return nil, err
+	}
+	replays := make([]*TraceResults, len(results))
+	for i, result := range results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("tracing transaction %#x failed: %s", result.TxHash, result.Error)
+		}
+		if replays[i], err = newTraceResults(result.Result); err != nil {
+			return nil, err
+		}
+		replays[i].TransactionHash = &results[i].TxHash
+	}
+	return replays, nil
+}
+
+// TraceCallRequest is a call to trace along with the requested trace types. It
+// is encoded as a two element array.
+type TraceCallRequest struct {
+	Args       ethapi.TransactionArgs
+	TraceTypes []string
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *TraceCallRequest) UnmarshalJSON(input []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(input, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 2 {
+		return errors.New("expected [call, traceTypes] pair")
+	}
+	if err := json.Unmarshal(fields[0], &r.Args); err != nil {
+		return err
+	}
+	return json.Unmarshal(fields[1], &r.TraceTypes)
+}
+
+// Call executes the given call on top of the state of the given block, returning
+// the requested trace types. The latest block is used if none is specified.
+func (t *TraceAPI) Call(ctx context.Context, args ethapi.TransactionArgs, traceTypes []string, blockNrOrHash *rpc.BlockNumberOrHash) (*TraceResults, error) {
+	results, err := t.traceCalls(ctx, []TraceCallRequest{{Args: args, TraceTypes: traceTypes}}, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// CallMany executes the given calls in sequence on top of the state of the given
+// block, returning the requested trace types for each of them. Every call sees
+// the state modifications of the preceding ones.
+func (t *TraceAPI) CallMany(ctx context.Context, calls []TraceCallRequest, blockNrOrHash *rpc.BlockNumberOrHash) ([]*TraceResults, error) {
+	return t.traceCalls(ctx, calls, blockNrOrHash)
+}
+
+func (t *TraceAPI) traceCalls(ctx context.Context, calls []TraceCallRequest, blockNrOrHash *rpc.BlockNumberOrHash) ([]*TraceResults, error) {
+	var (
+		block *types.Block
+		err   error
+	)
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block, err = t.api.blockByHash(ctx, hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		if number == rpc.PendingBlockNumber {
+			return nil, errors.New("tracing on top of pending is not supported")
+		}
+		block, err = t.api.blockByNumber(ctx, number)
+	} else {
+		return nil, errors.New("invalid arguments; neither block nor hash specified")
+	}
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]*TraceConfig, len(calls))
+	for i, call := range calls {
+		if configs[i], err = replayTraceConfig(call.TraceTypes); err != nil {
+			return nil, err
+		}
+	}
+	statedb, release, err := t.api.backend.StateAtBlock(ctx, block, defaultTraceReexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var (
+		chainConfig = t.api.backend.ChainConfig()
+		blockCtx    = core.NewEVMBlockContext(block.Header(), t.api.chainContext(ctx), nil)
+		results     = make([]*TraceResults, len(calls))
+	)
+	for i, call := range calls {
+		if err := call.Args.CallDefaults(t.api.backend.RPCGasCap(), blockCtx.BaseFee, chainConfig.ChainID); err != nil {
+			return nil, err
+		}
+		var (
+			msg   = call.Args.ToMessage(blockCtx.BaseFee, true, true)
+			tx    = call.Args.ToTransaction(types.LegacyTxType)
+			vmctx = blockCtx
+		)
+		// Lower the basefee to 0 to avoid breaking EVM
+		// invariants (basefee < feecap).
+		if msg.GasPrice.Sign() == 0 {
+			vmctx.BaseFee = new(big.Int)
+		}
+		if msg.BlobGasFeeCap != nil && msg.BlobGasFeeCap.BitLen() == 0 {
+			vmctx.BlobBaseFee = new(big.Int)
+		}
+		txctx := &Context{BlockNumber: block.Number(), TxIndex: i}
+		result, err := t.api.traceTx(ctx, tx, msg, txctx, vmctx, statedb, configs[i], nil)
+		if err != nil {
+			return nil, err
+		}
+		if results[i], err = newTraceResults(result); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// TraceFilterArgs is the filter criteria of trace_filter.
+type TraceFilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// Filter returns the call traces in the given block range matching the filter.
+// A trace matches if its sender is in FromAddress and its recipient is in
+// ToAddress, where an empty list matches any address. The block range defaults
+// to the latest block.
+func (t *TraceAPI) Filter(ctx context.Context, args TraceFilterArgs) ([]json.RawMessage, error) {
+	resolve := func(number *rpc.BlockNumber) (uint64, error) {
+		if number == nil {
+			number = new(rpc.BlockNumber)
+			*number = rpc.LatestBlockNumber
+		}
+		header, err := t.api.backend.HeaderByNumber(ctx, *number)
+		if err != nil {
+			return 0, err
+		}
+		if header == nil {
+			return 0, fmt.Errorf("block #%d not found", *number)
+		}
+		return header.Number.Uint64(), nil
+	}
+	from, err := resolve(args.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolve(args.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+	if to-from >= maxTraceFilterBlocks {
+		return nil, fmt.Errorf("block range too large, maximum is %d blocks", maxTraceFilterBlocks)
+	}
+	var (
+		skip    uint64
+		matches = []json.RawMessage{}
+	)
+	if args.After != nil {
+		skip = *args.After
+	}
+	for number := max(from, 1); number <= to; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := t.api.blockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		traces, err := t.blockTraces(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		for _, trace := range traces {
+			ok, err := matchTrace(trace, args.FromAddress, args.ToAddress)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			matches = append(matches, trace)
+			if args.Count != nil && uint64(len(matches)) >= *args.Count {
+				return matches, nil
+			}
+		}
+	}
+	return matches, nil
+}
+
+// matchTrace reports whether the sender and recipient of a flat call trace are
+// in the given address sets.
+func matchTrace(trace json.RawMessage, from, to []common.Address) (bool, error) {
+	if len(from) == 0 && len(to) == 0 {
+		return true, nil
+	}
+	var frame struct {
+		Action struct {
+			From          *common.Address `json:"from"`
+			To            *common.Address `json:"to"`
+			Address       *common.Address `json:"address"`
+			RefundAddress *common.Address `json:"refundAddress"`
+		} `json:"action"`
+		Result *struct {
+			Address *common.Address `json:"address"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(trace, &frame); err != nil {
+		return false, err
+	}
+	// Self-destructs are sent by the destroyed contract to the refund address,
+	// and creations are sent to the created contract.
+	sender, recipient := frame.Action.From, frame.Action.To
+	if frame.Action.Address != nil {
+		sender, recipient = frame.Action.Address, frame.Action.RefundAddress
+	}
+	if recipient == nil && frame.Result != nil {
+		recipient = frame.Result.Address
+	}
+	contains := func(set []common.Address, addr *common.Address) bool {
+		return len(set) == 0 || (addr != nil && slices.Contains(set, *addr))
+	}
+	return contains(from, sender) && contains(to, recipient), nil
+}
@@ -0,0 +1,352 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+)
+
+var (
+	traceKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	traceSender = crypto.PubkeyToAddress(traceKey.PublicKey)
+	traceRecv   = common.HexToAddress("0x1111")
+	traceStore  = common.HexToAddress("0x5705e")
+	traceProxy  = common.HexToAddress("0x9804")
+)
+
+// newTraceTestAPI creates a chain with the following transactions:
+//
+//	block 1: call to traceProxy, which calls traceStore; transfer to traceRecv
+//	block 2: call to traceStore
+//
+// traceStore saves its caller in slot 0.
+func newTraceTestAPI(t *testing.T) (*tracers.TraceAPI, []common.Hash) {
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			traceSender: {Balance: big.NewInt(params.Ether)},
+			// CALLER PUSH1 0 SSTORE STOP
+			traceStore: {Code: common.FromHex("3360005500")},
+			// CALL(GAS, traceStore, 0, 0, 0, 0, 0) STOP
+			traceProxy: {Code: append(append(common.FromHex("600060006000600060007f"), common.LeftPadBytes(traceStore.Bytes(), 32)...), common.FromHex("5af100")...)},
+		},
+	}
+	var (
+		signer = types.LatestSigner(gspec.Config)
+		hashes []common.Hash
+	)
+	send := func(b *core.BlockGen, to common.Address) {
+		tx, _ := types.SignNewTx(traceKey, signer, &types.LegacyTx{
+			Nonce:    b.TxNonce(traceSender),
+			To:       &to,
+			Value:    big.NewInt(1),
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+		})
+		b.AddTx(tx)
+		hashes = append(hashes, tx.Hash())
+	}
+	backend := tracers.NewTestBackend(t, 2, gspec, func(i int, b *core.BlockGen) {
+		switch i {
+		case 0:
+			send(b, traceProxy)
+			send(b, traceRecv)
+		case 1:
+			send(b, traceStore)
+		}
+	})
+	return tracers.NewTraceAPI(backend), hashes
+}
+
+// flatTrace is the subset of the flat call trace fields checked by the tests.
+type flatTrace struct {
+	Action struct {
+		From *common.Address `json:"from"`
+		To   *common.Address `json:"to"`
+	} `json:"action"`
+	BlockHash           *common.Hash `json:"blockHash"`
+	TraceAddress        []int        `json:"traceAddress"`
+	TransactionHash     *common.Hash `json:"transactionHash"`
+	TransactionPosition *uint64      `json:"transactionPosition"`
+	Type                string       `json:"type"`
+}
+
+func decodeTraces(t *testing.T, raw []json.RawMessage) []*flatTrace {
+	t.Helper()
+	traces := make([]*flatTrace, len(raw))
+	for i, blob := range raw {
+		traces[i] = new(flatTrace)
+		if err := json.Unmarshal(blob, traces[i]); err != nil {
+			t.Fatalf("failed to decode trace %d: %v", i, err)
+		}
+	}
+	return traces
+}
+
+func TestTraceBlockAndTransaction(t *testing.T) {
+	t.Parallel()
+	api, hashes := newTraceTestAPI(t)
+
+	raw, err := api.Block(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	traces := decodeTraces(t, raw)
+	if len(traces) != 3 {
+		t.Fatalf("wrong number of traces: have %d, want 3", len(traces))
+	}
+	if *traces[1].Action.From != traceProxy || *traces[1].Action.To != traceStore || len(traces[1].TraceAddress) != 1 {
+		t.Fatalf("wrong nested call trace: %+v", traces[1])
+	}
+	if *traces[2].TransactionHash != hashes[1] || *traces[2].TransactionPosition != 1 {
+		t.Fatalf("wrong trace position: %+v", traces[2])
+	}
+	raw, err = api.Transaction(context.Background(), hashes[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if traces := decodeTraces(t, raw); len(traces) != 2 || *traces[0].TransactionHash != hashes[0] {
+		t.Fatalf("wrong transaction traces: %v", raw)
+	}
+	// The genesis block has no traces.
+	raw, err = api.Block(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != 0 {
+		t.Fatalf("unexpected genesis traces: %v", raw)
+	}
+}
+
+// stateDiff is the decoded Parity state diff.
+type stateDiff map[common.Address]struct {
+	Balance json.RawMessage                 `json:"balance"`
+	Nonce   json.RawMessage                 `json:"nonce"`
+	Code    json.RawMessage                 `json:"code"`
+	Storage map[common.Hash]json.RawMessage `json:"storage"`
+}
+
+// storageChange decodes a modified storage slot.
+func storageChange(t *testing.T, blob json.RawMessage) (common.Hash, common.Hash) {
+	t.Helper()
+	var change struct {
+		Modified *struct {
+			From common.Hash `json:"from"`
+			To   common.Hash `json:"to"`
+		} `json:"*"`
+	}
+	if err := json.Unmarshal(blob, &change); err != nil || change.Modified == nil {
+		t.Fatalf("invalid storage change %s: %v", blob, err)
+	}
+	return change.Modified.From, change.Modified.To
+}
+
+func TestTraceReplayTransaction(t *testing.T) {
+	t.Parallel()
+	api, hashes := newTraceTestAPI(t)
+
+	res, err := api.ReplayTransaction(context.Background(), hashes[0], []string{"trace", "stateDiff", "vmTrace"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	traces := decodeTraces(t, res.Trace)
+	if len(traces) != 2 {
+		t.Fatalf("wrong number of traces: have %d, want 2", len(traces))
+	}
+	if traces[0].BlockHash != nil || traces[0].TransactionHash != nil {
+		t.Fatal("replayed trace contains chain position")
+	}
+	// The state diff contains the sender, the recipient of the value and the
+	// modified storage slot. The miner doesn't earn any tip.
+	var diff stateDiff
+	if err := json.Unmarshal(res.StateDiff, &diff); err != nil {
+		t.Fatal(err)
+	}
+	for _, addr := range []common.Address{traceSender, traceProxy, traceStore} {
+		if _, ok := diff[addr]; !ok {
+			t.Fatalf("state diff is missing %x: %s", addr, res.StateDiff)
+		}
+	}
+	if string(diff[traceStore].Balance) != `"="` || string(diff[traceStore].Code) != `"="` {
+		t.Fatalf("wrong diff of unchanged fields: %s", res.StateDiff)
+	}
+	from, to := storageChange(t, diff[traceStore].Storage[common.Hash{}])
+	if from != (common.Hash{}) || to != common.BytesToHash(traceProxy.Bytes()) {
+		t.Fatalf("wrong storage change: %x -> %x", from, to)
+	}
+	var nonce struct {
+		Modified struct {
+			From hexutil.Uint64 `json:"from"`
+			To   hexutil.Uint64 `json:"to"`
+		} `json:"*"`
+	}
+	if err := json.Unmarshal(diff[traceSender].Nonce, &nonce); err != nil || nonce.Modified.From != 0 || nonce.Modified.To != 1 {
+		t.Fatalf("wrong sender nonce change: %s", diff[traceSender].Nonce)
+	}
+	// The vm trace contains the nested call, including the storage write.
+	var vmTrace struct {
+		Ops []struct {
+			Sub *struct {
+				Ops []struct {
+					Ex struct {
+						Store *struct {
+							Key string `json:"key"`
+						} `json:"store"`
+					} `json:"ex"`
+				} `json:"ops"`
+			} `json:"sub"`
+		} `json:"ops"`
+	}
+	if err := json.Unmarshal(res.VMTrace, &vmTrace); err != nil {
+		t.Fatal(err)
+	}
+	if len(vmTrace.Ops) != 9 {
+		t.Fatalf("wrong number of ops: have %d, want 9", len(vmTrace.Ops))
+	}
+	call := vmTrace.Ops[7]
+	if call.Sub == nil || len(call.Sub.Ops) != 4 || call.Sub.Ops[2].Ex.Store == nil || call.Sub.Ops[2].Ex.Store.Key != "0x0" {
+		t.Fatalf("wrong nested vm trace: %s", res.VMTrace)
+	}
+}
+
+func TestTraceReplayBlockTransactions(t *testing.T) {
+	t.Parallel()
+	api, hashes := newTraceTestAPI(t)
+
+	results, err := api.ReplayBlockTransactions(context.Background(), 1, []string{"trace"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("wrong number of results: have %d, want 2", len(results))
+	}
+	for i, res := range results {
+		if *res.TransactionHash != hashes[i] {
+			t.Fatalf("result %d: wrong transaction hash", i)
+		}
+		if res.StateDiff != nil || res.VMTrace != nil {
+			t.Fatalf("result %d: unrequested trace types", i)
+		}
+	}
+	if len(results[0].Trace) != 2 || len(results[1].Trace) != 1 {
+		t.Fatal("wrong number of traces")
+	}
+	if _, err := api.ReplayBlockTransactions(context.Background(), 1, []string{"bogus"}); err == nil {
+		t.Fatal("invalid trace type accepted")
+	}
+}
+
+func TestTraceCallMany(t *testing.T) {
+	t.Parallel()
+	api, _ := newTraceTestAPI(t)
+
+	var (
+		alice  = common.HexToAddress("0xa11ce")
+		bob    = common.HexToAddress("0xb0b")
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	res, err := api.Call(context.Background(), ethapi.TransactionArgs{From: &alice, To: &traceStore}, []string{"stateDiff"}, &latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var diff stateDiff
+	if err := json.Unmarshal(res.StateDiff, &diff); err != nil {
+		t.Fatal(err)
+	}
+	// The state of the block has traceSender in the slot.
+	from, to := storageChange(t, diff[traceStore].Storage[common.Hash{}])
+	if from != common.BytesToHash(traceSender.Bytes()) || to != common.BytesToHash(alice.Bytes()) {
+		t.Fatalf("wrong storage change: %x -> %x", from, to)
+	}
+	// Subsequent calls see the changes of the previous ones.
+	results, err := api.CallMany(context.Background(), []tracers.TraceCallRequest{
+		{Args: ethapi.TransactionArgs{From: &alice, To: &traceStore}, TraceTypes: []string{"trace"}},
+		{Args: ethapi.TransactionArgs{From: &bob, To: &traceStore}, TraceTypes: []string{"stateDiff"}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results[0].Trace) != 1 || results[0].StateDiff != nil {
+		t.Fatal("wrong result of first call")
+	}
+	diff = nil
+	if err := json.Unmarshal(results[1].StateDiff, &diff); err != nil {
+		t.Fatal(err)
+	}
+	from, to = storageChange(t, diff[traceStore].Storage[common.Hash{}])
+	if from != common.BytesToHash(alice.Bytes()) || to != common.BytesToHash(bob.Bytes()) {
+		t.Fatalf("wrong storage change of second call: %x -> %x", from, to)
+	}
+	// The requests are encoded as [call, traceTypes] pairs.
+	var req tracers.TraceCallRequest
+	if err := json.Unmarshal([]byte(`[{"to":"0x0000000000000000000000000000000000001111"},["trace","vmTrace"]]`), &req); err != nil {
+		t.Fatal(err)
+	}
+	if *req.Args.To != traceRecv || len(req.TraceTypes) != 2 {
+		t.Fatalf("wrong decoded request: %+v", req)
+	}
+}
+
+func TestTraceFilter(t *testing.T) {
+	t.Parallel()
+	api, hashes := newTraceTestAPI(t)
+
+	var (
+		from  = rpc.BlockNumber(1)
+		to    = rpc.BlockNumber(2)
+		after = uint64(1)
+		count = uint64(1)
+	)
+	raw, err := api.Filter(context.Background(), tracers.TraceFilterArgs{FromBlock: &from, ToBlock: &to, ToAddress: []common.Address{traceStore}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	traces := decodeTraces(t, raw)
+	if len(traces) != 2 || *traces[0].TransactionHash != hashes[0] || *traces[1].TransactionHash != hashes[2] {
+		t.Fatalf("wrong filtered traces: %v", raw)
+	}
+	raw, err = api.Filter(context.Background(), tracers.TraceFilterArgs{FromBlock: &from, ToBlock: &to, ToAddress: []common.Address{traceStore}, After: &after, Count: &count})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if traces := decodeTraces(t, raw); len(traces) != 1 || *traces[0].TransactionHash != hashes[2] {
+		t.Fatalf("wrong paginated traces: %v", raw)
+	}
+	// Both sender and recipient must match.
+	raw, err = api.Filter(context.Background(), tracers.TraceFilterArgs{FromBlock: &from, ToBlock: &to, FromAddress: []common.Address{traceProxy}, ToAddress: []common.Address{traceStore}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if traces := decodeTraces(t, raw); len(traces) != 1 || len(traces[0].TraceAddress) != 1 {
+		t.Fatalf("wrong traces matching sender and recipient: %v", raw)
+	}
+}
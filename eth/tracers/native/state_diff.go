@@ -0,0 +1,250 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("stateDiffTracer", newStateDiffTracer, false)
+}
+
+// diffAccount tracks the state of a modified account before and after the
+// transaction.
+type diffAccount struct {
+	preBalance *big.Int
+	preNonce   uint64
+	preCode    []byte
+	preStorage map[common.Hash]common.Hash
+
+	balance    *big.Int
+	nonce      uint64
+	code       []byte
+	storage    map[common.Hash]common.Hash
+	destructed bool // Whether the code was removed by a self-destruct
+}
+
+// born reports whether the account did not exist before the transaction.
+func (a *diffAccount) born() bool {
+	return a.preBalance.Sign() == 0 && a.preNonce == 0 && len(a.preCode) == 0
+}
+
+// died reports whether the account was destroyed by the transaction.
+func (a *diffAccount) died() bool {
+	return a.destructed && !a.born() && a.balance.Sign() == 0
+}
+
+// stateDiffTracer reports the state modifications of a transaction in the
+// format of the Parity stateDiff trace. Modifications made by reverted call
+// frames are excluded.
+type stateDiffTracer struct {
+	env       *tracing.VMContext
+	accounts  map[common.Address]*diffAccount
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newStateDiffTracer returns a new stateDiffTracer.
+func newStateDiffTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	t := &stateDiffTracer{accounts: make(map[common.Address]*diffAccount)}
+	hooks, err := tracing.WrapWithJournal(&tracing.Hooks{
+		OnTxStart:       t.OnTxStart,
+		OnBalanceChange: t.OnBalanceChange,
+		OnNonceChangeV2: t.OnNonceChangeV2,
+		OnCodeChange:    t.OnCodeChange,
+		OnStorageChange: t.OnStorageChange,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tracers.Tracer{
+		Hooks:     hooks,
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *stateDiffTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.env = env
+}
+
+// account returns the tracked state of the given account, and whether it has
+// just started to be tracked. Since state hooks are invoked after the change is
+// applied, the caller must fix up the previous value of the modified field.
+func (t *stateDiffTracer) account(addr common.Address) (*diffAccount, bool) {
+	if acc := t.accounts[addr]; acc != nil {
+		return acc, false
+	}
+	var (
+		balance = t.env.StateDB.GetBalance(addr).ToBig()
+		nonce   = t.env.StateDB.GetNonce(addr)
+		code    = t.env.StateDB.GetCode(addr)
+	)
+	acc := &diffAccount{
+		preBalance: balance,
+		preNonce:   nonce,
+		preCode:    code,
+		preStorage: make(map[common.Hash]common.Hash),
+		balance:    balance,
+		nonce:      nonce,
+		code:       code,
+		storage:    make(map[common.Hash]common.Hash),
+	}
+	t.accounts[addr] = acc
+	return acc, true
+}
+
+func (t *stateDiffTracer) OnBalanceChange(addr common.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+	if t.interrupt.Load() {
+		return
+	}
+	acc, fresh := t.account(addr)
+	if fresh {
+		acc.preBalance = prev
+	}
+	acc.balance = new
+}
+
+func (t *stateDiffTracer) OnNonceChangeV2(addr common.Address, prev, new uint64, reason tracing.NonceChangeReason) {
+	if t.interrupt.Load() {
+		return
+	}
+	acc, fresh := t.account(addr)
+	if fresh {
+		acc.preNonce = prev
+	}
+	acc.nonce = new
+}
+
+func (t *stateDiffTracer) OnCodeChange(addr common.Address, prevCodeHash common.Hash, prev []byte, codeHash common.Hash, code []byte) {
+	if t.interrupt.Load() {
+		return
+	}
+	acc, fresh := t.account(addr)
+	if fresh {
+		acc.preCode = prev
+	}
+	acc.code = code
+
+	// Clearing a delegation removes the code of an account, but keeps it alive.
+	if _, delegated := types.ParseDelegation(prev); len(code) == 0 && len(prev) > 0 && !delegated {
+		acc.destructed = true
+	} else if len(code) > 0 {
+		acc.destructed = false
+	}
+}
+
+func (t *stateDiffTracer) OnStorageChange(addr common.Address, slot common.Hash, prev, new common.Hash) {
+	if t.interrupt.Load() {
+		return
+	}
+	acc, _ := t.account(addr)
+	if _, ok := acc.preStorage[slot]; !ok {
+		acc.preStorage[slot] = prev
+	}
+	acc.storage[slot] = new
+}
+
+// stateDiffAccount is the Parity representation of the changes to an account.
+// Every field holds either "=" if unchanged, or an object keyed by "+" (created),
+// "-" (destroyed) or "*" (modified).
+type stateDiffAccount struct {
+	Balance interface{}                 `json:"balance"`
+	Nonce   interface{}                 `json:"nonce"`
+	Code    interface{}                 `json:"code"`
+	Storage map[common.Hash]interface{} `json:"storage"`
+}
+
+// stateDiffChange is the representation of a modified value.
+type stateDiffChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// diffValue returns the Parity representation of a value change.
+func diffValue(born, died, equal bool, from, to interface{}) interface{} {
+	switch {
+	case born:
+		return map[string]interface{}{"+": to}
+	case died:
+		return map[string]interface{}{"-": from}
+	case equal:
+		return "="
+	default:
+		return map[string]*stateDiffChange{"*": {From: from, To: to}}
+	}
+}
+
+// GetResult returns the state changes of the transaction in the Parity format.
+func (t *stateDiffTracer) GetResult() (json.RawMessage, error) {
+	result := make(map[common.Address]*stateDiffAccount)
+	for addr, acc := range t.accounts {
+		var (
+			born = acc.born()
+			died = acc.died()
+		)
+		// Drop accounts which are not modified, and those which are both created
+		// and destroyed by the transaction.
+		if born && (acc.destructed || (acc.balance.Sign() == 0 && acc.nonce == 0 && len(acc.code) == 0)) {
+			continue
+		}
+		storage := make(map[common.Hash]interface{})
+		for slot, prev := range acc.preStorage {
+			cur := acc.storage[slot]
+			if prev == cur || (born && cur == (common.Hash{})) || (died && prev == (common.Hash{})) {
+				continue
+			}
+			storage[slot] = diffValue(born, died, false, prev, cur)
+		}
+		var (
+			balanceEqual = acc.preBalance.Cmp(acc.balance) == 0
+			nonceEqual   = acc.preNonce == acc.nonce
+			codeEqual    = bytes.Equal(acc.preCode, acc.code)
+		)
+		if !born && !died && balanceEqual && nonceEqual && codeEqual && len(storage) == 0 {
+			continue
+		}
+		result[addr] = &stateDiffAccount{
+			Balance: diffValue(born, died, balanceEqual, (*hexutil.Big)(acc.preBalance), (*hexutil.Big)(acc.balance)),
+			Nonce:   diffValue(born, died, nonceEqual, hexutil.Uint64(acc.preNonce), hexutil.Uint64(acc.nonce)),
+			Code:    diffValue(born, died, codeEqual, hexutil.Bytes(acc.preCode), hexutil.Bytes(acc.code)),
+			Storage: storage,
+		}
+	}
+	res, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *stateDiffTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
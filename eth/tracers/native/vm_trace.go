@@ -0,0 +1,253 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("vmTracer", newVMTracer, false)
+}
+
+// vmTrace is the Parity representation of the execution of a call frame.
+type vmTrace struct {
+	Code hexutil.Bytes `json:"code"`
+	Ops  []*vmTraceOp  `json:"ops"`
+}
+
+// vmTraceOp is a single executed instruction.
+type vmTraceOp struct {
+	Cost uint64     `json:"cost"`
+	Ex   *vmTraceEx `json:"ex"`
+	PC   uint64     `json:"pc"`
+	Sub  *vmTrace   `json:"sub"`
+}
+
+// vmTraceEx holds the effects of an executed instruction.
+type vmTraceEx struct {
+	Mem   *vmTraceMem   `json:"mem"`
+	Push  []string      `json:"push"`
+	Store *vmTraceStore `json:"store"`
+	Used  uint64        `json:"used"`
+}
+
+// vmTraceMem is a memory region written by an instruction.
+type vmTraceMem struct {
+	Data hexutil.Bytes `json:"data"`
+	Off  uint64        `json:"off"`
+}
+
+// vmTraceStore is a storage slot written by an instruction.
+type vmTraceStore struct {
+	Key string `json:"key"`
+	Val string `json:"val"`
+}
+
+// vmTraceFrame tracks the call frame being executed. The effects of an
+// instruction are only known when the next one starts, so the last instruction
+// is held pending until then.
+type vmTraceFrame struct {
+	trace   *vmTrace
+	pending *vmTraceOp
+	op      vm.OpCode
+	gas     uint64 // Gas available after the pending instruction, if it is the last one
+	memOff  uint64 // Offset of the memory written by the pending instruction
+	memLen  uint64 // Size of the memory written by the pending instruction
+}
+
+// vmTracer reports the executed instructions of a transaction in the format of
+// the Parity vmTrace.
+type vmTracer struct {
+	env       *tracing.VMContext
+	root      *vmTrace
+	frames    []*vmTraceFrame
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newVMTracer returns a new vmTracer.
+func newVMTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	t := new(vmTracer)
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.OnTxStart,
+			OnEnter:   t.OnEnter,
+			OnExit:    t.OnExit,
+			OnOpcode:  t.OnOpcode,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *vmTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.env = env
+}
+
+func (t *vmTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	trace := &vmTrace{Ops: []*vmTraceOp{}}
+	switch vm.OpCode(typ) {
+	case vm.CREATE, vm.CREATE2:
+		trace.Code = input
+	default:
+		trace.Code = t.env.StateDB.GetCode(to)
+	}
+	if len(t.frames) == 0 {
+		t.root = trace
+	} else if parent := t.frames[len(t.frames)-1]; parent.pending != nil {
+		parent.pending.Sub = trace
+	}
+	t.frames = append(t.frames, &vmTraceFrame{trace: trace})
+}
+
+func (t *vmTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	frame.flush(nil, frame.gas)
+	t.frames = t.frames[:len(t.frames)-1]
+}
+
+func (t *vmTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	frame.flush(scope, gas)
+
+	traceOp := &vmTraceOp{Cost: cost, PC: pc}
+	frame.trace.Ops = append(frame.trace.Ops, traceOp)
+	frame.pending = traceOp
+	frame.op = vm.OpCode(op)
+	frame.gas = gas - min(cost, gas)
+	frame.memOff, frame.memLen = 0, 0
+
+	// Record the memory region and storage slot written by the instruction,
+	// based on its arguments.
+	var (
+		stack = scope.StackData()
+		arg   = func(i int) *uint256.Int {
+			if i >= len(stack) {
+				return new(uint256.Int)
+			}
+			return &stack[len(stack)-1-i]
+		}
+	)
+	switch frame.op {
+	case vm.MSTORE:
+		frame.memOff, frame.memLen = arg(0).Uint64(), 32
+	case vm.MSTORE8:
+		frame.memOff, frame.memLen = arg(0).Uint64(), 1
+	case vm.CALLDATACOPY, vm.CODECOPY, vm.RETURNDATACOPY, vm.MCOPY:
+		frame.memOff, frame.memLen = arg(0).Uint64(), arg(2).Uint64()
+	case vm.EXTCODECOPY:
+		frame.memOff, frame.memLen = arg(1).Uint64(), arg(3).Uint64()
+	case vm.CALL, vm.CALLCODE:
+		frame.memOff, frame.memLen = arg(5).Uint64(), arg(6).Uint64()
+	case vm.DELEGATECALL, vm.STATICCALL:
+		frame.memOff, frame.memLen = arg(4).Uint64(), arg(5).Uint64()
+	case vm.SSTORE:
+		traceOp.Ex = &vmTraceEx{Store: &vmTraceStore{Key: arg(0).Hex(), Val: arg(1).Hex()}}
+	}
+}
+
+// flush completes the pending instruction of the frame, given the context and
+// the remaining gas after its execution. The scope is nil if the frame has
+// terminated.
+func (f *vmTraceFrame) flush(scope tracing.OpContext, gas uint64) {
+	if f.pending == nil {
+		return
+	}
+	ex := f.pending.Ex
+	if ex == nil {
+		ex = new(vmTraceEx)
+		f.pending.Ex = ex
+	}
+	ex.Used = gas
+	ex.Push = []string{}
+	if scope != nil {
+		stack := scope.StackData()
+		if n := min(pushedItems(f.op), len(stack)); n > 0 {
+			for _, item := range stack[len(stack)-n:] {
+				ex.Push = append(ex.Push, item.Hex())
+			}
+		}
+		if f.memLen > 0 {
+			mem := scope.MemoryData()
+			if f.memOff+f.memLen <= uint64(len(mem)) {
+				ex.Mem = &vmTraceMem{
+					Data: common.CopyBytes(mem[f.memOff : f.memOff+f.memLen]),
+					Off:  f.memOff,
+				}
+			}
+		}
+	}
+	f.pending = nil
+}
+
+// pushedItems returns the number of stack items reported as pushed by the given
+// instruction. Following Parity, the duplicated and swapped items are reported
+// for DUP and SWAP.
+func pushedItems(op vm.OpCode) int {
+	switch {
+	case op >= vm.DUP1 && op <= vm.DUP16:
+		return int(op-vm.DUP1) + 2
+	case op >= vm.SWAP1 && op <= vm.SWAP16:
+		return int(op-vm.SWAP1) + 2
+	case op.IsPush():
+		return 1
+	}
+	switch op {
+	case vm.STOP, vm.POP, vm.MSTORE, vm.MSTORE8, vm.SSTORE, vm.TSTORE, vm.JUMP, vm.JUMPI, vm.JUMPDEST,
+		vm.LOG0, vm.LOG1, vm.LOG2, vm.LOG3, vm.LOG4, vm.CALLDATACOPY, vm.CODECOPY, vm.RETURNDATACOPY,
+		vm.EXTCODECOPY, vm.MCOPY, vm.RETURN, vm.REVERT, vm.INVALID, vm.SELFDESTRUCT:
+		return 0
+	}
+	return 1
+}
+
+// GetResult returns the executed instructions of the transaction in the Parity
+// format.
+func (t *vmTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.root)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *vmTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
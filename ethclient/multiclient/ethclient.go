@@ -0,0 +1,305 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package multiclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Blockchain Access
+
+// ChainID retrieves the current chain ID for transaction replay protection.
+func (mc *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*big.Int, error) {
+		return ec.ChainID(ctx)
+	})
+}
+
+// BlockByHash returns the given full block.
+func (mc *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*types.Block, error) {
+		return ec.BlockByHash(ctx, hash)
+	})
+}
+
+// BlockByNumber returns a block from the current canonical chain. If number is
+// nil, the latest known block of the most up-to-date endpoint is returned.
+func (mc *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*types.Block, error) {
+		return ec.BlockByNumber(ctx, number)
+	})
+}
+
+// BlockNumber returns the most recent block number.
+func (mc *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (uint64, error) {
+		return ec.BlockNumber(ctx)
+	})
+}
+
+// BlockReceipts returns the receipts of a given block number or hash.
+func (mc *Client) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) ([]*types.Receipt, error) {
+		return ec.BlockReceipts(ctx, blockNrOrHash)
+	})
+}
+
+// HeaderByHash returns the block header with the given hash.
+func (mc *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*types.Header, error) {
+		return ec.HeaderByHash(ctx, hash)
+	})
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If
+// number is nil, the latest known header of the most up-to-date endpoint is
+// returned.
+func (mc *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*types.Header, error) {
+		return ec.HeaderByNumber(ctx, number)
+	})
+}
+
+// TransactionByHash returns the transaction with the given hash.
+func (mc *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	type result struct {
+		tx        *types.Transaction
+		isPending bool
+	}
+	res, err := read(ctx, mc, func(ec *ethclient.Client) (result, error) {
+		tx, isPending, err := ec.TransactionByHash(ctx, hash)
+		return result{tx, isPending}, err
+	})
+	return res.tx, res.isPending, err
+}
+
+// TransactionCount returns the total number of transactions in the given block.
+func (mc *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (uint, error) {
+		return ec.TransactionCount(ctx, blockHash)
+	})
+}
+
+// TransactionInBlock returns a single transaction at index in the given block.
+func (mc *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*types.Transaction, error) {
+		return ec.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+// Note that the receipt is not available for pending transactions.
+func (mc *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*types.Receipt, error) {
+		return ec.TransactionReceipt(ctx, txHash)
+	})
+}
+
+// SyncProgress retrieves the current progress of the sync algorithm of the most
+// up-to-date endpoint.
+func (mc *Client) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*ethereum.SyncProgress, error) {
+		return ec.SyncProgress(ctx)
+	})
+}
+
+// SubscribeNewHead subscribes to notifications about the current blockchain head
+// on the most up-to-date endpoint. The subscription is moved over to another
+// endpoint if the current one fails.
+func (mc *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return mc.subscribe(ctx, func(ctx context.Context, ec *ethclient.Client) (ethereum.Subscription, error) {
+		return ec.SubscribeNewHead(ctx, ch)
+	})
+}
+
+// State Access
+
+// NetworkID returns the network ID for this client.
+func (mc *Client) NetworkID(ctx context.Context) (*big.Int, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*big.Int, error) {
+		return ec.NetworkID(ctx)
+	})
+}
+
+// BalanceAt returns the wei balance of the given account.
+// The block number can be nil, in which case the balance is taken from the latest known block.
+func (mc *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*big.Int, error) {
+		return ec.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+// StorageAt returns the value of key in the contract storage of the given account.
+// The block number can be nil, in which case the value is taken from the latest known block.
+func (mc *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) ([]byte, error) {
+		return ec.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+// CodeAt returns the contract code of the given account.
+// The block number can be nil, in which case the code is taken from the latest known block.
+func (mc *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) ([]byte, error) {
+		return ec.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+// NonceAt returns the account nonce of the given account.
+// The block number can be nil, in which case the nonce is taken from the latest known block.
+func (mc *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (uint64, error) {
+		return ec.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+// Filters
+
+// FilterLogs executes a filter query.
+func (mc *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) ([]types.Log, error) {
+		return ec.FilterLogs(ctx, q)
+	})
+}
+
+// SubscribeFilterLogs subscribes to the results of a streaming filter query on
+// the most up-to-date endpoint. The subscription is moved over to another
+// endpoint if the current one fails, logs emitted in between might be missed.
+func (mc *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return mc.subscribe(ctx, func(ctx context.Context, ec *ethclient.Client) (ethereum.Subscription, error) {
+		return ec.SubscribeFilterLogs(ctx, q, ch)
+	})
+}
+
+// Pending State
+
+// PendingBalanceAt returns the wei balance of the given account in the pending state.
+func (mc *Client) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*big.Int, error) {
+		return ec.PendingBalanceAt(ctx, account)
+	})
+}
+
+// PendingStorageAt returns the value of key in the contract storage of the given account in the pending state.
+func (mc *Client) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) ([]byte, error) {
+		return ec.PendingStorageAt(ctx, account, key)
+	})
+}
+
+// PendingCodeAt returns the contract code of the given account in the pending state.
+func (mc *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) ([]byte, error) {
+		return ec.PendingCodeAt(ctx, account)
+	})
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state.
+// This is the nonce that should be used for the next transaction.
+func (mc *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (uint64, error) {
+		return ec.PendingNonceAt(ctx, account)
+	})
+}
+
+// PendingTransactionCount returns the total number of transactions in the pending state.
+func (mc *Client) PendingTransactionCount(ctx context.Context) (uint, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (uint, error) {
+		return ec.PendingTransactionCount(ctx)
+	})
+}
+
+// Contract Calling
+
+// CallContract executes a message call transaction, which is directly executed in the VM
+// of the node, but never mined into the blockchain.
+//
+// blockNumber selects the block height at which the call runs. It can be nil, in which
+// case the code is taken from the latest known block. Note that state from very old
+// blocks might not be available.
+func (mc *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) ([]byte, error) {
+		return ec.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+// PendingCallContract executes a message call transaction using the EVM.
+// The state seen by the contract call is the pending state.
+func (mc *Client) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) ([]byte, error) {
+		return ec.PendingCallContract(ctx, msg)
+	})
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price to allow a timely
+// execution of a transaction.
+func (mc *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*big.Int, error) {
+		return ec.SuggestGasPrice(ctx)
+	})
+}
+
+// SuggestGasTipCap retrieves the currently suggested gas tip cap after 1559 to
+// allow a timely execution of a transaction.
+func (mc *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*big.Int, error) {
+		return ec.SuggestGasTipCap(ctx)
+	})
+}
+
+// BlobBaseFee retrieves the current blob base fee.
+func (mc *Client) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*big.Int, error) {
+		return ec.BlobBaseFee(ctx)
+	})
+}
+
+// FeeHistory retrieves the fee market history.
+func (mc *Client) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (*ethereum.FeeHistory, error) {
+		return ec.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific transaction
+// based on the current pending state of the backend blockchain. There is no
+// guarantee that this is the true gas limit requirement as other transactions
+// may be added or removed by miners, but it should provide a basis for setting
+// a reasonable default.
+func (mc *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return read(ctx, mc, func(ec *ethclient.Client) (uint64, error) {
+		return ec.EstimateGas(ctx, msg)
+	})
+}
+
+// SendTransaction injects a signed transaction into the pending pool of all the
+// endpoints for execution. It succeeds if any of the endpoints accepted it.
+//
+// If the transaction was a contract creation use the TransactionReceipt method
+// to get the contract address after the transaction has been mined.
+func (mc *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return mc.broadcast(func(ec *ethclient.Client) error {
+		return ec.SendTransaction(ctx, tx)
+	})
+}
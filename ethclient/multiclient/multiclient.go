@@ -0,0 +1,347 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package multiclient provides an Ethereum RPC client spreading the requests
+// across multiple endpoints, failing over between them.
+package multiclient
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// errNoEndpoints is returned if a client is attempted to be created without any
+// endpoints to connect to.
+var errNoEndpoints = errors.New("no endpoints")
+
+// Config are the configuration parameters of the multi-endpoint client.
+type Config struct {
+	HealthCheckInterval time.Duration // Time interval between checking the heads of the endpoints
+	HealthCheckTimeout  time.Duration // Maximum time to wait for an endpoint to report its head
+	ResubscribeBackoff  time.Duration // Maximum time to wait between attempts to reestablish a subscription
+	MaxHeadLag          uint64        // Maximum number of blocks an endpoint may lag behind the best one to share the reads
+}
+
+// DefaultConfig contains the default configurations for the multi-endpoint client.
+var DefaultConfig = Config{
+	HealthCheckInterval: 5 * time.Second,
+	HealthCheckTimeout:  2 * time.Second,
+	ResubscribeBackoff:  10 * time.Second,
+	MaxHeadLag:          1,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.HealthCheckInterval <= 0 {
+		conf.HealthCheckInterval = DefaultConfig.HealthCheckInterval
+	}
+	if conf.HealthCheckTimeout <= 0 {
+		conf.HealthCheckTimeout = DefaultConfig.HealthCheckTimeout
+	}
+	if conf.ResubscribeBackoff <= 0 {
+		conf.ResubscribeBackoff = DefaultConfig.ResubscribeBackoff
+	}
+	return conf
+}
+
+// endpoint is a single backend node of the multi-endpoint client.
+type endpoint struct {
+	index  int               // Position of the endpoint in the user supplied list
+	client *ethclient.Client // Client connected to the endpoint
+
+	head    uint64 // Last head block number reported by the endpoint
+	healthy bool   // Whether the last request to the endpoint succeeded
+}
+
+// Client is an Ethereum RPC client backed by multiple endpoints. Reads are spread
+// round-robin across the healthy endpoints within MaxHeadLag blocks of the highest
+// head, and are retried on the others if the endpoint cannot be reached.
+// Transactions are sent to all of the endpoints, whereas subscriptions are pinned
+// to a single endpoint supporting them, and are transparently reestablished on
+// another one if it fails.
+//
+// Only transport failures are retried; errors reported by a node, such as a
+// missing item or a reverted call, are returned as is.
+type Client struct {
+	config    Config
+	endpoints []*endpoint
+	lock      sync.RWMutex  // Protects the health of the endpoints
+	next      atomic.Uint64 // Counter rotating the reads across the preferred endpoints
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Dial connects a multi-endpoint client to the given URLs.
+func Dial(ctx context.Context, urls []string, config Config) (*Client, error) {
+	clients := make([]*rpc.Client, 0, len(urls))
+	for _, url := range urls {
+		client, err := rpc.DialContext(ctx, url)
+		if err != nil {
+			for _, client := range clients {
+				client.Close()
+			}
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+	return New(clients, config)
+}
+
+// New creates a multi-endpoint client on top of the given RPC clients, checking
+// their health before returning. The order of the clients is used to break ties
+// between endpoints at the same head.
+func New(clients []*rpc.Client, config Config) (*Client, error) {
+	if len(clients) == 0 {
+		return nil, errNoEndpoints
+	}
+	mc := &Client{
+		config: config.sanitize(),
+		quit:   make(chan struct{}),
+	}
+	for i, client := range clients {
+		mc.endpoints = append(mc.endpoints, &endpoint{index: i, client: ethclient.NewClient(client)})
+	}
+	mc.checkHealth()
+
+	mc.wg.Add(1)
+	go mc.loop()
+	return mc, nil
+}
+
+// Close terminates the health checks and closes the connections to all endpoints.
+func (mc *Client) Close() {
+	close(mc.quit)
+	mc.wg.Wait()
+
+	for _, ep := range mc.endpoints {
+		ep.client.Close()
+	}
+}
+
+// loop periodically checks the health of the endpoints.
+func (mc *Client) loop() {
+	defer mc.wg.Done()
+
+	ticker := time.NewTicker(mc.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			mc.checkHealth()
+		case <-mc.quit:
+			return
+		}
+	}
+}
+
+// checkHealth retrieves the head of all endpoints concurrently, marking the ones
+// failing to respond unhealthy.
+func (mc *Client) checkHealth() {
+	var wg sync.WaitGroup
+	for _, ep := range mc.endpoints {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), mc.config.HealthCheckTimeout)
+			defer cancel()
+
+			head, err := ep.client.BlockNumber(ctx)
+
+			mc.lock.Lock()
+			defer mc.lock.Unlock()
+
+			if err != nil {
+				if ep.healthy {
+					log.Warn("RPC endpoint became unhealthy", "index", ep.index, "err", err)
+				}
+				ep.healthy = false
+				return
+			}
+			if !ep.healthy {
+				log.Debug("RPC endpoint became healthy", "index", ep.index, "head", head)
+			}
+			ep.head, ep.healthy = head, true
+		}(ep)
+	}
+	wg.Wait()
+}
+
+// markFailed flags an endpoint unhealthy until its next successful health check.
+func (mc *Client) markFailed(ep *endpoint, err error) {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+
+	if ep.healthy {
+		log.Warn("RPC endpoint request failed", "index", ep.index, "err", err)
+	}
+	ep.healthy = false
+}
+
+// candidates returns the endpoints in the order of preference: the healthy ones
+// ahead of the failing ones, the most up-to-date ones ahead of the lagging ones.
+// The healthy endpoints close enough to the highest head are rotated on every
+// call, balancing the load across them.
+func (mc *Client) candidates() []*endpoint {
+	mc.lock.RLock()
+	defer mc.lock.RUnlock()
+
+	eps := slices.Clone(mc.endpoints)
+	slices.SortStableFunc(eps, func(a, b *endpoint) int {
+		if a.healthy != b.healthy {
+			if a.healthy {
+				return -1
+			}
+			return 1
+		}
+		if a.head != b.head {
+			if a.head > b.head {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+	// Rotate the endpoints sharing the load, keeping the rest for failover
+	if len(eps) == 0 || !eps[0].healthy {
+		return eps
+	}
+	shared := 1
+	for shared < len(eps) && eps[shared].healthy && eps[shared].head+mc.config.MaxHeadLag >= eps[0].head {
+		shared++
+	}
+	if shared > 1 {
+		n := int((mc.next.Add(1) - 1) % uint64(shared))
+		copy(eps, slices.Concat(eps[n:shared], eps[:n]))
+	}
+	return eps
+}
+
+// retryable reports whether a failed request might succeed on another endpoint.
+// Errors reported by the node itself are deterministic, only the ones caused by
+// the transport are worth retrying.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// read executes an idempotent request on the preferred endpoint, retrying it on
+// the remaining ones in the order of preference if the endpoint fails.
+func read[T any](ctx context.Context, mc *Client, fn func(*ethclient.Client) (T, error)) (T, error) {
+	var (
+		result T
+		err    error
+	)
+	for _, ep := range mc.candidates() {
+		result, err = fn(ep.client)
+
+		// Endpoints not supporting the request, such as HTTP ones lacking
+		// subscriptions, are skipped without being faulty.
+		if errors.Is(err, rpc.ErrNotificationsUnsupported) && ctx.Err() == nil {
+			continue
+		}
+		if err == nil || !retryable(ctx, err) {
+			return result, err
+		}
+		mc.markFailed(ep, err)
+	}
+	return result, err
+}
+
+// subscribe establishes a subscription on the preferred endpoint supporting it.
+// If the subscription fails, it is reestablished on the currently preferred one.
+func (mc *Client) subscribe(ctx context.Context, fn func(context.Context, *ethclient.Client) (ethereum.Subscription, error)) (ethereum.Subscription, error) {
+	establish := func(ctx context.Context) (*pinnedSub, error) {
+		return read(ctx, mc, func(client *ethclient.Client) (*pinnedSub, error) {
+			sub, err := fn(ctx, client)
+			if err != nil {
+				return nil, err
+			}
+			return &pinnedSub{sub: sub, client: client}, nil
+		})
+	}
+	// Establish the initial subscription synchronously to surface any errors
+	pinned, err := establish(ctx)
+	if err != nil {
+		return nil, err
+	}
+	initial := pinned.sub
+	return event.ResubscribeErr(mc.config.ResubscribeBackoff, func(ctx context.Context, lastErr error) (event.Subscription, error) {
+		if initial != nil {
+			sub := initial
+			initial = nil
+			return sub, nil
+		}
+		for _, ep := range mc.endpoints {
+			if ep.client == pinned.client {
+				mc.markFailed(ep, lastErr)
+			}
+		}
+		resub, err := establish(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pinned = resub
+		return resub.sub, nil
+	}), nil
+}
+
+// pinnedSub is a subscription along with the endpoint it is established on.
+type pinnedSub struct {
+	sub    ethereum.Subscription
+	client *ethclient.Client
+}
+
+// broadcast executes a request on all endpoints concurrently, succeeding if any
+// of them succeeds.
+func (mc *Client) broadcast(fn func(*ethclient.Client) error) error {
+	var (
+		errs = make([]error, len(mc.endpoints))
+		wg   sync.WaitGroup
+	)
+	for i, ep := range mc.endpoints {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			errs[i] = fn(ep.client)
+		}(i, ep)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package multiclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testService is a minimal eth namespace of a single endpoint, reporting a fixed
+// head and its own index as the balance of every account.
+type testService struct {
+	index int
+	head  uint64
+	heads chan *types.Header
+
+	lock sync.Mutex
+	txs  []common.Hash
+}
+
+func (s *testService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.head)
+}
+
+func (s *testService) GetBalance(addr common.Address, block string) *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(int64(s.index)))
+}
+
+func (s *testService) SendRawTransaction(input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.txs = append(s.txs, tx.Hash())
+	return tx.Hash(), nil
+}
+
+func (s *testService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case head := <-s.heads:
+				notifier.Notify(sub.ID, head)
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// newTestEndpoints creates a batch of in-process endpoints at the given heads.
+func newTestEndpoints(t *testing.T, heads ...uint64) ([]*testService, []*rpc.Server, []*rpc.Client) {
+	var (
+		services []*testService
+		servers  []*rpc.Server
+		clients  []*rpc.Client
+	)
+	for i, head := range heads {
+		service := &testService{index: i, head: head, heads: make(chan *types.Header)}
+		server := rpc.NewServer()
+		if err := server.RegisterName("eth", service); err != nil {
+			t.Fatalf("failed to register service: %v", err)
+		}
+		t.Cleanup(server.Stop)
+
+		services = append(services, service)
+		servers = append(servers, server)
+		clients = append(clients, rpc.DialInProc(server))
+	}
+	return services, servers, clients
+}
+
+// Tests that reads are routed to the most up-to-date endpoint and fail over to
+// the others if it becomes unavailable.
+func TestReadFailover(t *testing.T) {
+	_, _, clients := newTestEndpoints(t, 10, 20, 15)
+
+	mc, err := New(clients, Config{HealthCheckInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer mc.Close()
+
+	ctx := context.Background()
+	if index, err := mc.BalanceAt(ctx, common.Address{}, nil); err != nil || index.Int64() != 1 {
+		t.Fatalf("read routed to wrong endpoint: have %v, want 1, err %v", index, err)
+	}
+	// Kill the preferred endpoint, the read should be retried on the next best
+	clients[1].Close()
+	if index, err := mc.BalanceAt(ctx, common.Address{}, nil); err != nil || index.Int64() != 2 {
+		t.Fatalf("read failed over to wrong endpoint: have %v, want 2, err %v", index, err)
+	}
+	// Kill all endpoints, the read should fail
+	clients[0].Close()
+	clients[2].Close()
+	if _, err := mc.BalanceAt(ctx, common.Address{}, nil); !errors.Is(err, rpc.ErrClientQuit) {
+		t.Fatalf("read succeeded without endpoints: %v", err)
+	}
+}
+
+// Tests that reads are spread across the endpoints close to the highest head,
+// but not the lagging ones.
+func TestReadBalancing(t *testing.T) {
+	_, _, clients := newTestEndpoints(t, 10, 9, 10, 5)
+
+	mc, err := New(clients, Config{HealthCheckInterval: time.Hour, MaxHeadLag: 1})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer mc.Close()
+
+	reads := make(map[int64]int)
+	for i := 0; i < 30; i++ {
+		index, err := mc.BalanceAt(context.Background(), common.Address{}, nil)
+		if err != nil {
+			t.Fatalf("read %d failed: %v", i, err)
+		}
+		reads[index.Int64()]++
+	}
+	for index, want := range []int{10, 10, 10, 0} {
+		if reads[int64(index)] != want {
+			t.Errorf("endpoint %d: wrong number of reads: have %d, want %d", index, reads[int64(index)], want)
+		}
+	}
+}
+
+// Tests that transactions are sent to all the endpoints.
+func TestSendBroadcast(t *testing.T) {
+	services, _, clients := newTestEndpoints(t, 1, 1, 1)
+
+	mc, err := New(clients, Config{HealthCheckInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer mc.Close()
+
+	key, _ := crypto.GenerateKey()
+	tx := types.MustSignNewTx(key, types.HomesteadSigner{}, &types.LegacyTx{Gas: 21000, GasPrice: big.NewInt(1)})
+
+	// Sending should succeed as long as any endpoint accepts the transaction
+	clients[0].Close()
+	if err := mc.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+	for i, service := range services[1:] {
+		if len(service.txs) != 1 || service.txs[0] != tx.Hash() {
+			t.Errorf("endpoint %d: transaction not received: %v", i+1, service.txs)
+		}
+	}
+	clients[1].Close()
+	clients[2].Close()
+	if err := mc.SendTransaction(context.Background(), tx); err == nil {
+		t.Fatalf("transaction sent without endpoints")
+	}
+}
+
+// Tests that subscriptions are pinned to the most up-to-date endpoint and are
+// reestablished on another one if it fails.
+func TestSubscriptionFailover(t *testing.T) {
+	services, servers, clients := newTestEndpoints(t, 10, 12)
+
+	mc, err := New(clients, Config{HealthCheckInterval: time.Hour, ResubscribeBackoff: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer mc.Close()
+
+	heads := make(chan *types.Header)
+	sub, err := mc.SubscribeNewHead(context.Background(), heads)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	services[1].heads <- &types.Header{Number: big.NewInt(13), Difficulty: common.Big0}
+	if head := <-heads; head.Number.Uint64() != 13 {
+		t.Fatalf("head mismatch: have %d, want 13", head.Number)
+	}
+	// Kill the pinned endpoint, the subscription should move over to the other
+	servers[1].Stop()
+
+	select {
+	case services[0].heads <- &types.Header{Number: big.NewInt(11), Difficulty: common.Big0}:
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("subscription not reestablished")
+	}
+	if head := <-heads; head.Number.Uint64() != 11 {
+		t.Fatalf("head mismatch: have %d, want 11", head.Number)
+	}
+}
+
+// Tests that subscriptions skip the endpoints not supporting them, without
+// considering those unhealthy.
+func TestSubscriptionSkipsHTTP(t *testing.T) {
+	services, servers, clients := newTestEndpoints(t, 10, 12)
+
+	// Replace the preferred endpoint with an HTTP connection
+	httpsrv := httptest.NewServer(servers[1])
+	defer httpsrv.Close()
+
+	client, err := rpc.DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatalf("failed to dial HTTP endpoint: %v", err)
+	}
+	clients[1] = client
+
+	mc, err := New(clients, Config{HealthCheckInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer mc.Close()
+
+	heads := make(chan *types.Header)
+	sub, err := mc.SubscribeNewHead(context.Background(), heads)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	services[0].heads <- &types.Header{Number: big.NewInt(11), Difficulty: common.Big0}
+	if head := <-heads; head.Number.Uint64() != 11 {
+		t.Fatalf("head mismatch: have %d, want 11", head.Number)
+	}
+	if !mc.endpoints[1].healthy {
+		t.Fatal("HTTP endpoint marked unhealthy")
+	}
+}
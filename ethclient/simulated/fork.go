@@ -0,0 +1,82 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/holiman/uint256"
+)
+
+// forkRequestTimeout is the maximum time to wait for a single state retrieval
+// from the forked remote node.
+const forkRequestTimeout = 30 * time.Second
+
+// rpcForkSource implements state.ForkSource, retrieving the state of a remote
+// chain at a pinned block through the standard RPC methods.
+type rpcForkSource struct {
+	eth    *ethclient.Client
+	geth   *gethclient.Client
+	number *big.Int
+}
+
+// Account retrieves the account through eth_getProof, as there is no other way
+// to retrieve all of the account fields at once.
+func (s *rpcForkSource) Account(addr common.Address) (*types.StateAccount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), forkRequestTimeout)
+	defer cancel()
+
+	result, err := s.geth.GetProof(ctx, addr, nil, s.number)
+	if err != nil {
+		return nil, err
+	}
+	if result.Nonce == 0 && result.Balance.Sign() == 0 && (result.CodeHash == types.EmptyCodeHash || result.CodeHash == common.Hash{}) {
+		return nil, nil
+	}
+	return &types.StateAccount{
+		Nonce:    result.Nonce,
+		Balance:  uint256.MustFromBig(result.Balance),
+		Root:     result.StorageHash,
+		CodeHash: result.CodeHash.Bytes(),
+	}, nil
+}
+
+// Storage retrieves the storage slot through eth_getStorageAt.
+func (s *rpcForkSource) Storage(addr common.Address, slot common.Hash) (common.Hash, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), forkRequestTimeout)
+	defer cancel()
+
+	value, err := s.eth.StorageAt(ctx, addr, slot, s.number)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(value), nil
+}
+
+// Code retrieves the contract code through eth_getCode.
+func (s *rpcForkSource) Code(addr common.Address, codeHash common.Hash) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), forkRequestTimeout)
+	defer cancel()
+
+	return s.eth.CodeAt(ctx, addr, s.number)
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestForkRemoteState(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		contract = common.Address{0xc0}
+		balance  = big.NewInt(params.Ether)
+		slot0    = common.Hash{}
+		slot1    = common.Hash{31: 1}
+		value    = common.Hash{31: 0xff}
+
+		// Contract storing its input into the first slot
+		code = []byte{byte(vm.PUSH1), 0, byte(vm.CALLDATALOAD), byte(vm.PUSH1), 0, byte(vm.SSTORE), byte(vm.STOP)}
+	)
+	upstream := NewBackend(types.GenesisAlloc{
+		testAddr: {Balance: balance},
+		contract: {Code: code, Storage: map[common.Hash]common.Hash{slot0: value, slot1: value}},
+	})
+	defer upstream.Close()
+
+	rpcClient := upstream.node.Attach()
+	defer rpcClient.Close()
+
+	fork, err := WithFork(ctx, rpcClient, big.NewInt(0))
+	if err != nil {
+		t.Fatalf("failed to configure fork: %v", err)
+	}
+	sim := NewBackend(nil, fork)
+	defer sim.Close()
+
+	// Progress the upstream chain, the fork must remain at the pinned state
+	tx, err := newTx(upstream, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := upstream.Client().SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	upstream.Commit()
+
+	client := sim.Client()
+	if have, err := client.BalanceAt(ctx, testAddr, nil); err != nil || have.Cmp(balance) != 0 {
+		t.Fatalf("forked balance mismatch: have %v, want %v, err %v", have, balance, err)
+	}
+	if have, err := client.CodeAt(ctx, contract, nil); err != nil || !bytes.Equal(have, code) {
+		t.Fatalf("forked code mismatch: have %x, want %x, err %v", have, code, err)
+	}
+	// Transact on top of the forked state, clearing a remote storage slot
+	chainid, _ := client.ChainID(ctx)
+	head, _ := client.HeaderByNumber(ctx, nil)
+	tx = types.MustSignNewTx(testKey, types.LatestSignerForChainID(chainid), &types.DynamicFeeTx{
+		ChainID:   chainid,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: new(big.Int).Add(head.BaseFee, big.NewInt(params.GWei)),
+		Gas:       100000,
+		To:        &contract,
+		Data:      make([]byte, 32),
+	})
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		t.Fatalf("failed to send transaction on fork: %v", err)
+	}
+	sim.Commit()
+
+	if have, _ := client.StorageAt(ctx, contract, slot0, nil); common.BytesToHash(have) != (common.Hash{}) {
+		t.Fatalf("cleared slot resurrected from upstream: %x", have)
+	}
+	if have, _ := client.StorageAt(ctx, contract, slot1, nil); common.BytesToHash(have) != value {
+		t.Fatalf("untouched slot mismatch: have %x, want %x", have, value)
+	}
+	if have, _ := client.NonceAt(ctx, testAddr, nil); have != 1 {
+		t.Fatalf("forked nonce mismatch: have %d, want 1", have)
+	}
+	// Changes on the fork must not leak upstream
+	if have, _ := upstream.Client().StorageAt(ctx, contract, slot0, nil); common.BytesToHash(have) != value {
+		t.Fatalf("upstream slot modified: have %x, want %x", have, value)
+	}
+}
+
+// Tests that an unreachable remote node is reported when configuring the fork.
+func TestForkUnreachable(t *testing.T) {
+	upstream := NewBackend(nil)
+	defer upstream.Close()
+
+	rpcClient := upstream.node.Attach()
+	rpcClient.Close()
+
+	if _, err := WithFork(context.Background(), rpcClient, nil); err == nil {
+		t.Fatal("fork of unreachable node configured")
+	}
+}
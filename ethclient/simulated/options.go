@@ -17,10 +17,15 @@
 package simulated
 
 import (
+	"context"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
 	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 // WithBlockGasLimit configures the simulated backend to target a specific gas limit
@@ -53,3 +58,33 @@ func WithMinerMinTip(tip *big.Int) func(nodeConf *node.Config, ethConf *ethconfi
 		ethConf.Miner.GasPrice = tip
 	}
 }
+
+// WithFork configures the simulated backend to run on top of the state of a remote
+// chain at the given block, or at its latest block if nil. Accounts, contract code
+// and storage slots missing from the simulated chain are lazily retrieved from
+// the remote node and cached.
+//
+// The simulated chain itself still starts from its own genesis block, only its
+// state is layered over the remote one. Genesis allocations take precedence over
+// the remote accounts.
+//
+// If no block is given, the latest one is retrieved from the remote node right
+// away, failing if it is unreachable.
+func WithFork(ctx context.Context, client *rpc.Client, number *big.Int) (func(nodeConf *node.Config, ethConf *ethconfig.Config), error) {
+	eth := ethclient.NewClient(client)
+	if number == nil {
+		head, err := eth.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve fork block: %w", err)
+		}
+		number = head.Number
+	}
+	source := &rpcForkSource{
+		eth:    eth,
+		geth:   gethclient.New(client),
+		number: new(big.Int).Set(number),
+	}
+	return func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		ethConf.StateFork = source
+	}, nil
+}
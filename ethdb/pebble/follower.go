@@ -0,0 +1,208 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pebble
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// followerCheckpointRetries is the number of attempts made at creating a
+// consistent checkpoint of a database, before giving up.
+const followerCheckpointRetries = 10
+
+// followerDatabase is a pebble database opened from a checkpoint, which deletes
+// the checkpoint when closed.
+type followerDatabase struct {
+	*Database
+	dir    string               // Directory of the checkpoint
+	source string               // Directory of the followed database
+	files  map[string]fileStamp // Files of the followed database before checkpointing
+}
+
+// fileStamp identifies the content of a file of the followed database.
+type fileStamp struct {
+	size    int64
+	modTime int64 // Nanoseconds since the epoch
+}
+
+// Modified reports whether the followed database has been modified since the
+// checkpoint was created, in which case a new follower has to be opened to
+// observe the changes.
+func (db *followerDatabase) Modified() (bool, error) {
+	files, err := fileStamps(db.source)
+	if err != nil {
+		return false, err
+	}
+	return !maps.Equal(db.files, files), nil
+}
+
+// Close closes the database and deletes its checkpoint.
+func (db *followerDatabase) Close() error {
+	err := db.Database.Close()
+	if rmErr := os.RemoveAll(db.dir); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// NewFollower opens a read-only snapshot of the pebble database in file, which is
+// written by another process. Writes made afterwards by the owner only become
+// visible by opening a new snapshot.
+//
+// The snapshot is a checkpoint created in a fresh directory within checkpoints,
+// which must be located on the same filesystem as the database. The table, log
+// and manifest files of the database are hard-linked into the checkpoint, thus
+// the owner deleting them during compactions doesn't affect the snapshot.
+//
+// Tables are immutable, but the write-ahead logs are not: the live log is being
+// appended to, and the logs made obsolete by a flush are recycled, i.e. renamed
+// and overwritten from the start. The linked logs are only read while opening
+// the checkpoint, which replays them up to their last complete record, like the
+// owner recovering after a crash would. A log is only recycled after a flush,
+// which installs a new version of the database, therefore the checkpoint is only
+// accepted if the owner didn't install a new version while it was created and
+// opened, otherwise it is retried. The checkpoint is deleted when the returned
+// database is closed.
+func NewFollower(file string, checkpoints string, cache int, handles int, namespace string) (ethdb.KeyValueStore, error) {
+	if err := os.MkdirAll(checkpoints, 0700); err != nil {
+		return nil, err
+	}
+	for i := 0; ; i++ {
+		// Stamp the files before linking them, any change made afterwards is
+		// reported by the follower as a modification.
+		files, err := fileStamps(file)
+		if err != nil {
+			return nil, err
+		}
+		before, err := versionFiles(file)
+		if err != nil {
+			return nil, err
+		}
+		dir, err := os.MkdirTemp(checkpoints, "checkpoint-")
+		if err != nil {
+			return nil, err
+		}
+		var db *Database
+		if err = linkFiles(file, dir); err == nil {
+			db, err = newDatabase(dir, cache, handles, namespace, true, false, true)
+		}
+		after, verr := versionFiles(file)
+		if verr == nil && maps.Equal(before, after) {
+			if err != nil {
+				os.RemoveAll(dir)
+				return nil, err
+			}
+			return &followerDatabase{Database: db, dir: dir, source: file, files: files}, nil
+		}
+		// The owner modified the database in the meantime, try again
+		if db != nil {
+			db.Close()
+		}
+		os.RemoveAll(dir)
+
+		if verr != nil {
+			return nil, verr
+		}
+		if i == followerCheckpointRetries {
+			return nil, errors.New("database modified too frequently to create a checkpoint")
+		}
+	}
+}
+
+// isTableOrLog reports whether the given pebble file is a table or a write-ahead
+// log. Their changes are either reflected in a new version of the database, or
+// are appends to the live log, which are tolerated by the replay of the log.
+func isTableOrLog(name string) bool {
+	return strings.HasSuffix(name, ".sst") || strings.HasSuffix(name, ".log")
+}
+
+// versionFiles returns the names and sizes of the files of a pebble database
+// which describe its current version, such as the manifests, the markers and
+// the options. Any change of these indicates that a new version was installed.
+func versionFiles(dir string) (map[string]int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]int64)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || isTableOrLog(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue // deleted meanwhile, the version change is detected by the caller
+			}
+			return nil, err
+		}
+		files[entry.Name()] = info.Size()
+	}
+	return files, nil
+}
+
+// fileStamps returns the sizes and modification times of all the files of a
+// pebble database, except its lock.
+func fileStamps(dir string) (map[string]fileStamp, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]fileStamp)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || entry.Name() == "LOCK" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue // deleted meanwhile, reported as a modification later
+			}
+			return nil, err
+		}
+		files[entry.Name()] = fileStamp{size: info.Size(), modTime: info.ModTime().UnixNano()}
+	}
+	return files, nil
+}
+
+// linkFiles hard-links all files of a pebble database, except its lock, into
+// the given directory.
+func linkFiles(src string, dst string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || entry.Name() == "LOCK" {
+			continue
+		}
+		if err := os.Link(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			return fmt.Errorf("failed to checkpoint database, the checkpoint directory must be on the same filesystem: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pebble
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Tests that a follower snapshot is unaffected by the owner overwriting and
+// compacting the database, and that its checkpoint is deleted when closed.
+func TestFollower(t *testing.T) {
+	var (
+		datadir     = t.TempDir()
+		checkpoints = filepath.Join(t.TempDir(), "checkpoints")
+	)
+	owner, err := New(datadir, 16, 16, "", false, false)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer owner.Close()
+
+	// Write some data, part of it flushed into tables, part of it only logged
+	for i := 0; i < 100; i++ {
+		owner.Put([]byte(fmt.Sprintf("key-%03d", i)), []byte("old"))
+	}
+	if err := owner.Compact(nil, nil); err != nil {
+		t.Fatalf("Failed to compact database: %v", err)
+	}
+	owner.Put([]byte("key-100"), []byte("old"))
+
+	follower, err := NewFollower(datadir, checkpoints, 16, 16, "")
+	if err != nil {
+		t.Fatalf("Failed to open follower: %v", err)
+	}
+	modified := func() bool {
+		t.Helper()
+		ok, err := follower.(*followerDatabase).Modified()
+		if err != nil {
+			t.Fatalf("Failed to check modifications: %v", err)
+		}
+		return ok
+	}
+	if modified() {
+		t.Fatal("Unmodified database reported as modified")
+	}
+	// Overwrite everything and compact the old tables away
+	for i := 0; i <= 100; i++ {
+		owner.Put([]byte(fmt.Sprintf("key-%03d", i)), []byte("new"))
+	}
+	if err := owner.Compact(nil, nil); err != nil {
+		t.Fatalf("Failed to compact database: %v", err)
+	}
+	if !modified() {
+		t.Fatal("Modified database not reported")
+	}
+	it := follower.NewIterator([]byte("key-"), nil)
+	count := 0
+	for it.Next() {
+		if !bytes.Equal(it.Value(), []byte("old")) {
+			t.Fatalf("Unexpected value of %s: have %s, want old", it.Key(), it.Value())
+		}
+		count++
+	}
+	if err := it.Error(); err != nil {
+		t.Fatalf("Iteration failed: %v", err)
+	}
+	it.Release()
+	if count != 101 {
+		t.Fatalf("Unexpected number of entries: have %d, want %d", count, 101)
+	}
+	if err := follower.Put([]byte("key"), []byte("value")); err == nil {
+		t.Fatal("Follower modified the database")
+	}
+	// Closing the follower should delete its checkpoint
+	if err := follower.Close(); err != nil {
+		t.Fatalf("Failed to close follower: %v", err)
+	}
+	entries, err := os.ReadDir(checkpoints)
+	if err != nil {
+		t.Fatalf("Failed to read checkpoint directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("Checkpoints left behind: %v", entries)
+	}
+}
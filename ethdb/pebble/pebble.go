@@ -145,6 +145,12 @@ func (l panicLogger) Fatalf(format string, args ...interface{}) {
 // New returns a wrapped pebble DB object. The namespace is the prefix that the
 // metrics reporting should use for surfacing internal stats.
 func New(file string, cache int, handles int, namespace string, readonly bool, ephemeral bool) (*Database, error) {
+	return newDatabase(file, cache, handles, namespace, readonly, ephemeral, false)
+}
+
+// newDatabase opens a pebble database. A follower database is a read-only
+// checkpoint, which is reopened frequently, thus it is opened quietly.
+func newDatabase(file string, cache int, handles int, namespace string, readonly bool, ephemeral bool, follower bool) (*Database, error) {
 	// Ensure we have some minimal caching and file guarantees
 	if cache < minCache {
 		cache = minCache
@@ -153,7 +159,9 @@ func New(file string, cache int, handles int, namespace string, readonly bool, e
 		handles = minHandles
 	}
 	logger := log.New("database", file)
-	logger.Info("Allocated cache and file handles", "cache", common.StorageSize(cache*1024*1024), "handles", handles)
+	if !follower {
+		logger.Info("Allocated cache and file handles", "cache", common.StorageSize(cache*1024*1024), "handles", handles)
+	}
 
 	// The max memtable size is limited by the uint32 offsets stored in
 	// internal/arenaskl.node, DeferredBatchOp, and flushableBatchEntry.
@@ -220,7 +228,7 @@ func New(file string, cache int, handles int, namespace string, readonly bool, e
 			{TargetFileSize: 64 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
 			{TargetFileSize: 128 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
 		},
-		ReadOnly: readonly,
+		ReadOnly: readonly || follower,
 		EventListener: &pebble.EventListener{
 			CompactionBegin: db.onCompactionBegin,
 			CompactionEnd:   db.onCompactionEnd,
@@ -235,6 +243,12 @@ func New(file string, cache int, handles int, namespace string, readonly bool, e
 
 	// Open the db and recover any potential corruptions
 	innerDB, err := pebble.Open(file, opt)
+
+	// Followers are reopened on every refresh of the followed database, release
+	// the reference to the cache so that it's freed along with the database.
+	if follower {
+		opt.Cache.Unref()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -17,7 +17,9 @@
 package ethapi
 
 import (
+	"cmp"
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -34,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -41,6 +44,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/gasestimator"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/params"
@@ -55,6 +59,11 @@ const estimateGasErrorRatio = 0.015
 
 var errBlobTxNotSupported = errors.New("signing blob transactions not supported")
 
+// errHistoricProof is returned if a proof of a state is requested, which is no
+// longer persisted and only reconstructed from the state histories. The tries
+// of such states are not available.
+var errHistoricProof = errors.New("proofs are not supported for historic state, only for recent or archived state")
+
 // EthereumAPI provides an API to access Ethereum related information.
 type EthereumAPI struct {
 	b Backend
@@ -380,6 +389,9 @@ func (api *BlockChainAPI) GetProof(ctx context.Context, address common.Address,
 	if statedb == nil || err != nil {
 		return nil, err
 	}
+	if _, ok := statedb.Database().(*state.HistoricDB); ok {
+		return nil, errHistoricProof
+	}
 	codeHash := statedb.GetCodeHash(address)
 	storageRoot := statedb.GetStorageRoot(address)
 
@@ -614,7 +626,7 @@ func (api *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rp
 
 	result := make([]map[string]interface{}, len(receipts))
 	for i, receipt := range receipts {
-		result[i] = marshalReceipt(receipt, block.Hash(), block.NumberU64(), signer, txs[i], i)
+		result[i] = MarshalReceipt(receipt, block.Hash(), block.NumberU64(), signer, txs[i], i)
 	}
 
 	return result, nil
@@ -738,14 +750,26 @@ func applyMessageWithEVM(ctx context.Context, evm *vm.EVM, msg *core.Message, ti
 	return result, nil
 }
 
-func DoCall(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, timeout time.Duration, globalGasCap uint64) (*core.ExecutionResult, error) {
+func DoCall(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, timeout time.Duration, globalGasCap uint64) (_ *core.ExecutionResult, err error) {
 	defer func(start time.Time) { log.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
 
+	ctx, span := telemetry.Start(ctx, "ethapi.DoCall", telemetry.WithAttributes(telemetry.String("block", blockNrOrHash.String())))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
 		return nil, err
 	}
-	return doCall(ctx, b, args, state, header, overrides, blockOverrides, timeout, globalGasCap)
+	span.SetAttributes(telemetry.Uint64("number", header.Number.Uint64()))
+
+	result, err := doCall(ctx, b, args, state, header, overrides, blockOverrides, timeout, globalGasCap)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(telemetry.Uint64("gasUsed", result.UsedGas), telemetry.Bool("failed", result.Failed()))
+	return result, nil
 }
 
 // Call executes the given transaction on the state for the given block number.
@@ -812,12 +836,19 @@ func (api *BlockChainAPI) SimulateV1(ctx context.Context, opts simOpts, blockNrO
 // successfully at block `blockNrOrHash`. It returns error if the transaction would revert, or if
 // there are unexpected failures. The gas limit is capped by both `args.Gas` (if non-nil &
 // non-zero) and `gasCap` (if non-zero).
-func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, gasCap uint64) (hexutil.Uint64, error) {
+func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, gasCap uint64) (_ hexutil.Uint64, err error) {
+	ctx, span := telemetry.Start(ctx, "ethapi.DoEstimateGas", telemetry.WithAttributes(telemetry.String("block", blockNrOrHash.String())))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
 	// Retrieve the base state and mutate it with any overrides
 	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
 		return 0, err
 	}
+	span.SetAttributes(telemetry.Uint64("number", header.Number.Uint64()))
+
 	if err := overrides.Apply(state, nil); err != nil {
 		return 0, err
 	}
@@ -848,6 +879,7 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 		}
 		return 0, err
 	}
+	span.SetAttributes(telemetry.Uint64("estimate", estimate))
 	return hexutil.Uint64(estimate), nil
 }
 
@@ -919,7 +951,7 @@ func RPCMarshalBlock(block *types.Block, inclTx bool, fullTx bool, config *param
 		}
 		if fullTx {
 			formatTx = func(idx int, tx *types.Transaction) interface{} {
-				return newRPCTransactionFromBlockIndex(block, uint64(idx), config)
+				return NewRPCTransactionFromBlockIndex(block, uint64(idx), config)
 			}
 		}
 		txs := block.Transactions()
@@ -1087,8 +1119,9 @@ func NewRPCPendingTransaction(tx *types.Transaction, current *types.Header, conf
 	return newRPCTransaction(tx, common.Hash{}, blockNumber, blockTime, 0, baseFee, config)
 }
 
-// newRPCTransactionFromBlockIndex returns a transaction that will serialize to the RPC representation.
-func newRPCTransactionFromBlockIndex(b *types.Block, index uint64, config *params.ChainConfig) *RPCTransaction {
+// NewRPCTransactionFromBlockIndex returns the transaction at the given index of a block
+// in its RPC representation.
+func NewRPCTransactionFromBlockIndex(b *types.Block, index uint64, config *params.ChainConfig) *RPCTransaction {
 	txs := b.Transactions()
 	if index >= uint64(len(txs)) {
 		return nil
@@ -1281,7 +1314,7 @@ func (api *TransactionAPI) GetBlockTransactionCountByHash(ctx context.Context, b
 func (api *TransactionAPI) GetTransactionByBlockNumberAndIndex(ctx context.Context, blockNr rpc.BlockNumber, index hexutil.Uint) (*RPCTransaction, error) {
 	block, err := api.b.BlockByNumber(ctx, blockNr)
 	if block != nil {
-		return newRPCTransactionFromBlockIndex(block, uint64(index), api.b.ChainConfig()), nil
+		return NewRPCTransactionFromBlockIndex(block, uint64(index), api.b.ChainConfig()), nil
 	}
 	return nil, err
 }
@@ -1290,7 +1323,7 @@ func (api *TransactionAPI) GetTransactionByBlockNumberAndIndex(ctx context.Conte
 func (api *TransactionAPI) GetTransactionByBlockHashAndIndex(ctx context.Context, blockHash common.Hash, index hexutil.Uint) (*RPCTransaction, error) {
 	block, err := api.b.BlockByHash(ctx, blockHash)
 	if block != nil {
-		return newRPCTransactionFromBlockIndex(block, uint64(index), api.b.ChainConfig()), nil
+		return NewRPCTransactionFromBlockIndex(block, uint64(index), api.b.ChainConfig()), nil
 	}
 	return nil, err
 }
@@ -1371,6 +1404,148 @@ func (api *TransactionAPI) GetRawTransactionByHash(ctx context.Context, hash com
 	return tx.MarshalBinary()
 }
 
+const (
+	// defaultAddressTransactions is the number of transactions returned by
+	// eth_getTransactionsByAddress if no limit is requested.
+	defaultAddressTransactions = 100
+
+	// maxAddressTransactions is the maximum number of transactions returned by
+	// a single eth_getTransactionsByAddress call.
+	maxAddressTransactions = 1000
+)
+
+var errAddressIndexUnavailable = errors.New("address index is not available, enable it with --history.addresses")
+
+// AddressTransactionsArgs represents the arguments of eth_getTransactionsByAddress.
+type AddressTransactionsArgs struct {
+	FromBlock *hexutil.Uint64 `json:"fromBlock"` // First block to search, defaults to the index tail
+	ToBlock   *hexutil.Uint64 `json:"toBlock"`   // Last block to search, defaults to the index head
+	Cursor    hexutil.Bytes   `json:"cursor"`    // Position to continue from, as returned by the previous page
+	Limit     *hexutil.Uint   `json:"limit"`     // Maximum number of transactions to return
+	Reverse   bool            `json:"reverse"`   // Whether to return the most recent transactions first
+}
+
+// AddressTransactionsResult is a page of the transactions an address appears in.
+type AddressTransactionsResult struct {
+	Transactions []*RPCTransaction `json:"transactions"`
+	Next         hexutil.Bytes     `json:"next,omitempty"` // Cursor of the next page, if any
+}
+
+// GetTransactionsByAddress returns the transactions in which the given address
+// appears, as sender, recipient, created contract, set-code authority or, if
+// enabled, participant of an internal call. The results are paginated, with the
+// next page being requested by passing the returned cursor.
+//
+// The transactions are retrieved from the address appearance index, so only the
+// blocks covered by it are searched.
+func (api *TransactionAPI) GetTransactionsByAddress(ctx context.Context, address common.Address, args *AddressTransactionsArgs) (*AddressTransactionsResult, error) {
+	var (
+		db   = api.b.ChainDb()
+		tail = rawdb.ReadAddressIndexTail(db)
+		head = rawdb.ReadAddressIndexHead(db)
+	)
+	if tail == nil || head == nil {
+		return nil, errAddressIndexUnavailable
+	}
+	if args == nil {
+		args = new(AddressTransactionsArgs)
+	}
+	limit := defaultAddressTransactions
+	if args.Limit != nil {
+		limit = int(*args.Limit)
+		if limit == 0 || limit > maxAddressTransactions {
+			return nil, fmt.Errorf("invalid limit %d, must be between 1 and %d", limit, maxAddressTransactions)
+		}
+	}
+	// Resolve the range of appearances to be searched
+	var (
+		start = rawdb.AddressAppearance{BlockNumber: *tail}
+		end   = rawdb.AddressAppearance{BlockNumber: *head + 1}
+	)
+	if args.FromBlock != nil {
+		start.BlockNumber = max(start.BlockNumber, uint64(*args.FromBlock))
+	}
+	if args.ToBlock != nil {
+		end.BlockNumber = min(end.BlockNumber, uint64(*args.ToBlock)+1)
+	}
+	if args.Cursor != nil {
+		cursor, err := decodeAddressCursor(args.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if args.Reverse {
+			// The cursor is the next appearance to be returned, hence inclusive.
+			next := rawdb.AddressAppearance{BlockNumber: cursor.BlockNumber, TxIndex: cursor.TxIndex + 1}
+			if cursor.TxIndex == gomath.MaxUint32 {
+				next = rawdb.AddressAppearance{BlockNumber: cursor.BlockNumber + 1}
+			}
+			if compareAddressAppearances(next, end) < 0 {
+				end = next
+			}
+		} else if compareAddressAppearances(cursor, start) > 0 {
+			start = cursor
+		}
+	}
+	// Retrieve one more appearance than requested, to tell where the next page
+	// starts.
+	var appearances []rawdb.AddressAppearance
+	if compareAddressAppearances(start, end) < 0 {
+		if args.Reverse {
+			appearances = rawdb.ReadAddressAppearanceRangeReverse(db, address, start, end, limit+1)
+		} else {
+			appearances = rawdb.ReadAddressAppearanceRange(db, address, start, end, limit+1)
+		}
+	}
+	result := &AddressTransactionsResult{Transactions: []*RPCTransaction{}}
+	if len(appearances) > limit {
+		result.Next = encodeAddressCursor(appearances[limit])
+		appearances = appearances[:limit]
+	}
+	var block *types.Block
+	for _, a := range appearances {
+		if block == nil || block.NumberU64() != a.BlockNumber {
+			var err error
+			block, err = api.b.BlockByNumber(ctx, rpc.BlockNumber(a.BlockNumber))
+			if err != nil {
+				return nil, err
+			}
+			if block == nil {
+				return nil, fmt.Errorf("block #%d not found", a.BlockNumber)
+			}
+		}
+		if tx := NewRPCTransactionFromBlockIndex(block, uint64(a.TxIndex), api.b.ChainConfig()); tx != nil {
+			result.Transactions = append(result.Transactions, tx)
+		}
+	}
+	return result, nil
+}
+
+// encodeAddressCursor encodes the position of an address appearance into an
+// opaque pagination cursor.
+func encodeAddressCursor(a rawdb.AddressAppearance) hexutil.Bytes {
+	cursor := make([]byte, 12)
+	binary.BigEndian.PutUint64(cursor, a.BlockNumber)
+	binary.BigEndian.PutUint32(cursor[8:], a.TxIndex)
+	return cursor
+}
+
+// decodeAddressCursor decodes a pagination cursor into the position of an
+// address appearance.
+func decodeAddressCursor(cursor hexutil.Bytes) (rawdb.AddressAppearance, error) {
+	if len(cursor) != 12 {
+		return rawdb.AddressAppearance{}, errors.New("invalid cursor")
+	}
+	return rawdb.AddressAppearance{
+		BlockNumber: binary.BigEndian.Uint64(cursor),
+		TxIndex:     binary.BigEndian.Uint32(cursor[8:]),
+	}, nil
+}
+
+// compareAddressAppearances compares the positions of two address appearances.
+func compareAddressAppearances(a, b rawdb.AddressAppearance) int {
+	return cmp.Or(cmp.Compare(a.BlockNumber, b.BlockNumber), cmp.Compare(a.TxIndex, b.TxIndex))
+}
+
 // GetTransactionReceipt returns the transaction receipt for the given transaction hash.
 func (api *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
 	found, tx, blockHash, blockNumber, index := api.b.GetTransaction(hash)
@@ -1397,11 +1572,11 @@ func (api *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash commo
 
 	// Derive the sender.
 	signer := types.MakeSigner(api.b.ChainConfig(), header.Number, header.Time)
-	return marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index)), nil
+	return MarshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index)), nil
 }
 
-// marshalReceipt marshals a transaction receipt into a JSON object.
-func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, tx *types.Transaction, txIndex int) map[string]interface{} {
+// MarshalReceipt marshals a transaction receipt into a JSON object.
+func MarshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, tx *types.Transaction, txIndex int) map[string]interface{} {
 	from, _ := types.Sender(signer, tx)
 
 	fields := map[string]interface{}{
@@ -49,6 +49,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -613,6 +614,9 @@ func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transactio
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
 }
+func (b testBackend) SubscribeTxLifecycleEvent(events chan<- []*txpool.TxLifecycleEvent) event.Subscription {
+	panic("implement me")
+}
 func (b testBackend) ChainConfig() *params.ChainConfig { return b.chain.Config() }
 func (b testBackend) Engine() consensus.Engine         { return b.chain.Engine() }
 func (b testBackend) GetLogs(ctx context.Context, blockHash common.Hash, number uint64) ([][]*types.Log, error) {
@@ -3740,3 +3744,136 @@ func TestCreateAccessListWithStateOverrides(t *testing.T) {
 	}}
 	require.Equal(t, expected, result.Accesslist)
 }
+
+func TestRPCGetTransactionsByAddress(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = crypto.GenerateKey()
+		sender  = crypto.PubkeyToAddress(key.PublicKey)
+		recv    = common.HexToAddress("0xaaaa")
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+		hashes []common.Hash
+	)
+	backend := newTestBackend(t, 10, genesis, ethash.NewFaker(), func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), recv, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), signer, key)
+		b.AddTx(tx)
+		hashes = append(hashes, tx.Hash())
+	})
+	api := NewTransactionAPI(backend, new(AddrLocker))
+
+	if _, err := api.GetTransactionsByAddress(context.Background(), recv, nil); err != errAddressIndexUnavailable {
+		t.Fatalf("wrong error without address index: %v", err)
+	}
+	if err := core.IndexAddresses(backend.db, genesis.Config, 0, 10, 0, nil); err != nil {
+		t.Fatalf("failed to index addresses: %v", err)
+	}
+	// paginate retrieves all pages of the query, returning the transaction hashes.
+	paginate := func(args AddressTransactionsArgs) []common.Hash {
+		var result []common.Hash
+		for {
+			page, err := api.GetTransactionsByAddress(context.Background(), recv, &args)
+			if err != nil {
+				t.Fatalf("failed to retrieve transactions: %v", err)
+			}
+			for _, tx := range page.Transactions {
+				result = append(result, tx.Hash)
+			}
+			if page.Next == nil {
+				return result
+			}
+			args.Cursor = page.Next
+		}
+	}
+	limit := hexutil.Uint(3)
+	if have := paginate(AddressTransactionsArgs{Limit: &limit}); !reflect.DeepEqual(have, hashes) {
+		t.Errorf("wrong forward transactions: have %v, want %v", have, hashes)
+	}
+	reversed := slices.Clone(hashes)
+	slices.Reverse(reversed)
+	if have := paginate(AddressTransactionsArgs{Limit: &limit, Reverse: true}); !reflect.DeepEqual(have, reversed) {
+		t.Errorf("wrong reverse transactions: have %v, want %v", have, reversed)
+	}
+	from, to := hexutil.Uint64(3), hexutil.Uint64(6)
+	if have := paginate(AddressTransactionsArgs{FromBlock: &from, ToBlock: &to, Limit: &limit}); !reflect.DeepEqual(have, hashes[2:6]) {
+		t.Errorf("wrong transactions in range: have %v, want %v", have, hashes[2:6])
+	}
+	if have := paginate(AddressTransactionsArgs{FromBlock: &from, ToBlock: &to, Reverse: true}); !reflect.DeepEqual(have, reversed[4:8]) {
+		t.Errorf("wrong reverse transactions in range: have %v, want %v", have, reversed[4:8])
+	}
+	invalid := hexutil.Uint(maxAddressTransactions + 1)
+	if _, err := api.GetTransactionsByAddress(context.Background(), recv, &AddressTransactionsArgs{Limit: &invalid}); err == nil {
+		t.Error("expected error for excessive limit")
+	}
+}
+
+// historicBackend serves the historic states reconstructed from the state
+// histories, like the full node does once they are no longer persisted.
+type historicBackend struct {
+	*testBackend
+}
+
+func (b historicBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	number, _ := blockNrOrHash.Number()
+	header, err := b.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, nil, err
+	}
+	statedb, err := b.chain.HistoricState(header.Root)
+	return statedb, header, err
+}
+
+// Tests that proofs of historic states are rejected with a clear error, as
+// their tries are not available.
+func TestGetProofHistoricState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = crypto.GenerateKey()
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(genesis.Config)
+		engine = ethash.NewFaker()
+	)
+	db, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 4, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(address), common.Address{0x01}, big.NewInt(1), params.TxGas, b.BaseFee(), nil), signer, key)
+		b.AddTx(tx)
+	})
+	cacheConfig := core.DefaultCacheConfigWithScheme(rawdb.PathScheme)
+	cacheConfig.SnapshotLimit = 0
+	cacheConfig.HistoricStateWindow = 16
+	// The state histories are kept in the freezer
+	chaindb, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer chaindb.Close()
+	chain, err := core.NewBlockChain(chaindb, cacheConfig, genesis, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	// Persist the head state, turning the states of the older blocks historic.
+	if err := chain.TrieDB().Commit(blocks[3].Root(), false); err != nil {
+		t.Fatalf("failed to persist state: %v", err)
+	}
+	api := NewBlockChainAPI(historicBackend{&testBackend{db: db, chain: chain}})
+
+	block := rpc.BlockNumberOrHashWithNumber(1)
+	if _, err := api.GetBalance(context.Background(), address, block); err != nil {
+		t.Fatalf("failed to read historic state: %v", err)
+	}
+	if _, err := api.GetProof(context.Background(), address, nil, block); !errors.Is(err, errHistoricProof) {
+		t.Fatalf("unexpected error: have %v, want %v", err, errHistoricProof)
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// tracesPath is the default OTLP/HTTP path of the trace export endpoint.
+	tracesPath = "/v1/traces"
+
+	queueSize     = 4096             // Maximum number of ended spans waiting for export
+	batchSize     = 512              // Maximum number of spans exported in one request
+	flushInterval = 5 * time.Second  // Interval of exporting the pending spans
+	exportTimeout = 10 * time.Second // Timeout of a single export request
+
+	// scopeName is the instrumentation scope reported for all spans.
+	scopeName = "github.com/ethereum/go-ethereum"
+)
+
+var droppedSpansMeter = metrics.NewRegisteredMeter("telemetry/spans/dropped", nil)
+
+// Config contains the settings of the span export.
+type Config struct {
+	Endpoint    string            // OTLP/HTTP collector URL, the trace path is appended if none is set
+	Headers     map[string]string // Extra HTTP headers sent with each export, e.g. for authentication
+	ServiceName string            // Name of the service reported to the collector
+	SampleRatio float64           // Fraction of the new traces recorded, in the [0, 1] range
+}
+
+// Enable starts exporting spans to the configured collector and installs the
+// returned tracer as the global one. The tracer must be closed to flush the
+// pending spans.
+func Enable(config Config) (*Tracer, error) {
+	endpoint, err := exportURL(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid trace sample ratio %v", config.SampleRatio)
+	}
+	if config.ServiceName == "" {
+		config.ServiceName = "geth"
+	}
+	t := &Tracer{
+		ratio:    config.SampleRatio,
+		exporter: newExporter(endpoint, config),
+	}
+	if !globalTracer.CompareAndSwap(nil, t) {
+		t.exporter.close()
+		return nil, errors.New("tracing already enabled")
+	}
+	return t, nil
+}
+
+// Close stops the tracer, exporting all pending spans. If the tracer is the
+// global one, tracing is disabled.
+func (t *Tracer) Close() {
+	globalTracer.CompareAndSwap(t, nil)
+	t.exporter.close()
+}
+
+// exportURL returns the URL to post the spans to.
+func exportURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid trace endpoint: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid trace endpoint %q, only http(s) is supported", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = tracesPath
+	}
+	return u.String(), nil
+}
+
+// exporter batches the ended spans and posts them to an OTLP/HTTP collector
+// in the JSON encoding.
+type exporter struct {
+	endpoint string
+	config   Config
+	client   *http.Client
+
+	queue   chan *Span
+	closeCh chan struct{}
+	closed  chan struct{}
+}
+
+func newExporter(endpoint string, config Config) *exporter {
+	e := &exporter{
+		endpoint: endpoint,
+		config:   config,
+		client:   &http.Client{Timeout: exportTimeout},
+		queue:    make(chan *Span, queueSize),
+		closeCh:  make(chan struct{}),
+		closed:   make(chan struct{}),
+	}
+	go e.loop()
+	return e
+}
+
+// enqueue schedules a span for export, dropping it if the queue is full.
+func (e *exporter) enqueue(s *Span) {
+	select {
+	case e.queue <- s:
+	default:
+		droppedSpansMeter.Mark(1)
+	}
+}
+
+// close flushes the pending spans and terminates the export loop.
+func (e *exporter) close() {
+	select {
+	case <-e.closeCh:
+	default:
+		close(e.closeCh)
+	}
+	<-e.closed
+}
+
+// loop collects the ended spans and exports them in batches, either when a
+// batch fills up or periodically.
+func (e *exporter) loop() {
+	defer close(e.closed)
+
+	var (
+		batch  = make([]*Span, 0, batchSize)
+		ticker = time.NewTicker(flushInterval)
+	)
+	defer ticker.Stop()
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.export(batch); err != nil {
+			log.Warn("Failed to export trace spans", "endpoint", e.endpoint, "spans", len(batch), "err", err)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case s := <-e.queue:
+			if batch = append(batch, s); len(batch) == batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.closeCh:
+			for {
+				select {
+				case s := <-e.queue:
+					if batch = append(batch, s); len(batch) == batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// export posts a batch of spans to the collector.
+func (e *exporter) export(spans []*Span) error {
+	blob, err := json.Marshal(e.encode(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(blob))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.config.Headers {
+		req.Header.Set(key, value)
+	}
+	res, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("collector responded with %s", res.Status)
+	}
+	return nil
+}
+
+// The types below are the OTLP/JSON encoding of an export request, as defined
+// by the ExportTraceServiceRequest message of the OpenTelemetry protocol.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            *otlpStatus    `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"` // 64-bit integers are encoded as strings
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// otlpStatusError is the status code of failed spans.
+const otlpStatusError = 2
+
+// encode converts a batch of spans into an OTLP export request.
+func (e *exporter) encode(spans []*Span) *otlpRequest {
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		s.lock.Lock()
+		span := otlpSpan{
+			TraceID:           s.sc.TraceID.String(),
+			SpanID:            s.sc.SpanID.String(),
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        encodeAttributes(s.attrs),
+		}
+		if s.parent != (SpanID{}) {
+			span.ParentSpanID = s.parent.String()
+		}
+		if s.failed {
+			span.Status = &otlpStatus{Code: otlpStatusError, Message: s.err}
+		}
+		s.lock.Unlock()
+
+		encoded = append(encoded, span)
+	}
+	return &otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: encodeAttributes([]Attribute{String("service.name", e.config.ServiceName)}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: scopeName},
+				Spans: encoded,
+			}},
+		}},
+	}
+}
+
+// encodeAttributes converts span attributes into their OTLP encoding.
+func encodeAttributes(attrs []Attribute) []otlpKeyValue {
+	encoded := make([]otlpKeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		kv := otlpKeyValue{Key: attr.Key}
+		switch v := attr.Value.(type) {
+		case string:
+			kv.Value.StringValue = &v
+		case int64:
+			s := strconv.FormatInt(v, 10)
+			kv.Value.IntValue = &s
+		case bool:
+			kv.Value.BoolValue = &v
+		case float64:
+			kv.Value.DoubleValue = &v
+		default:
+			s := fmt.Sprint(v)
+			kv.Value.StringValue = &s
+		}
+		encoded = append(encoded, kv)
+	}
+	return encoded
+}
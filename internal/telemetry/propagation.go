@@ -0,0 +1,107 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TraceParentHeader is the W3C Trace Context header carrying the span context.
+const TraceParentHeader = "traceparent"
+
+// sampledFlag is the trace flag marking a trace as sampled.
+const sampledFlag = 0x01
+
+var errInvalidTraceParent = errors.New("invalid traceparent")
+
+// ParseTraceParent parses the value of a W3C traceparent header, formatted as
+// version-traceid-parentid-flags.
+func ParseTraceParent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	version, err := hex.DecodeString(parts[0])
+	if err != nil || version[0] == 0xff {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	// Version 00 has exactly four fields, future versions may append more
+	if version[0] == 0 && len(parts) != 4 {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	var (
+		sc    = SpanContext{Remote: true}
+		flags []byte
+	)
+	if len(parts[1]) != 2*len(sc.TraceID) || len(parts[2]) != 2*len(sc.SpanID) || len(parts[3]) != 2 {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	if flags, err = hex.DecodeString(parts[3]); err != nil {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	if !sc.IsValid() {
+		return SpanContext{}, errInvalidTraceParent
+	}
+	sc.Sampled = flags[0]&sampledFlag != 0
+	return sc, nil
+}
+
+// FormatTraceParent formats the span context as a W3C traceparent header value.
+func FormatTraceParent(sc SpanContext) string {
+	var flags byte
+	if sc.Sampled {
+		flags |= sampledFlag
+	}
+	return fmt.Sprintf("00-%s-%s-%02x", sc.TraceID, sc.SpanID, flags)
+}
+
+// Extract returns a copy of the context carrying the span context found in the
+// traceparent header, if tracing is enabled and the header is valid. Spans
+// started from the returned context continue the remote trace.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	if !Enabled() {
+		return ctx
+	}
+	value := header.Get(TraceParentHeader)
+	if value == "" {
+		return ctx
+	}
+	sc, err := ParseTraceParent(value)
+	if err != nil {
+		return ctx
+	}
+	return ContextWithSpanContext(ctx, sc)
+}
+
+// Inject sets the traceparent header to the span context carried by the context,
+// if any, propagating the trace to the remote end.
+func Inject(ctx context.Context, header http.Header) {
+	if sc, ok := SpanContextFromContext(ctx); ok {
+		header.Set(TraceParentHeader, FormatTraceParent(sc))
+	}
+}
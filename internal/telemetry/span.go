@@ -0,0 +1,263 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package telemetry implements request-scoped distributed tracing, exporting the
+// recorded spans to an OpenTelemetry collector over OTLP/HTTP.
+//
+// Tracing is disabled by default, in which case starting a span is a cheap noop
+// and returns a nil span, on which all methods are safe to call.
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceID is the unique identifier of a trace.
+type TraceID [16]byte
+
+// String returns the lowercase hex encoding of the trace ID.
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+
+// SpanID is the unique identifier of a span within a trace.
+type SpanID [8]byte
+
+// String returns the lowercase hex encoding of the span ID.
+func (id SpanID) String() string { return hex.EncodeToString(id[:]) }
+
+// SpanContext is the part of a span which is propagated to its children, also
+// across process boundaries.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+	Remote  bool // Whether the span context was received from a remote peer
+}
+
+// IsValid reports whether the span context carries non-zero identifiers.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != (TraceID{}) && sc.SpanID != (SpanID{})
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a copy of the context carrying the given span
+// context, which new spans started from the returned context will be children of.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context carried by the context, if any.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// SpanKind describes the relationship of a span to its parent and children.
+type SpanKind int
+
+// Span kinds, numbered as in the OTLP protocol.
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// Attribute is a key-value pair annotating a span.
+type Attribute struct {
+	Key   string
+	Value interface{} // string, int64, bool or float64
+}
+
+// String creates a string valued attribute.
+func String(key, value string) Attribute { return Attribute{key, value} }
+
+// Int64 creates an integer valued attribute.
+func Int64(key string, value int64) Attribute { return Attribute{key, value} }
+
+// Uint64 creates an integer valued attribute. OTLP has no unsigned integers,
+// values exceeding the int64 range wrap around.
+func Uint64(key string, value uint64) Attribute { return Attribute{key, int64(value)} }
+
+// Bool creates a boolean valued attribute.
+func Bool(key string, value bool) Attribute { return Attribute{key, value} }
+
+// Float64 creates a floating point valued attribute.
+func Float64(key string, value float64) Attribute { return Attribute{key, value} }
+
+// Span is a timed operation within a trace. Spans are created by Start and must
+// be ended once the operation completes, after which they are handed to the
+// exporter. A nil span (returned if tracing is disabled or the trace is not
+// sampled) ignores all calls.
+type Span struct {
+	tracer *Tracer
+	sc     SpanContext
+	parent SpanID
+	name   string
+	kind   SpanKind
+	start  time.Time
+
+	lock   sync.Mutex
+	end    time.Time
+	attrs  []Attribute
+	err    string
+	failed bool
+	ended  bool
+}
+
+// SpanOption configures a span on creation.
+type SpanOption func(*Span)
+
+// WithKind sets the kind of the span, the default being SpanKindInternal.
+func WithKind(kind SpanKind) SpanOption {
+	return func(s *Span) { s.kind = kind }
+}
+
+// WithAttributes sets the initial attributes of the span.
+func WithAttributes(attrs ...Attribute) SpanOption {
+	return func(s *Span) { s.attrs = append(s.attrs, attrs...) }
+}
+
+// globalTracer is the tracer used for all spans, nil if tracing is disabled.
+var globalTracer atomic.Pointer[Tracer]
+
+// Enabled reports whether tracing is enabled.
+func Enabled() bool {
+	return globalTracer.Load() != nil
+}
+
+// Start creates a span with the given name, as a child of the span carried by
+// the context if any, otherwise as the root of a new trace. The returned context
+// carries the new span. If tracing is disabled or the trace is not sampled, the
+// returned span is nil.
+func Start(ctx context.Context, name string, opts ...SpanOption) (context.Context, *Span) {
+	t := globalTracer.Load()
+	if t == nil {
+		return ctx, nil
+	}
+	return t.start(ctx, name, opts...)
+}
+
+// SetAttributes adds the given attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// RecordError marks the span as failed with the given error. Nil errors are
+// ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.failed, s.err = true, err.Error()
+}
+
+// SpanContext returns the span context of the span.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// End completes the span and queues it for export. Calls after the first one
+// are ignored.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	if s.ended {
+		s.lock.Unlock()
+		return
+	}
+	s.ended, s.end = true, time.Now()
+	s.lock.Unlock()
+
+	s.tracer.export(s)
+}
+
+// Tracer creates spans and feeds the ended ones into an exporter.
+type Tracer struct {
+	ratio    float64
+	exporter *exporter
+}
+
+// start creates a new span as a child of the one in the context.
+func (t *Tracer) start(ctx context.Context, name string, opts ...SpanOption) (context.Context, *Span) {
+	var (
+		sc     SpanContext
+		parent SpanID
+	)
+	if psc, ok := SpanContextFromContext(ctx); ok {
+		sc.TraceID, sc.Sampled = psc.TraceID, psc.Sampled
+		parent = psc.SpanID
+	} else {
+		rand.Read(sc.TraceID[:])
+		sc.Sampled = t.sample(sc.TraceID)
+	}
+	rand.Read(sc.SpanID[:])
+
+	ctx = ContextWithSpanContext(ctx, sc)
+	if !sc.Sampled {
+		return ctx, nil
+	}
+	span := &Span{
+		tracer: t,
+		sc:     sc,
+		parent: parent,
+		name:   name,
+		kind:   SpanKindInternal,
+		start:  time.Now(),
+	}
+	for _, opt := range opts {
+		opt(span)
+	}
+	return ctx, span
+}
+
+// sample decides whether a new trace is recorded, deterministically based on
+// its identifier.
+func (t *Tracer) sample(id TraceID) bool {
+	if t.ratio >= 1 {
+		return true
+	}
+	if t.ratio <= 0 {
+		return false
+	}
+	return binary.BigEndian.Uint64(id[8:])>>1 < uint64(t.ratio*(1<<63))
+}
+
+// export hands an ended span to the exporter.
+func (t *Tracer) export(s *Span) {
+	if t.exporter != nil {
+		t.exporter.enqueue(s)
+	}
+}
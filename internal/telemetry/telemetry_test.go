@@ -0,0 +1,202 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// testCollector is a stand-in OTLP/HTTP collector recording the received spans.
+type testCollector struct {
+	lock    sync.Mutex
+	spans   []otlpSpan
+	service string
+	auth    string
+}
+
+func newTestCollector(t *testing.T) (*testCollector, *httptest.Server) {
+	c := new(testCollector)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != tracesPath {
+			http.NotFound(w, r)
+			return
+		}
+		var req otlpRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		c.auth = r.Header.Get("Authorization")
+		for _, rs := range req.ResourceSpans {
+			c.service = *rs.Resource.Attributes[0].Value.StringValue
+			for _, ss := range rs.ScopeSpans {
+				c.spans = append(c.spans, ss.Spans...)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return c, srv
+}
+
+func TestSpanExport(t *testing.T) {
+	collector, srv := newTestCollector(t)
+
+	tracer, err := Enable(Config{Endpoint: srv.URL, Headers: map[string]string{"Authorization": "Bearer token"}, SampleRatio: 1})
+	if err != nil {
+		t.Fatalf("failed to enable tracing: %v", err)
+	}
+	// Continue a remote trace and create a nested span
+	header := make(http.Header)
+	header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := Extract(context.Background(), header)
+
+	ctx, parent := Start(ctx, "parent", WithKind(SpanKindServer), WithAttributes(String("method", "eth_call")))
+	_, child := Start(ctx, "child")
+	child.SetAttributes(Int64("number", 42), Bool("ok", false))
+	child.RecordError(errors.New("boom"))
+	child.End()
+	parent.End()
+	parent.End() // Second call must be ignored
+
+	// Propagate the trace further
+	out := make(http.Header)
+	Inject(ctx, out)
+	if want := FormatTraceParent(parent.SpanContext()); out.Get(TraceParentHeader) != want {
+		t.Fatalf("wrong propagated traceparent: have %s, want %s", out.Get(TraceParentHeader), want)
+	}
+	tracer.Close()
+	if Enabled() {
+		t.Fatal("tracing enabled after closing the tracer")
+	}
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	if collector.service != "geth" || collector.auth != "Bearer token" {
+		t.Fatalf("wrong export metadata: service %q, auth %q", collector.service, collector.auth)
+	}
+	if len(collector.spans) != 2 {
+		t.Fatalf("wrong number of exported spans: have %d, want 2", len(collector.spans))
+	}
+	c, p := collector.spans[0], collector.spans[1]
+	if p.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || c.TraceID != p.TraceID {
+		t.Fatalf("wrong trace ids: parent %s, child %s", p.TraceID, c.TraceID)
+	}
+	if p.ParentSpanID != "00f067aa0ba902b7" || c.ParentSpanID != p.SpanID {
+		t.Fatalf("wrong span hierarchy: parent %s/%s, child %s", p.ParentSpanID, p.SpanID, c.ParentSpanID)
+	}
+	if p.Name != "parent" || p.Kind != SpanKindServer || len(p.Attributes) != 1 || *p.Attributes[0].Value.StringValue != "eth_call" {
+		t.Fatalf("wrong parent span: %+v", p)
+	}
+	if c.Status == nil || c.Status.Code != otlpStatusError || c.Status.Message != "boom" {
+		t.Fatalf("wrong child status: %+v", c.Status)
+	}
+	if len(c.Attributes) != 2 || *c.Attributes[0].Value.IntValue != "42" || *c.Attributes[1].Value.BoolValue {
+		t.Fatalf("wrong child attributes: %+v", c.Attributes)
+	}
+}
+
+func TestSampling(t *testing.T) {
+	collector, srv := newTestCollector(t)
+
+	tracer, err := Enable(Config{Endpoint: srv.URL, SampleRatio: 0})
+	if err != nil {
+		t.Fatalf("failed to enable tracing: %v", err)
+	}
+	// Unsampled roots must not be recorded, nor their children
+	ctx, root := Start(context.Background(), "root")
+	if root != nil {
+		t.Fatal("unsampled root span recorded")
+	}
+	if _, child := Start(ctx, "child"); child != nil {
+		t.Fatal("child of unsampled span recorded")
+	}
+	// Remote sampling decisions must be respected
+	header := make(http.Header)
+	header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, span := Start(Extract(context.Background(), header), "remote")
+	if span == nil {
+		t.Fatal("sampled remote trace not recorded")
+	}
+	span.End()
+	tracer.Close()
+
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	if len(collector.spans) != 1 || collector.spans[0].Name != "remote" {
+		t.Fatalf("wrong exported spans: %+v", collector.spans)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	ctx, span := Start(context.Background(), "noop")
+	if span != nil {
+		t.Fatal("span created while tracing is disabled")
+	}
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("boom"))
+	span.End()
+
+	header := make(http.Header)
+	Inject(ctx, header)
+	if header.Get(TraceParentHeader) != "" {
+		t.Fatal("traceparent injected while tracing is disabled")
+	}
+}
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		value   string
+		valid   bool
+		sampled bool
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true, true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false, false},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01", false, false},
+		{"", false, false},
+	}
+	for i, test := range tests {
+		sc, err := ParseTraceParent(test.value)
+		if (err == nil) != test.valid {
+			t.Errorf("test %d: validity mismatch: have %v, want %v", i, err == nil, test.valid)
+			continue
+		}
+		if !test.valid {
+			continue
+		}
+		if sc.Sampled != test.sampled || !sc.Remote {
+			t.Errorf("test %d: wrong span context %+v", i, sc)
+		}
+		if test.value[:2] == "00" && FormatTraceParent(sc) != test.value {
+			t.Errorf("test %d: roundtrip mismatch: have %s", i, FormatTraceParent(sc))
+		}
+	}
+}
@@ -0,0 +1,496 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// maxBundleFutureBlocks is the maximum distance of the target block of a
+	// bundle from the current chain head.
+	maxBundleFutureBlocks = 16
+
+	// maxBundlesPerBlock is the maximum number of bundles kept for a single
+	// target block. Each of them is simulated on its own copy of the state when
+	// building a payload for the block.
+	maxBundlesPerBlock = 64
+
+	// maxBundleTxs is the maximum number of transactions in a bundle.
+	maxBundleTxs = 64
+)
+
+var (
+	errEmptyBundle        = errors.New("bundle has no transactions")
+	errBundleTooLarge     = errors.New("bundle has too many transactions")
+	errBundleBlobTx       = errors.New("blob transactions are not supported in bundles")
+	errBundleStale        = errors.New("bundle target block is already mined")
+	errBundleTooFar       = errors.New("bundle target block is too far in the future")
+	errBundleTimestamps   = errors.New("bundle has invalid timestamp bounds")
+	errBundleNotNext      = errors.New("bundle does not target the next block")
+	errBundlePoolFull     = errors.New("too many bundles for target block")
+	errBundleTxReverted   = errors.New("bundle transaction reverted")
+	errBundleNotProfiting = errors.New("bundle does not pay the fee recipient")
+)
+
+// Bundle is an ordered list of transactions which is included in a block
+// atomically: either all of them at the top of the block, in the given order,
+// or none at all.
+type Bundle struct {
+	Txs               types.Transactions // Transactions to include, in order
+	BlockNumber       uint64             // Number of the only block the bundle is valid for
+	MinTimestamp      uint64             // Minimum timestamp of the block, 0 if unbounded
+	MaxTimestamp      uint64             // Maximum timestamp of the block, 0 if unbounded
+	RevertingTxHashes []common.Hash      // Transactions which are allowed to revert
+}
+
+// Hash returns the identifier of the bundle, the hash of its transaction hashes.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
+
+// canRevert reports whether the transaction is allowed to revert without the
+// bundle being discarded.
+func (b *Bundle) canRevert(hash common.Hash) bool {
+	return slices.Contains(b.RevertingTxHashes, hash)
+}
+
+// validFor reports whether the bundle can be included in the given block.
+func (b *Bundle) validFor(header *types.Header) bool {
+	if b.BlockNumber != header.Number.Uint64() {
+		return false
+	}
+	if b.MinTimestamp != 0 && header.Time < b.MinTimestamp {
+		return false
+	}
+	if b.MaxTimestamp != 0 && header.Time > b.MaxTimestamp {
+		return false
+	}
+	return true
+}
+
+// BundleTxResult is the outcome of executing a single transaction of a bundle.
+type BundleTxResult struct {
+	TxHash       common.Hash
+	From         common.Address
+	To           *common.Address
+	GasUsed      uint64
+	GasPrice     *big.Int // Effective tip paid per gas to the fee recipient
+	GasFees      *big.Int // Total tip paid to the fee recipient
+	CoinbaseDiff *big.Int // Balance change of the fee recipient, including direct payments
+	Reverted     bool
+}
+
+// BundleResult is the outcome of executing a bundle.
+type BundleResult struct {
+	BundleHash       common.Hash
+	StateBlockNumber uint64 // Number of the block the bundle was executed on top of
+	GasUsed          uint64
+	GasFees          *big.Int // Total tips paid to the fee recipient
+	CoinbaseDiff     *big.Int // Balance change of the fee recipient, including direct payments
+	Txs              []*BundleTxResult
+}
+
+// bundleSimKey identifies the simulation of a bundle at the top of a block with
+// a given parent, fee recipient and timestamp.
+type bundleSimKey struct {
+	parent   common.Hash
+	coinbase common.Address
+	time     uint64
+	bundle   *Bundle
+}
+
+// bundleSim is the outcome of a bundle simulation.
+type bundleSim struct {
+	number uint64   // Number of the block the bundle was simulated in
+	price  *big.Int // Payment to the fee recipient per gas, nil if not includable
+}
+
+// bundlePool stores the bundles submitted for inclusion, indexed by their
+// target block, along with the outcome of their simulations. The payloads are
+// rebuilt periodically, the simulations are cached to only execute each bundle
+// once per payload.
+type bundlePool struct {
+	bundles map[uint64][]*Bundle
+	sims    map[bundleSimKey]*bundleSim
+	lock    sync.Mutex
+}
+
+func newBundlePool() *bundlePool {
+	return &bundlePool{
+		bundles: make(map[uint64][]*Bundle),
+		sims:    make(map[bundleSimKey]*bundleSim),
+	}
+}
+
+// add inserts a bundle into the pool, replacing the one with the same hash and
+// dropping its simulations.
+func (p *bundlePool) add(bundle *Bundle) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	hash := bundle.Hash()
+	bundles := p.bundles[bundle.BlockNumber]
+	for i, b := range bundles {
+		if b.Hash() == hash {
+			bundles[i] = bundle
+			for key := range p.sims {
+				if key.bundle == b {
+					delete(p.sims, key)
+				}
+			}
+			return nil
+		}
+	}
+	if len(bundles) >= maxBundlesPerBlock {
+		return errBundlePoolFull
+	}
+	p.bundles[bundle.BlockNumber] = append(bundles, bundle)
+	return nil
+}
+
+// pending returns the bundles which can be included in the given block, and
+// drops the ones targeting earlier blocks.
+func (p *bundlePool) pending(header *types.Header) []*Bundle {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	number := header.Number.Uint64()
+	for target := range p.bundles {
+		if target < number {
+			delete(p.bundles, target)
+		}
+	}
+	for key, sim := range p.sims {
+		if sim.number < number {
+			delete(p.sims, key)
+		}
+	}
+	var bundles []*Bundle
+	for _, bundle := range p.bundles[number] {
+		if bundle.validFor(header) {
+			bundles = append(bundles, bundle)
+		}
+	}
+	return bundles
+}
+
+// simulation returns the cached outcome of a bundle simulation, if any.
+func (p *bundlePool) simulation(key bundleSimKey) *bundleSim {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.sims[key]
+}
+
+// setSimulation caches the outcome of a bundle simulation, as long as the
+// bundle is still stored in the pool.
+func (p *bundlePool) setSimulation(key bundleSimKey, sim *bundleSim) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if slices.Contains(p.bundles[key.bundle.BlockNumber], key.bundle) {
+		p.sims[key] = sim
+	}
+}
+
+// SendBundle validates a bundle and stores it for inclusion in the payloads
+// built for its target block.
+func (miner *Miner) SendBundle(bundle *Bundle) error {
+	if err := miner.validateBundle(bundle); err != nil {
+		return err
+	}
+	return miner.bundles.add(bundle)
+}
+
+// validateBundle checks the contents of a bundle and whether its target block
+// is within the accepted range ahead of the current chain head.
+func (miner *Miner) validateBundle(bundle *Bundle) error {
+	switch {
+	case len(bundle.Txs) == 0:
+		return errEmptyBundle
+	case len(bundle.Txs) > maxBundleTxs:
+		return errBundleTooLarge
+	case bundle.MaxTimestamp != 0 && bundle.MinTimestamp > bundle.MaxTimestamp:
+		return errBundleTimestamps
+	}
+	for _, tx := range bundle.Txs {
+		if tx.Type() == types.BlobTxType {
+			return errBundleBlobTx
+		}
+	}
+	head := miner.chain.CurrentBlock().Number.Uint64()
+	if bundle.BlockNumber <= head {
+		return errBundleStale
+	}
+	if bundle.BlockNumber > head+maxBundleFutureBlocks {
+		return errBundleTooFar
+	}
+	return nil
+}
+
+// CallBundle executes a bundle in a new block on top of the current chain head,
+// without storing it. The bundle is validated as on submission, and must target
+// the next block. The block is timestamped with the current time, raised to the
+// minimum timestamp of the bundle if needed. The transactions of the pool are
+// not executed before the bundle. Contrary to block inclusion, the execution is
+// not aborted if a transaction reverts, the reverts are reported in the result
+// instead.
+//
+// The gas available to the bundle is capped at gasCap if non-zero, and the
+// execution is aborted when the context is cancelled.
+func (miner *Miner) CallBundle(ctx context.Context, bundle *Bundle, gasCap uint64) (*BundleResult, error) {
+	if err := miner.validateBundle(bundle); err != nil {
+		return nil, err
+	}
+	var (
+		parent     = miner.chain.CurrentBlock()
+		timestamp  = max(uint64(time.Now().Unix()), bundle.MinTimestamp)
+		withdrawal types.Withdrawals
+	)
+	if bundle.BlockNumber != parent.Number.Uint64()+1 {
+		return nil, errBundleNotNext
+	}
+	if miner.chainConfig.IsShanghai(new(big.Int).Add(parent.Number, common.Big1), timestamp) {
+		withdrawal = []*types.Withdrawal{}
+	}
+	miner.confMu.RLock()
+	coinbase := miner.config.PendingFeeRecipient
+	miner.confMu.RUnlock()
+
+	env, err := miner.prepareWork(&generateParams{
+		timestamp:   timestamp,
+		parentHash:  parent.Hash(),
+		coinbase:    coinbase,
+		withdrawals: withdrawal,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	if !bundle.validFor(env.header) {
+		return nil, fmt.Errorf("%w: block timestamp %d", errBundleTimestamps, env.header.Time)
+	}
+	gas := env.header.GasLimit
+	if gasCap != 0 && gasCap < gas {
+		gas = gasCap
+	}
+	env.gasPool = new(core.GasPool).AddGas(gas)
+
+	// Abort the execution when the context is cancelled
+	stop := context.AfterFunc(ctx, env.evm.Cancel)
+	defer stop()
+
+	result, err := miner.applyBundle(env, bundle, true)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("bundle execution aborted: %w", ctxErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.StateBlockNumber = parent.Number.Uint64()
+	return result, nil
+}
+
+// applyBundle executes the transactions of a bundle on top of the environment.
+// If allowReverts is not set, the execution is aborted with an error as soon as
+// a transaction not marked as revertible reverts. On error the environment is
+// left in an undefined state, the caller is responsible for rolling it back.
+func (miner *Miner) applyBundle(env *environment, bundle *Bundle, allowReverts bool) (*BundleResult, error) {
+	result := &BundleResult{
+		BundleHash:   bundle.Hash(),
+		GasFees:      new(big.Int),
+		CoinbaseDiff: new(big.Int),
+	}
+	for i, tx := range bundle.Txs {
+		if tx.Protected() && !miner.chainConfig.IsEIP155(env.header.Number) {
+			return nil, fmt.Errorf("tx %d: replay protected transaction before EIP-155", i)
+		}
+		from, err := types.Sender(env.signer, tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx %d: %w", i, err)
+		}
+		before := env.state.GetBalance(env.coinbase).ToBig()
+
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		receipt, err := core.ApplyTransaction(env.evm, env.gasPool, env.state, env.header, tx, &env.header.GasUsed)
+		if err != nil {
+			return nil, fmt.Errorf("tx %d: %w", i, err)
+		}
+		reverted := receipt.Status == types.ReceiptStatusFailed
+		if reverted && !allowReverts && !bundle.canRevert(tx.Hash()) {
+			return nil, fmt.Errorf("tx %d: %w", i, errBundleTxReverted)
+		}
+		env.txs = append(env.txs, tx)
+		env.receipts = append(env.receipts, receipt)
+		env.tcount++
+
+		tip, _ := tx.EffectiveGasTip(env.header.BaseFee)
+		txResult := &BundleTxResult{
+			TxHash:       tx.Hash(),
+			From:         from,
+			To:           tx.To(),
+			GasUsed:      receipt.GasUsed,
+			GasPrice:     tip,
+			GasFees:      new(big.Int).Mul(tip, new(big.Int).SetUint64(receipt.GasUsed)),
+			CoinbaseDiff: new(big.Int).Sub(env.state.GetBalance(env.coinbase).ToBig(), before),
+			Reverted:     reverted,
+		}
+		result.Txs = append(result.Txs, txResult)
+		result.GasUsed += receipt.GasUsed
+		result.GasFees.Add(result.GasFees, txResult.GasFees)
+		result.CoinbaseDiff.Add(result.CoinbaseDiff, txResult.CoinbaseDiff)
+	}
+	return result, nil
+}
+
+// commitBundle includes a bundle into the block being built, atomically. If
+// any transaction fails or reverts without being allowed to, the environment
+// is rolled back and the bundle is skipped.
+//
+// The state journal does not span across transactions, so the bundle is
+// executed on a copy of the state, which replaces the original one on success.
+func (miner *Miner) commitBundle(env *environment, bundle *Bundle) (*BundleResult, error) {
+	var (
+		state    = env.state
+		evm      = env.evm
+		gas      = env.gasPool.Gas()
+		gasUsed  = env.header.GasUsed
+		tcount   = env.tcount
+		txs      = len(env.txs)
+		receipts = len(env.receipts)
+	)
+	env.state = state.Copy()
+	env.evm = vm.NewEVM(evm.Context, env.state, miner.chainConfig, evm.Config)
+
+	result, err := miner.applyBundle(env, bundle, false)
+	if err == nil && result.CoinbaseDiff.Sign() <= 0 {
+		err = errBundleNotProfiting
+	}
+	if err != nil {
+		env.state, env.evm = state, evm
+		env.gasPool.SetGas(gas)
+		env.header.GasUsed = gasUsed
+		env.tcount = tcount
+		env.txs = env.txs[:txs]
+		env.receipts = env.receipts[:receipts]
+		return nil, err
+	}
+	env.witness = env.state.Witness()
+	return result, nil
+}
+
+// simulateBundle executes a bundle at the top of the block being built, leaving
+// the environment untouched, and returns its payment to the fee recipient per
+// gas. The bundle is executed on a copy of the state.
+func (miner *Miner) simulateBundle(env *environment, bundle *Bundle) *bundleSim {
+	sim := &environment{
+		signer:   env.signer,
+		state:    env.state,
+		evm:      env.evm,
+		coinbase: env.coinbase,
+		header:   types.CopyHeader(env.header),
+		gasPool:  new(core.GasPool).AddGas(env.gasPool.Gas()),
+	}
+	result, err := miner.commitBundle(sim, bundle)
+	if err != nil {
+		log.Debug("Discarding failing bundle", "hash", bundle.Hash(), "block", bundle.BlockNumber, "err", err)
+		return &bundleSim{number: bundle.BlockNumber}
+	}
+	if result.GasUsed == 0 {
+		return &bundleSim{number: bundle.BlockNumber}
+	}
+	return &bundleSim{
+		number: bundle.BlockNumber,
+		price:  new(big.Int).Div(result.CoinbaseDiff, new(big.Int).SetUint64(result.GasUsed)),
+	}
+}
+
+// commitBundles includes the profitable bundles targeting the block being built
+// into the environment. The bundles are first simulated independently on top
+// of the environment, then merged in the order of their profit per gas, dropping
+// the ones which fail on top of the previously merged bundles. The simulations
+// are reused across the rebuilds of the same payload. No more bundles are
+// simulated or merged once the interrupt signal is raised.
+func (miner *Miner) commitBundles(interrupt *atomic.Int32, env *environment, bundles []*Bundle) {
+	type simulated struct {
+		bundle *Bundle
+		price  *big.Int
+	}
+	var sims []simulated
+	for _, bundle := range bundles {
+		if interrupt.Load() != commitInterruptNone {
+			return
+		}
+		key := bundleSimKey{
+			parent:   env.header.ParentHash,
+			coinbase: env.coinbase,
+			time:     env.header.Time,
+			bundle:   bundle,
+		}
+		sim := miner.bundles.simulation(key)
+		if sim == nil {
+			sim = miner.simulateBundle(env, bundle)
+			miner.bundles.setSimulation(key, sim)
+		}
+		if sim.price == nil {
+			continue
+		}
+		sims = append(sims, simulated{bundle: bundle, price: sim.price})
+	}
+	sort.SliceStable(sims, func(i, j int) bool {
+		return sims[i].price.Cmp(sims[j].price) > 0
+	})
+	for _, sim := range sims {
+		if interrupt.Load() != commitInterruptNone {
+			return
+		}
+		if env.gasPool.Gas() < params.TxGas {
+			break
+		}
+		result, err := miner.commitBundle(env, sim.bundle)
+		if err != nil {
+			log.Debug("Skipping conflicting bundle", "hash", sim.bundle.Hash(), "err", err)
+			continue
+		}
+		// Account the payments to the fee recipient which are not covered by the
+		// transaction fees, such as direct transfers.
+		if direct := new(big.Int).Sub(result.CoinbaseDiff, result.GasFees); direct.Sign() > 0 {
+			env.bundleValue.Add(env.bundleValue, direct)
+		}
+		env.bundleCount++
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// makeBundleTxs creates a bundle of two transactions of the test bank: a contract
+// creation reverting on deployment, and a direct payment to the fee recipient.
+func makeBundleTxs(recipient common.Address, payment int64) types.Transactions {
+	signer := types.LatestSigner(params.TestChainConfig)
+	revert := types.MustSignNewTx(testBankKey, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     0,
+		Gas:       100000,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(2 * params.InitialBaseFee),
+		Data:      []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.REVERT)},
+	})
+	pay := types.MustSignNewTx(testBankKey, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     1,
+		To:        &recipient,
+		Value:     big.NewInt(payment),
+		Gas:       params.TxGas,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(2 * params.InitialBaseFee),
+	})
+	return types.Transactions{revert, pay}
+}
+
+func TestSendBundleValidation(t *testing.T) {
+	w, _ := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	txs := makeBundleTxs(common.Address{0xfe}, 1)
+
+	tests := []struct {
+		bundle *Bundle
+		err    error
+	}{
+		{&Bundle{BlockNumber: 1}, errEmptyBundle},
+		{&Bundle{Txs: txs, BlockNumber: 0}, errBundleStale},
+		{&Bundle{Txs: txs, BlockNumber: maxBundleFutureBlocks + 1}, errBundleTooFar},
+		{&Bundle{Txs: txs, BlockNumber: 1, MinTimestamp: 2, MaxTimestamp: 1}, errBundleTimestamps},
+		{&Bundle{Txs: txs, BlockNumber: 1}, nil},
+	}
+	for i, test := range tests {
+		if err := w.SendBundle(test.bundle); !errors.Is(err, test.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, test.err)
+		}
+	}
+}
+
+func TestCallBundle(t *testing.T) {
+	var (
+		recipient = common.Address{0xfe}
+		payment   = int64(params.Ether / 1000)
+	)
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	w.config.PendingFeeRecipient = recipient
+
+	bundle := &Bundle{Txs: makeBundleTxs(recipient, payment), BlockNumber: 1}
+	result, err := w.CallBundle(context.Background(), bundle, 0)
+	if err != nil {
+		t.Fatalf("failed to simulate bundle: %v", err)
+	}
+	if result.BundleHash != bundle.Hash() || result.StateBlockNumber != b.chain.CurrentBlock().Number.Uint64() {
+		t.Fatalf("wrong bundle metadata: hash %x, state block %d", result.BundleHash, result.StateBlockNumber)
+	}
+	if len(result.Txs) != 2 || !result.Txs[0].Reverted || result.Txs[1].Reverted {
+		t.Fatalf("wrong transaction results: %+v", result.Txs)
+	}
+	if result.GasUsed != result.Txs[0].GasUsed+result.Txs[1].GasUsed {
+		t.Fatalf("wrong total gas used: have %d", result.GasUsed)
+	}
+	want := new(big.Int).Add(result.GasFees, big.NewInt(payment))
+	if result.CoinbaseDiff.Cmp(want) != 0 {
+		t.Fatalf("wrong coinbase diff: have %v, want %v", result.CoinbaseDiff, want)
+	}
+	// The gas available to the bundle must be capped
+	if _, err := w.CallBundle(context.Background(), bundle, result.GasUsed-1); !errors.Is(err, core.ErrGasLimitReached) {
+		t.Fatalf("gas cap not applied: %v", err)
+	}
+	// Bundles must be validated and target the simulated block
+	now := uint64(time.Now().Unix())
+	blob := types.NewTx(&types.BlobTx{ChainID: uint256.MustFromBig(params.TestChainConfig.ChainID)})
+	tests := []struct {
+		bundle *Bundle
+		err    error
+	}{
+		{&Bundle{Txs: bundle.Txs, BlockNumber: 0}, errBundleStale},
+		{&Bundle{Txs: bundle.Txs, BlockNumber: 2}, errBundleNotNext},
+		{&Bundle{Txs: types.Transactions{blob}, BlockNumber: 1}, errBundleBlobTx},
+		{&Bundle{Txs: bundle.Txs, BlockNumber: 1, MaxTimestamp: now - 1}, errBundleTimestamps},
+	}
+	for i, test := range tests {
+		if _, err := w.CallBundle(context.Background(), test.bundle, 0); !errors.Is(err, test.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, test.err)
+		}
+	}
+	// The block must be timestamped within the bounds of the bundle
+	if _, err := w.CallBundle(context.Background(), &Bundle{Txs: bundle.Txs, BlockNumber: 1, MinTimestamp: now + 100}, 0); err != nil {
+		t.Fatalf("failed to simulate bundle with minimum timestamp: %v", err)
+	}
+	// The execution must be aborted on cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := w.CallBundle(ctx, bundle, 0); !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled execution not aborted: %v", err)
+	}
+}
+
+func TestBundleInclusion(t *testing.T) {
+	var (
+		recipient = common.Address{0xfe}
+		payment   = int64(params.Ether / 1000)
+	)
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	genParams := &generateParams{
+		timestamp:  uint64(time.Now().Unix()),
+		forceTime:  true,
+		parentHash: b.chain.CurrentBlock().Hash(),
+		coinbase:   recipient,
+	}
+	// A bundle with a reverting transaction must not be included
+	txs := makeBundleTxs(recipient, payment)
+	if err := w.SendBundle(&Bundle{Txs: txs, BlockNumber: 1}); err != nil {
+		t.Fatalf("failed to send bundle: %v", err)
+	}
+	r := w.generateWork(genParams, false)
+	if r.err != nil {
+		t.Fatalf("failed to generate block: %v", r.err)
+	}
+	if r.bundles != 0 || len(r.block.Transactions()) != 1 || r.block.Transactions()[0].Hash() != pendingTxs[0].Hash() {
+		t.Fatalf("reverting bundle included, %d bundles", r.bundles)
+	}
+	plainFees := r.fees
+
+	// Allowing the revert gets the bundle included in place of the pool transaction
+	if err := w.SendBundle(&Bundle{Txs: txs, BlockNumber: 1, RevertingTxHashes: []common.Hash{txs[0].Hash()}}); err != nil {
+		t.Fatalf("failed to send bundle: %v", err)
+	}
+	r = w.generateWork(genParams, false)
+	if r.err != nil {
+		t.Fatalf("failed to generate block: %v", r.err)
+	}
+	if r.bundles != 1 || len(r.block.Transactions()) != 2 {
+		t.Fatalf("bundle not included: %d bundles, %d txs", r.bundles, len(r.block.Transactions()))
+	}
+	if len(w.bundles.sims) != 1 {
+		t.Fatalf("wrong number of cached simulations: have %d, want 1", len(w.bundles.sims))
+	}
+	for i, tx := range r.block.Transactions() {
+		if tx.Hash() != txs[i].Hash() {
+			t.Fatalf("tx %d: wrong transaction %x, want %x", i, tx.Hash(), txs[i].Hash())
+		}
+	}
+	if r.receipts[0].Status != types.ReceiptStatusFailed || r.receipts[1].Status != types.ReceiptStatusSuccessful {
+		t.Fatal("wrong receipt statuses")
+	}
+	want := new(big.Int).Add(totalFees(r.block, r.receipts), big.NewInt(payment))
+	if r.fees.Cmp(want) != 0 || r.fees.Cmp(plainFees) <= 0 {
+		t.Fatalf("wrong block value: have %v, want %v, plain %v", r.fees, want, plainFees)
+	}
+	// Bundles of mined blocks must be dropped
+	if bundles := w.bundles.pending(&types.Header{Number: big.NewInt(2)}); len(bundles) != 0 || len(w.bundles.bundles) != 0 || len(w.bundles.sims) != 0 {
+		t.Fatalf("stale bundles retained: %d bundles, %d simulations", len(w.bundles.bundles), len(w.bundles.sims))
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -48,6 +49,7 @@ type Config struct {
 	GasCeil             uint64         // Target gas ceiling for mined blocks.
 	GasPrice            *big.Int       // Minimum gas price for mining a transaction
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
+	Ordering            string         `toml:",omitempty"` // Transaction ordering strategy (price, fifo or a registered custom one)
 }
 
 // DefaultConfig contains default settings for miner.
@@ -71,20 +73,29 @@ type Miner struct {
 	engine      consensus.Engine
 	txpool      *txpool.TxPool
 	prio        []common.Address // A list of senders to prioritize
+	ordering    Ordering         // Strategy for ordering the pending transactions
 	chain       *core.BlockChain
 	pending     *pending
 	pendingMu   sync.Mutex // Lock protects the pending block
+	bundles     *bundlePool
 }
 
 // New creates a new miner with provided config.
 func New(eth Backend, config Config, engine consensus.Engine) *Miner {
+	ordering, err := LookupOrdering(config.Ordering)
+	if err != nil {
+		log.Warn("Falling back to price ordering", "err", err)
+		ordering, _ = LookupOrdering(OrderingPrice)
+	}
 	return &Miner{
 		config:      &config,
+		ordering:    ordering,
 		chainConfig: eth.BlockChain().Config(),
 		engine:      engine,
 		txpool:      eth.TxPool(),
 		chain:       eth.BlockChain(),
 		pending:     &pending{},
+		bundles:     newBundlePool(),
 	}
 }
 
@@ -18,7 +18,12 @@ package miner
 
 import (
 	"container/heap"
+	"fmt"
+	"math"
 	"math/big"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/txpool"
@@ -26,6 +31,93 @@ import (
 	"github.com/holiman/uint256"
 )
 
+// TransactionSet is a set of pending transactions yielding them in the order
+// of an ordering strategy, while honouring the nonce order within accounts.
+type TransactionSet interface {
+	// Peek returns the next transaction along with its priority. When filling a
+	// block from multiple sets (i.e. plain and blob transactions), the next
+	// transaction with the higher priority is included first.
+	Peek() (*txpool.LazyTransaction, *uint256.Int)
+
+	// Shift replaces the next transaction with the following one from the same
+	// account.
+	Shift()
+
+	// Pop removes the next transaction, *not* replacing it with the following one
+	// from the same account. It is used when a transaction cannot be executed, in
+	// which case all subsequent ones from the same account are discarded.
+	Pop()
+
+	// Empty returns whether the set has no more transactions.
+	Empty() bool
+
+	// Clear removes all transactions from the set.
+	Clear()
+}
+
+// Ordering is a strategy for ordering the pending transactions in the built
+// blocks. It creates a transaction set from the pending transactions of each
+// account, sorted by nonce.
+//
+// Note, the input map is reowned by the set, the caller should not interact
+// with it any more.
+type Ordering func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) TransactionSet
+
+// Names of the built-in ordering strategies.
+const (
+	OrderingPrice = "price" // Highest effective miner tip first
+	OrderingFIFO  = "fifo"  // First seen by the transaction pool first
+)
+
+var (
+	orderings = map[string]Ordering{
+		OrderingPrice: func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) TransactionSet {
+			return newTransactionsByPriceAndNonce(signer, txs, baseFee)
+		},
+		OrderingFIFO: func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) TransactionSet {
+			return newTransactionsByArrivalAndNonce(signer, txs, baseFee)
+		},
+	}
+	orderingsLock sync.RWMutex
+)
+
+// RegisterOrdering makes a custom ordering strategy selectable by name in the
+// miner configuration. Registering a strategy under an existing name replaces
+// it.
+func RegisterOrdering(name string, ordering Ordering) {
+	orderingsLock.Lock()
+	defer orderingsLock.Unlock()
+
+	orderings[name] = ordering
+}
+
+// LookupOrdering returns the ordering strategy registered under the given name.
+// The empty name stands for the default, price based ordering.
+func LookupOrdering(name string) (Ordering, error) {
+	if name == "" {
+		name = OrderingPrice
+	}
+	orderingsLock.RLock()
+	defer orderingsLock.RUnlock()
+
+	ordering, ok := orderings[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown transaction ordering %q, available: %s", name, strings.Join(orderingNames(), ", "))
+	}
+	return ordering, nil
+}
+
+// orderingNames returns the sorted names of the registered ordering strategies.
+// The caller must hold the lock.
+func orderingNames() []string {
+	names := make([]string, 0, len(orderings))
+	for name := range orderings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // txWithMinerFee wraps a transaction with its gas price or effective miner gasTipCap
 type txWithMinerFee struct {
 	tx   *txpool.LazyTransaction
@@ -164,3 +256,115 @@ func (t *transactionsByPriceAndNonce) Empty() bool {
 func (t *transactionsByPriceAndNonce) Clear() {
 	t.heads, t.txs = nil, nil
 }
+
+// txByArrival implements the heap interface, ordering the transactions by the
+// time they were first seen, breaking ties by hash for deterministic sorting.
+type txByArrival []*txWithMinerFee
+
+func (s txByArrival) Len() int { return len(s) }
+func (s txByArrival) Less(i, j int) bool {
+	if s[i].tx.Time.Equal(s[j].tx.Time) {
+		return s[i].tx.Hash.Cmp(s[j].tx.Hash) < 0
+	}
+	return s[i].tx.Time.Before(s[j].tx.Time)
+}
+func (s txByArrival) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *txByArrival) Push(x interface{}) {
+	*s = append(*s, x.(*txWithMinerFee))
+}
+
+func (s *txByArrival) Pop() interface{} {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*s = old[0 : n-1]
+	return x
+}
+
+// transactionsByArrivalAndNonce represents a set of transactions that returns
+// transactions in the order they were first seen by the transaction pool (first
+// come, first served), while honouring the nonce order within accounts. A
+// transaction is thus only yielded after all its predecessors of the same
+// account, even if it arrived earlier than some of them.
+type transactionsByArrivalAndNonce struct {
+	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
+	heads   txByArrival                                  // Next transaction for each unique account (arrival heap)
+	signer  types.Signer                                 // Signer for the set of transactions
+	baseFee *uint256.Int                                 // Current base fee
+}
+
+// newTransactionsByArrivalAndNonce creates a transaction set that can retrieve
+// arrival sorted transactions in a nonce-honouring way.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// if after providing it to the constructor.
+func newTransactionsByArrivalAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *transactionsByArrivalAndNonce {
+	var baseFeeUint *uint256.Int
+	if baseFee != nil {
+		baseFeeUint = uint256.MustFromBig(baseFee)
+	}
+	heads := make(txByArrival, 0, len(txs))
+	for from, accTxs := range txs {
+		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFeeUint)
+		if err != nil {
+			delete(txs, from)
+			continue
+		}
+		heads = append(heads, wrapped)
+		txs[from] = accTxs[1:]
+	}
+	heap.Init(&heads)
+
+	return &transactionsByArrivalAndNonce{
+		txs:     txs,
+		heads:   heads,
+		signer:  signer,
+		baseFee: baseFeeUint,
+	}
+}
+
+// Peek returns the next transaction by arrival. The priority is derived from
+// the arrival time, the earlier the transaction was seen, the higher it is.
+// Transactions without a known arrival time, which are sorted first, get the
+// highest priority.
+func (t *transactionsByArrivalAndNonce) Peek() (*txpool.LazyTransaction, *uint256.Int) {
+	if len(t.heads) == 0 {
+		return nil, nil
+	}
+	tx := t.heads[0].tx
+	if tx.Time.IsZero() || tx.Time.UnixNano() < 0 {
+		return tx, uint256.NewInt(math.MaxUint64)
+	}
+	return tx, uint256.NewInt(math.MaxUint64 - uint64(tx.Time.UnixNano()))
+}
+
+// Shift replaces the current head with the next one from the same account.
+func (t *transactionsByArrivalAndNonce) Shift() {
+	acc := t.heads[0].from
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+			t.heads[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(&t.heads, 0)
+			return
+		}
+	}
+	heap.Pop(&t.heads)
+}
+
+// Pop removes the current head, *not* replacing it with the next one from the
+// same account.
+func (t *transactionsByArrivalAndNonce) Pop() {
+	heap.Pop(&t.heads)
+}
+
+// Empty returns if the arrival heap is empty.
+func (t *transactionsByArrivalAndNonce) Empty() bool {
+	return len(t.heads) == 0
+}
+
+// Clear removes the entire content of the heap.
+func (t *transactionsByArrivalAndNonce) Clear() {
+	t.heads, t.txs = nil, nil
+}
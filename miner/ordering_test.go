@@ -18,8 +18,10 @@ package miner
 
 import (
 	"crypto/ecdsa"
+	"math"
 	"math/big"
 	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
@@ -194,3 +196,125 @@ func TestTransactionTimeSort(t *testing.T) {
 		}
 	}
 }
+
+// Tests that transactions are yielded in the order they arrived by the FIFO
+// ordering, regardless of their price, but with increasing nonces when issued
+// by the same account.
+func TestTransactionArrivalNonceSort(t *testing.T) {
+	t.Parallel()
+
+	// Generate a batch of accounts to start with
+	keys := make([]*ecdsa.PrivateKey, 5)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	signer := types.HomesteadSigner{}
+
+	// Generate transactions with random prices and arrival times, the nonces of
+	// an account not necessarily arriving in order
+	groups := map[common.Address][]*txpool.LazyTransaction{}
+	for _, key := range keys {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		for nonce := 0; nonce < 10; nonce++ {
+			tx, _ := types.SignTx(types.NewTransaction(uint64(nonce), common.Address{}, big.NewInt(100), 100, big.NewInt(int64(1+rand.Intn(50))), nil), signer, key)
+			tx.SetTime(time.Unix(0, rand.Int63n(1000)))
+
+			groups[addr] = append(groups[addr], &txpool.LazyTransaction{
+				Hash:      tx.Hash(),
+				Tx:        tx,
+				Time:      tx.Time(),
+				GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+				GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+				Gas:       tx.Gas(),
+			})
+		}
+	}
+	ordering, err := LookupOrdering(OrderingFIFO)
+	if err != nil {
+		t.Fatalf("failed to look up ordering: %v", err)
+	}
+	// The set takes ownership of the groups, hand it a copy to check against
+	owned := make(map[common.Address][]*txpool.LazyTransaction, len(groups))
+	for addr, group := range groups {
+		owned[addr] = slices.Clone(group)
+	}
+	txset := ordering(signer, owned, nil)
+
+	var (
+		txs   types.Transactions
+		prios []*uint256.Int
+	)
+	for tx, prio := txset.Peek(); tx != nil; tx, prio = txset.Peek() {
+		txs, prios = append(txs, tx.Tx), append(prios, prio)
+		txset.Shift()
+	}
+	if len(txs) != 50 {
+		t.Fatalf("expected 50 transactions, found %d", len(txs))
+	}
+	nonces := make(map[common.Address]uint64)
+	for i, tx := range txs {
+		from, _ := types.Sender(signer, tx)
+		if tx.Nonce() != nonces[from] {
+			t.Fatalf("invalid nonce ordering: tx #%d (A=%x N=%v), expected nonce %d", i, from[:4], tx.Nonce(), nonces[from])
+		}
+		nonces[from]++
+
+		// The priority must be derived from the arrival time alone
+		if want := uint256.NewInt(^uint64(tx.Time().UnixNano())); prios[i].Cmp(want) != 0 {
+			t.Fatalf("tx #%d: wrong priority %v, want %v", i, prios[i], want)
+		}
+	}
+	// Each yielded transaction must be the earliest arrived one among the next
+	// transactions of all accounts at the time
+	next := make(map[common.Address]int)
+	for i, tx := range txs {
+		from, _ := types.Sender(signer, tx)
+		for addr, group := range groups {
+			if idx := next[addr]; idx < len(group) && addr != from && group[idx].Time.Before(tx.Time()) {
+				t.Fatalf("tx #%d (A=%x T=%v) yielded before earlier arrived tx (A=%x T=%v)", i, from[:4], tx.Time(), addr[:4], group[idx].Time)
+			}
+		}
+		next[from]++
+	}
+	if len(next) != len(groups) {
+		t.Fatalf("transactions of %d accounts yielded, want %d", len(next), len(groups))
+	}
+}
+
+// Tests that transactions without a known arrival time are prioritised first
+// by the FIFO ordering.
+func TestTransactionArrivalZeroTime(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	signer := types.HomesteadSigner{}
+	tx, _ := types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(100), 100, big.NewInt(1), nil), signer, key)
+
+	txs := map[common.Address][]*txpool.LazyTransaction{
+		crypto.PubkeyToAddress(key.PublicKey): {{Hash: tx.Hash(), Tx: tx, GasFeeCap: uint256.NewInt(1), GasTipCap: uint256.NewInt(1), Gas: tx.Gas()}},
+	}
+	if _, prio := newTransactionsByArrivalAndNonce(signer, txs, nil).Peek(); prio.Cmp(uint256.NewInt(math.MaxUint64)) != 0 {
+		t.Fatalf("wrong priority of transaction without arrival time: have %v, want %v", prio, uint64(math.MaxUint64))
+	}
+}
+
+func TestLookupOrdering(t *testing.T) {
+	if _, err := LookupOrdering(""); err != nil {
+		t.Fatalf("default ordering not found: %v", err)
+	}
+	if _, err := LookupOrdering("random"); err == nil {
+		t.Fatal("unknown ordering found")
+	}
+	RegisterOrdering("random", func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) TransactionSet {
+		return newTransactionsByPriceAndNonce(signer, txs, baseFee)
+	})
+	t.Cleanup(func() {
+		orderingsLock.Lock()
+		defer orderingsLock.Unlock()
+
+		delete(orderings, "random")
+	})
+	if _, err := LookupOrdering("random"); err != nil {
+		t.Fatalf("registered ordering not found: %v", err)
+	}
+}
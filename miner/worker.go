@@ -59,6 +59,9 @@ type environment struct {
 	sidecars []*types.BlobTxSidecar
 	blobs    int
 
+	bundleCount int      // Number of bundles included
+	bundleValue *big.Int // Direct payments of the bundles to the fee recipient
+
 	witness *stateless.Witness
 }
 
@@ -79,6 +82,7 @@ type newPayloadResult struct {
 	receipts []*types.Receipt       // Receipts collected during construction
 	requests [][]byte               // Consensus layer requests collected during block construction
 	witness  *stateless.Witness     // Witness is an optional stateless proof
+	bundles  int                    // Number of bundles included in the block
 }
 
 // generateParams wraps various settings for generating sealing task.
@@ -93,19 +97,52 @@ type generateParams struct {
 	noTxs       bool              // Flag whether an empty block without any transaction is expected
 }
 
-// generateWork generates a sealing block based on the given parameters.
+// generateWork generates a sealing block based on the given parameters. If there
+// are bundles targeting the block, a second block is built with the bundles
+// merged at its top, and the one yielding the higher value is returned. Both
+// blocks are built within the same time allowance.
 func (miner *Miner) generateWork(params *generateParams, witness bool) *newPayloadResult {
-	work, err := miner.prepareWork(params, witness)
-	if err != nil {
-		return &newPayloadResult{err: err}
-	}
+	var interrupt *atomic.Int32
 	if !params.noTxs {
-		interrupt := new(atomic.Int32)
+		interrupt = new(atomic.Int32)
 		timer := time.AfterFunc(miner.config.Recommit, func() {
 			interrupt.Store(commitInterruptTimeout)
 		})
 		defer timer.Stop()
+	}
+	result := miner.buildWork(interrupt, params, witness, nil)
+	if params.noTxs || result.err != nil || interrupt.Load() != commitInterruptNone {
+		return result
+	}
+	bundles := miner.bundles.pending(result.block.Header())
+	if len(bundles) == 0 {
+		return result
+	}
+	merged := miner.buildWork(interrupt, params, witness, bundles)
+	if merged.err != nil {
+		log.Debug("Failed to build block with bundles", "number", result.block.Number(), "err", merged.err)
+		return result
+	}
+	if merged.bundles == 0 || merged.fees.Cmp(result.fees) <= 0 {
+		return result
+	}
+	log.Debug("Merged bundles into block", "number", merged.block.Number(), "bundles", merged.bundles, "fees", merged.fees, "plain", result.fees)
+	return merged
+}
 
+// buildWork builds a sealing block based on the given parameters, including
+// the given bundles at the top of the block. The interrupt signal is only used
+// if the block includes transactions.
+func (miner *Miner) buildWork(interrupt *atomic.Int32, params *generateParams, witness bool, bundles []*Bundle) *newPayloadResult {
+	work, err := miner.prepareWork(params, witness)
+	if err != nil {
+		return &newPayloadResult{err: err}
+	}
+	if !params.noTxs {
+		if len(bundles) > 0 {
+			work.gasPool = new(core.GasPool).AddGas(work.header.GasLimit)
+			miner.commitBundles(interrupt, work, bundles)
+		}
 		err := miner.fillTransactions(interrupt, work)
 		if errors.Is(err, errBlockInterruptedByTimeout) {
 			log.Warn("Block building is interrupted", "allowance", common.PrettyDuration(miner.config.Recommit))
@@ -144,14 +181,18 @@ func (miner *Miner) generateWork(params *generateParams, witness bool) *newPaylo
 	if err != nil {
 		return &newPayloadResult{err: err}
 	}
+	fees := totalFees(block, work.receipts)
+	fees.Add(fees, work.bundleValue)
+
 	return &newPayloadResult{
 		block:    block,
-		fees:     totalFees(block, work.receipts),
+		fees:     fees,
 		sidecars: work.sidecars,
 		stateDB:  work.state,
 		receipts: work.receipts,
 		requests: requests,
 		witness:  work.witness,
+		bundles:  work.bundleCount,
 	}
 }
 
@@ -253,12 +294,13 @@ func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase
 	}
 	// Note the passed coinbase may be different with header.Coinbase.
 	return &environment{
-		signer:   types.MakeSigner(miner.chainConfig, header.Number, header.Time),
-		state:    state,
-		coinbase: coinbase,
-		header:   header,
-		witness:  state.Witness(),
-		evm:      vm.NewEVM(core.NewEVMBlockContext(header, miner.chain, &coinbase), state, miner.chainConfig, vm.Config{}),
+		signer:      types.MakeSigner(miner.chainConfig, header.Number, header.Time),
+		state:       state,
+		coinbase:    coinbase,
+		header:      header,
+		witness:     state.Witness(),
+		evm:         vm.NewEVM(core.NewEVMBlockContext(header, miner.chain, &coinbase), state, miner.chainConfig, vm.Config{}),
+		bundleValue: new(big.Int),
 	}, nil
 }
 
@@ -316,7 +358,7 @@ func (miner *Miner) applyTransaction(env *environment, tx *types.Transaction) (*
 	return receipt, err
 }
 
-func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce, interrupt *atomic.Int32) error {
+func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs TransactionSet, interrupt *atomic.Int32) error {
 	gasLimit := env.header.GasLimit
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
@@ -343,7 +385,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		// Retrieve the next transaction and abort if all done.
 		var (
 			ltx *txpool.LazyTransaction
-			txs *transactionsByPriceAndNonce
+			txs TransactionSet
 		)
 		pltx, ptip := plainTxs.Peek()
 		bltx, btip := blobTxs.Peek()
@@ -426,8 +468,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 }
 
 // fillTransactions retrieves the pending transactions from the txpool and fills them
-// into the given sealing block. The transaction selection and ordering strategy can
-// be customized with the plugin in the future.
+// into the given sealing block, in the order of the configured ordering strategy.
 func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment) error {
 	miner.confMu.RLock()
 	tip := miner.config.GasPrice
@@ -466,16 +507,16 @@ func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment)
 	}
 	// Fill the block with all available pending transactions.
 	if len(prioPlainTxs) > 0 || len(prioBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, prioPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, prioBlobTxs, env.header.BaseFee)
+		plainTxs := miner.ordering(env.signer, prioPlainTxs, env.header.BaseFee)
+		blobTxs := miner.ordering(env.signer, prioBlobTxs, env.header.BaseFee)
 
 		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
 			return err
 		}
 	}
 	if len(normalPlainTxs) > 0 || len(normalBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, normalPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, normalBlobTxs, env.header.BaseFee)
+		plainTxs := miner.ordering(env.signer, normalPlainTxs, env.header.BaseFee)
+		blobTxs := miner.ordering(env.signer, normalBlobTxs, env.header.BaseFee)
 
 		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
 			return err
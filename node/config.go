@@ -96,6 +96,20 @@ type Config struct {
 	// SmartCardDaemonPath is the path to the smartcard daemon's socket.
 	SmartCardDaemonPath string `toml:",omitempty"`
 
+	// PKCS11Module is the path to the PKCS#11 module of a hardware security
+	// module to sign with. An empty path disables PKCS#11 support.
+	PKCS11Module string `toml:",omitempty"`
+
+	// PKCS11Slot restricts the PKCS#11 tokens used to the one in the given slot.
+	PKCS11Slot *uint `toml:",omitempty"`
+
+	// PKCS11Token restricts the PKCS#11 tokens used to the ones with the given label.
+	PKCS11Token string `toml:",omitempty"`
+
+	// PKCS11PINFile is the path to the file containing the user PIN of the
+	// PKCS#11 tokens.
+	PKCS11PINFile string `toml:",omitempty"`
+
 	// IPCPath is the requested location to place the IPC endpoint. If the path is
 	// a simple file name, it is placed inside the data directory (or on the root
 	// pipe path on Windows), whereas if it's a resolvable path name (absolute or
@@ -204,6 +218,11 @@ type Config struct {
 	// BatchResponseMaxSize is the maximum number of bytes returned from a batched rpc call.
 	BatchResponseMaxSize int `toml:",omitempty"`
 
+	// RPCRateLimits configures per-client and per-method request limits applied
+	// to the HTTP, WebSocket and IPC endpoints. The authenticated endpoints serving
+	// the engine API to the consensus client are not limited.
+	RPCRateLimits rpc.RateLimitConfig `toml:",omitempty"`
+
 	// JWTSecret is the path to the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
 
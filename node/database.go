@@ -18,6 +18,7 @@ package node
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -37,6 +38,10 @@ type openOptions struct {
 	Handles           int    // number of files to be open simultaneously
 	ReadOnly          bool
 
+	// RemoteAncients, if set, replaces the local chain freezer with a read-only
+	// one served from an object storage.
+	RemoteAncients *rawdb.RemoteFreezerConfig
+
 	// Ephemeral means that filesystem sync operations should be avoided:
 	// data integrity in the face of a crash is not important. This option
 	// should typically be used in tests.
@@ -56,7 +61,14 @@ func openDatabase(o openOptions) (ethdb.Database, error) {
 	if len(o.AncientsDirectory) == 0 {
 		return kvdb, nil
 	}
-	frdb, err := rawdb.NewDatabaseWithFreezer(kvdb, o.AncientsDirectory, o.Namespace, o.ReadOnly)
+	var frdb ethdb.Database
+	if o.RemoteAncients != nil {
+		log.Info("Using remote chain freezer", "endpoint", o.RemoteAncients.Endpoint)
+		log.Warn("Remote chain freezer is read-only, new blocks will be kept in the key-value store")
+		frdb, err = rawdb.NewDatabaseWithRemoteFreezer(kvdb, o.AncientsDirectory, *o.RemoteAncients)
+	} else {
+		frdb, err = rawdb.NewDatabaseWithFreezer(kvdb, o.AncientsDirectory, o.Namespace, o.ReadOnly)
+	}
 	if err != nil {
 		kvdb.Close()
 		return nil, err
@@ -64,6 +76,28 @@ func openDatabase(o openOptions) (ethdb.Database, error) {
 	return frdb, nil
 }
 
+// openFollowerDatabase opens a database written by another process in read-only
+// mode, integrating it with the chain freezer if the AncientsDirectory option has
+// been set. The key-value store is accessed through checkpoints created in the
+// given directory, which is only supported by pebble.
+func openFollowerDatabase(o openOptions, checkpoints string) (*rawdb.FollowerDatabase, error) {
+	if existingDb := rawdb.PreexistingDatabase(o.Directory); existingDb != rawdb.DBPebble {
+		return nil, fmt.Errorf("following requires an existing pebble database, found %q", existingDb)
+	}
+	if len(o.Type) != 0 && o.Type != rawdb.DBPebble {
+		return nil, fmt.Errorf("db.engine choice was %v but following requires pebble", o.Type)
+	}
+	// Drop the checkpoints left behind by an unclean shutdown
+	if err := os.RemoveAll(checkpoints); err != nil {
+		return nil, err
+	}
+	log.Info("Following pebble database", "path", o.Directory, "checkpoints", checkpoints)
+	open := func() (ethdb.KeyValueStore, error) {
+		return pebble.NewFollower(o.Directory, checkpoints, o.Cache, o.Handles, o.Namespace)
+	}
+	return rawdb.NewFollowerDatabase(open, o.AncientsDirectory, o.Namespace)
+}
+
 // openKeyValueDatabase opens a disk-based key-value database, e.g. leveldb or pebble.
 //
 //	                      type == null          type != null
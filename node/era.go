@@ -0,0 +1,422 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// eraChecksumsFile is the name of the checksum list written along with the
+// era1 files by the history export.
+const eraChecksumsFile = "checksums.txt"
+
+// eraOpenFiles is the maximum number of era1 files kept open for serving
+// single blocks and receipts.
+const eraOpenFiles = 16
+
+// errEraHandlerClosed is returned if an item is requested after the era handler
+// was closed.
+var errEraHandlerClosed = errors.New("era handler closed")
+
+// eraFile is an era1 file served by the era handler.
+type eraFile struct {
+	Name     string `json:"name"`
+	Epoch    int    `json:"epoch"`
+	Start    uint64 `json:"start"`
+	Count    uint64 `json:"count"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// openEra is an opened era1 file, closed when it's both evicted from the cache
+// and no longer in use by any request.
+type openEra struct {
+	*era.Era
+	refs    int  // Number of requests reading the file
+	evicted bool // Whether the file was dropped from the cache
+}
+
+// EraHandler is an HTTP handler serving the era1 files of a directory, so that
+// nodes with pruned history can backfill it lazily. Besides the files, single
+// blocks and receipts are served by number, looked up via the block index of
+// the containing era1 file. The handler is expected to be mounted with the
+// prefix stripped, the following paths are served:
+//
+//	/                   JSON list of the era1 files
+//	/checksums.txt      sha256 checksums of the era1 files, in epoch order
+//	/<name>.era1        era1 file, with its checksum as the ETag
+//	/header/<number>    RLP-encoded header
+//	/block/<number>     RLP-encoded block
+//	/receipts/<number>  RLP-encoded receipts
+type EraHandler struct {
+	dir    string
+	files  []*eraFile // Era1 files sorted by epoch
+	names  map[string]*eraFile
+	open   lru.BasicLRU[string, *openEra] // Recently read era1 files, kept open
+	closed bool
+	lock   sync.Mutex // Lock protecting the lazily computed checksums and the open files
+}
+
+// NewEraHandler creates an era handler for the era1 files of the specified
+// network in the given directory. If the network is empty, it's detected from
+// the file names. The checksums are loaded from the checksum list if present,
+// otherwise they are computed on demand.
+func NewEraHandler(dir string, network string) (*EraHandler, error) {
+	if network == "" {
+		var err error
+		if network, err = detectEraNetwork(dir); err != nil {
+			return nil, err
+		}
+	}
+	names, err := era.ReadDir(dir, network)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no era1 files found in %s", dir)
+	}
+	h := &EraHandler{
+		dir:   dir,
+		names: make(map[string]*eraFile),
+		open:  lru.NewBasicLRU[string, *openEra](eraOpenFiles),
+	}
+	for epoch, name := range names {
+		e, err := era.Open(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		file := &eraFile{Name: name, Epoch: epoch, Start: e.Start(), Count: e.Count()}
+		e.Close()
+
+		stat, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		file.Size = stat.Size()
+		h.files = append(h.files, file)
+		h.names[name] = file
+	}
+	// Load the checksums written by the history export, if they cover the
+	// served files.
+	if blob, err := os.ReadFile(filepath.Join(dir, eraChecksumsFile)); err == nil {
+		checksums := strings.Split(strings.TrimSpace(string(blob)), "\n")
+		if len(checksums) == len(h.files) {
+			for i, checksum := range checksums {
+				h.files[i].Checksum = strings.TrimSpace(checksum)
+			}
+		} else {
+			log.Warn("Ignoring mismatching era1 checksums", "files", len(h.files), "checksums", len(checksums))
+		}
+	}
+	log.Info("Serving era1 files", "dir", dir, "network", network, "files", len(h.files))
+	return h, nil
+}
+
+// detectEraNetwork determines the network of the era1 files in the directory.
+func detectEraNetwork(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var networks []string
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".era1" {
+			continue
+		}
+		if network, _, ok := strings.Cut(entry.Name(), "-"); ok && !slices.Contains(networks, network) {
+			networks = append(networks, network)
+		}
+	}
+	switch len(networks) {
+	case 0:
+		return "", fmt.Errorf("no era1 files found in %s", dir)
+	case 1:
+		return networks[0], nil
+	default:
+		return "", fmt.Errorf("era1 files of multiple networks found in %s: %v", dir, networks)
+	}
+}
+
+// checksum returns the checksum of the era1 file, computing it if not known yet.
+func (h *EraHandler) checksum(file *eraFile) (string, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if file.Checksum != "" {
+		return file.Checksum, nil
+	}
+	f, err := os.Open(filepath.Join(h.dir, file.Name))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+	file.Checksum = common.BytesToHash(hasher.Sum(nil)).Hex()
+	return file.Checksum, nil
+}
+
+// acquire returns the opened era1 file, opening it if it's not cached yet. The
+// file must be released after use.
+func (h *EraHandler) acquire(file *eraFile) (*openEra, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.closed {
+		return nil, errEraHandlerClosed
+	}
+	if e, ok := h.open.Get(file.Name); ok {
+		e.refs++
+		return e, nil
+	}
+	e, err := era.Open(filepath.Join(h.dir, file.Name))
+	if err != nil {
+		return nil, err
+	}
+	if h.open.Len() == eraOpenFiles {
+		_, old, _ := h.open.RemoveOldest()
+		h.evict(old)
+	}
+	opened := &openEra{Era: e, refs: 1}
+	h.open.Add(file.Name, opened)
+	return opened, nil
+}
+
+// release marks the era1 file as no longer used by the caller, closing it if
+// it was evicted from the cache meanwhile.
+func (h *EraHandler) release(e *openEra) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	e.refs--
+	if e.refs == 0 && e.evicted {
+		e.Close()
+	}
+}
+
+// evict marks the era1 file as dropped from the cache, closing it unless it's
+// still in use. The caller must hold the lock.
+func (h *EraHandler) evict(e *openEra) {
+	e.evicted = true
+	if e.refs == 0 {
+		e.Close()
+	}
+}
+
+// Close releases all the era1 files kept open by the handler. Requests still
+// in flight finish reading their files, any later block or receipt requests
+// fail.
+func (h *EraHandler) Close() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.closed {
+		return nil
+	}
+	h.closed = true
+	for _, name := range h.open.Keys() {
+		e, _ := h.open.Peek(name)
+		h.evict(e)
+	}
+	h.open.Purge()
+	return nil
+}
+
+// find returns the era1 file containing the block with the given number.
+func (h *EraHandler) find(number uint64) *eraFile {
+	i := sort.Search(len(h.files), func(i int) bool {
+		return h.files[i].Start+h.files[i].Count > number
+	})
+	if i == len(h.files) || h.files[i].Start > number {
+		return nil
+	}
+	return h.files[i]
+}
+
+// ServeHTTP implements http.Handler.
+func (h *EraHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	switch {
+	case path == "":
+		h.serveList(w)
+	case path == eraChecksumsFile:
+		h.serveChecksums(w)
+	case strings.HasSuffix(path, ".era1"):
+		h.serveFile(w, r, path)
+	default:
+		kind, number, ok := strings.Cut(path, "/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		n, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid block number", http.StatusBadRequest)
+			return
+		}
+		h.serveItem(w, r, kind, n)
+	}
+}
+
+// serveList writes the list of the served era1 files, along with the known
+// checksums.
+func (h *EraHandler) serveList(w http.ResponseWriter) {
+	h.lock.Lock()
+	blob, err := json.Marshal(h.files)
+	h.lock.Unlock()
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(blob)
+}
+
+// serveChecksums writes the checksums of all era1 files in epoch order, in the
+// format expected by the history import.
+func (h *EraHandler) serveChecksums(w http.ResponseWriter) {
+	checksums := make([]string, len(h.files))
+	for i, file := range h.files {
+		checksum, err := h.checksum(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		checksums[i] = checksum
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, strings.Join(checksums, "\n"))
+}
+
+// serveFile writes the content of the requested era1 file, supporting range
+// requests. The checksum of the file is reported as its ETag.
+func (h *EraHandler) serveFile(w http.ResponseWriter, r *http.Request, name string) {
+	file, ok := h.names[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	checksum, err := h.checksum(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	f, err := os.Open(filepath.Join(h.dir, file.Name))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("ETag", strconv.Quote(checksum))
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, file.Name, stat.ModTime(), f)
+}
+
+// serveItem writes the RLP encoding of the requested item of the given block.
+func (h *EraHandler) serveItem(w http.ResponseWriter, r *http.Request, kind string, number uint64) {
+	file := h.find(number)
+	if file == nil {
+		http.Error(w, "block not found", http.StatusNotFound)
+		return
+	}
+	e, err := h.acquire(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer h.release(e)
+
+	var item interface{}
+	switch kind {
+	case "header":
+		item, err = e.GetHeaderByNumber(number)
+	case "block":
+		item, err = e.GetBlockByNumber(number)
+	case "receipts":
+		item, err = e.GetReceiptsByNumber(number)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	blob, err := rlp.EncodeToBytes(item)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(blob)
+}
+
+// eraService closes the era handler mounted on the node when it's stopped.
+type eraService struct {
+	handler *EraHandler
+}
+
+// Start implements node.Lifecycle, starting no goroutines.
+func (s *eraService) Start() error { return nil }
+
+// Stop implements node.Lifecycle, closing the era1 files kept open.
+func (s *eraService) Stop() error { return s.handler.Close() }
+
+// RegisterEraHandler mounts the era handler serving the era1 files of the given
+// directory on the HTTP server, under the /era/ path. The handler is closed
+// along with the node.
+func (n *Node) RegisterEraHandler(dir string, network string) error {
+	handler, err := NewEraHandler(dir, network)
+	if err != nil {
+		return err
+	}
+	n.RegisterHandler("Era1 history", "/era/", http.StripPrefix("/era", handler))
+	n.RegisterLifecycle(&eraService{handler: handler})
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// makeEraFiles writes era1 files of the given number of epochs with the given
+// number of blocks each into the directory.
+func makeEraFiles(t *testing.T, dir string, epochs, size int) []*types.Block {
+	t.Helper()
+
+	var blocks []*types.Block
+	for epoch := 0; epoch < epochs; epoch++ {
+		var buf bytes.Buffer
+		builder := era.NewBuilder(&buf)
+		for i := 0; i < size; i++ {
+			number := int64(epoch*size + i)
+			header := &types.Header{Number: big.NewInt(number), Difficulty: big.NewInt(1), GasLimit: uint64(number)}
+			body := &types.Body{Transactions: []*types.Transaction{types.NewTransaction(uint64(number), common.Address{byte(number)}, nil, 0, nil, nil)}}
+			block := types.NewBlockWithHeader(header).WithBody(*body)
+			receipts := types.Receipts{{CumulativeGasUsed: uint64(number), Logs: []*types.Log{}}}
+			if err := builder.Add(block, receipts, big.NewInt(number+1)); err != nil {
+				t.Fatalf("failed to add block %d: %v", number, err)
+			}
+			blocks = append(blocks, block)
+		}
+		root, err := builder.Finalize()
+		if err != nil {
+			t.Fatalf("failed to finalize era1: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, era.Filename("testnet", epoch, root)), buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return blocks
+}
+
+func eraGet(t *testing.T, url string) (*http.Response, []byte) {
+	t.Helper()
+
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("request %s failed: %v", url, err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read response of %s: %v", url, err)
+	}
+	return res, body
+}
+
+func TestEraHandler(t *testing.T) {
+	dir := t.TempDir()
+	blocks := makeEraFiles(t, dir, 3, 16)
+
+	handler, err := NewEraHandler(dir, "")
+	if err != nil {
+		t.Fatalf("failed to create era handler: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/era/", http.StripPrefix("/era", handler))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	// Check the listing and the checksums
+	res, body := eraGet(t, srv.URL+"/era/")
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("failed to list era1 files: %s", res.Status)
+	}
+	var files []*eraFile
+	if err := json.Unmarshal(body, &files); err != nil {
+		t.Fatalf("failed to decode listing: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("wrong number of era1 files, want 3, got %d", len(files))
+	}
+	res, body = eraGet(t, srv.URL+"/era/checksums.txt")
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("failed to retrieve checksums: %s", res.Status)
+	}
+	checksums := strings.Split(string(body), "\n")
+	for i, file := range files {
+		if file.Epoch != i || file.Start != uint64(i*16) || file.Count != 16 {
+			t.Fatalf("file %d: unexpected metadata %+v", i, file)
+		}
+		res, blob := eraGet(t, srv.URL+"/era/"+file.Name)
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("failed to retrieve %s: %s", file.Name, res.Status)
+		}
+		sum := sha256.Sum256(blob)
+		if want := common.Hash(sum).Hex(); checksums[i] != want {
+			t.Fatalf("file %d: checksum mismatch, want %s, got %s", i, want, checksums[i])
+		}
+		if etag := res.Header.Get("ETag"); etag != strconv.Quote(checksums[i]) {
+			t.Fatalf("file %d: wrong etag %s", i, etag)
+		}
+	}
+	// Retrieve single blocks via the block index
+	for _, number := range []int{0, 15, 16, 30, 47} {
+		res, body := eraGet(t, fmt.Sprintf("%s/era/block/%d", srv.URL, number))
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("failed to retrieve block %d: %s", number, res.Status)
+		}
+		var block types.Block
+		if err := rlp.DecodeBytes(body, &block); err != nil {
+			t.Fatalf("failed to decode block %d: %v", number, err)
+		}
+		if block.Hash() != blocks[number].Hash() || block.Transactions()[0].Hash() != blocks[number].Transactions()[0].Hash() {
+			t.Fatalf("block %d mismatch", number)
+		}
+		res, body = eraGet(t, fmt.Sprintf("%s/era/header/%d", srv.URL, number))
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("failed to retrieve header %d: %s", number, res.Status)
+		}
+		var header types.Header
+		if err := rlp.DecodeBytes(body, &header); err != nil || header.Hash() != blocks[number].Hash() {
+			t.Fatalf("header %d mismatch: %v", number, err)
+		}
+		res, body = eraGet(t, fmt.Sprintf("%s/era/receipts/%d", srv.URL, number))
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("failed to retrieve receipts %d: %s", number, res.Status)
+		}
+		var receipts types.Receipts
+		if err := rlp.DecodeBytes(body, &receipts); err != nil {
+			t.Fatalf("failed to decode receipts %d: %v", number, err)
+		}
+		if len(receipts) != 1 || receipts[0].CumulativeGasUsed != uint64(number) {
+			t.Fatalf("receipts %d mismatch", number)
+		}
+	}
+	// Unknown items must not be found
+	for _, path := range []string{"/era/block/48", "/era/unknown/1", "/era/testnet-00005-00000000.era1"} {
+		if res, _ := eraGet(t, srv.URL+path); res.StatusCode != http.StatusNotFound {
+			t.Fatalf("%s: unexpected status %s", path, res.Status)
+		}
+	}
+}
+
+// Tests that the era handler keeps a bounded number of era1 files open, closing
+// the evicted ones once no request reads them anymore.
+func TestEraHandlerOpenFiles(t *testing.T) {
+	dir := t.TempDir()
+	makeEraFiles(t, dir, eraOpenFiles+2, 2)
+
+	handler, err := NewEraHandler(dir, "")
+	if err != nil {
+		t.Fatalf("failed to create era handler: %v", err)
+	}
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	// Hold the first file, as if a request was reading it
+	held, err := handler.acquire(handler.files[0])
+	if err != nil {
+		t.Fatalf("failed to open era1 file: %v", err)
+	}
+	for i := range handler.files {
+		if res, _ := eraGet(t, fmt.Sprintf("%s/header/%d", srv.URL, 2*i)); res.StatusCode != http.StatusOK {
+			t.Fatalf("failed to retrieve header %d: %s", 2*i, res.Status)
+		}
+	}
+	if n := handler.open.Len(); n != eraOpenFiles {
+		t.Fatalf("wrong number of open era1 files: have %d, want %d", n, eraOpenFiles)
+	}
+	if !held.evicted {
+		t.Fatal("held era1 file not evicted")
+	}
+	// The evicted file must stay readable until released
+	if _, err := held.GetHeaderByNumber(0); err != nil {
+		t.Fatalf("evicted era1 file closed while in use: %v", err)
+	}
+	handler.release(held)
+	if _, err := held.GetHeaderByNumber(0); err == nil {
+		t.Fatal("released era1 file not closed")
+	}
+	// Closing the handler must release all files and fail later requests
+	if err := handler.Close(); err != nil {
+		t.Fatalf("failed to close era handler: %v", err)
+	}
+	if n := handler.open.Len(); n != 0 {
+		t.Fatalf("era1 files left open after close: %d", n)
+	}
+	if res, _ := eraGet(t, srv.URL+"/header/0"); res.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("unexpected status after close: %s", res.Status)
+	}
+}
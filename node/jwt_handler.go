@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang-jwt/jwt/v4"
 )
 
@@ -75,6 +76,9 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
 		http.Error(out, "future token", http.StatusUnauthorized)
 	default:
+		if claims.Subject != "" {
+			r = r.WithContext(rpc.NewContextWithIdentity(r.Context(), claims.Subject))
+		}
 		handler.next.ServeHTTP(out, r)
 	}
 }
@@ -154,7 +154,7 @@ func New(conf *Config) (*Node, error) {
 	node.httpAuth = newHTTPServer(node.log, conf.HTTPTimeouts)
 	node.ws = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
 	node.wsAuth = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
-	node.ipc = newIPCServer(node.log, conf.IPCEndpoint())
+	node.ipc = newIPCServer(node.log, conf.IPCEndpoint(), conf.RPCRateLimits)
 
 	return node, nil
 }
@@ -393,6 +393,7 @@ func (n *Node) startRPC() error {
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		rateLimits:             n.config.RPCRateLimits,
 	}
 
 	initHttp := func(server *httpServer, port int) error {
@@ -758,6 +759,71 @@ func (n *Node) OpenDatabaseWithFreezer(name string, cache, handles int, ancient
 	return db, err
 }
 
+// OpenDatabaseWithRemoteFreezer opens an existing database with the given name
+// (or creates one if no previous can be found) from within the node's data
+// directory, attaching a read-only chain freezer served from an object storage
+// to it. The local ancient directory is still used for the other ancient stores.
+func (n *Node) OpenDatabaseWithRemoteFreezer(name string, cache, handles int, ancient string, remote rawdb.RemoteFreezerConfig, namespace string) (ethdb.Database, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.state == closedState {
+		return nil, ErrNodeStopped
+	}
+	if n.config.DataDir == "" {
+		return nil, errors.New("remote freezer requires a data directory")
+	}
+	db, err := openDatabase(openOptions{
+		Type:              n.config.DBEngine,
+		Directory:         n.ResolvePath(name),
+		AncientsDirectory: n.ResolveAncient(name, ancient),
+		Namespace:         namespace,
+		Cache:             cache,
+		Handles:           handles,
+		RemoteAncients:    &remote,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return n.wrapDatabase(db), nil
+}
+
+// OpenFollowerDatabase opens the database with the given name from within the
+// instance directory of another node sharing the same configuration, located in
+// the specified data directory, along with its chain freezer. The database is
+// accessed through read-only checkpoints, allowing the other node to keep writing
+// it. The checkpoints are created in the instance directory of this node, which
+// must be on the same filesystem. The writes are observed by refreshing the
+// returned database.
+func (n *Node) OpenFollowerDatabase(datadir string, name string, cache, handles int, ancient string, namespace string) (*rawdb.FollowerDatabase, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.state == closedState {
+		return nil, ErrNodeStopped
+	}
+	owner := &Config{DataDir: datadir, Name: n.config.name()}
+	directory := owner.ResolvePath(name)
+	switch {
+	case ancient == "":
+		ancient = filepath.Join(directory, "ancient")
+	case !filepath.IsAbs(ancient):
+		ancient = owner.ResolvePath(ancient)
+	}
+	db, err := openFollowerDatabase(openOptions{
+		Type:              n.config.DBEngine,
+		Directory:         directory,
+		AncientsDirectory: ancient,
+		Namespace:         namespace,
+		Cache:             cache,
+		Handles:           handles,
+		ReadOnly:          true,
+	}, n.ResolvePath(name+"-checkpoints"))
+	if err != nil {
+		return nil, err
+	}
+	n.wrapDatabase(db)
+	return db, nil
+}
+
 // ResolvePath returns the absolute path of a resource in the instance directory.
 func (n *Node) ResolvePath(x string) string {
 	return n.config.ResolvePath(x)
@@ -235,3 +235,69 @@ func offsetTimeAuth(secret [32]byte, offset time.Duration) rpc.HTTPAuth {
 		return nil
 	}
 }
+
+// Tests that the rate limits don't apply to the authenticated endpoints, which
+// serve the engine API to the consensus client.
+func TestAuthEndpointNotRateLimited(t *testing.T) {
+	var secret [32]byte
+	if _, err := crand.Read(secret[:]); err != nil {
+		t.Fatalf("failed to create jwt secret: %v", err)
+	}
+	jwtPath := filepath.Join(t.TempDir(), "jwt_secret")
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+		t.Fatalf("failed to prepare jwt secret file: %v", err)
+	}
+	conf := &Config{
+		AuthAddr:  "127.0.0.1",
+		AuthPort:  0,
+		JWTSecret: jwtPath,
+		RPCRateLimits: rpc.RateLimitConfig{
+			Client:  rpc.RateLimit{Rate: 0.001, Burst: 1},
+			Methods: map[string]rpc.RateLimit{"engine_*": {Rate: 0.001, Burst: 1}},
+		},
+	}
+	node, err := New(conf)
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	node.RegisterAPIs([]rpc.API{{
+		Namespace:     "engine",
+		Service:       helloRPC("hello engine"),
+		Authenticated: true,
+	}})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start test node: %v", err)
+	}
+	defer node.Close()
+
+	call := func(subject string) error {
+		cl, err := rpc.DialOptions(context.Background(), node.HTTPAuthEndpoint(), rpc.WithHTTPAuth(subjectAuth(secret, subject)))
+		if err != nil {
+			t.Fatalf("failed to dial rpc endpoint: %v", err)
+		}
+		defer cl.Close()
+
+		var x string
+		return cl.Call(&x, "engine_helloWorld")
+	}
+	for i := 0; i < 5; i++ {
+		if err := call("cl"); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+}
+
+func subjectAuth(secret [32]byte, subject string) rpc.HTTPAuth {
+	return func(header http.Header) error {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"iat": &jwt.NumericDate{Time: time.Now()},
+			"sub": subject,
+		})
+		s, err := token.SignedString(secret[:])
+		if err != nil {
+			return fmt.Errorf("failed to create JWT token: %w", err)
+		}
+		header.Set("Authorization", "Bearer "+s)
+		return nil
+	}
+}
@@ -58,6 +58,7 @@ type rpcEndpointConfig struct {
 	batchItemLimit         int
 	batchResponseSizeLimit int
 	httpBodyLimit          int
+	rateLimits             rpc.RateLimitConfig
 }
 
 type rpcHandler struct {
@@ -306,6 +307,7 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	// Create RPC server and handler.
 	srv := rpc.NewServer()
 	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
+	srv.SetRateLimits(config.rateLimits)
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
@@ -341,6 +343,7 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	// Create RPC server and handler.
 	srv := rpc.NewServer()
 	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
+	srv.SetRateLimits(config.rateLimits)
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
@@ -585,16 +588,17 @@ func newGzipHandler(next http.Handler) http.Handler {
 }
 
 type ipcServer struct {
-	log      log.Logger
-	endpoint string
+	log        log.Logger
+	endpoint   string
+	rateLimits rpc.RateLimitConfig
 
 	mu       sync.Mutex
 	listener net.Listener
 	srv      *rpc.Server
 }
 
-func newIPCServer(log log.Logger, endpoint string) *ipcServer {
-	return &ipcServer{log: log, endpoint: endpoint}
+func newIPCServer(log log.Logger, endpoint string, rateLimits rpc.RateLimitConfig) *ipcServer {
+	return &ipcServer{log: log, endpoint: endpoint, rateLimits: rateLimits}
 }
 
 // start starts the httpServer's http.Server
@@ -605,7 +609,10 @@ func (is *ipcServer) start(apis []rpc.API) error {
 	if is.listener != nil {
 		return nil // already running
 	}
-	listener, srv, err := rpc.StartIPCEndpoint(is.endpoint, apis)
+	// Configure the limits before accepting any connection.
+	srv := rpc.NewServer()
+	srv.SetRateLimits(is.rateLimits)
+	listener, err := rpc.ServeIPCEndpoint(srv, is.endpoint, apis)
 	if err != nil {
 		is.log.Warn("IPC opening failed", "url", is.endpoint, "error", err)
 		return err
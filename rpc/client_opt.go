@@ -41,6 +41,7 @@ type clientConfig struct {
 	idgen              func() ID
 	batchItemLimit     int
 	batchResponseLimit int
+	limiter            *rateLimiter
 }
 
 func (cfg *clientConfig) initHeaders() {
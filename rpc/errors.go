@@ -55,12 +55,14 @@ var (
 	_ Error = new(invalidMessageError)
 	_ Error = new(invalidParamsError)
 	_ Error = new(internalServerError)
+	_ Error = new(limitExceededError)
 )
 
 const (
 	errcodeDefault          = -32000
 	errcodeTimeout          = -32002
 	errcodeResponseTooLarge = -32003
+	errcodeLimitExceeded    = -32005
 	errcodePanic            = -32603
 	errcodeMarshalError     = -32603
 
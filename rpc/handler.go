@@ -28,6 +28,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -65,6 +66,7 @@ type handler struct {
 	allowSubscribe       bool
 	batchRequestLimit    int
 	batchResponseMaxSize int
+	limiter              *rateLimiter // nil on the client side
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -494,6 +496,13 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if !msg.isUnsubscribe() {
+		release, err := h.limiter.acquire(cp.ctx, msg.Method)
+		if err != nil {
+			return msg.errorResponse(err)
+		}
+		defer release()
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -515,8 +524,16 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	if err != nil {
 		return msg.errorResponse(&invalidParamsError{err.Error()})
 	}
+	ctx, span := telemetry.Start(cp.ctx, msg.Method,
+		telemetry.WithKind(telemetry.SpanKindServer),
+		telemetry.WithAttributes(telemetry.String("rpc.system", "jsonrpc"), telemetry.String("rpc.method", msg.Method)),
+	)
 	start := time.Now()
-	answer := h.runMethod(cp.ctx, msg, callb, args)
+	answer := h.runMethod(ctx, msg, callb, args)
+	if answer.Error != nil {
+		span.RecordError(answer.Error)
+	}
+	span.End()
 
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
@@ -32,6 +32,12 @@ var (
 	serveTimeHistName = "rpc/duration"
 
 	rpcServingTimer = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	// rateLimitedName is the prefix of the per-method rate limit rejection meters.
+	rateLimitedName = "rpc/ratelimited/method"
+
+	rateLimitedAllMeter    = metrics.NewRegisteredMeter("rpc/ratelimited/all", nil)
+	rateLimitedClientMeter = metrics.NewRegisteredMeter("rpc/ratelimited/client", nil)
 )
 
 // updateServeTimeHistogram tracks the serving time of a remote RPC call.
@@ -48,3 +54,9 @@ func updateServeTimeHistogram(method string, success bool, elapsed time.Duration
 	}
 	metrics.GetOrRegisterHistogramLazy(h, nil, sampler).Update(elapsed.Nanoseconds())
 }
+
+// rateLimitedMeter returns the meter tracking rejections by the rate limiter for
+// the given method limit key.
+func rateLimitedMeter(key string) *metrics.Meter {
+	return metrics.GetOrRegisterMeter(fmt.Sprintf("%s/%s", rateLimitedName, key), nil)
+}
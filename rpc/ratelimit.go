@@ -0,0 +1,279 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"golang.org/x/time/rate"
+)
+
+// maxTrackedClients is the number of distinct clients for which rate limiting
+// state is retained. Least recently seen clients are dropped beyond this.
+const maxTrackedClients = 4096
+
+// RateLimit configures the limits applied to a class of requests.
+type RateLimit struct {
+	// Rate is the sustained number of requests per second. Zero means unlimited.
+	Rate float64 `toml:",omitempty"`
+
+	// Burst is the maximum number of requests that may be served at once when
+	// the token bucket is full. It defaults to one if Rate is set.
+	Burst int `toml:",omitempty"`
+
+	// MaxConcurrent is the maximum number of requests that may be in flight at
+	// the same time. Zero means unlimited.
+	MaxConcurrent int `toml:",omitempty"`
+}
+
+// enabled reports whether the limit restricts anything.
+func (l RateLimit) enabled() bool {
+	return l.Rate > 0 || l.MaxConcurrent > 0
+}
+
+// RateLimitConfig configures the request limits of a Server. All limits are
+// tracked per client. Clients are identified by their authenticated identity if
+// available (see NewContextWithIdentity), falling back to their IP address. The
+// clients of connections without a remote address, such as IPC, are tracked per
+// connection.
+type RateLimitConfig struct {
+	// Client limits all requests made by a single client, regardless of method.
+	Client RateLimit `toml:",omitempty"`
+
+	// Methods limits requests to specific methods. Keys are either full method
+	// names such as "eth_getLogs" or namespace wildcards such as "debug_*". An
+	// exact match takes precedence over a wildcard.
+	Methods map[string]RateLimit `toml:",omitempty"`
+}
+
+// enabled reports whether any limit is configured.
+func (cfg *RateLimitConfig) enabled() bool {
+	if cfg.Client.enabled() {
+		return true
+	}
+	for _, l := range cfg.Methods {
+		if l.enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// limitExceededError is returned when a request is rejected by the rate limiter.
+type limitExceededError struct{ message string }
+
+func (e *limitExceededError) ErrorCode() int { return errcodeLimitExceeded }
+
+func (e *limitExceededError) Error() string { return e.message }
+
+// limitState tracks the usage of a single RateLimit by a single client.
+type limitState struct {
+	limit    RateLimit
+	bucket   *rate.Limiter
+	inflight int
+}
+
+func newLimitState(limit RateLimit) *limitState {
+	s := &limitState{limit: limit}
+	if limit.Rate > 0 {
+		s.bucket = rate.NewLimiter(rate.Limit(limit.Rate), max(limit.Burst, 1))
+	}
+	return s
+}
+
+// clientLimits holds the limiter state of a single client.
+type clientLimits struct {
+	all     *limitState
+	methods map[string]*limitState // keyed by RateLimitConfig.Methods key
+}
+
+// rateLimiter enforces a RateLimitConfig. A rateLimiter is shared by all
+// connections of a Server and is safe for concurrent use.
+type rateLimiter struct {
+	mu      sync.Mutex
+	cfg     RateLimitConfig
+	active  bool
+	clients lru.BasicLRU[string, *clientLimits]
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{clients: lru.NewBasicLRU[string, *clientLimits](maxTrackedClients)}
+}
+
+// setConfig replaces the active configuration, discarding all tracked usage.
+func (rl *rateLimiter) setConfig(cfg RateLimitConfig) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.cfg = cfg
+	rl.active = cfg.enabled()
+	rl.clients.Purge()
+}
+
+// methodKey returns the configuration key which applies to the given method.
+func (rl *rateLimiter) methodKey(method string) (string, bool) {
+	if l, ok := rl.cfg.Methods[method]; ok && l.enabled() {
+		return method, true
+	}
+	if i := strings.Index(method, serviceMethodSeparator); i > 0 {
+		key := method[:i+1] + "*"
+		if l, ok := rl.cfg.Methods[key]; ok && l.enabled() {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// acquire checks whether the client making the request in ctx may call the given
+// method. If so, it returns a function which must be called once the request has
+// been processed. Otherwise, it returns an error to send back to the client.
+func (rl *rateLimiter) acquire(ctx context.Context, method string) (func(), error) {
+	if rl == nil {
+		return func() {}, nil
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if !rl.active {
+		return func() {}, nil
+	}
+	id := clientIdentity(ctx)
+	client, ok := rl.clients.Get(id)
+	if !ok {
+		client = &clientLimits{
+			all:     newLimitState(rl.cfg.Client),
+			methods: make(map[string]*limitState),
+		}
+		rl.clients.Add(id, client)
+	}
+	states := []*limitState{client.all}
+	key, ok := rl.methodKey(method)
+	if ok {
+		state := client.methods[key]
+		if state == nil {
+			state = newLimitState(rl.cfg.Methods[key])
+			client.methods[key] = state
+		}
+		states = append(states, state)
+	}
+	// Check the concurrency caps before consuming any tokens, so that rejected
+	// requests don't count towards the rate.
+	for _, s := range states {
+		if s.limit.MaxConcurrent > 0 && s.inflight >= s.limit.MaxConcurrent {
+			markRateLimited(s == client.all, key)
+			return nil, &limitExceededError{fmt.Sprintf("too many concurrent requests for %s", method)}
+		}
+	}
+	var (
+		now          = time.Now()
+		reservations = make([]*rate.Reservation, 0, len(states))
+	)
+	for _, s := range states {
+		if s.bucket == nil {
+			continue
+		}
+		r := s.bucket.ReserveN(now, 1)
+		reservations = append(reservations, r)
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			// Return the tokens taken from all buckets checked so far.
+			for _, r := range reservations {
+				r.CancelAt(now)
+			}
+			markRateLimited(s == client.all, key)
+			return nil, &limitExceededError{fmt.Sprintf("rate limit exceeded for %s", method)}
+		}
+	}
+	for _, s := range states {
+		s.inflight++
+	}
+	release := func() {
+		rl.mu.Lock()
+		defer rl.mu.Unlock()
+		for _, s := range states {
+			s.inflight--
+		}
+	}
+	return release, nil
+}
+
+// markRateLimited updates the rejection meters. Rejections due to the per-client
+// limit are tracked separately from those due to the limit of a method.
+func markRateLimited(clientLimit bool, key string) {
+	rateLimitedAllMeter.Mark(1)
+	if clientLimit {
+		rateLimitedClientMeter.Mark(1)
+	} else {
+		rateLimitedMeter(key).Mark(1)
+	}
+}
+
+// clientIdentity returns the key under which the limits of the client making
+// the request in ctx are tracked.
+func clientIdentity(ctx context.Context) string {
+	info := PeerInfoFromContext(ctx)
+	if info.Identity != "" {
+		return "id:" + info.Identity
+	}
+	if info.RemoteAddr == "" {
+		return fmt.Sprintf("conn:%s/%d", info.Transport, connectionID(ctx))
+	}
+	host, _, err := net.SplitHostPort(info.RemoteAddr)
+	if err != nil {
+		host = info.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// connectionCounter numbers the connections served by all servers, so that
+// connections without a remote address can be told apart.
+var connectionCounter atomic.Uint64
+
+type connectionIDContextKey struct{}
+
+// newContextWithConnectionID wraps the given context, adding a unique number of
+// the connection.
+func newContextWithConnectionID(ctx context.Context) context.Context {
+	return context.WithValue(ctx, connectionIDContextKey{}, connectionCounter.Add(1))
+}
+
+// connectionID is used to extract the connection number from context.
+func connectionID(ctx context.Context) uint64 {
+	id, _ := ctx.Value(connectionIDContextKey{}).(uint64)
+	return id
+}
+
+type identityContextKey struct{}
+
+// NewContextWithIdentity wraps the given context, adding the authenticated identity of
+// the client. When passed to Server.ServeHTTP or Server.WebsocketHandler via the HTTP
+// request, the identity is made available in PeerInfo and used for rate limiting.
+func NewContextWithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// identityFromContext is used to extract the client identity from context.
+func identityFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(identityContextKey{}).(string)
+	return id
+}
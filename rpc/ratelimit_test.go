@@ -0,0 +1,224 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func checkLimitExceeded(t *testing.T, err error) {
+	t.Helper()
+	re, ok := err.(Error)
+	if !ok {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if re.ErrorCode() != errcodeLimitExceeded {
+		t.Fatalf("wrong error code, have %d want %d", re.ErrorCode(), errcodeLimitExceeded)
+	}
+}
+
+func TestServerMethodRateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimitConfig{
+		Methods: map[string]RateLimit{"test_echo": {Rate: 0.001, Burst: 2}},
+	})
+	client := DialInProc(server)
+	defer client.Close()
+
+	var res echoResult
+	for i := 0; i < 2; i++ {
+		if err := client.Call(&res, "test_echo", "x", 1); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+	checkLimitExceeded(t, client.Call(&res, "test_echo", "x", 1))
+
+	// Other methods are not affected by the method limit.
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal("unlimited method failed:", err)
+	}
+}
+
+func TestServerNamespaceRateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimitConfig{
+		Methods: map[string]RateLimit{
+			"test_*":    {Rate: 0.001, Burst: 1},
+			"test_echo": {Rate: 1000, Burst: 1000},
+		},
+	})
+	client := DialInProc(server)
+	defer client.Close()
+
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal("first call failed:", err)
+	}
+	checkLimitExceeded(t, client.Call(nil, "test_null"))
+
+	// The exact match takes precedence over the namespace wildcard.
+	var res echoResult
+	for i := 0; i < 5; i++ {
+		if err := client.Call(&res, "test_echo", "x", 1); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+}
+
+func TestServerConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimitConfig{
+		Client: RateLimit{MaxConcurrent: 1},
+	})
+	client := DialInProc(server)
+	defer client.Close()
+
+	// Occupy the only slot with a slow call.
+	done := make(chan error, 1)
+	go func() { done <- client.Call(nil, "test_sleep", 500*time.Millisecond) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err := client.Call(nil, "test_noArgsRets")
+		if err != nil {
+			checkLimitExceeded(t, err)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("concurrency limit not applied")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := <-done; err != nil {
+		t.Fatal("slow call failed:", err)
+	}
+	// Once the slow call is done, the slot becomes available again.
+	deadline = time.Now().Add(5 * time.Second)
+	for {
+		err := client.Call(nil, "test_noArgsRets")
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("slot not released:", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServerRateLimitPerClient(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimitConfig{
+		Client: RateLimit{Rate: 0.001, Burst: 1},
+	})
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	client, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal("first call failed:", err)
+	}
+	checkLimitExceeded(t, client.Call(nil, "test_noArgsRets"))
+
+	// A client with a different identity has its own budget.
+	authed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.ServeHTTP(w, r.WithContext(NewContextWithIdentity(r.Context(), "alice")))
+	}))
+	defer authed.Close()
+
+	client2, err := DialHTTP(authed.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client2.Close()
+
+	var info PeerInfo
+	if err := client2.Call(&info, "test_peerInfo"); err != nil {
+		t.Fatal("call with identity failed:", err)
+	}
+	if info.Identity != "alice" {
+		t.Fatalf("wrong identity in peer info: %q", info.Identity)
+	}
+	checkLimitExceeded(t, client2.Call(nil, "test_noArgsRets"))
+}
+
+func TestServerRateLimitReconfigure(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimitConfig{
+		Client: RateLimit{Rate: 0.001, Burst: 1},
+	})
+	client := DialInProc(server)
+	defer client.Close()
+
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal("first call failed:", err)
+	}
+	checkLimitExceeded(t, client.Call(nil, "test_noArgsRets"))
+
+	server.SetRateLimits(RateLimitConfig{})
+	for i := 0; i < 5; i++ {
+		if err := client.Call(nil, "test_noArgsRets"); err != nil {
+			t.Fatalf("call %d failed after removing limits: %v", i, err)
+		}
+	}
+}
+
+func TestServerRateLimitPerConnection(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimitConfig{
+		Client: RateLimit{Rate: 0.001, Burst: 1},
+	})
+	// Connections without a remote address, like IPC ones, are limited separately.
+	client1 := DialInProc(server)
+	defer client1.Close()
+	client2 := DialInProc(server)
+	defer client2.Close()
+
+	if err := client1.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal("first call failed:", err)
+	}
+	checkLimitExceeded(t, client1.Call(nil, "test_noArgsRets"))
+
+	if err := client2.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal("call on another connection failed:", err)
+	}
+}
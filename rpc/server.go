@@ -54,6 +54,7 @@ type Server struct {
 	batchItemLimit     int
 	batchResponseLimit int
 	httpBodyLimit      int
+	limiter            *rateLimiter
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -62,6 +63,7 @@ func NewServer() *Server {
 		idgen:         randomIDGenerator(),
 		codecs:        make(map[ServerCodec]struct{}),
 		httpBodyLimit: defaultBodyLimit,
+		limiter:       newRateLimiter(),
 	}
 	server.run.Store(true)
 	// Register the default service providing meta information about the RPC service such
@@ -89,6 +91,15 @@ func (s *Server) SetHTTPBodyLimit(limit int) {
 	s.httpBodyLimit = limit
 }
 
+// SetRateLimits configures per-client and per-method request limits. Requests exceeding
+// a limit are rejected with a "limit exceeded" error (code -32005).
+//
+// Unlike the other limits, this method may be called while the server is running. Doing
+// so resets the usage tracked for all clients.
+func (s *Server) SetRateLimits(cfg RateLimitConfig) {
+	s.limiter.setConfig(cfg)
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -114,6 +125,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		limiter:            s.limiter,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -149,6 +161,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit)
 	h.allowSubscribe = false
+	h.limiter = s.limiter
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -226,6 +239,10 @@ type PeerInfo struct {
 	// Address of client. This will usually contain the IP address and port.
 	RemoteAddr string
 
+	// Identity is the authenticated identity of the client, e.g. the subject of
+	// its JWT token. This is empty unless set through NewContextWithIdentity.
+	Identity string
+
 	// Additional information for HTTP and WebSocket connections.
 	HTTP struct {
 		// Protocol version, i.e. "HTTP/1.1". This is not set for WebSocket.
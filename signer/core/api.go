@@ -25,14 +25,17 @@ import (
 	"math/big"
 	"os"
 	"reflect"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/pkcs11wallet"
 	"github.com/ethereum/go-ethereum/accounts/scwallet"
 	"github.com/ethereum/go-ethereum/accounts/usbwallet"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -44,9 +47,9 @@ const (
 	// numberOfAccountsToDerive For hardware wallets, the number of accounts to derive
 	numberOfAccountsToDerive = 10
 	// ExternalAPIVersion -- see extapi_changelog.md
-	ExternalAPIVersion = "6.1.0"
+	ExternalAPIVersion = "6.2.0"
 	// InternalAPIVersion -- see intapi_changelog.md
-	InternalAPIVersion = "7.0.1"
+	InternalAPIVersion = "7.3.0"
 )
 
 // ExternalAPI defines the external API through which signing requests are made.
@@ -67,6 +70,8 @@ type ExternalAPI interface {
 	Version(ctx context.Context) (string, error)
 	// SignGnosisSafeTx signs/confirms a gnosis-safe multisig transaction
 	SignGnosisSafeTx(ctx context.Context, signerAddress common.MixedcaseAddress, gnosisTx GnosisSafeTx, methodSelector *string) (*GnosisSafeTx, error)
+	// SignAuthorization - request to sign an EIP-7702 code delegation authorization
+	SignAuthorization(ctx context.Context, addr common.MixedcaseAddress, auth apitypes.AuthorizationArgs) (*types.SetCodeAuthorization, error)
 }
 
 // UIClientAPI specifies what method a UI needs to implement to be able to be used as a
@@ -98,6 +103,17 @@ type UIClientAPI interface {
 	RegisterUIServer(api *UIServerAPI)
 }
 
+// TxOutcomeNotifier is an optional interface of a UIClientAPI, notified about
+// the outcome of the approved transaction requests.
+type TxOutcomeNotifier interface {
+	// OnSignedTx notifies the UI about an approved transaction having been signed.
+	// It is invoked before OnApprovedTx.
+	OnSignedTx(request *SignTxRequest, tx ethapi.SignTransactionResult)
+	// OnAbortedTx notifies the UI about an approved transaction not having been
+	// signed, e.g. because the account is locked or the wallet is unavailable.
+	OnAbortedTx(request *SignTxRequest, err error)
+}
+
 // Validator defines the methods required to validate a transaction against some
 // sanity defaults as well as any underlying 4byte method database.
 //
@@ -119,6 +135,8 @@ type SignerAPI struct {
 	validator   Validator
 	rejectMode  bool
 	credentials storage.Storage
+
+	requests atomic.Uint64 // Counter of the transaction requests, used as their identifiers
 }
 
 // Metadata about a request
@@ -130,7 +148,7 @@ type Metadata struct {
 	Origin    string `json:"Origin"`
 }
 
-func StartClefAccountManager(ksLocation string, nousb, lightKDF bool, scpath string) *accounts.Manager {
+func StartClefAccountManager(ksLocation string, nousb, lightKDF bool, scpath string, p11config *pkcs11wallet.Config) *accounts.Manager {
 	var (
 		backends []accounts.Backend
 		n, p     = keystore.StandardScryptN, keystore.StandardScryptP
@@ -184,6 +202,15 @@ func StartClefAccountManager(ksLocation string, nousb, lightKDF bool, scpath str
 			}
 		}
 	}
+	// Start a hub for the tokens of a hardware security module
+	if p11config != nil {
+		if p11hub, err := pkcs11wallet.NewHub(*p11config); err != nil {
+			log.Warn(fmt.Sprintf("Failed to start PKCS#11 hub, disabling: %v", err))
+		} else {
+			backends = append(backends, p11hub)
+			log.Debug("PKCS#11 support enabled", "module", p11config.Module)
+		}
+	}
 	return accounts.NewManager(nil, backends...)
 }
 
@@ -223,6 +250,7 @@ func (m Metadata) String() string {
 type (
 	// SignTxRequest contains info about a Transaction to sign
 	SignTxRequest struct {
+		ID          uint64                    `json:"-"` // Identifier of the request, correlating the notifications about its outcome
 		Transaction apitypes.SendTxArgs       `json:"transaction"`
 		Callinfo    []apitypes.ValidationInfo `json:"call_info"`
 		Meta        Metadata                  `json:"meta"`
@@ -241,6 +269,12 @@ type (
 		Callinfo    []apitypes.ValidationInfo `json:"call_info"`
 		Hash        hexutil.Bytes             `json:"hash"`
 		Meta        Metadata                  `json:"meta"`
+
+		// Authorization is set if the request is to sign an EIP-7702 authorization
+		Authorization *apitypes.AuthorizationArgs `json:"authorization,omitempty"`
+
+		// Domain is set if the request is to sign EIP-712 typed data
+		Domain *apitypes.TypedDataDomain `json:"domain,omitempty"`
 	}
 	SignDataResponse struct {
 		Approved bool `json:"approved"`
@@ -285,7 +319,14 @@ func NewSignerAPI(am *accounts.Manager, chainID int64, noUSB bool, ui UIClientAP
 	if advancedMode {
 		log.Info("Clef is in advanced mode: will warn instead of reject")
 	}
-	signer := &SignerAPI{big.NewInt(chainID), am, ui, validator, !advancedMode, credentials}
+	signer := &SignerAPI{
+		chainID:     big.NewInt(chainID),
+		am:          am,
+		UI:          ui,
+		validator:   validator,
+		rejectMode:  !advancedMode,
+		credentials: credentials,
+	}
 	if !noUSB {
 		signer.startUSBListener()
 	}
@@ -353,6 +394,13 @@ func (api *SignerAPI) derivationLoop(events chan accounts.WalletEvent) {
 		case accounts.WalletOpened:
 			status, _ := event.Wallet.Status()
 			log.Info("New wallet appeared", "url", event.Wallet.URL(), "status", status)
+			if event.Wallet.URL().Scheme == pkcs11wallet.Scheme {
+				// Keys on PKCS#11 tokens are not derived, but discovered on open
+				for _, account := range event.Wallet.Accounts() {
+					log.Info("Discovered account", "address", account.Address, "url", account.URL)
+				}
+				continue
+			}
 			var derive = func(limit int, next func() accounts.DerivationPath) {
 				// Derive first N accounts, hardcoded for now
 				for i := 0; i < limit; i++ {
@@ -515,6 +563,10 @@ func logDiff(original *SignTxRequest, new *SignTxResponse) bool {
 		modified = true
 		log.Info("Nonce changed by UI", "was", n0, "is", n1)
 	}
+	if a0, a1 := original.Transaction.AuthorizationList, new.Transaction.AuthorizationList; !reflect.DeepEqual(a0, a1) {
+		modified = true
+		log.Info("Authorization list changed by UI", "was", len(a0), "is", len(a1))
+	}
 	return modified
 }
 
@@ -564,6 +616,7 @@ func (api *SignerAPI) SignTransaction(ctx context.Context, args apitypes.SendTxA
 		}
 	}
 	req := SignTxRequest{
+		ID:          api.requests.Add(1),
 		Transaction: args,
 		Meta:        MetadataFromContext(ctx),
 		Callinfo:    msgs.Messages,
@@ -576,6 +629,13 @@ func (api *SignerAPI) SignTransaction(ctx context.Context, args apitypes.SendTxA
 	if !result.Approved {
 		return nil, ErrRequestDenied
 	}
+	// Notify the UI if the approved transaction ends up not being signed
+	abort := func(err error) (*ethapi.SignTransactionResult, error) {
+		if notifier, ok := api.UI.(TxOutcomeNotifier); ok {
+			notifier.OnAbortedTx(&req, err)
+		}
+		return nil, err
+	}
 	// Log changes made by the UI to the signing-request
 	logDiff(&req, &result)
 	var (
@@ -585,33 +645,36 @@ func (api *SignerAPI) SignTransaction(ctx context.Context, args apitypes.SendTxA
 	acc = accounts.Account{Address: result.Transaction.From.Address()}
 	wallet, err = api.am.Find(acc)
 	if err != nil {
-		return nil, err
+		return abort(err)
 	}
 	// Convert fields into a real transaction
 	unsignedTx, err := result.Transaction.ToTransaction()
 	if err != nil {
-		return nil, err
+		return abort(err)
 	}
 	// Get the password for the transaction
 	pw, err := api.lookupOrQueryPassword(acc.Address, "Account password",
 		fmt.Sprintf("Please enter the password for account %s", acc.Address.String()))
 	if err != nil {
-		return nil, err
+		return abort(err)
 	}
 	// The one to sign is the one that was returned from the UI
 	signedTx, err := wallet.SignTxWithPassphrase(acc, pw, unsignedTx, api.chainID)
 	if err != nil {
 		api.UI.ShowError(err.Error())
-		return nil, err
+		return abort(err)
 	}
 
 	data, err := signedTx.MarshalBinary()
 	if err != nil {
-		return nil, err
+		return abort(err)
 	}
 	response := ethapi.SignTransactionResult{Raw: data, Tx: signedTx}
 
 	// Finally, send the signed tx to the UI
+	if notifier, ok := api.UI.(TxOutcomeNotifier); ok {
+		notifier.OnSignedTx(&req, response)
+	}
 	api.UI.OnApprovedTx(response)
 	// ...and to the external caller
 	return &response, nil
@@ -37,6 +37,7 @@ import (
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/ethereum/go-ethereum/signer/fourbyte"
 	"github.com/ethereum/go-ethereum/signer/storage"
+	"github.com/holiman/uint256"
 )
 
 // Used for testing
@@ -121,7 +122,7 @@ func setup(t *testing.T) (*core.SignerAPI, *headlessUi) {
 		t.Fatal(err.Error())
 	}
 	ui := &headlessUi{make(chan string, 20), make(chan string, 20)}
-	am := core.StartClefAccountManager(tmpDirName(t), true, true, "")
+	am := core.StartClefAccountManager(tmpDirName(t), true, true, "", nil)
 	api := core.NewSignerAPI(am, 1337, true, ui, db, true, &storage.NoStorage{})
 	return api, ui
 }
@@ -320,3 +321,60 @@ func TestSignTx(t *testing.T) {
 		t.Error("Expected tx to be modified by UI")
 	}
 }
+
+func TestSignAuthorization(t *testing.T) {
+	t.Parallel()
+	api, control := setup(t)
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := common.NewMixedcaseAddress(list[0])
+	delegate, _ := common.NewMixedcaseAddressFromString("0x63c0c19a282a1B52b07dD5a65b58948A07DAE32B")
+
+	args := apitypes.AuthorizationArgs{
+		ChainID: hexutil.U256(*uint256.NewInt(1337)),
+		Address: *delegate,
+		Nonce:   7,
+	}
+	control.approveCh <- "No way"
+	if _, err := api.SignAuthorization(context.Background(), a, args); err != core.ErrRequestDenied {
+		t.Errorf("Expected ErrRequestDenied! %v", err)
+	}
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	auth, err := api.SignAuthorization(context.Background(), a, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.Address != delegate.Address() || auth.Nonce != 7 || auth.ChainID.Uint64() != 1337 {
+		t.Errorf("Authorization fields mismatch: %+v", auth)
+	}
+	authority, err := auth.Authority()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authority != a.Address() {
+		t.Errorf("Authority mismatch: have %v, want %v", authority, a.Address())
+	}
+	// The same authorization can be signed as raw data
+	blob, _ := rlp.EncodeToBytes([]any{auth.ChainID, auth.Address, auth.Nonce})
+	payload := append([]byte{0x05}, blob...)
+
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	sig, err := api.SignData(context.Background(), apitypes.SetCodeAuthorization.Mime, a, hexutil.Encode(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig[64] != auth.V || !bytes.Equal(sig[:32], auth.R.PaddedBytes(32)) || !bytes.Equal(sig[32:64], auth.S.PaddedBytes(32)) {
+		t.Errorf("Raw signature mismatch: have %x", sig)
+	}
+	// Authorizations for other chains must be rejected outright
+	args.ChainID = hexutil.U256(*uint256.NewInt(1))
+	if _, err := api.SignAuthorization(context.Background(), a, args); err == nil {
+		t.Error("Expected error for mismatching chain id")
+	}
+}
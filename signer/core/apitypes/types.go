@@ -111,6 +111,9 @@ type SendTxArgs struct {
 	Blobs       []kzg4844.Blob       `json:"blobs,omitempty"`
 	Commitments []kzg4844.Commitment `json:"commitments,omitempty"`
 	Proofs      []kzg4844.Proof      `json:"proofs,omitempty"`
+
+	// For SetCodeTxType
+	AuthorizationList []types.SetCodeAuthorization `json:"authorizationList,omitempty"`
 }
 
 func (args SendTxArgs) String() string {
@@ -171,6 +174,51 @@ func (args *SendTxArgs) ToTransaction() (*types.Transaction, error) {
 			}
 		}
 
+	case args.AuthorizationList != nil:
+		if to == nil {
+			return nil, errors.New("set code transaction must have a recipient")
+		}
+		if len(args.AuthorizationList) == 0 {
+			return nil, errors.New("set code transaction with empty authorization list")
+		}
+		if args.ChainID == nil {
+			return nil, errors.New("set code transaction must have a chainId")
+		}
+		if args.MaxFeePerGas == nil || args.MaxPriorityFeePerGas == nil {
+			return nil, errors.New("set code transaction must have maxFeePerGas and maxPriorityFeePerGas")
+		}
+		chainID, err := toUint256("chainId", args.ChainID)
+		if err != nil {
+			return nil, err
+		}
+		feeCap, err := toUint256("maxFeePerGas", args.MaxFeePerGas)
+		if err != nil {
+			return nil, err
+		}
+		tipCap, err := toUint256("maxPriorityFeePerGas", args.MaxPriorityFeePerGas)
+		if err != nil {
+			return nil, err
+		}
+		value, err := toUint256("value", &args.Value)
+		if err != nil {
+			return nil, err
+		}
+		al := types.AccessList{}
+		if args.AccessList != nil {
+			al = *args.AccessList
+		}
+		data = &types.SetCodeTx{
+			To:         *to,
+			ChainID:    chainID,
+			Nonce:      uint64(args.Nonce),
+			Gas:        uint64(args.Gas),
+			GasFeeCap:  feeCap,
+			GasTipCap:  tipCap,
+			Value:      value,
+			Data:       args.data(),
+			AccessList: al,
+			AuthList:   args.AuthorizationList,
+		}
 	case args.MaxFeePerGas != nil:
 		al := types.AccessList{}
 		if args.AccessList != nil {
@@ -212,6 +260,16 @@ func (args *SendTxArgs) ToTransaction() (*types.Transaction, error) {
 	return types.NewTx(data), nil
 }
 
+// toUint256 converts a numeric transaction field to a 256 bit integer, failing
+// if it doesn't fit.
+func toUint256(field string, v *hexutil.Big) (*uint256.Int, error) {
+	n, overflow := uint256.FromBig((*big.Int)(v))
+	if overflow {
+		return nil, fmt.Errorf("%s larger than 256 bits", field)
+	}
+	return n, nil
+}
+
 // validateTxSidecar validates blob data, if present
 func (args *SendTxArgs) validateTxSidecar() error {
 	// No blobs, we're done.
@@ -304,6 +362,10 @@ var (
 		accounts.MimetypeTextPlain,
 		0x45,
 	}
+	SetCodeAuthorization = SigFormat{
+		accounts.MimetypeSetCodeAuthorization,
+		0x05,
+	}
 )
 
 type ValidatorData struct {
@@ -311,6 +373,23 @@ type ValidatorData struct {
 	Message hexutil.Bytes
 }
 
+// AuthorizationArgs represents the arguments of an EIP-7702 authorization to be
+// signed, delegating the code of the signing account to the given address.
+type AuthorizationArgs struct {
+	ChainID hexutil.U256            `json:"chainId"`
+	Address common.MixedcaseAddress `json:"address"`
+	Nonce   hexutil.Uint64          `json:"nonce"`
+}
+
+// ToAuthorization converts the arguments to an unsigned authorization.
+func (args *AuthorizationArgs) ToAuthorization() types.SetCodeAuthorization {
+	return types.SetCodeAuthorization{
+		ChainID: uint256.Int(args.ChainID),
+		Address: args.Address.Address(),
+		Nonce:   uint64(args.Nonce),
+	}
+}
+
 // TypedData is a type to encapsulate EIP-712 typed messages
 type TypedData struct {
 	Types       Types            `json:"types"`
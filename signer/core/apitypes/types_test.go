@@ -19,9 +19,11 @@ package apitypes
 import (
 	"crypto/sha256"
 	"encoding/json"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/holiman/uint256"
@@ -108,6 +110,51 @@ func TestTxArgs(t *testing.T) {
 	*/
 }
 
+func TestSetCodeTxArgs(t *testing.T) {
+	data := []byte(`{"from":"0x1b442286e32ddcaa6e2570ce9ed85f4b4fc87425","to":"0x1b442286e32ddcaa6e2570ce9ed85f4b4fc87425","chainId":"0x7","gas":"0x124f8","maxFeePerGas":"0x6fc23ac00","maxPriorityFeePerGas":"0x3b9aca00","nonce":"0x0","value":"0x0","input":"0x","authorizationList":[{"chainId":"0x7","address":"0x63c0c19a282a1b52b07dd5a65b58948a07dae32b","nonce":"0x1","yParity":"0x1","r":"0xd9fb5af8d07dd83f5293291a34bd89073455425a46170b34323fe71ba2205ab7","s":"0x1a20e93d9c97ec47b6a1ce9aad5536a61c53f21c8a0bb5e45cc5759e7e3e7ded"}]}`)
+
+	var txArgs SendTxArgs
+	if err := json.Unmarshal(data, &txArgs); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := txArgs.ToTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := tx.Type(); have != types.SetCodeTxType {
+		t.Errorf("have type %d, want type %d", have, types.SetCodeTxType)
+	}
+	auths := tx.SetCodeAuthorizations()
+	if len(auths) != 1 {
+		t.Fatalf("have %d authorizations, want 1", len(auths))
+	}
+	if have, want := auths[0].Address, common.HexToAddress("0x63c0c19a282a1b52b07dd5a65b58948a07dae32b"); have != want {
+		t.Errorf("delegate mismatch: have %v, want %v", have, want)
+	}
+	// Set code transactions cannot create contracts
+	txArgs.To = nil
+	if _, err := txArgs.ToTransaction(); err == nil {
+		t.Error("expected error for set code transaction without recipient")
+	}
+	// Missing or invalid fields are rejected instead of panicking
+	for i, mutate := range []func(args *SendTxArgs){
+		func(args *SendTxArgs) { args.AuthorizationList = []types.SetCodeAuthorization{} },
+		func(args *SendTxArgs) { args.ChainID = nil },
+		func(args *SendTxArgs) { args.MaxFeePerGas = nil },
+		func(args *SendTxArgs) { args.MaxPriorityFeePerGas = nil },
+		func(args *SendTxArgs) { args.MaxFeePerGas = (*hexutil.Big)(new(big.Int).Lsh(big.NewInt(1), 256)) },
+	} {
+		var args SendTxArgs
+		if err := json.Unmarshal(data, &args); err != nil {
+			t.Fatal(err)
+		}
+		mutate(&args)
+		if _, err := args.ToTransaction(); err == nil {
+			t.Errorf("test %d: expected error for invalid set code transaction", i)
+		}
+	}
+}
+
 func TestBlobTxs(t *testing.T) {
 	blob := kzg4844.Blob{0x1}
 	commitment, err := kzg4844.BlobToCommitment(&blob)
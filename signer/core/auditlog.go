@@ -24,11 +24,19 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
+// DecisionAuditor records the decisions taken by automatic rule engines.
+type DecisionAuditor interface {
+	// AuditDecision records the decision (approve, reject, fallback) taken by an
+	// engine on a request, along with the reason for it.
+	AuditDecision(engine, method, decision, reason string, request interface{})
+}
+
 type AuditLogger struct {
 	log log.Logger
 	api ExternalAPI
@@ -91,6 +99,20 @@ func (l *AuditLogger) SignGnosisSafeTx(ctx context.Context, addr common.Mixedcas
 	return res, e
 }
 
+func (l *AuditLogger) SignAuthorization(ctx context.Context, addr common.MixedcaseAddress, auth apitypes.AuthorizationArgs) (*types.SetCodeAuthorization, error) {
+	data, _ := json.Marshal(auth) // can ignore error, marshalling what we just unmarshalled
+	l.log.Info("SignAuthorization", "type", "request", "metadata", MetadataFromContext(ctx).String(),
+		"addr", addr.String(), "data", string(data))
+	res, e := l.api.SignAuthorization(ctx, addr, auth)
+	if res != nil {
+		data, _ := json.Marshal(res) // can ignore error, marshalling what we just unmarshalled
+		l.log.Info("SignAuthorization", "type", "response", "data", string(data), "error", e)
+	} else {
+		l.log.Info("SignAuthorization", "type", "response", "data", res, "error", e)
+	}
+	return res, e
+}
+
 func (l *AuditLogger) SignTypedData(ctx context.Context, addr common.MixedcaseAddress, data apitypes.TypedData) (hexutil.Bytes, error) {
 	l.log.Info("SignTypedData", "type", "request", "metadata", MetadataFromContext(ctx).String(),
 		"addr", addr.String(), "data", data)
@@ -107,6 +129,13 @@ func (l *AuditLogger) EcRecover(ctx context.Context, data hexutil.Bytes, sig hex
 	return b, e
 }
 
+// AuditDecision records an automatic decision taken on a request by a rule engine.
+func (l *AuditLogger) AuditDecision(engine, method, decision, reason string, request interface{}) {
+	data, _ := json.Marshal(request) // can ignore error, marshalling a request of our own
+	l.log.Info(method, "type", "decision", "engine", engine, "decision", decision, "reason", reason,
+		"data", string(data))
+}
+
 func (l *AuditLogger) Version(ctx context.Context) (string, error) {
 	l.log.Info("Version", "type", "request", "metadata", MetadataFromContext(ctx).String())
 	data, err := l.api.Version(ctx)
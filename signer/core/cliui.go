@@ -142,6 +142,17 @@ func (ui *CommandlineUI) ApproveTx(request *SignTxRequest) (SignTxResponse, erro
 			fmt.Printf("   %v\n", bh)
 		}
 	}
+	if list := request.Transaction.AuthorizationList; len(list) > 0 {
+		fmt.Printf("Authorizations:\n")
+		for i, auth := range list {
+			authority := "<invalid signature>"
+			if addr, err := auth.Authority(); err == nil {
+				authority = addr.Hex()
+			}
+			fmt.Printf(" %d. %v delegates to %v\n", i, authority, auth.Address)
+			fmt.Printf("    chainid: %v, nonce: %d\n", auth.ChainID.Dec(), auth.Nonce)
+		}
+	}
 	if request.Transaction.Data != nil {
 		d := *request.Transaction.Data
 		if len(d) > 0 {
@@ -171,6 +182,13 @@ func (ui *CommandlineUI) ApproveSignData(request *SignDataRequest) (SignDataResp
 
 	fmt.Printf("-------- Sign data request--------------\n")
 	fmt.Printf("Account:  %s\n", request.Address.String())
+	if auth := request.Authorization; auth != nil {
+		fmt.Printf("Delegate: %s\n", auth.Address.Original())
+		if !auth.Address.ValidChecksum() {
+			fmt.Printf("\nWARNING: Invalid checksum on delegate address!\n\n")
+		}
+		fmt.Printf("chainid:  %v\n", &auth.ChainID)
+	}
 	if len(request.Callinfo) != 0 {
 		fmt.Printf("\nValidation messages:\n")
 		for _, m := range request.Callinfo {
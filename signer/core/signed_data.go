@@ -29,8 +29,10 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/holiman/uint256"
 )
 
 // sign receives a request and produces a signature
@@ -164,6 +166,17 @@ func (api *SignerAPI) determineSignatureFormat(ctx context.Context, contentType
 		// Clique uses V on the form 0 or 1
 		useEthereumV = false
 		req = &SignDataRequest{ContentType: mediaType, Rawdata: cliqueRlp, Messages: messages, Hash: sighash}
+	case apitypes.SetCodeAuthorization.Mime:
+		// EIP-7702 authorization to delegate the code of the account
+		auth, err := UnmarshalAuthorizationArgs(data)
+		if err != nil {
+			return nil, useEthereumV, err
+		}
+		if req, err = api.authorizationRequest(auth); err != nil {
+			return nil, useEthereumV, err
+		}
+		// Authorizations use V on the form 0 or 1
+		useEthereumV = false
 	case apitypes.DataTyped.Mime:
 		// EIP-712 conformant typed data
 		var err error
@@ -194,6 +207,91 @@ func (api *SignerAPI) determineSignatureFormat(ctx context.Context, contentType
 	return req, useEthereumV, nil
 }
 
+// SignAuthorization signs an EIP-7702 authorization, delegating the code of the
+// signing account to the requested address.
+func (api *SignerAPI) SignAuthorization(ctx context.Context, addr common.MixedcaseAddress, args apitypes.AuthorizationArgs) (*types.SetCodeAuthorization, error) {
+	req, err := api.authorizationRequest(args)
+	if err != nil {
+		return nil, err
+	}
+	req.Address = addr
+	req.Meta = MetadataFromContext(ctx)
+
+	signature, err := api.sign(req, false)
+	if err != nil {
+		api.UI.ShowError(err.Error())
+		return nil, err
+	}
+	auth := args.ToAuthorization()
+	auth.R.SetBytes(signature[:32])
+	auth.S.SetBytes(signature[32:64])
+	auth.V = signature[64]
+	return &auth, nil
+}
+
+// authorizationRequest validates an EIP-7702 authorization and converts it into
+// a SignDataRequest.
+func (api *SignerAPI) authorizationRequest(args apitypes.AuthorizationArgs) (*SignDataRequest, error) {
+	auth := args.ToAuthorization()
+
+	msgs := new(apitypes.ValidationMessages)
+	if auth.ChainID.IsZero() {
+		msgs.Crit("Authorization is valid on all chains")
+	} else if auth.ChainID.ToBig().Cmp(api.chainID) != 0 {
+		return nil, fmt.Errorf("requested chainid %d does not match the configuration of the signer", &auth.ChainID)
+	}
+	if !args.Address.ValidChecksum() {
+		msgs.Warn("Invalid checksum on delegate address")
+	}
+	if auth.Address == (common.Address{}) {
+		msgs.Info("Authorization clears the code delegation of the account")
+	}
+	// If we are in 'rejectMode', then reject rather than show the user warnings
+	if api.rejectMode {
+		if err := msgs.GetWarnings(); err != nil {
+			log.Info("Signing aborted due to warnings. In order to continue despite warnings, please use the flag '--advanced'.")
+			return nil, err
+		}
+	}
+	// The signature covers 0x05 || rlp([chain_id, address, nonce])
+	blob, err := rlp.EncodeToBytes([]any{auth.ChainID, auth.Address, auth.Nonce})
+	if err != nil {
+		return nil, err
+	}
+	rawdata := append([]byte{apitypes.SetCodeAuthorization.ByteVersion}, blob...)
+
+	messages := []*apitypes.NameValueType{
+		{
+			Name:  "This is a request to delegate the code of the account to a contract (see EIP 7702)",
+			Typ:   "description",
+			Value: "",
+		},
+		{
+			Name:  "Delegate address",
+			Typ:   "address",
+			Value: auth.Address.Hex(),
+		},
+		{
+			Name:  "Chain id",
+			Typ:   "uint256",
+			Value: auth.ChainID.Dec(),
+		},
+		{
+			Name:  "Account nonce",
+			Typ:   "uint64",
+			Value: fmt.Sprintf("%d", auth.Nonce),
+		},
+	}
+	return &SignDataRequest{
+		ContentType:   apitypes.SetCodeAuthorization.Mime,
+		Rawdata:       rawdata,
+		Messages:      messages,
+		Callinfo:      msgs.Messages,
+		Hash:          crypto.Keccak256(rawdata),
+		Authorization: &args,
+	}, nil
+}
+
 // SignTextValidator signs the given message which can be further recovered
 // with the given validator.
 // hash = keccak256("\x19\x00"${address}${data}).
@@ -286,7 +384,8 @@ func typedDataRequest(data any) (*SignDataRequest, error) {
 		ContentType: apitypes.DataTyped.Mime,
 		Rawdata:     []byte(rawData),
 		Messages:    messages,
-		Hash:        sighash}, nil
+		Hash:        sighash,
+		Domain:      &typedData.Domain}, nil
 }
 
 // EcRecover recovers the address associated with the given sig.
@@ -318,6 +417,45 @@ func (api *SignerAPI) EcRecover(ctx context.Context, data hexutil.Bytes, sig hex
 	return crypto.PubkeyToAddress(*rpk), nil
 }
 
+// UnmarshalAuthorizationArgs converts the given data into the arguments of an
+// EIP-7702 authorization. The data is either a JSON object or the hex encoded
+// signing payload 0x05 || rlp([chain_id, address, nonce]).
+func UnmarshalAuthorizationArgs(data interface{}) (apitypes.AuthorizationArgs, error) {
+	if args, ok := data.(apitypes.AuthorizationArgs); ok {
+		return args, nil
+	}
+	var args apitypes.AuthorizationArgs
+	if _, ok := data.(string); ok {
+		payload, err := fromHex(data)
+		if err != nil {
+			return args, err
+		}
+		if len(payload) == 0 || payload[0] != apitypes.SetCodeAuthorization.ByteVersion {
+			return args, errors.New("authorization payload is missing the 0x05 prefix")
+		}
+		var auth struct {
+			ChainID uint256.Int
+			Address common.Address
+			Nonce   uint64
+		}
+		if err := rlp.DecodeBytes(payload[1:], &auth); err != nil {
+			return args, fmt.Errorf("authorization error: %w", err)
+		}
+		args.ChainID = hexutil.U256(auth.ChainID)
+		args.Address = common.NewMixedcaseAddress(auth.Address)
+		args.Nonce = hexutil.Uint64(auth.Nonce)
+		return args, nil
+	}
+	blob, err := json.Marshal(data)
+	if err != nil {
+		return args, err
+	}
+	if err := json.Unmarshal(blob, &args); err != nil {
+		return args, fmt.Errorf("authorization error: %w", err)
+	}
+	return args, nil
+}
+
 // UnmarshalValidatorData converts the bytes input to typed data
 func UnmarshalValidatorData(data interface{}) (apitypes.ValidatorData, error) {
 	raw, ok := data.(map[string]interface{})
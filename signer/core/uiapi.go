@@ -134,6 +134,52 @@ func (api *UIServerAPI) ImportRawKey(privkey string, password string) (accounts.
 	return fetchKeystore(api.am).ImportECDSA(key, password)
 }
 
+// seedAccounts is a JSON representation of a hierarchical deterministic seed
+// stored in the keystore, along with accounts derived from it.
+type seedAccounts struct {
+	Seed     accounts.URL       `json:"seed"`
+	Accounts []accounts.Account `json:"accounts"`
+}
+
+// ImportMnemonic stores the seed of the given BIP-39 mnemonic, protected by the
+// optional mnemonic password, into the key directory and derives the first count
+// accounts from it. Both the seed and the accounts are encrypted with the password.
+// The URL of the seed is returned, to derive further accounts via DeriveAccounts.
+// Example call
+// {"jsonrpc":"2.0","method":"clef_importMnemonic","params":["test test test test test test test test test test test junk","","longpassword",2], "id":6}
+func (api *UIServerAPI) ImportMnemonic(mnemonic string, mnemonicPassword string, password string, count int) (seedAccounts, error) {
+	if count < 1 {
+		return seedAccounts{}, errors.New("at least one account must be derived")
+	}
+	if err := ValidatePasswordFormat(password); err != nil {
+		return seedAccounts{}, fmt.Errorf("password requirements not met: %v", err)
+	}
+	ks := fetchKeystore(api.am)
+	if ks == nil {
+		return seedAccounts{}, errors.New("password based accounts not supported")
+	}
+	seed, accs, err := ks.ImportMnemonic(mnemonic, mnemonicPassword, password, count)
+	if err != nil {
+		return seedAccounts{}, err
+	}
+	return seedAccounts{Seed: seed, Accounts: accs}, nil
+}
+
+// DeriveAccounts derives the next count accounts from a seed imported before via
+// ImportMnemonic, encrypting them with the password of the seed.
+// Example call
+// {"jsonrpc":"2.0","method":"clef_deriveAccounts","params":["keystore:///home/user/.ethereum/keystore/UTC--2025-01-01T00-00-00.000000000Z--seed-2b9c7e46-4f0a-4dd2-9a44-25e6ba3c6e7a","longpassword",1], "id":6}
+func (api *UIServerAPI) DeriveAccounts(seed accounts.URL, password string, count int) ([]accounts.Account, error) {
+	if count < 1 {
+		return nil, errors.New("at least one account must be derived")
+	}
+	ks := fetchKeystore(api.am)
+	if ks == nil {
+		return nil, errors.New("password based accounts not supported")
+	}
+	return ks.DeriveAccounts(seed, password, count)
+}
+
 // OpenWallet initiates a hardware wallet opening procedure, establishing a USB
 // connection and attempting to authenticate via the provided passphrase. Note,
 // the method may return an extra challenge requiring a second open (e.g. the
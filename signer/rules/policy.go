@@ -0,0 +1,451 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/signer/core"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/ethereum/go-ethereum/signer/storage"
+)
+
+const (
+	// FallbackManual forwards the requests not covered by the policy to the next
+	// handler, i.e. the JS ruleset or the user.
+	FallbackManual = "manual"
+
+	// FallbackReject rejects the requests not covered by the policy.
+	FallbackReject = "reject"
+)
+
+// Policy is a declarative ruleset to automatically approve or reject requests.
+//
+// Allow-lists (recipients, methods, domains) define which requests the policy
+// covers: requests outside of them are handled according to the fallback. Limits
+// (value caps, daily limits) are enforced on the covered requests: exceeding a
+// limit rejects the request.
+type Policy struct {
+	Transactions *TxPolicy        `json:"transactions,omitempty"`
+	TypedData    *TypedDataPolicy `json:"typedData,omitempty"`
+	Fallback     string           `json:"fallback,omitempty"` // Action for uncovered requests, "manual" (default) or "reject"
+}
+
+// TxPolicy is the part of a policy governing transaction signing.
+type TxPolicy struct {
+	Recipients map[common.Address]RecipientPolicy `json:"recipients"`           // Recipients allowed to be transacted with
+	Methods    []string                           `json:"methods,omitempty"`    // Method signatures or 4byte selectors allowed to be called
+	DailyLimit *math.HexOrDecimal256              `json:"dailyLimit,omitempty"` // Maximum value and fees spent by an account in a day (UTC)
+}
+
+// RecipientPolicy is the policy of a single allowed recipient.
+type RecipientPolicy struct {
+	MaxValue *math.HexOrDecimal256 `json:"maxValue,omitempty"` // Maximum value sent in a single transaction
+}
+
+// TypedDataPolicy is the part of a policy governing EIP-712 typed data signing.
+type TypedDataPolicy struct {
+	Domains []DomainPolicy `json:"domains"` // Domains allowed to be signed for
+}
+
+// DomainPolicy is a typed data domain allowed to be signed for. Unset fields
+// match any value.
+type DomainPolicy struct {
+	Name              string                `json:"name,omitempty"`
+	Version           string                `json:"version,omitempty"`
+	ChainId           *math.HexOrDecimal256 `json:"chainId,omitempty"`
+	VerifyingContract *common.Address       `json:"verifyingContract,omitempty"`
+}
+
+// ParsePolicy parses and validates a JSON encoded policy.
+func ParsePolicy(blob []byte) (*Policy, error) {
+	dec := json.NewDecoder(bytes.NewReader(blob))
+	dec.DisallowUnknownFields()
+
+	policy := new(Policy)
+	if err := dec.Decode(policy); err != nil {
+		return nil, err
+	}
+	switch policy.Fallback {
+	case "":
+		policy.Fallback = FallbackManual
+	case FallbackManual, FallbackReject:
+	default:
+		return nil, fmt.Errorf("unknown fallback %q", policy.Fallback)
+	}
+	if policy.Transactions != nil {
+		for i, method := range policy.Transactions.Methods {
+			if strings.HasPrefix(method, "0x") {
+				if id, err := hexutil.Decode(method); err != nil || len(id) != 4 {
+					return nil, fmt.Errorf("invalid method selector %q", method)
+				}
+				continue
+			}
+			method = strings.ReplaceAll(method, " ", "")
+			if _, err := abi.ParseSelector(method); err != nil {
+				return nil, fmt.Errorf("invalid method signature %q: %v", method, err)
+			}
+			policy.Transactions.Methods[i] = method
+		}
+	}
+	return policy, nil
+}
+
+// SelectorResolver resolves 4byte method selectors into method signatures. It is
+// implemented by fourbyte.Database.
+type SelectorResolver interface {
+	Selector(id []byte) (string, error)
+}
+
+// decision is the outcome of evaluating a request against the policy.
+type decision int
+
+const (
+	decisionFallback decision = iota // Request not covered by the policy
+	decisionApprove                  // Request approved by the policy
+	decisionReject                   // Request rejected by the policy
+)
+
+func (d decision) String() string {
+	switch d {
+	case decisionApprove:
+		return "approve"
+	case decisionReject:
+		return "reject"
+	default:
+		return "fallback"
+	}
+}
+
+// spending is the value sent by an account on a given day, tracked in storage.
+type spending struct {
+	Day   int64                 `json:"day"`
+	Value *math.HexOrDecimal256 `json:"value"`
+}
+
+// reservation is the maximum cost of a transaction approved by the policy, which
+// counts towards the daily limit of its sender until it's signed. Reservations
+// are only held in memory, as the pending requests don't survive a restart of
+// the signer either.
+type reservation struct {
+	from common.Address
+	cost *big.Int
+}
+
+// policyUI provides an implementation of UIClientAPI that evaluates a declarative
+// policy for the approval requests, forwarding anything else to the next handler.
+type policyUI struct {
+	next      core.UIClientAPI // The next handler, for requests not covered by the policy
+	policy    *Policy
+	storage   storage.Storage // Storage to track the daily spending in
+	selectors SelectorResolver
+	auditor   core.DecisionAuditor
+	now       func() time.Time
+
+	reserved map[uint64]*reservation // Approved transactions waiting to be signed, keyed by request
+	lock     sync.Mutex              // Protects the spending tracked in storage and the reservations
+}
+
+// NewPolicyEvaluator creates a UI handler evaluating the given policy before
+// forwarding the requests to the next handler.
+func NewPolicyEvaluator(next core.UIClientAPI, policy *Policy, storage storage.Storage, selectors SelectorResolver) *policyUI {
+	return &policyUI{
+		next:      next,
+		policy:    policy,
+		storage:   storage,
+		selectors: selectors,
+		now:       time.Now,
+		reserved:  make(map[uint64]*reservation),
+	}
+}
+
+// SetAuditor sets the audit log to record the policy decisions into.
+func (p *policyUI) SetAuditor(auditor core.DecisionAuditor) {
+	p.auditor = auditor
+}
+
+// record logs the decision taken on a request, resolving the fallback.
+func (p *policyUI) record(method string, d decision, reason string, request interface{}) decision {
+	if d == decisionFallback && p.policy.Fallback == FallbackReject {
+		d, reason = decisionReject, reason+", rejected by fallback"
+	}
+	log.Info("Policy decision", "method", method, "decision", d, "reason", reason)
+	if p.auditor != nil {
+		p.auditor.AuditDecision("policy", method, d.String(), reason, request)
+	}
+	return d
+}
+
+func (p *policyUI) RegisterUIServer(api *core.UIServerAPI) {
+	p.next.RegisterUIServer(api)
+}
+
+func (p *policyUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse, error) {
+	d, reason := p.evaluateTx(request)
+	switch p.record("ApproveTx", d, reason, request) {
+	case decisionApprove:
+		return core.SignTxResponse{Transaction: request.Transaction, Approved: true}, nil
+	case decisionReject:
+		return core.SignTxResponse{Transaction: request.Transaction, Approved: false}, nil
+	default:
+		return p.next.ApproveTx(request)
+	}
+}
+
+// evaluateTx checks a transaction request against the transaction policy.
+func (p *policyUI) evaluateTx(request *core.SignTxRequest) (decision, string) {
+	tx := &request.Transaction
+	policy := p.policy.Transactions
+	if policy == nil {
+		return decisionFallback, "no transaction policy"
+	}
+	if tx.To == nil {
+		return decisionFallback, "contract creation"
+	}
+	if len(tx.AuthorizationList) > 0 || len(tx.BlobHashes) > 0 {
+		return decisionFallback, "unsupported transaction type"
+	}
+	recipient, ok := policy.Recipients[tx.To.Address()]
+	if !ok {
+		return decisionFallback, fmt.Sprintf("recipient %v not allowed", tx.To.Address())
+	}
+	// Recipient allowed, check the called method, if any
+	data := tx.Data
+	if tx.Input != nil {
+		data = tx.Input
+	}
+	if data != nil && len(*data) > 0 {
+		if method, ok := p.allowedMethod(*data); !ok {
+			return decisionFallback, fmt.Sprintf("method %s not allowed", method)
+		}
+	}
+	// Transaction covered by the policy, enforce the limits
+	value := tx.Value.ToInt()
+	if recipient.MaxValue != nil && value.Cmp((*big.Int)(recipient.MaxValue)) > 0 {
+		return decisionReject, fmt.Sprintf("value %v exceeds recipient cap %v", value, (*big.Int)(recipient.MaxValue))
+	}
+	if policy.DailyLimit != nil {
+		// Reserve the cost until the transaction is signed, so that concurrent
+		// requests cannot exceed the limit together.
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		var (
+			from  = tx.From.Address()
+			cost  = txCost(tx)
+			spent = p.spent(from)
+		)
+		for _, r := range p.reserved {
+			if r.from == from {
+				spent.Add(spent, r.cost)
+			}
+		}
+		if total := new(big.Int).Add(spent, cost); total.Cmp((*big.Int)(policy.DailyLimit)) > 0 {
+			return decisionReject, fmt.Sprintf("cost %v exceeds remaining daily limit %v", cost, new(big.Int).Sub((*big.Int)(policy.DailyLimit), spent))
+		}
+		p.reserved[request.ID] = &reservation{from: from, cost: cost}
+	}
+	return decisionApprove, "transaction allowed"
+}
+
+// txCost returns the maximum cost of a transaction to its sender: the value and
+// the gas paid at the maximum fee.
+func txCost(tx *apitypes.SendTxArgs) *big.Int {
+	cost := new(big.Int).Set(tx.Value.ToInt())
+	price := tx.MaxFeePerGas
+	if price == nil {
+		price = tx.GasPrice
+	}
+	if price != nil {
+		cost.Add(cost, new(big.Int).Mul(new(big.Int).SetUint64(uint64(tx.Gas)), price.ToInt()))
+	}
+	return cost
+}
+
+// allowedMethod checks whether the method invoked by the call data is allowed by
+// the policy, either via its selector or its signature resolved through the 4byte
+// database. The method is returned in the most descriptive form known.
+func (p *policyUI) allowedMethod(data []byte) (string, bool) {
+	if len(data) < 4 {
+		return hexutil.Encode(data), false
+	}
+	selector := hexutil.Encode(data[:4])
+	for _, method := range p.policy.Transactions.Methods {
+		if method == selector {
+			return selector, true
+		}
+	}
+	if p.selectors == nil {
+		return selector, false
+	}
+	signature, err := p.selectors.Selector(data[:4])
+	if err != nil {
+		return selector, false
+	}
+	// The 4byte database might contain user submitted entries, verify the match
+	signature = strings.ReplaceAll(signature, " ", "")
+	if !bytes.Equal(crypto.Keccak256([]byte(signature))[:4], data[:4]) {
+		return selector, false
+	}
+	for _, method := range p.policy.Transactions.Methods {
+		if method == signature {
+			return signature, true
+		}
+	}
+	return signature, false
+}
+
+// spentKey is the storage key tracking the daily spending of an account.
+func spentKey(account common.Address) string {
+	return "policy-spent-" + strings.ToLower(account.Hex())
+}
+
+// spent returns the value and fees spent by the account today. The caller must
+// hold the lock.
+func (p *policyUI) spent(account common.Address) *big.Int {
+	stored, err := p.storage.Get(spentKey(account))
+	if err != nil {
+		return new(big.Int)
+	}
+	var spend spending
+	if err := json.Unmarshal([]byte(stored), &spend); err != nil {
+		log.Warn("Corrupted policy spending", "account", account, "err", err)
+		return new(big.Int)
+	}
+	if spend.Day != p.now().Unix()/86400 || spend.Value == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set((*big.Int)(spend.Value))
+}
+
+func (p *policyUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
+	d, reason := p.evaluateSignData(request)
+	switch p.record("ApproveSignData", d, reason, request) {
+	case decisionApprove:
+		return core.SignDataResponse{Approved: true}, nil
+	case decisionReject:
+		return core.SignDataResponse{Approved: false}, nil
+	default:
+		return p.next.ApproveSignData(request)
+	}
+}
+
+// evaluateSignData checks a data signing request against the typed data policy.
+func (p *policyUI) evaluateSignData(request *core.SignDataRequest) (decision, string) {
+	if request.ContentType != apitypes.DataTyped.Mime || request.Domain == nil {
+		return decisionFallback, fmt.Sprintf("content type %s", request.ContentType)
+	}
+	if p.policy.TypedData == nil {
+		return decisionFallback, "no typed data policy"
+	}
+	domain := request.Domain
+	for _, allowed := range p.policy.TypedData.Domains {
+		if allowed.Name != "" && allowed.Name != domain.Name {
+			continue
+		}
+		if allowed.Version != "" && allowed.Version != domain.Version {
+			continue
+		}
+		if allowed.ChainId != nil && (domain.ChainId == nil || (*big.Int)(allowed.ChainId).Cmp((*big.Int)(domain.ChainId)) != 0) {
+			continue
+		}
+		if allowed.VerifyingContract != nil && !strings.EqualFold(allowed.VerifyingContract.Hex(), domain.VerifyingContract) {
+			continue
+		}
+		return decisionApprove, "typed data domain allowed"
+	}
+	return decisionFallback, fmt.Sprintf("typed data domain %q not allowed", domain.Name)
+}
+
+func (p *policyUI) OnApprovedTx(tx ethapi.SignTransactionResult) {
+	p.next.OnApprovedTx(tx)
+}
+
+// OnSignedTx implements core.TxOutcomeNotifier, tracking the spending of all
+// signed transactions, regardless of who approved them, and replacing the
+// reservation made on approval by the policy.
+func (p *policyUI) OnSignedTx(request *core.SignTxRequest, tx ethapi.SignTransactionResult) {
+	p.lock.Lock()
+	delete(p.reserved, request.ID)
+	if p.policy.Transactions != nil && p.policy.Transactions.DailyLimit != nil && tx.Tx != nil {
+		if from, err := types.Sender(types.LatestSignerForChainID(tx.Tx.ChainId()), tx.Tx); err != nil {
+			log.Warn("Failed to track policy spending", "err", err)
+		} else {
+			spent := new(big.Int).Add(p.spent(from), tx.Tx.Cost())
+			blob, _ := json.Marshal(spending{Day: p.now().Unix() / 86400, Value: (*math.HexOrDecimal256)(spent)})
+			p.storage.Put(spentKey(from), string(blob))
+		}
+	}
+	p.lock.Unlock()
+
+	if notifier, ok := p.next.(core.TxOutcomeNotifier); ok {
+		notifier.OnSignedTx(request, tx)
+	}
+}
+
+// OnAbortedTx implements core.TxOutcomeNotifier, releasing the reservation of
+// an approved transaction which failed to be signed.
+func (p *policyUI) OnAbortedTx(request *core.SignTxRequest, err error) {
+	p.lock.Lock()
+	delete(p.reserved, request.ID)
+	p.lock.Unlock()
+
+	if notifier, ok := p.next.(core.TxOutcomeNotifier); ok {
+		notifier.OnAbortedTx(request, err)
+	}
+}
+
+// ApproveListing not handled by the policy
+func (p *policyUI) ApproveListing(request *core.ListRequest) (core.ListResponse, error) {
+	return p.next.ApproveListing(request)
+}
+
+// ApproveNewAccount not handled by the policy
+func (p *policyUI) ApproveNewAccount(request *core.NewAccountRequest) (core.NewAccountResponse, error) {
+	return p.next.ApproveNewAccount(request)
+}
+
+// OnInputRequired not handled by the policy
+func (p *policyUI) OnInputRequired(info core.UserInputRequest) (core.UserInputResponse, error) {
+	return p.next.OnInputRequired(info)
+}
+
+func (p *policyUI) ShowError(message string) {
+	p.next.ShowError(message)
+}
+
+func (p *policyUI) ShowInfo(message string) {
+	p.next.ShowInfo(message)
+}
+
+func (p *policyUI) OnSignerStartup(info core.StartupInfo) {
+	p.next.OnSignerStartup(info)
+}
@@ -0,0 +1,330 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rules
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/signer/core"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/ethereum/go-ethereum/signer/storage"
+)
+
+const testPolicy = `{
+	"transactions": {
+		"recipients": {
+			"0x000000000000000000000000000000000000dead": {"maxValue": "1000"},
+			"0x000000000000000000000000000000000000beef": {}
+		},
+		"methods": ["transfer(address, uint256)", "0x095ea7b3"],
+		"dailyLimit": "100000000000"
+	},
+	"typedData": {
+		"domains": [{"name": "Ether Mail", "chainId": 1}]
+	}
+}`
+
+// testLimitPolicy allows transfers to 0x..dead, costing at most 43500 wei a day
+// including the fees.
+const testLimitPolicy = `{
+	"transactions": {
+		"recipients": {"0x000000000000000000000000000000000000dead": {}},
+		"dailyLimit": "43500"
+	}
+}`
+
+// testSelectors is a 4byte database resolving a fixed set of selectors.
+type testSelectors map[string]string
+
+func (s testSelectors) Selector(id []byte) (string, error) {
+	if sig, ok := s[hexutil.Encode(id)]; ok {
+		return sig, nil
+	}
+	return "", errors.New("not found")
+}
+
+// testAuditor collects the decisions recorded by a rule engine.
+type testAuditor struct {
+	decisions []string
+	lock      sync.Mutex
+}
+
+func (a *testAuditor) AuditDecision(engine, method, decision, reason string, request interface{}) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.decisions = append(a.decisions, decision)
+}
+
+func newTestPolicy(t *testing.T, blob string) (*policyUI, *dummyUI, *testAuditor) {
+	t.Helper()
+
+	policy, err := ParsePolicy([]byte(blob))
+	if err != nil {
+		t.Fatalf("failed to parse policy: %v", err)
+	}
+	var (
+		next      = new(dummyUI)
+		auditor   = new(testAuditor)
+		selectors = testSelectors{
+			"0xa9059cbb": "transfer(address,uint256)",
+			"0x23b872dd": "transferFrom(address,address,uint256)",
+			"0xdeadbeef": "transfer(address,uint256)", // Forged entry
+		}
+	)
+	ui := NewPolicyEvaluator(next, policy, storage.NewEphemeralStorage(), selectors)
+	ui.SetAuditor(auditor)
+	ui.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return ui, next, auditor
+}
+
+func TestParsePolicy(t *testing.T) {
+	t.Parallel()
+	for i, blob := range []string{
+		`{"fallback": "approve"}`,
+		`{"transactions": {"methods": ["transfer(address"]}}`,
+		`{"transactions": {"methods": ["0xa9059c"]}}`,
+		`{"transactions": {"recipients": {}, "maxValue": "1"}}`,
+	} {
+		if _, err := ParsePolicy([]byte(blob)); err == nil {
+			t.Errorf("test %d: invalid policy accepted", i)
+		}
+	}
+	policy, err := ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("failed to parse policy: %v", err)
+	}
+	if policy.Fallback != FallbackManual {
+		t.Errorf("fallback mismatch: have %q, want %q", policy.Fallback, FallbackManual)
+	}
+}
+
+func TestPolicyTx(t *testing.T) {
+	t.Parallel()
+
+	beef, _ := mixAddr("000000000000000000000000000000000000beef")
+	withData := func(req *core.SignTxRequest, data string) *core.SignTxRequest {
+		input := hexutil.Bytes(common.FromHex(data))
+		req.Transaction.Input = &input
+		return req
+	}
+	withTo := func(req *core.SignTxRequest, to *common.MixedcaseAddress) *core.SignTxRequest {
+		req.Transaction.To = to
+		return req
+	}
+	tests := []struct {
+		req      *core.SignTxRequest
+		decision string
+	}{
+		{dummyTxWithV(1000), "approve"},
+		{dummyTxWithV(1001), "reject"},
+		{withTo(dummyTxWithV(1001), beef), "approve"},
+		{withTo(dummyTxWithV(1), nil), "fallback"},
+		{withTo(dummyTxWithV(1), &common.MixedcaseAddress{}), "fallback"},
+		{withData(dummyTxWithV(0), "0xa9059cbb"), "approve"},
+		{withData(dummyTxWithV(0), "0x095ea7b3"), "approve"},
+		{withData(dummyTxWithV(0), "0x23b872dd"), "fallback"},
+		{withData(dummyTxWithV(0), "0xdeadbeef"), "fallback"},
+		{withData(dummyTxWithV(0), "0x12345678"), "fallback"},
+		{withData(dummyTxWithV(0), "0x12"), "fallback"},
+	}
+	for i, tt := range tests {
+		ui, next, auditor := newTestPolicy(t, testPolicy)
+
+		resp, err := ui.ApproveTx(tt.req)
+		if have := auditor.decisions[0]; have != tt.decision {
+			t.Errorf("test %d: decision mismatch: have %s, want %s", i, have, tt.decision)
+		}
+		switch tt.decision {
+		case "approve", "reject":
+			if err != nil || resp.Approved != (tt.decision == "approve") || len(next.calls) != 0 {
+				t.Errorf("test %d: response mismatch: approved %v, err %v, forwarded %v", i, resp.Approved, err, next.calls)
+			}
+		default:
+			if !errors.Is(err, core.ErrRequestDenied) || len(next.calls) != 1 {
+				t.Errorf("test %d: request not forwarded: %v", i, next.calls)
+			}
+		}
+	}
+}
+
+func TestPolicyFallbackReject(t *testing.T) {
+	t.Parallel()
+
+	ui, next, auditor := newTestPolicy(t, `{"fallback": "reject"}`)
+	resp, err := ui.ApproveTx(dummyTxWithV(1))
+	if err != nil || resp.Approved || len(next.calls) != 0 {
+		t.Fatalf("uncovered request not rejected: approved %v, err %v, forwarded %v", resp.Approved, err, next.calls)
+	}
+	if auditor.decisions[0] != "reject" {
+		t.Fatalf("decision mismatch: have %s, want reject", auditor.decisions[0])
+	}
+}
+
+func TestPolicyDailyLimit(t *testing.T) {
+	t.Parallel()
+
+	ui, _, _ := newTestPolicy(t, testLimitPolicy)
+	key, _ := crypto.GenerateKey()
+	from := common.NewMixedcaseAddress(crypto.PubkeyToAddress(key.PublicKey))
+
+	// Every transfer pays 21000 wei of fees on top of its value
+	nonce := uint64(0)
+	send := func(value int64) bool {
+		req := newLimitTx(from, nonce, value)
+		resp, _ := ui.ApproveTx(req)
+		if resp.Approved {
+			tx := types.MustSignNewTx(key, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+				ChainID:   big.NewInt(1),
+				Nonce:     nonce,
+				To:        &common.Address{0xde, 0xad},
+				Value:     big.NewInt(value),
+				Gas:       21000,
+				GasFeeCap: big.NewInt(1),
+			})
+			ui.OnSignedTx(req, ethapi.SignTransactionResult{Tx: tx})
+			nonce++
+		}
+		return resp.Approved
+	}
+	if !send(1000) || !send(500) {
+		t.Fatalf("transfers within the daily limit rejected")
+	}
+	if send(0) {
+		t.Fatalf("fees over the daily limit approved")
+	}
+	// The limit is reset on the next day
+	ui.now = func() time.Time { return time.Unix(1700000000+86400, 0) }
+	if !send(1000) {
+		t.Fatalf("transfer rejected after daily limit reset")
+	}
+}
+
+// Tests that approved transactions reserve their cost until signed, so that
+// concurrent requests cannot exceed the daily limit, and that the reservation
+// is released if the signing fails.
+func TestPolicyDailyLimitReservation(t *testing.T) {
+	t.Parallel()
+
+	ui, _, _ := newTestPolicy(t, testLimitPolicy)
+	from, _ := mixAddr("000000000000000000000000000000000000dead")
+
+	var (
+		approved = make(chan *core.SignTxRequest, 8)
+		wg       sync.WaitGroup
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(nonce uint64) {
+			defer wg.Done()
+
+			req := newLimitTx(*from, nonce, 1000)
+			if resp, _ := ui.ApproveTx(req); resp.Approved {
+				approved <- req
+			}
+		}(uint64(i))
+	}
+	wg.Wait()
+	close(approved)
+
+	if len(approved) != 1 {
+		t.Fatalf("wrong number of approved transfers: have %d, want 1", len(approved))
+	}
+	// Failing to sign the approved transfer releases its reservation
+	ui.OnAbortedTx(<-approved, errors.New("account locked"))
+	if resp, _ := ui.ApproveTx(newLimitTx(*from, 8, 1000)); !resp.Approved {
+		t.Fatalf("transfer rejected after the reservation was released")
+	}
+}
+
+// Tests that the reservations are tracked per request, so that requests reusing
+// the nonce of another request don't release its reservation.
+func TestPolicyDailyLimitDuplicateNonce(t *testing.T) {
+	t.Parallel()
+
+	ui, _, _ := newTestPolicy(t, testLimitPolicy)
+	from, _ := mixAddr("000000000000000000000000000000000000dead")
+
+	// Two requests of the same nonce fitting the limit together
+	first, second := newLimitTx(*from, 0, 300), newLimitTx(*from, 0, 1000)
+	first.ID, second.ID = 1, 2
+	for _, req := range []*core.SignTxRequest{first, second} {
+		if resp, _ := ui.ApproveTx(req); !resp.Approved {
+			t.Fatalf("request %d rejected", req.ID)
+		}
+	}
+	// Aborting the second request must release its own reservation, leaving
+	// 22200 wei of the limit available
+	ui.OnAbortedTx(second, errors.New("account locked"))
+
+	third := newLimitTx(*from, 1, 1100)
+	third.ID = 3
+	if resp, _ := ui.ApproveTx(third); !resp.Approved {
+		t.Fatalf("transfer rejected after the reservation was released")
+	}
+	fourth := newLimitTx(*from, 2, 0)
+	fourth.ID = 4
+	if resp, _ := ui.ApproveTx(fourth); resp.Approved {
+		t.Fatalf("transfer approved despite the reservations of the pending requests")
+	}
+}
+
+// newLimitTx creates a transfer request to 0x..dead paying 21000 wei of fees.
+func newLimitTx(from common.MixedcaseAddress, nonce uint64, value int64) *core.SignTxRequest {
+	req := dummyTxWithV(uint64(value))
+	req.ID = nonce + 1
+	req.Transaction.From = from
+	req.Transaction.Nonce = hexutil.Uint64(nonce)
+	req.Transaction.GasPrice = (*hexutil.Big)(big.NewInt(1))
+	return req
+}
+
+func TestPolicyTypedData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		domain   *apitypes.TypedDataDomain
+		decision string
+	}{
+		{&apitypes.TypedDataDomain{Name: "Ether Mail", Version: "1", ChainId: math.NewHexOrDecimal256(1)}, "approve"},
+		{&apitypes.TypedDataDomain{Name: "Ether Mail", ChainId: math.NewHexOrDecimal256(5)}, "fallback"},
+		{&apitypes.TypedDataDomain{Name: "Ether Mail"}, "fallback"},
+		{&apitypes.TypedDataDomain{Name: "Permit2", ChainId: math.NewHexOrDecimal256(1)}, "fallback"},
+		{nil, "fallback"},
+	}
+	for i, tt := range tests {
+		ui, next, auditor := newTestPolicy(t, testPolicy)
+
+		resp, _ := ui.ApproveSignData(&core.SignDataRequest{ContentType: apitypes.DataTyped.Mime, Domain: tt.domain})
+		if have := auditor.decisions[0]; have != tt.decision {
+			t.Errorf("test %d: decision mismatch: have %s, want %s", i, have, tt.decision)
+		}
+		if resp.Approved != (tt.decision == "approve") || (len(next.calls) == 1) != (tt.decision == "fallback") {
+			t.Errorf("test %d: response mismatch: approved %v, forwarded %v", i, resp.Approved, next.calls)
+		}
+	}
+}
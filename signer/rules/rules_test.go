@@ -624,3 +624,64 @@ function ApproveSignData(r){
 		t.Fatalf("Expected approved")
 	}
 }
+
+func TestSignDelegation(t *testing.T) {
+	t.Parallel()
+	js := `
+	var trusted = "0x63c0c19a282a1b52b07dd5a65b58948a07dae32b";
+
+	function ApproveTx(r){
+		var auths = r.transaction.authorizationList || [];
+		for (var i = 0; i < auths.length; i++) {
+			if (auths[i].address.toLowerCase() != trusted) { return "Reject" }
+		}
+		return "Approve"
+	}
+	function ApproveSignData(r){
+		if (r.authorization) {
+			return r.authorization.address.toLowerCase() == trusted ? "Approve" : "Reject"
+		}
+	}`
+	r, err := initRuleEngine(js)
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+	from, _ := mixAddr("0x0000000000000000000000000000000000001337")
+	trusted, _ := mixAddr("0x63c0c19a282a1B52b07dD5a65b58948A07DAE32B")
+	untrusted, _ := mixAddr("0x000000000000000000000000000000000000dead")
+
+	for i, tt := range []struct {
+		delegate *common.MixedcaseAddress
+		approved bool
+	}{
+		{trusted, true},
+		{untrusted, false},
+	} {
+		resp, err := r.ApproveTx(&core.SignTxRequest{
+			Transaction: apitypes.SendTxArgs{
+				From: *from,
+				To:   from,
+				AuthorizationList: []types.SetCodeAuthorization{
+					{Address: tt.delegate.Address(), Nonce: 1},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("test %d: unexpected error %v", i, err)
+		}
+		if resp.Approved != tt.approved {
+			t.Errorf("test %d: transaction approval mismatch: have %v, want %v", i, resp.Approved, tt.approved)
+		}
+		data, err := r.ApproveSignData(&core.SignDataRequest{
+			ContentType:   apitypes.SetCodeAuthorization.Mime,
+			Address:       *from,
+			Authorization: &apitypes.AuthorizationArgs{Address: *tt.delegate, Nonce: 1},
+		})
+		if err != nil {
+			t.Fatalf("test %d: unexpected error %v", i, err)
+		}
+		if data.Approved != tt.approved {
+			t.Errorf("test %d: authorization approval mismatch: have %v, want %v", i, data.Approved, tt.approved)
+		}
+	}
+}
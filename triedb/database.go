@@ -17,11 +17,13 @@
 package triedb
 
 import (
+	"context"
 	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/telemetry"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/trie/trienode"
 	"github.com/ethereum/go-ethereum/triedb/database"
@@ -129,6 +131,17 @@ func (db *Database) StateReader(blockRoot common.Hash) (database.StateReader, er
 	return db.backend.StateReader(blockRoot)
 }
 
+// HistoricReader returns a reader that allows access to the state data of the
+// specified historical state, reconstructed from the state histories. It's only
+// supported by path-based database, within the configured historic state window.
+func (db *Database) HistoricReader(blockRoot common.Hash) (database.StateReader, error) {
+	pdb, ok := db.backend.(*pathdb.Database)
+	if !ok {
+		return nil, errors.New("not supported")
+	}
+	return pdb.HistoricReader(blockRoot)
+}
+
 // Update performs a state transition by committing dirty nodes contained in the
 // given set in order to update state from the specified parent to the specified
 // root. The held pre-images accumulated up to this point will be flushed in case
@@ -137,26 +150,57 @@ func (db *Database) StateReader(blockRoot common.Hash) (database.StateReader, er
 // The passed in maps(nodes, states) will be retained to avoid copying everything.
 // Therefore, these maps must not be changed afterwards.
 func (db *Database) Update(root common.Hash, parent common.Hash, block uint64, nodes *trienode.MergedNodeSet, states *StateSet) error {
+	return db.UpdateContext(context.Background(), root, parent, block, nodes, states)
+}
+
+// UpdateContext is like Update, but traces the state transition within the
+// span carried by the given context.
+func (db *Database) UpdateContext(ctx context.Context, root common.Hash, parent common.Hash, block uint64, nodes *trienode.MergedNodeSet, states *StateSet) error {
+	_, span := telemetry.Start(ctx, "triedb.Database.Update", telemetry.WithAttributes(
+		telemetry.String("scheme", db.Scheme()),
+		telemetry.String("root", root.Hex()),
+		telemetry.Uint64("block", block),
+	))
+	defer span.End()
+
 	if db.preimages != nil {
 		db.preimages.commit(false)
 	}
+	var err error
 	switch b := db.backend.(type) {
 	case *hashdb.Database:
-		return b.Update(root, parent, block, nodes)
+		err = b.Update(root, parent, block, nodes)
 	case *pathdb.Database:
-		return b.Update(root, parent, block, nodes, states.internal())
+		err = b.Update(root, parent, block, nodes, states.internal())
+	default:
+		err = errors.New("unknown backend")
 	}
-	return errors.New("unknown backend")
+	span.RecordError(err)
+	return err
 }
 
 // Commit iterates over all the children of a particular node, writes them out
 // to disk. As a side effect, all pre-images accumulated up to this point are
 // also written.
 func (db *Database) Commit(root common.Hash, report bool) error {
+	return db.CommitContext(context.Background(), root, report)
+}
+
+// CommitContext is like Commit, but traces the flush within the span carried
+// by the given context.
+func (db *Database) CommitContext(ctx context.Context, root common.Hash, report bool) error {
+	_, span := telemetry.Start(ctx, "triedb.Database.Commit", telemetry.WithAttributes(
+		telemetry.String("scheme", db.Scheme()),
+		telemetry.String("root", root.Hex()),
+	))
+	defer span.End()
+
 	if db.preimages != nil {
 		db.preimages.commit(true)
 	}
-	return db.backend.Commit(root, report)
+	err := db.backend.Commit(root, report)
+	span.RecordError(err)
+	return err
 }
 
 // Size returns the storage size of diff layer nodes above the persistent disk
@@ -312,6 +356,18 @@ func (db *Database) Journal(root common.Hash) error {
 	return pdb.Journal(root)
 }
 
+// Reload reconstructs the in-memory state layers from the persistent state, which
+// may have been advanced by another process owning the database. It's only
+// supported by path-based database in read only mode, while hash-based database
+// always reads the latest persistent state.
+func (db *Database) Reload() error {
+	pdb, ok := db.backend.(*pathdb.Database)
+	if !ok {
+		return nil
+	}
+	return pdb.Reload()
+}
+
 // IsVerkle returns the indicator if the database is holding a verkle tree.
 func (db *Database) IsVerkle() bool {
 	return db.config.IsVerkle
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -110,10 +111,11 @@ type layer interface {
 
 // Config contains the settings for database.
 type Config struct {
-	StateHistory    uint64 // Number of recent blocks to maintain state history for
-	CleanCacheSize  int    // Maximum memory allowance (in bytes) for caching clean nodes
-	WriteBufferSize int    // Maximum memory allowance (in bytes) for write buffer
-	ReadOnly        bool   // Flag whether the database is opened in read only mode.
+	StateHistory        uint64 // Number of recent blocks to maintain state history for
+	HistoricStateWindow uint64 // Number of recent blocks whose state can be read from state histories (0 = disabled)
+	CleanCacheSize      int    // Maximum memory allowance (in bytes) for caching clean nodes
+	WriteBufferSize     int    // Maximum memory allowance (in bytes) for write buffer
+	ReadOnly            bool   // Flag whether the database is opened in read only mode.
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -136,6 +138,9 @@ func (c *Config) fields() []interface{} {
 	list = append(list, "cache", common.StorageSize(c.CleanCacheSize))
 	list = append(list, "buffer", common.StorageSize(c.WriteBufferSize))
 	list = append(list, "history", c.StateHistory)
+	if c.HistoricStateWindow != 0 {
+		list = append(list, "window", c.HistoricStateWindow)
+	}
 	return list
 }
 
@@ -198,6 +203,9 @@ type Database struct {
 	tree    *layerTree                   // The group for all known layers
 	freezer ethdb.ResettableAncientStore // Freezer for storing trie histories, nil possible in tests
 	lock    sync.RWMutex                 // Lock to prevent mutations from happening at the same time
+
+	histories    *lru.Cache[uint64, *historyStates] // Decoded state histories for serving historic state reads
+	historyIndex *historyIndex                      // Index of the state histories for serving historic state reads
 }
 
 // New attempts to load an already existing layer from a persistent key-value
@@ -210,11 +218,13 @@ func New(diskdb ethdb.Database, config *Config, isVerkle bool) *Database {
 	config = config.sanitize()
 
 	db := &Database{
-		readOnly: config.ReadOnly,
-		isVerkle: isVerkle,
-		config:   config,
-		diskdb:   diskdb,
-		hasher:   merkleNodeHasher,
+		readOnly:     config.ReadOnly,
+		isVerkle:     isVerkle,
+		config:       config,
+		diskdb:       diskdb,
+		hasher:       merkleNodeHasher,
+		histories:    lru.NewCache[uint64, *historyStates](historyCacheSize),
+		historyIndex: newHistoryIndex(historyIndexLimit),
 	}
 	// Establish a dedicated database namespace tailored for verkle-specific
 	// data, ensuring the isolation of both verkle and merkle tree data. It's
@@ -402,6 +412,8 @@ func (db *Database) Enable(root common.Hash) error {
 		if err := db.freezer.Reset(); err != nil {
 			return err
 		}
+		db.histories.Purge()
+		db.historyIndex.reset()
 	}
 	// Re-construct a new disk layer backed by persistent state
 	// with **empty clean cache and node buffer**.
@@ -458,6 +470,9 @@ func (db *Database) Recover(root common.Hash) error {
 	if err != nil {
 		return err
 	}
+	// The ids of the truncated histories will be reused, drop them.
+	db.histories.Purge()
+	db.historyIndex.reset()
 	log.Debug("Recovered state", "root", root, "elapsed", common.PrettyDuration(time.Since(start)))
 	return nil
 }
@@ -496,6 +511,35 @@ func (db *Database) Recoverable(root common.Hash) bool {
 	}) == nil
 }
 
+// Reload discards all the layers and reconstructs them from the persistent state
+// and the layer journal, if the persistent state has been changed since the last
+// load. It's only permitted in read only mode and is meant to be used by the
+// followers of a database written by another process.
+func (db *Database) Reload() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if !db.readOnly {
+		return errors.New("reload is only supported in read only mode")
+	}
+	root, err := db.hasher(rawdb.ReadAccountTrieNode(db.diskdb, nil))
+	if err != nil {
+		return err
+	}
+	bottom := db.tree.bottom()
+	if root == bottom.rootHash() {
+		return nil
+	}
+	// Invalidate the layers derived from the previous persistent state, all
+	// readers of them will be rejected from now on.
+	bottom.resetCache()
+	bottom.markStale()
+
+	db.tree.reset(db.loadLayers())
+	log.Debug("Reloaded path database", "root", root, "layers", db.tree.len())
+	return nil
+}
+
 // Close closes the trie database and the held freezer.
 func (db *Database) Close() error {
 	db.lock.Lock()
@@ -0,0 +1,415 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb/database"
+)
+
+// historyCacheSize is the maximum number of decoded state histories kept in
+// memory for serving historic state reads.
+const historyCacheSize = 64
+
+// historyStates is the state set of a state history, keyed by the hash of the
+// account address and the hash of the storage slot key.
+type historyStates struct {
+	accounts map[common.Hash][]byte
+	storages map[common.Hash]map[common.Hash][]byte
+}
+
+// historyKey identifies a state in the state histories, either an account or,
+// if storage is set, a storage slot of an account.
+type historyKey struct {
+	account common.Hash
+	slot    common.Hash
+	storage bool
+}
+
+// lookup returns the original value of the given state, if it was mutated by
+// the state history.
+func (s *historyStates) lookup(key historyKey) ([]byte, bool) {
+	if !key.storage {
+		blob, ok := s.accounts[key.account]
+		return blob, ok
+	}
+	slots, ok := s.storages[key.account]
+	if !ok {
+		return nil, false
+	}
+	blob, ok := slots[key.slot]
+	return blob, ok
+}
+
+// historyIndexLimit is the maximum number of state mutations tracked by the
+// history index, bounding its memory usage to roughly 150MB.
+const historyIndexLimit = 1024 * 1024
+
+// historyIndex is an in-memory index of the state histories within the historic
+// state window, tracking the ids of the histories which mutated each state. It
+// allows serving a historic read from the single relevant state history, rather
+// than scanning all state histories since the historic state.
+//
+// The index covers the histories [first, last]. It is extended as new histories
+// are written and trimmed as the window moves on. If the states mutated within
+// the window exceed the configured limit, the oldest histories are dropped from
+// the index and the reads of the historic states below it fall back to scanning
+// those histories.
+type historyIndex struct {
+	first uint64                  // Id of the first indexed state history
+	last  uint64                  // Id of the last indexed state history, 0 if the index is empty
+	ids   map[historyKey][]uint64 // Ids of the histories mutating a state, ascending
+	sizes []int                   // Number of states mutated by each indexed history
+	size  int                     // Number of state mutations indexed
+	limit int                     // Maximum number of state mutations indexed
+	lock  sync.Mutex
+}
+
+// newHistoryIndex creates an empty state history index, tracking at most the
+// given number of state mutations.
+func newHistoryIndex(limit int) *historyIndex {
+	return &historyIndex{ids: make(map[historyKey][]uint64), limit: limit}
+}
+
+// reset drops the index, which is needed if the indexed state histories were
+// truncated or deleted, as their ids will be reused.
+func (idx *historyIndex) reset() {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.clear(0)
+}
+
+// clear empties the index, to be extended from the given state history onwards.
+// The caller must hold the index lock.
+func (idx *historyIndex) clear(first uint64) {
+	idx.first, idx.last = first, 0
+	if first > 0 {
+		idx.last = first - 1
+	}
+	idx.ids = make(map[historyKey][]uint64)
+	idx.sizes, idx.size = nil, 0
+}
+
+// update brings the index in line with the state histories up to the given head,
+// the id of the disk layer, dropping the ones below the historic state window.
+// It returns the id of the first state history within the window. The caller
+// must hold the index lock.
+func (idx *historyIndex) update(db *Database, head uint64) (uint64, error) {
+	tail, err := db.freezer.Tail()
+	if err != nil {
+		return 0, err
+	}
+	first := tail + 1
+	if window := db.config.HistoricStateWindow; head > window && head-window+1 > first {
+		first = head - window + 1
+	}
+	// Start over if the index is empty, lagging too far behind to be extended or
+	// ahead of the state histories, which might happen if they were reverted.
+	if idx.last == 0 || idx.last+1 < first || idx.last > head {
+		idx.clear(first)
+	}
+	for id := idx.last + 1; id <= head; id++ {
+		h, err := readHistory(db.freezer, id)
+		if err != nil {
+			return 0, err
+		}
+		accounts, storages := h.stateSet()
+		size := len(accounts)
+		for account := range accounts {
+			key := historyKey{account: account}
+			idx.ids[key] = append(idx.ids[key], id)
+		}
+		for account, slots := range storages {
+			for slot := range slots {
+				key := historyKey{account: account, slot: slot, storage: true}
+				idx.ids[key] = append(idx.ids[key], id)
+			}
+			size += len(slots)
+		}
+		idx.last = id
+		idx.sizes = append(idx.sizes, size)
+		idx.size += size
+
+		// Drop the oldest histories if the index grew too large, leaving some
+		// room to avoid trimming it on every update.
+		if idx.size > idx.limit {
+			drop := idx.first
+			for size := idx.size; size > idx.limit*3/4 && drop < idx.last; drop++ {
+				size -= idx.sizes[drop-idx.first]
+			}
+			idx.trim(drop)
+		}
+	}
+	// Trim the index in batches, as it requires iterating all of it
+	if first > idx.first && first-idx.first > db.config.HistoricStateWindow/16 {
+		idx.trim(first)
+	}
+	return first, nil
+}
+
+// trim drops the state histories below the given id from the index. The caller
+// must hold the index lock.
+func (idx *historyIndex) trim(first uint64) {
+	if first <= idx.first {
+		return
+	}
+	if first > idx.last {
+		idx.clear(first)
+		return
+	}
+	for key, ids := range idx.ids {
+		n, _ := slices.BinarySearch(ids, first)
+		if n == len(ids) {
+			delete(idx.ids, key)
+		} else if n > 0 {
+			idx.ids[key] = slices.Clone(ids[n:])
+		}
+	}
+	for _, size := range idx.sizes[:first-idx.first] {
+		idx.size -= size
+	}
+	idx.sizes = slices.Clone(idx.sizes[first-idx.first:])
+	idx.first = first
+}
+
+// lookup returns the id of the first state history within [from, to] which
+// mutated the given state. The state histories up to to must be present. The
+// histories which were dropped from the index due to its size limit are
+// scanned one by one.
+func (idx *historyIndex) lookup(db *Database, key historyKey, from, to uint64) (uint64, bool, error) {
+	for {
+		idx.lock.Lock()
+		window, err := idx.update(db, to)
+		if err != nil {
+			idx.lock.Unlock()
+			return 0, false, err
+		}
+		if from < window {
+			idx.lock.Unlock()
+			return 0, false, fmt.Errorf("state history %d is out of the historic state window", from)
+		}
+		if from >= idx.first {
+			defer idx.lock.Unlock()
+
+			ids := idx.ids[key]
+			if n, _ := slices.BinarySearch(ids, from); n < len(ids) && ids[n] <= to {
+				return ids[n], true, nil
+			}
+			return 0, false, nil
+		}
+		first := idx.first
+		idx.lock.Unlock()
+
+		for ; from < first && from <= to; from++ {
+			states, err := db.historyStates(from)
+			if err != nil {
+				return 0, false, err
+			}
+			if _, ok := states.lookup(key); ok {
+				return from, true, nil
+			}
+		}
+		if from > to {
+			return 0, false, nil
+		}
+	}
+}
+
+// historyStates returns the decoded state set of the state history with the
+// given id.
+func (db *Database) historyStates(id uint64) (*historyStates, error) {
+	if states, ok := db.histories.Get(id); ok {
+		return states, nil
+	}
+	h, err := readHistory(db.freezer, id)
+	if err != nil {
+		return nil, err
+	}
+	accounts, storages := h.stateSet()
+	states := &historyStates{accounts: accounts, storages: storages}
+	db.histories.Add(id, states)
+	return states, nil
+}
+
+// layerNodes is a trie node database which resolves the nodes of a single layer.
+type layerNodes struct {
+	layer layer
+}
+
+// NodeReader implements database.NodeDatabase, returning a reader of the layer.
+func (n *layerNodes) NodeReader(root common.Hash) (database.NodeReader, error) {
+	return &reader{layer: n.layer}, nil
+}
+
+// historicReader is a state reader of a historical state below the disk layer.
+// The values of the state are resolved from the state histories recorded since
+// then, which hold the original values of the mutated states, falling back to
+// the tries of the disk layer for the states left untouched.
+type historicReader struct {
+	db   *Database
+	root common.Hash // Root of the historical state
+	id   uint64      // State id of the historical state
+}
+
+// HistoricReader returns a reader of the specified historical state, which is
+// persisted already and whose subsequent state histories are all retained. The
+// distance from the disk layer is limited by the configured historic state window.
+func (db *Database) HistoricReader(root common.Hash) (database.StateReader, error) {
+	if db.freezer == nil {
+		return nil, errors.New("state histories are not available")
+	}
+	if db.isVerkle {
+		return nil, errors.New("historic state reads are not supported in verkle")
+	}
+	if db.config.HistoricStateWindow == 0 {
+		return nil, errors.New("historic state reads are disabled")
+	}
+	id := rawdb.ReadStateID(db.diskdb, root)
+	if id == nil {
+		return nil, fmt.Errorf("state %#x is not available", root)
+	}
+	dl := db.tree.bottom()
+	if *id > dl.stateID() {
+		return nil, fmt.Errorf("state %#x is not historic", root)
+	}
+	if dl.stateID()-*id > db.config.HistoricStateWindow {
+		return nil, fmt.Errorf("state %#x is out of the historic state window, distance: %d, window: %d", root, dl.stateID()-*id, db.config.HistoricStateWindow)
+	}
+	tail, err := db.freezer.Tail()
+	if err != nil {
+		return nil, err
+	}
+	if *id < tail {
+		return nil, fmt.Errorf("state %#x is pruned, id: %d, tail: %d", root, *id, tail)
+	}
+	return &historicReader{db: db, root: root, id: *id}, nil
+}
+
+// resolve looks up a state value in the state histories between the historical
+// state and the given disk layer, falling back to reading the disk layer if
+// the state was not mutated since. The disk layer might be invalidated while
+// it's being read, in which case the newly persisted disk layer is retried.
+func (r *historicReader) resolve(key historyKey, read func(*diskLayer) ([]byte, error)) ([]byte, error) {
+	for {
+		dl := r.db.tree.bottom()
+		if dl.stateID() < r.id {
+			return nil, fmt.Errorf("state %#x is reverted", r.root)
+		}
+		// The histories with the ids within [id+1, disk] contain the original
+		// values of the states they mutated. The first one containing the
+		// requested state holds its value as of the historical state.
+		id, found, err := r.db.historyIndex.lookup(r.db, key, r.id+1, dl.stateID())
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			states, err := r.db.historyStates(id)
+			if err != nil {
+				return nil, err
+			}
+			blob, _ := states.lookup(key)
+			return blob, nil
+		}
+		blob, err := read(dl)
+		if errors.Is(err, errSnapshotStale) {
+			continue
+		}
+		return blob, err
+	}
+}
+
+// account resolves the slim RLP of the account with the given hash.
+func (r *historicReader) account(hash common.Hash) ([]byte, error) {
+	return r.resolve(historyKey{account: hash}, func(dl *diskLayer) ([]byte, error) {
+		return readLayerAccount(dl, hash)
+	})
+}
+
+// Account implements database.StateReader, retrieving the account associated
+// with a particular hash in the historical state.
+func (r *historicReader) Account(hash common.Hash) (*types.SlimAccount, error) {
+	blob, err := r.account(hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	account := new(types.SlimAccount)
+	if err := rlp.DecodeBytes(blob, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Storage implements database.StateReader, retrieving the storage slot associated
+// with a particular hash within a particular account in the historical state.
+func (r *historicReader) Storage(accountHash, storageHash common.Hash) ([]byte, error) {
+	key := historyKey{account: accountHash, slot: storageHash, storage: true}
+	return r.resolve(key, func(dl *diskLayer) ([]byte, error) {
+		return readLayerStorage(dl, accountHash, storageHash)
+	})
+}
+
+// readLayerAccount reads the account with the given hash from the account trie
+// of the layer, returning it in the slim format.
+func readLayerAccount(l layer, hash common.Hash) ([]byte, error) {
+	tr, err := trie.New(trie.StateTrieID(l.rootHash()), &layerNodes{layer: l})
+	if err != nil {
+		return nil, err
+	}
+	blob, err := tr.Get(hash.Bytes())
+	if err != nil || len(blob) == 0 {
+		return nil, err
+	}
+	account, err := types.FullAccount(blob)
+	if err != nil {
+		return nil, err
+	}
+	return types.SlimAccountRLP(*account), nil
+}
+
+// readLayerStorage reads the storage slot with the given hash from the storage
+// trie of the specified account in the layer.
+func readLayerStorage(l layer, accountHash, storageHash common.Hash) ([]byte, error) {
+	blob, err := readLayerAccount(l, accountHash)
+	if err != nil || len(blob) == 0 {
+		return nil, err
+	}
+	account, err := types.FullAccount(blob)
+	if err != nil {
+		return nil, err
+	}
+	if account.Root == types.EmptyRootHash {
+		return nil, nil
+	}
+	tr, err := trie.New(trie.StorageTrieID(l.rootHash(), accountHash, account.Root), &layerNodes{layer: l})
+	if err != nil {
+		return nil, err
+	}
+	return tr.Get(storageHash.Bytes())
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/triedb/database"
+)
+
+func checkHistoricState(reader database.StateReader, accounts map[common.Hash][]byte, storages map[common.Hash]map[common.Hash][]byte, latest map[common.Hash][]byte) error {
+	for addrHash, blob := range accounts {
+		account, err := reader.Account(addrHash)
+		if err != nil {
+			return err
+		}
+		if account == nil {
+			return fmt.Errorf("account %x is missing", addrHash)
+		}
+		got, _ := rlp.EncodeToBytes(account)
+		if !bytes.Equal(got, blob) {
+			return fmt.Errorf("account %x is mismatched, want: %x, got: %x", addrHash, blob, got)
+		}
+	}
+	// The accounts created afterwards must be absent
+	for addrHash := range latest {
+		if _, ok := accounts[addrHash]; ok {
+			continue
+		}
+		account, err := reader.Account(addrHash)
+		if err != nil {
+			return err
+		}
+		if account != nil {
+			return fmt.Errorf("unexpected account %x", addrHash)
+		}
+	}
+	for addrHash, slots := range storages {
+		for slotHash, want := range slots {
+			got, err := reader.Storage(addrHash, slotHash)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(got, want) {
+				return fmt.Errorf("slot %x/%x is mismatched, want: %x, got: %x", addrHash, slotHash, want, got)
+			}
+		}
+	}
+	return nil
+}
+
+func TestHistoricReader(t *testing.T) {
+	// Redefine the diff layer depth allowance for faster testing.
+	maxDiffLayers = 4
+	defer func() {
+		maxDiffLayers = 128
+	}()
+
+	tester := newTester(t, 0, false, 12)
+	defer tester.release()
+
+	if err := tester.db.Commit(tester.lastHash(), false); err != nil {
+		t.Fatalf("Failed to cap database, err: %v", err)
+	}
+	// Historic reads are disabled by default
+	if _, err := tester.db.HistoricReader(tester.roots[0]); err == nil {
+		t.Fatal("Historic reader is expected to be disabled")
+	}
+	tester.db.config.HistoricStateWindow = uint64(len(tester.roots))
+
+	latest := tester.snapAccounts[tester.roots[len(tester.roots)-2]]
+	for i := 0; i < len(tester.roots)-1; i++ {
+		root := tester.roots[i]
+		reader, err := tester.db.HistoricReader(root)
+		if err != nil {
+			t.Fatalf("Failed to open historic reader %d, err: %v", i, err)
+		}
+		if err := checkHistoricState(reader, tester.snapAccounts[root], tester.snapStorages[root], latest); err != nil {
+			t.Fatalf("Historic state %d is invalid, err: %v", i, err)
+		}
+	}
+	// The states beyond the window must be rejected
+	tester.db.config.HistoricStateWindow = 4
+	for i := 0; i < len(tester.roots); i++ {
+		_, err := tester.db.HistoricReader(tester.roots[i])
+		if i < len(tester.roots)-5 && err == nil {
+			t.Fatalf("State %d out of the window is expected to be rejected", i)
+		}
+		if i >= len(tester.roots)-5 && err != nil {
+			t.Fatalf("Failed to open historic reader %d, err: %v", i, err)
+		}
+	}
+	// Unknown states must be rejected
+	if _, err := tester.db.HistoricReader(types.EmptyCodeHash); err == nil {
+		t.Fatal("Unknown state is expected to be rejected")
+	}
+	// Reads of states which fell out of the window after opening the reader
+	// must be rejected too
+	root := tester.roots[len(tester.roots)-5]
+	reader, err := tester.db.HistoricReader(root)
+	if err != nil {
+		t.Fatalf("Failed to open historic reader, err: %v", err)
+	}
+	tester.db.config.HistoricStateWindow = 2
+	if err := checkHistoricState(reader, tester.snapAccounts[root], nil, nil); err == nil {
+		t.Fatal("State out of the window is expected to be rejected")
+	}
+	// Revert the disk layer, the remaining histories must still be served
+	// correctly after the reverted ones were indexed
+	tester.db.config.HistoricStateWindow = uint64(len(tester.roots))
+	head := len(tester.roots) - 4
+	if err := tester.db.Recover(tester.roots[head]); err != nil {
+		t.Fatalf("Failed to revert database, err: %v", err)
+	}
+	latest = tester.snapAccounts[tester.roots[head]]
+	for i := 0; i < head; i++ {
+		root := tester.roots[i]
+		reader, err := tester.db.HistoricReader(root)
+		if err != nil {
+			t.Fatalf("Failed to open historic reader %d, err: %v", i, err)
+		}
+		if err := checkHistoricState(reader, tester.snapAccounts[root], tester.snapStorages[root], latest); err != nil {
+			t.Fatalf("Historic state %d is invalid after revert, err: %v", i, err)
+		}
+	}
+}
+
+// Tests that the historic states are served correctly if the history index is
+// limited, scanning the state histories dropped from it.
+func TestHistoricReaderIndexLimit(t *testing.T) {
+	// Redefine the diff layer depth allowance for faster testing.
+	maxDiffLayers = 4
+	defer func() {
+		maxDiffLayers = 128
+	}()
+
+	tester := newTester(t, 0, false, 12)
+	defer tester.release()
+
+	if err := tester.db.Commit(tester.lastHash(), false); err != nil {
+		t.Fatalf("Failed to cap database, err: %v", err)
+	}
+	tester.db.config.HistoricStateWindow = uint64(len(tester.roots))
+	tester.db.historyIndex = newHistoryIndex(1)
+
+	latest := tester.snapAccounts[tester.roots[len(tester.roots)-2]]
+	for i := 0; i < len(tester.roots)-1; i++ {
+		root := tester.roots[i]
+		reader, err := tester.db.HistoricReader(root)
+		if err != nil {
+			t.Fatalf("Failed to open historic reader %d, err: %v", i, err)
+		}
+		if err := checkHistoricState(reader, tester.snapAccounts[root], tester.snapStorages[root], latest); err != nil {
+			t.Fatalf("Historic state %d is invalid, err: %v", i, err)
+		}
+	}
+	idx := tester.db.historyIndex
+	if idx.first != idx.last {
+		t.Fatalf("Unexpected index range: [%d, %d]", idx.first, idx.last)
+	}
+	if len(idx.sizes) != 1 || idx.size != idx.sizes[0] {
+		t.Fatalf("Unexpected index size: %d, histories: %v", idx.size, idx.sizes)
+	}
+}